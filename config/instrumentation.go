@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config // import "go.opentelemetry.io/contrib/config"
+
+// Instrumentation is the "instrumentation" node of a configuration file,
+// holding the configuration of instrumentation packages by language.
+//
+// The node is not part of the version of the configuration schema
+// OpenTelemetryConfiguration is generated from, it is therefore decoded
+// separately from the same document, e.g. with the
+// github.com/mitchellh/mapstructure package:
+//
+//	var inst config.Instrumentation
+//	err := mapstructure.Decode(doc["instrumentation"], &inst)
+type Instrumentation struct {
+	// Go holds the configuration of the Go instrumentation packages keyed
+	// by package name.
+	Go LanguageSpecificInstrumentation `mapstructure:"go,omitempty"`
+}
+
+// LanguageSpecificInstrumentation holds the configuration nodes of the
+// instrumentation packages of a language keyed by package name.
+type LanguageSpecificInstrumentation map[string]interface{}
+
+// GoInstrumentation returns the configuration node of the Go instrumentation
+// package with the passed name, e.g. "runtime" or "host", from the "go"
+// section of the instrumentation node, and whether the package is configured.
+//
+// The node is meant to be passed to the ConfigOptions function of the
+// instrumentation package, e.g.:
+//
+//	if node, ok := inst.GoInstrumentation("runtime"); ok {
+//		opts, err := runtime.ConfigOptions(node)
+//		// ...
+//		err = runtime.Start(opts...)
+//	}
+func (i *Instrumentation) GoInstrumentation(name string) (map[string]interface{}, bool) {
+	if i == nil {
+		return nil, false
+	}
+	v, ok := i.Go[name]
+	if !ok {
+		return nil, false
+	}
+	// An instrumentation package configured without any setting, e.g.
+	// "runtime:" in YAML, is enabled with its defaults.
+	node, _ := v.(map[string]interface{})
+	return node, true
+}
@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoInstrumentation(t *testing.T) {
+	cfg := &Instrumentation{
+		Go: LanguageSpecificInstrumentation{
+			"runtime": map[string]interface{}{"metrics": []interface{}{"memory"}},
+			"host":    nil,
+		},
+	}
+
+	node, ok := cfg.GoInstrumentation("runtime")
+	assert.True(t, ok)
+	assert.Equal(t, map[string]interface{}{"metrics": []interface{}{"memory"}}, node)
+
+	node, ok = cfg.GoInstrumentation("host")
+	assert.True(t, ok)
+	assert.Nil(t, node)
+
+	_, ok = cfg.GoInstrumentation("otelhttp")
+	assert.False(t, ok)
+
+	_, ok = (&Instrumentation{}).GoInstrumentation("runtime")
+	assert.False(t, ok)
+
+	var nilCfg *Instrumentation
+	_, ok = nilCfg.GoInstrumentation("runtime")
+	assert.False(t, ok)
+}
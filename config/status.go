@@ -0,0 +1,366 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config // import "go.opentelemetry.io/contrib/config"
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// EffectiveConfigName is the key used for the SDK configuration within
+	// the EffectiveConfig.ConfigMap.
+	EffectiveConfigName = ""
+
+	// EffectiveConfigContentType is the content type of the body of the
+	// SDK configuration within the EffectiveConfig.ConfigMap.
+	EffectiveConfigContentType = "application/json"
+)
+
+var errNilConfig = errors.New("config: nil configuration")
+
+// redactedValue replaces the values of Headers in the EffectiveConfig.
+const redactedValue = "[REDACTED]"
+
+var headersType = reflect.TypeOf(Headers(nil))
+
+// ConfigFile is a single configuration document. It mirrors the OpAMP
+// AgentConfigFile message.
+type ConfigFile struct {
+	// Body is the encoded configuration.
+	Body []byte
+	// ContentType is the MIME type of Body.
+	ContentType string
+}
+
+// EffectiveConfig is the configuration currently in effect for the SDK. It
+// mirrors the OpAMP EffectiveConfig message so it can be directly translated
+// by an agent reporting to an OpAMP server.
+type EffectiveConfig struct {
+	// ConfigMap holds the configuration documents keyed by name. The SDK
+	// configuration is stored under EffectiveConfigName.
+	ConfigMap map[string]ConfigFile
+}
+
+// ComponentHealth is the health of a component and its sub-components. It
+// mirrors the OpAMP ComponentHealth message.
+type ComponentHealth struct {
+	// Healthy is true if the component and all of its sub-components are
+	// healthy.
+	Healthy bool
+	// StartTimeUnixNano is the time the component was started.
+	StartTimeUnixNano uint64
+	// LastError is the last error reported by the component, if any.
+	LastError string
+	// Status is a human readable status of the component.
+	Status string
+	// StatusTimeUnixNano is the time Status was last updated.
+	StatusTimeUnixNano uint64
+	// ComponentHealthMap holds the health of the sub-components keyed by
+	// name.
+	ComponentHealthMap map[string]*ComponentHealth
+}
+
+// Status is the configuration and health of the SDK suitable for reporting
+// over OpAMP.
+type Status struct {
+	// EffectiveConfig is the configuration currently in effect.
+	EffectiveConfig EffectiveConfig
+	// Health is the aggregated health of the SDK components.
+	Health ComponentHealth
+}
+
+// NewEffectiveConfig returns the EffectiveConfig of cfg. Values that are not
+// set in cfg but have a default defined by the OpenTelemetry specification are
+// reported with that default.
+//
+// The values of exporter headers are replaced with a placeholder since they
+// commonly hold credentials. Their keys are reported.
+func NewEffectiveConfig(cfg *OpenTelemetryConfiguration) (EffectiveConfig, error) {
+	if cfg == nil {
+		return EffectiveConfig{}, errNilConfig
+	}
+
+	m, _ := toMap(reflect.ValueOf(*cfg)).(map[string]interface{})
+	applyDefaults(m)
+
+	body, err := json.Marshal(m)
+	if err != nil {
+		return EffectiveConfig{}, err
+	}
+	return EffectiveConfig{
+		ConfigMap: map[string]ConfigFile{
+			EffectiveConfigName: {
+				Body:        body,
+				ContentType: EffectiveConfigContentType,
+			},
+		},
+	}, nil
+}
+
+// StatusReporter tracks the configuration and the health of the SDK
+// components created from it. It is safe for concurrent use.
+type StatusReporter struct {
+	cfg   *OpenTelemetryConfiguration
+	start time.Time
+	now   func() time.Time
+
+	mu         sync.Mutex
+	components map[string]*ComponentHealth
+}
+
+// NewStatusReporter returns a StatusReporter for cfg.
+func NewStatusReporter(cfg *OpenTelemetryConfiguration) *StatusReporter {
+	return newStatusReporter(cfg, time.Now)
+}
+
+func newStatusReporter(cfg *OpenTelemetryConfiguration, now func() time.Time) *StatusReporter {
+	return &StatusReporter{
+		cfg:        cfg,
+		start:      now(),
+		now:        now,
+		components: make(map[string]*ComponentHealth),
+	}
+}
+
+// ReportHealth records the health of the named component. A nil err marks the
+// component as healthy, otherwise the component is marked as unhealthy and err
+// is recorded as its last error.
+//
+// Component names are expected to follow the configuration structure, e.g.
+// "tracer_provider/processors/0".
+func (r *StatusReporter) ReportHealth(component string, err error) {
+	now := unixNano(r.now())
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	h, ok := r.components[component]
+	if !ok {
+		h = &ComponentHealth{StartTimeUnixNano: now}
+		r.components[component] = h
+	}
+	h.StatusTimeUnixNano = now
+	if err != nil {
+		h.Healthy = false
+		h.LastError = err.Error()
+		h.Status = "error"
+		return
+	}
+	h.Healthy = true
+	h.Status = "ok"
+}
+
+// Status returns the current Status.
+func (r *StatusReporter) Status() (Status, error) {
+	ec, err := NewEffectiveConfig(r.cfg)
+	if err != nil {
+		return Status{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	health := ComponentHealth{
+		Healthy:            true,
+		StartTimeUnixNano:  unixNano(r.start),
+		Status:             "ok",
+		StatusTimeUnixNano: unixNano(r.start),
+		ComponentHealthMap: make(map[string]*ComponentHealth, len(r.components)),
+	}
+
+	names := make([]string, 0, len(r.components))
+	for name := range r.components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		h := *r.components[name]
+		health.ComponentHealthMap[name] = &h
+		if h.StatusTimeUnixNano > health.StatusTimeUnixNano {
+			health.StatusTimeUnixNano = h.StatusTimeUnixNano
+		}
+		if !h.Healthy {
+			health.Healthy = false
+			health.Status = "error"
+			health.LastError = name + ": " + h.LastError
+		}
+	}
+
+	return Status{EffectiveConfig: ec, Health: health}, nil
+}
+
+func unixNano(t time.Time) uint64 {
+	n := t.UnixNano()
+	if n < 0 {
+		return 0
+	}
+	return uint64(n)
+}
+
+// toMap converts v into its configuration file representation using the
+// mapstructure field tags of the configuration model. Unset optional fields
+// are omitted and the values of Headers are redacted.
+func toMap(v reflect.Value) interface{} {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return toMap(v.Elem())
+	case reflect.Struct:
+		m := make(map[string]interface{})
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			name, opts, _ := strings.Cut(f.Tag.Get("mapstructure"), ",")
+			if name == "" || name == "-" {
+				continue
+			}
+			fv := v.Field(i)
+			if strings.Contains(opts, "omitempty") && fv.IsZero() {
+				continue
+			}
+			m[name] = toMap(fv)
+		}
+		return m
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		redact := v.Type() == headersType
+		m := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			if redact {
+				m[iter.Key().String()] = redactedValue
+				continue
+			}
+			m[iter.Key().String()] = toMap(iter.Value())
+		}
+		return m
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		s := make([]interface{}, v.Len())
+		for i := range s {
+			s[i] = toMap(v.Index(i))
+		}
+		return s
+	default:
+		return v.Interface()
+	}
+}
+
+// Default values defined by the OpenTelemetry specification.
+var (
+	defaultAttributeLimits = map[string]interface{}{
+		"attribute_count_limit": 128,
+	}
+	defaultSpanLimits = map[string]interface{}{
+		"attribute_count_limit":       128,
+		"event_count_limit":           128,
+		"link_count_limit":            128,
+		"event_attribute_count_limit": 128,
+		"link_attribute_count_limit":  128,
+	}
+	defaultLogRecordLimits = map[string]interface{}{
+		"attribute_count_limit": 128,
+	}
+	defaultBatchSpanProcessor = map[string]interface{}{
+		"schedule_delay":        5000,
+		"export_timeout":        30000,
+		"max_queue_size":        2048,
+		"max_export_batch_size": 512,
+	}
+	defaultBatchLogRecordProcessor = map[string]interface{}{
+		"schedule_delay":        1000,
+		"export_timeout":        30000,
+		"max_queue_size":        2048,
+		"max_export_batch_size": 512,
+	}
+	defaultPeriodicMetricReader = map[string]interface{}{
+		"interval": 60000,
+		"timeout":  30000,
+	}
+	defaultPropagators = []interface{}{"tracecontext", "baggage"}
+)
+
+func applyDefaults(m map[string]interface{}) {
+	if m == nil {
+		return
+	}
+	setDefault(m, "disabled", false)
+	m["attribute_limits"] = withDefaults(m["attribute_limits"], defaultAttributeLimits)
+	if p, ok := m["propagator"].(map[string]interface{}); !ok {
+		m["propagator"] = map[string]interface{}{"composite": defaultPropagators}
+	} else {
+		setDefault(p, "composite", defaultPropagators)
+	}
+
+	if tp, ok := m["tracer_provider"].(map[string]interface{}); ok {
+		tp["limits"] = withDefaults(tp["limits"], defaultSpanLimits)
+		eachProcessor(tp["processors"], "batch", defaultBatchSpanProcessor)
+	}
+	if lp, ok := m["logger_provider"].(map[string]interface{}); ok {
+		lp["limits"] = withDefaults(lp["limits"], defaultLogRecordLimits)
+		eachProcessor(lp["processors"], "batch", defaultBatchLogRecordProcessor)
+	}
+	if mp, ok := m["meter_provider"].(map[string]interface{}); ok {
+		eachProcessor(mp["readers"], "periodic", defaultPeriodicMetricReader)
+	}
+}
+
+// eachProcessor applies defaults to the kind entry of each element of list.
+func eachProcessor(list interface{}, kind string, defaults map[string]interface{}) {
+	s, _ := list.([]interface{})
+	for _, p := range s {
+		pm, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if k, ok := pm[kind].(map[string]interface{}); ok {
+			for key, val := range defaults {
+				setDefault(k, key, val)
+			}
+		}
+	}
+}
+
+func withDefaults(v interface{}, defaults map[string]interface{}) map[string]interface{} {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		m = make(map[string]interface{}, len(defaults))
+	}
+	for key, val := range defaults {
+		setDefault(m, key, val)
+	}
+	return m
+}
+
+func setDefault(m map[string]interface{}, key string, val interface{}) {
+	if _, ok := m[key]; !ok {
+		m[key] = val
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+func TestNewEffectiveConfig(t *testing.T) {
+	cfg := &OpenTelemetryConfiguration{
+		FileFormat: "0.1",
+		TracerProvider: &TracerProvider{
+			Processors: []SpanProcessor{
+				{
+					Batch: &BatchSpanProcessor{
+						MaxQueueSize: ptr(100),
+						Exporter: SpanExporter{
+							OTLP: &OTLP{
+								Endpoint: "http://localhost:4318",
+								Protocol: "http/protobuf",
+							},
+						},
+					},
+				},
+			},
+		},
+		MeterProvider: &MeterProvider{
+			Readers: []MetricReader{
+				{Periodic: &PeriodicMetricReader{Exporter: MetricExporter{Console: Console{}}}},
+			},
+		},
+	}
+
+	ec, err := NewEffectiveConfig(cfg)
+	require.NoError(t, err)
+	require.Contains(t, ec.ConfigMap, EffectiveConfigName)
+	file := ec.ConfigMap[EffectiveConfigName]
+	assert.Equal(t, EffectiveConfigContentType, file.ContentType)
+
+	var got map[string]interface{}
+	require.NoError(t, json.Unmarshal(file.Body, &got))
+
+	assert.Equal(t, "0.1", got["file_format"])
+	assert.Equal(t, false, got["disabled"])
+	assert.Equal(t, map[string]interface{}{
+		"composite": []interface{}{"tracecontext", "baggage"},
+	}, got["propagator"])
+
+	tp := got["tracer_provider"].(map[string]interface{})
+	batch := tp["processors"].([]interface{})[0].(map[string]interface{})["batch"].(map[string]interface{})
+	assert.Equal(t, float64(100), batch["max_queue_size"], "explicit value overridden")
+	assert.Equal(t, float64(5000), batch["schedule_delay"])
+	assert.Equal(t, float64(512), batch["max_export_batch_size"])
+	assert.Equal(t, "http://localhost:4318", batch["exporter"].(map[string]interface{})["otlp"].(map[string]interface{})["endpoint"])
+	assert.Equal(t, float64(128), tp["limits"].(map[string]interface{})["link_count_limit"])
+
+	mp := got["meter_provider"].(map[string]interface{})
+	periodic := mp["readers"].([]interface{})[0].(map[string]interface{})["periodic"].(map[string]interface{})
+	assert.Equal(t, float64(60000), periodic["interval"])
+	assert.Equal(t, map[string]interface{}{}, periodic["exporter"].(map[string]interface{})["console"])
+
+	assert.NotContains(t, got, "logger_provider")
+}
+
+func TestNewEffectiveConfigRedactsHeaders(t *testing.T) {
+	cfg := &OpenTelemetryConfiguration{
+		TracerProvider: &TracerProvider{
+			Processors: []SpanProcessor{
+				{
+					Simple: &SimpleSpanProcessor{
+						Exporter: SpanExporter{
+							OTLP: &OTLP{
+								Endpoint: "http://localhost:4318",
+								Headers:  Headers{"api-key": "secret", "tenant": "team-a"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	ec, err := NewEffectiveConfig(cfg)
+	require.NoError(t, err)
+	body := ec.ConfigMap[EffectiveConfigName].Body
+	assert.NotContains(t, string(body), "secret")
+	assert.NotContains(t, string(body), "team-a")
+
+	var got map[string]interface{}
+	require.NoError(t, json.Unmarshal(body, &got))
+	tp := got["tracer_provider"].(map[string]interface{})
+	simple := tp["processors"].([]interface{})[0].(map[string]interface{})["simple"].(map[string]interface{})
+	otlp := simple["exporter"].(map[string]interface{})["otlp"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{
+		"api-key": redactedValue,
+		"tenant":  redactedValue,
+	}, otlp["headers"])
+	assert.Equal(t, "http://localhost:4318", otlp["endpoint"])
+
+	assert.Equal(t, "secret", cfg.TracerProvider.Processors[0].Simple.Exporter.OTLP.Headers["api-key"], "configuration modified")
+}
+
+func TestNewEffectiveConfigNil(t *testing.T) {
+	_, err := NewEffectiveConfig(nil)
+	assert.ErrorIs(t, err, errNilConfig)
+}
+
+func TestStatusReporter(t *testing.T) {
+	now := time.Unix(10, 0)
+	r := newStatusReporter(&OpenTelemetryConfiguration{FileFormat: "0.1"}, func() time.Time { return now })
+
+	s, err := r.Status()
+	require.NoError(t, err)
+	assert.True(t, s.Health.Healthy)
+	assert.Equal(t, uint64(10*time.Second), s.Health.StartTimeUnixNano)
+	assert.Empty(t, s.Health.ComponentHealthMap)
+
+	now = time.Unix(20, 0)
+	r.ReportHealth("tracer_provider/processors/0", nil)
+	r.ReportHealth("meter_provider/readers/0", errors.New("connection refused"))
+
+	s, err = r.Status()
+	require.NoError(t, err)
+	assert.False(t, s.Health.Healthy)
+	assert.Equal(t, "error", s.Health.Status)
+	assert.Equal(t, "meter_provider/readers/0: connection refused", s.Health.LastError)
+	assert.Equal(t, uint64(20*time.Second), s.Health.StatusTimeUnixNano)
+	assert.Equal(t, &ComponentHealth{
+		Healthy:            true,
+		StartTimeUnixNano:  uint64(20 * time.Second),
+		Status:             "ok",
+		StatusTimeUnixNano: uint64(20 * time.Second),
+	}, s.Health.ComponentHealthMap["tracer_provider/processors/0"])
+
+	now = time.Unix(30, 0)
+	r.ReportHealth("meter_provider/readers/0", nil)
+
+	s, err = r.Status()
+	require.NoError(t, err)
+	assert.True(t, s.Health.Healthy)
+	h := s.Health.ComponentHealthMap["meter_provider/readers/0"]
+	assert.True(t, h.Healthy)
+	assert.Equal(t, "connection refused", h.LastError, "last error retained")
+	assert.Equal(t, uint64(20*time.Second), h.StartTimeUnixNano)
+	assert.Equal(t, uint64(30*time.Second), h.StatusTimeUnixNano)
+}
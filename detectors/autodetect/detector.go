@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autodetect // import "go.opentelemetry.io/contrib/detectors/autodetect"
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/contrib/detectors/aws/ec2"
+	"go.opentelemetry.io/contrib/detectors/aws/ecs"
+	"go.opentelemetry.io/contrib/detectors/aws/eks"
+	"go.opentelemetry.io/contrib/detectors/aws/lambda"
+	"go.opentelemetry.io/contrib/detectors/gcp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// otelResourceDetectorsEnvKey is the environment variable name identifying
+// resource detectors to use.
+const otelResourceDetectorsEnvKey = "OTEL_RESOURCE_DETECTORS"
+
+// NewDetector returns a new resource detector composed of detectors or of
+// the ones defined by the OTEL_RESOURCE_DETECTORS environment variable. The
+// detectors defined by OTEL_RESOURCE_DETECTORS, if set, take precedence over
+// detectors.
+//
+// The supported environment variable detectors can be extended to include
+// custom 3rd-party detectors. See the RegisterDetector function for more
+// information.
+//
+// If OTEL_RESOURCE_DETECTORS is not defined and detectors are not provided,
+// the returned detector does not detect any resource.
+func NewDetector(detectors ...resource.Detector) resource.Detector {
+	// Environment variable defined detector has precedence over arguments.
+	envDetector, err := parseEnv()
+	if err != nil {
+		// Communicate to the user their supplied value will not be used.
+		otel.Handle(err)
+	}
+	if envDetector != nil {
+		return envDetector
+	}
+
+	if len(detectors) == 1 {
+		// Do not add overhead with a composite detector wrapping a single
+		// detector, return it directly.
+		return detectors[0]
+	}
+	return composite(detectors)
+}
+
+// errUnknownDetector is returned when an unknown detector name is used in
+// the OTEL_RESOURCE_DETECTORS environment variable.
+var errUnknownDetector = errors.New("unknown resource detector")
+
+// parseEnv returns the composite detector defined by the
+// OTEL_RESOURCE_DETECTORS environment variable. A nil detector is returned
+// if no detector is defined for the environment variable. A no-op detector
+// is returned if "none" is defined anywhere in the environment variable.
+func parseEnv() (resource.Detector, error) {
+	names := os.Getenv(otelResourceDetectorsEnvKey)
+	if names == "" {
+		return nil, nil
+	}
+
+	split := strings.Split(names, ",")
+	for i := range split {
+		split[i] = strings.TrimSpace(split[i])
+	}
+	return Detector(split...)
+}
+
+// notOnPlatform are the errors returned by detectors not applicable to the
+// platform the process is running on. They are not reported: composite
+// detection is expected to include detectors of other platforms.
+var notOnPlatform = []error{
+	ec2.ErrNotOnPlatform,
+	ecs.ErrNotOnPlatform,
+	eks.ErrNotOnPlatform,
+	gcp.ErrNotOnPlatform,
+	lambda.ErrNotOnPlatform,
+}
+
+// isNotOnPlatform returns if err reports a detector not applicable to the
+// platform the process is running on.
+func isNotOnPlatform(err error) bool {
+	for _, target := range notOnPlatform {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// composite is a resource detector merging the resources detected by its
+// detectors, in order.
+type composite []resource.Detector
+
+// compile time assertion that composite implements the resource.Detector
+// interface.
+var _ resource.Detector = composite(nil)
+
+// Detect returns the merge of the resources detected by the detectors, the
+// attributes of the latter detectors taking precedence. As with the SDK, the
+// resources returned with an error are ignored unless the error is a
+// resource.ErrPartialResource, and the errors are joined. The errors of the
+// detectors not applicable to the platform, e.g. the Lambda detector outside
+// of AWS Lambda, are not reported.
+func (c composite) Detect(ctx context.Context) (*resource.Resource, error) {
+	var (
+		res  *resource.Resource
+		errs []error
+	)
+	for _, d := range c {
+		r, err := d.Detect(ctx)
+		if err != nil && isNotOnPlatform(err) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, err)
+			if !errors.Is(err, resource.ErrPartialResource) {
+				continue
+			}
+		}
+		if r == nil {
+			continue
+		}
+		if res, err = resource.Merge(res, r); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return res, errors.Join(errs...)
+}
@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autodetect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/detectors/aws/ec2"
+	"go.opentelemetry.io/contrib/detectors/aws/ecs"
+	"go.opentelemetry.io/contrib/detectors/aws/eks"
+	"go.opentelemetry.io/contrib/detectors/aws/lambda"
+	"go.opentelemetry.io/contrib/detectors/gcp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+type handler struct{ err error }
+
+func (h *handler) Handle(err error) { h.err = err }
+
+func TestNewDetectorDefault(t *testing.T) {
+	t.Setenv(otelResourceDetectorsEnvKey, "")
+
+	res, err := NewDetector().Detect(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, res)
+
+	d := staticDetector{attribute.String("a", "1")}
+	assert.Equal(t, d, NewDetector(d))
+}
+
+func TestNewDetectorEnv(t *testing.T) {
+	t.Setenv(otelResourceDetectorsEnvKey, "env, os")
+	t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "key=value")
+	t.Setenv("OTEL_SERVICE_NAME", "my-service")
+
+	d := NewDetector(staticDetector{attribute.String("a", "1")})
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+
+	set := res.Set()
+	v, ok := set.Value("key")
+	assert.True(t, ok)
+	assert.Equal(t, "value", v.AsString())
+	v, ok = set.Value(semconv.ServiceNameKey)
+	assert.True(t, ok)
+	assert.Equal(t, "my-service", v.AsString())
+	_, ok = set.Value(semconv.OSTypeKey)
+	assert.True(t, ok)
+	_, ok = set.Value("a")
+	assert.False(t, ok, "argument detector used")
+}
+
+func TestNewDetectorEnvUnknown(t *testing.T) {
+	h := &handler{}
+	otel.SetErrorHandler(h)
+	t.Setenv(otelResourceDetectorsEnvKey, "unknown")
+
+	d := staticDetector{attribute.String("a", "1")}
+	assert.Equal(t, d, NewDetector(d), "argument detector not used")
+	assert.ErrorIs(t, h.err, errUnknownDetector)
+}
+
+// errDetector returns its resource and error.
+type errDetector struct {
+	res *resource.Resource
+	err error
+}
+
+func (d errDetector) Detect(context.Context) (*resource.Resource, error) {
+	return d.res, d.err
+}
+
+func TestCompositeDetect(t *testing.T) {
+	err1 := errors.New("first")
+	err2 := fmt.Errorf("%w: second", resource.ErrPartialResource)
+	c := composite{
+		errDetector{
+			res: resource.NewSchemaless(attribute.String("ignored", "0")),
+			err: err1,
+		},
+		staticDetector{attribute.String("a", "1")},
+		errDetector{},
+		errDetector{
+			res: resource.NewSchemaless(attribute.String("b", "2")),
+			err: err2,
+		},
+	}
+
+	res, err := c.Detect(context.Background())
+	assert.ErrorIs(t, err, err1)
+	assert.ErrorIs(t, err, err2)
+	assert.Equal(t, resource.NewSchemaless(
+		attribute.String("a", "1"),
+		attribute.String("b", "2"),
+	), res)
+}
+
+func TestCompositeDetectNotOnPlatform(t *testing.T) {
+	err := errors.New("failure")
+	c := composite{
+		errDetector{res: resource.Empty(), err: lambda.ErrNotOnPlatform},
+		errDetector{err: fmt.Errorf("%w: %w", eks.ErrNotOnPlatform, errors.New("cause"))},
+		errDetector{err: ec2.ErrNotOnPlatform},
+		errDetector{err: ecs.ErrNotOnPlatform},
+		errDetector{err: gcp.ErrNotOnPlatform},
+		staticDetector{attribute.String("a", "1")},
+		errDetector{err: err},
+	}
+
+	res, gotErr := c.Detect(context.Background())
+	assert.ErrorIs(t, gotErr, err)
+	assert.NotErrorIs(t, gotErr, lambda.ErrNotOnPlatform)
+	assert.NotErrorIs(t, gotErr, eks.ErrNotOnPlatform)
+	assert.NotErrorIs(t, gotErr, ec2.ErrNotOnPlatform)
+	assert.NotErrorIs(t, gotErr, ecs.ErrNotOnPlatform)
+	assert.NotErrorIs(t, gotErr, gcp.ErrNotOnPlatform)
+	assert.Equal(t, resource.NewSchemaless(attribute.String("a", "1")), res)
+}
+
+func TestCompositeDetectSchemaConflict(t *testing.T) {
+	c := composite{
+		errDetector{res: resource.NewWithAttributes("https://a", attribute.String("a", "1"))},
+		errDetector{res: resource.NewWithAttributes("https://b", attribute.String("b", "2"))},
+	}
+
+	_, err := c.Detect(context.Background())
+	assert.ErrorContains(t, err, "conflicting Schema URL")
+}
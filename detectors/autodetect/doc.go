@@ -0,0 +1,24 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package autodetect provides an OpenTelemetry resource detector creation
+// function selecting the detectors to use with the OTEL_RESOURCE_DETECTORS
+// environment variable.
+//
+// The environment variable is a comma-separated list of detector names, e.g.
+// "env,host,ec2,eks". The detectors of this repository and the ones of the
+// SDK are supported by default, see the Detector function for the list of
+// names. The supported names can be extended to include custom detectors
+// with the RegisterDetector function.
+package autodetect // import "go.opentelemetry.io/contrib/detectors/autodetect"
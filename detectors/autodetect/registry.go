@@ -0,0 +1,220 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autodetect // import "go.opentelemetry.io/contrib/detectors/autodetect"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/contrib/detectors/aws/ec2"
+	"go.opentelemetry.io/contrib/detectors/aws/ecs"
+	"go.opentelemetry.io/contrib/detectors/aws/eks"
+	"go.opentelemetry.io/contrib/detectors/aws/lambda"
+	"go.opentelemetry.io/contrib/detectors/azure"
+	"go.opentelemetry.io/contrib/detectors/container"
+	"go.opentelemetry.io/contrib/detectors/flyio"
+	"go.opentelemetry.io/contrib/detectors/gcp"
+	"go.opentelemetry.io/contrib/detectors/heroku"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// none is the special "detector" name that means no detector shall be
+// configured.
+const none = "none"
+
+// detectors is the registry of resource detectors registered with this
+// package. It includes the detectors of this repository and of the SDK at
+// startup.
+var detectors = &registry{
+	names: map[string]resource.Detector{
+		// OTEL_RESOURCE_ATTRIBUTES and OTEL_SERVICE_NAME.
+		"env": options{resource.WithFromEnv()},
+		// host.name.
+		"host": options{resource.WithHost()},
+		// host.id from the machine-id (Linux), IOPlatformUUID (macOS),
+		// MachineGuid (Windows) or hostid (BSD) of the host.
+		"host_id": options{resource.WithHostID()},
+		// os.type and os.description.
+		"os": options{resource.WithOS()},
+		// process.* attributes.
+		"process": options{resource.WithProcess()},
+		// container.id from the cgroup and mountinfo files.
+		"container": container.NewResourceDetector(),
+		// AWS EC2.
+		"ec2": ec2.NewResourceDetector(),
+		// AWS ECS.
+		"ecs": ecs.NewResourceDetector(),
+		// AWS EKS.
+		"eks": eks.NewResourceDetector(),
+		// AWS Lambda.
+		"lambda": lambda.NewResourceDetector(),
+		// Google Cloud Platform.
+		"gcp": gcp.NewDetector(),
+		// Microsoft Azure.
+		"azure": azure.NewResourceDetector(),
+		// Heroku.
+		"heroku": heroku.NewResourceDetector(),
+		// Fly.io.
+		"flyio": flyio.NewResourceDetector(),
+
+		// No-op detector.
+		none: composite(nil),
+	},
+}
+
+// options is a resource detector detecting the resource configured by the
+// SDK resource options it holds.
+type options []resource.Option
+
+// Detect returns the resource configured by the options.
+func (o options) Detect(ctx context.Context) (*resource.Resource, error) {
+	return resource.New(ctx, o...)
+}
+
+// registry maintains a map of detector names to resource detectors that is
+// safe for concurrent use by multiple goroutines without additional locking
+// or coordination.
+type registry struct {
+	mu    sync.Mutex
+	names map[string]resource.Detector
+}
+
+// load returns the value stored in the registry index for a key, or nil if no
+// value is present. The ok result indicates whether value was found in the
+// index.
+func (r *registry) load(key string) (d resource.Detector, ok bool) {
+	r.mu.Lock()
+	d, ok = r.names[key]
+	r.mu.Unlock()
+	return d, ok
+}
+
+var errDupReg = errors.New("duplicate registration")
+
+// store sets the value for a key if is not already in the registry. errDupReg
+// is returned if the registry already contains key.
+func (r *registry) store(key string, value resource.Detector) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.names == nil {
+		r.names = map[string]resource.Detector{key: value}
+		return nil
+	}
+	if _, ok := r.names[key]; ok {
+		return fmt.Errorf("%w: %q", errDupReg, key)
+	}
+	r.names[key] = value
+	return nil
+}
+
+// drop removes key from the registry if it exists, otherwise nothing.
+func (r *registry) drop(key string) {
+	r.mu.Lock()
+	delete(r.names, key)
+	r.mu.Unlock()
+}
+
+// RegisterDetector sets the resource detector d to be used when the
+// OTEL_RESOURCE_DETECTORS environment variable contains the detector name.
+// This will panic if name has already been registered or is a default (env,
+// host, host_id, os, process, container, ec2, ecs, eks, lambda, gcp, azure,
+// heroku, or flyio).
+func RegisterDetector(name string, d resource.Detector) {
+	if err := detectors.store(name, d); err != nil {
+		// detectors.store will return errDupReg if name is already
+		// registered. Panic here so the user is made aware of the duplicate
+		// registration, which could be done by malicious code trying to
+		// intercept cross-cutting concerns.
+		//
+		// Panic for all other errors as well. At this point there should not
+		// be any other errors returned from the store operation. If there
+		// are, alert the developer that adding them as soon as possible that
+		// they need to be handled here.
+		panic(err)
+	}
+}
+
+// Detector returns a resource detector composed from the passed names of
+// registered detectors. Each name must match an already registered detector
+// (see the RegisterDetector function for more information) or a default:
+//
+//   - env: the OTEL_RESOURCE_ATTRIBUTES and OTEL_SERVICE_NAME environment
+//     variables.
+//   - host: the host.name attribute.
+//   - host_id: the host.id attribute, a stable identifier of the host read
+//     from its machine-id (Linux), IOPlatformUUID (macOS), MachineGuid
+//     (Windows) or hostid (BSD).
+//   - os: the os.type and os.description attributes.
+//   - process: the process.* attributes.
+//   - container: the container.id attribute.
+//   - ec2, ecs, eks, and lambda: the AWS environments.
+//   - gcp: the Google Cloud Platform environments.
+//   - azure: the Microsoft Azure environments.
+//   - heroku: the Heroku dynos.
+//   - flyio: the Fly.io Machines.
+//
+// The returned detector merges the resources detected by the detectors in
+// the order of the names, the attributes of the latter detectors taking
+// precedence.
+//
+// If "none" is included in the arguments, or no names are provided, the
+// returned detector does not detect any resource.
+//
+// An error is returned for any un-registered names. The remaining, known,
+// names will be used to compose a detector that is returned with the error,
+// nil if there are none.
+func Detector(names ...string) (resource.Detector, error) {
+	var (
+		ds      []resource.Detector
+		unknown []string
+	)
+
+	for _, name := range names {
+		if name == none {
+			// If "none" is passed in combination with any other detector,
+			// the result still needs to be a no-op detector. Therefore,
+			// short-circuit here.
+			return composite(nil), nil
+		}
+
+		d, ok := detectors.load(name)
+		if !ok {
+			unknown = append(unknown, name)
+			continue
+		}
+		ds = append(ds, d)
+	}
+
+	var err error
+	if len(unknown) > 0 {
+		err = fmt.Errorf("%w: %s", errUnknownDetector, strings.Join(unknown, ","))
+	}
+
+	switch len(ds) {
+	case 0:
+		if err != nil {
+			return nil, err
+		}
+		return composite(nil), nil
+	case 1:
+		// Do not return a composite of a single detector.
+		return ds[0], err
+	default:
+		return composite(ds), err
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autodetect
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+var noop = composite(nil)
+
+// staticDetector detects a resource with its attributes.
+type staticDetector []attribute.KeyValue
+
+func (d staticDetector) Detect(context.Context) (*resource.Resource, error) {
+	return resource.NewSchemaless(d...), nil
+}
+
+func TestRegistryEmptyStore(t *testing.T) {
+	r := registry{}
+	assert.NotPanics(t, func() {
+		require.NoError(t, r.store("first", noop))
+	})
+}
+
+func TestRegistryEmptyLoad(t *testing.T) {
+	r := registry{}
+	assert.NotPanics(t, func() {
+		v, ok := r.load("non-existent")
+		assert.False(t, ok, "empty registry should hold nothing")
+		assert.Nil(t, v, "non-nil detector returned")
+	})
+}
+
+func TestRegistryConcurrentSafe(t *testing.T) {
+	const name = "detector"
+
+	r := registry{}
+	assert.NotPanics(t, func() {
+		require.NoError(t, r.store(name, noop))
+	})
+
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		assert.NotPanics(t, func() {
+			require.ErrorIs(t, r.store(name, noop), errDupReg)
+		})
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		assert.NotPanics(t, func() {
+			v, ok := r.load(name)
+			assert.True(t, ok, "missing detector in registry")
+			assert.Equal(t, noop, v, "wrong detector returned")
+		})
+	}()
+
+	wg.Wait()
+}
+
+func TestRegisterDetector(t *testing.T) {
+	const name = "custom"
+	d := staticDetector{attribute.String("custom", "value")}
+	RegisterDetector(name, d)
+	t.Cleanup(func() { detectors.drop(name) })
+
+	got, err := Detector(name)
+	require.NoError(t, err)
+	assert.Equal(t, d, got)
+}
+
+func TestDuplicateRegisterDetectorPanics(t *testing.T) {
+	const name = "dup"
+	RegisterDetector(name, noop)
+	t.Cleanup(func() { detectors.drop(name) })
+
+	errString := `duplicate registration: "dup"`
+	assert.PanicsWithError(t, errString, func() {
+		RegisterDetector(name, noop)
+	})
+}
+
+func TestDefaultDetectorsRegistered(t *testing.T) {
+	for _, name := range []string{
+		"env", "host", "host_id", "os", "process", "container",
+		"ec2", "ecs", "eks", "lambda", "gcp", "azure", "heroku", "flyio",
+	} {
+		d, ok := detectors.load(name)
+		assert.Truef(t, ok, "%s not registered", name)
+		assert.NotNilf(t, d, "%s registered as nil", name)
+	}
+}
+
+func TestDetector(t *testing.T) {
+	a := staticDetector{attribute.String("a", "1"), attribute.String("k", "a")}
+	b := staticDetector{attribute.String("b", "2"), attribute.String("k", "b")}
+	RegisterDetector("a", a)
+	RegisterDetector("b", b)
+	t.Cleanup(func() {
+		detectors.drop("a")
+		detectors.drop("b")
+	})
+
+	d, err := Detector("a", "b")
+	require.NoError(t, err)
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, resource.NewSchemaless(
+		attribute.String("a", "1"),
+		attribute.String("b", "2"),
+		attribute.String("k", "b"),
+	), res)
+
+	d, err = Detector("a", "unknown", "b", "other")
+	assert.ErrorIs(t, err, errUnknownDetector)
+	assert.ErrorContains(t, err, "unknown,other")
+	assert.Equal(t, composite{a, b}, d)
+
+	d, err = Detector("unknown")
+	assert.ErrorIs(t, err, errUnknownDetector)
+	assert.Nil(t, d)
+}
+
+func TestDetectorNone(t *testing.T) {
+	for _, names := range [][]string{nil, {none}, {"host", none, "unknown"}} {
+		d, err := Detector(names...)
+		require.NoError(t, err)
+		res, err := d.Detect(context.Background())
+		require.NoError(t, err)
+		assert.Nil(t, res)
+	}
+}
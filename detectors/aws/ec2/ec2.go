@@ -16,9 +16,13 @@ package ec2 // import "go.opentelemetry.io/contrib/detectors/aws/ec2"
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"sync"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/ec2metadata"
 	"github.com/aws/aws-sdk-go/aws/session"
@@ -28,13 +32,21 @@ import (
 	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
 )
 
+// ErrNotOnPlatform is returned by the detector when the instance metadata
+// service cannot be reached, i.e. when the process is not running on EC2. It
+// distinguishes an inapplicable detector from a detection failure.
+var ErrNotOnPlatform = errors.New("process is not running on EC2, the instance metadata service cannot be reached")
+
 type config struct {
-	c Client
+	c          Client
+	httpClient *http.Client
+	timeout    time.Duration
+	maxRetries int
 }
 
 // newConfig returns an appropriately configured config.
 func newConfig(options ...Option) *config {
-	c := new(config)
+	c := &config{maxRetries: aws.UseServiceDefaultRetries}
 	for _, option := range options {
 		option.apply(c)
 	}
@@ -60,13 +72,71 @@ func WithClient(t Client) Option {
 	})
 }
 
+// WithHTTPClient sets the HTTP client used by the default client to make
+// requests to the instance metadata service (IMDS), e.g. one with a proxy
+// or a custom TLS configuration. The HTTP client of the AWS SDK, with a
+// short timeout, is used if it is not set.
+//
+// This option is ignored if a client is set with WithClient.
+func WithHTTPClient(client *http.Client) Option {
+	return optionFunc(func(c *config) {
+		c.httpClient = client
+	})
+}
+
+// WithTimeout sets the timeout of the HTTP requests made to the instance
+// metadata service (IMDS) by the default client. The short default timeout
+// of the AWS SDK is used if it is not positive. If an HTTP client is set
+// with WithHTTPClient, its timeout is overridden.
+//
+// This option is ignored if a client is set with WithClient.
+func WithTimeout(timeout time.Duration) Option {
+	return optionFunc(func(c *config) {
+		c.timeout = timeout
+	})
+}
+
+// WithFailFast disables the retries of the requests made to the instance
+// metadata service (IMDS) by the default client. When not running on EC2,
+// the detection then fails after a single request timeout instead of
+// delaying the startup by several seconds of retries.
+//
+// This option is ignored if a client is set with WithClient.
+func WithFailFast() Option {
+	return WithMaxRetries(0)
+}
+
+// WithMaxRetries sets the maximum number of times the requests made to the
+// instance metadata service (IMDS) by the default client are retried. The
+// retry policy of the AWS SDK is used if n is negative.
+//
+// This option is ignored if a client is set with WithClient.
+func WithMaxRetries(n int) Option {
+	return optionFunc(func(c *config) {
+		if n < 0 {
+			n = aws.UseServiceDefaultRetries
+		}
+		c.maxRetries = n
+	})
+}
+
 func (cfg *config) getClient() Client {
 	return cfg.c
 }
 
 // resource detector collects resource information from EC2 environment.
 type resourceDetector struct {
-	c Client
+	c          Client
+	httpClient *http.Client
+	timeout    time.Duration
+	maxRetries int
+
+	// once guards the creation of the default client. It is created once
+	// and reused so the IMDSv2 session token it fetches is cached across
+	// detections.
+	once          sync.Once
+	defaultClient Client
+	err           error
 }
 
 // Client implements methods to capture EC2 environment metadata information.
@@ -82,7 +152,12 @@ var _ resource.Detector = (*resourceDetector)(nil)
 // NewResourceDetector returns a resource detector that will detect AWS EC2 resources.
 func NewResourceDetector(opts ...Option) resource.Detector {
 	c := newConfig(opts...)
-	return &resourceDetector{c.getClient()}
+	return &resourceDetector{
+		c:          c.getClient(),
+		httpClient: c.httpClient,
+		timeout:    c.timeout,
+		maxRetries: c.maxRetries,
+	}
 }
 
 // Detect detects associated resources when running in AWS environment.
@@ -92,8 +167,12 @@ func (detector *resourceDetector) Detect(ctx context.Context) (*resource.Resourc
 		return nil, err
 	}
 
-	if !client.Available() {
-		return nil, nil
+	ok, err := available(ctx, client)
+	if !ok {
+		if err == nil {
+			err = ErrNotOnPlatform
+		}
+		return nil, err
 	}
 
 	doc, err := client.GetInstanceIdentityDocument()
@@ -129,12 +208,70 @@ func (detector *resourceDetector) client() (Client, error) {
 		return detector.c, nil
 	}
 
+	detector.once.Do(func() {
+		detector.defaultClient, detector.err = detector.newClient()
+	})
+	return detector.defaultClient, detector.err
+}
+
+// newClient returns an IMDS client configured with the detector options. The
+// client uses IMDSv2 session tokens, falling back to IMDSv1 if a token
+// cannot be fetched, and caches the token until it expires.
+func (detector *resourceDetector) newClient() (Client, error) {
 	s, err := session.NewSession()
 	if err != nil {
 		return nil, err
 	}
 
-	return ec2metadata.New(s), nil
+	cfg := aws.NewConfig()
+	httpClient := detector.httpClient
+	if detector.timeout > 0 {
+		if httpClient == nil {
+			httpClient = &http.Client{}
+		} else {
+			// Do not modify the HTTP client of the user.
+			c := *httpClient
+			httpClient = &c
+		}
+		httpClient.Timeout = detector.timeout
+	}
+	if httpClient != nil {
+		cfg = cfg.WithHTTPClient(httpClient)
+	}
+	if detector.maxRetries != aws.UseServiceDefaultRetries {
+		cfg = cfg.WithMaxRetries(detector.maxRetries)
+	}
+	return ec2metadata.New(s, cfg), nil
+}
+
+// metadataWithContext is implemented by the clients supporting the
+// cancellation of their requests, e.g. *ec2metadata.EC2Metadata.
+type metadataWithContext interface {
+	GetMetadataWithContext(ctx aws.Context, p string) (string, error)
+}
+
+// available returns whether the IMDS is available, honoring the
+// cancellation of ctx if the client supports it.
+//
+// An error is returned if the IMDS is reachable but fails the request, e.g.
+// when IMDSv2 is required but no session token could be fetched because of
+// the hop limit of the instance. This is a misconfiguration to report, not
+// the process running outside of EC2.
+func available(ctx context.Context, client Client) (bool, error) {
+	c, ok := client.(metadataWithContext)
+	if !ok {
+		return client.Available(), nil
+	}
+
+	_, err := c.GetMetadataWithContext(ctx, "instance-id")
+	if err == nil {
+		return true, nil
+	}
+	if rf, ok := err.(awserr.RequestFailure); ok && rf.StatusCode() != 0 {
+		return false, fmt.Errorf("instance metadata service: %d %s", rf.StatusCode(), rf.Code())
+	}
+	// The IMDS cannot be reached.
+	return false, nil
 }
 
 type metadata struct {
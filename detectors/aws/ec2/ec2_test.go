@@ -21,6 +21,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/ec2metadata"
 	"github.com/stretchr/testify/assert"
@@ -78,6 +79,7 @@ func TestAWS_Detect(t *testing.T) {
 	}{
 		"Unavailable": {
 			Fields: fields{Client: &clientMock{}},
+			Want:   want{Error: ErrNotOnPlatform.Error()},
 		},
 		"Instance ID Error": {
 			Fields: fields{
@@ -173,6 +175,91 @@ func TestAWS_Detect(t *testing.T) {
 	}
 }
 
+func TestDefaultClientCached(t *testing.T) {
+	detector := NewResourceDetector(WithTimeout(100*time.Millisecond), WithFailFast()).(*resourceDetector)
+
+	c1, err := detector.client()
+	require.NoError(t, err)
+	c2, err := detector.client()
+	require.NoError(t, err)
+	assert.Same(t, c1, c2, "default client not reused")
+
+	client, ok := c1.(*ec2metadata.EC2Metadata)
+	require.True(t, ok, "unexpected default client type %T", c1)
+	assert.Equal(t, 100*time.Millisecond, client.Config.HTTPClient.Timeout)
+	assert.Equal(t, 0, *client.Config.MaxRetries)
+}
+
+func TestDefaultClientHTTPClient(t *testing.T) {
+	httpClient := &http.Client{Timeout: time.Second}
+	detector := NewResourceDetector(WithHTTPClient(httpClient), WithMaxRetries(5)).(*resourceDetector)
+
+	c, err := detector.client()
+	require.NoError(t, err)
+	client, ok := c.(*ec2metadata.EC2Metadata)
+	require.True(t, ok, "unexpected default client type %T", c)
+	assert.Same(t, httpClient, client.Config.HTTPClient)
+	assert.Equal(t, 5, *client.Config.MaxRetries)
+}
+
+func TestDefaultClientHTTPClientTimeout(t *testing.T) {
+	httpClient := &http.Client{Timeout: time.Second}
+	detector := NewResourceDetector(
+		WithHTTPClient(httpClient),
+		WithTimeout(100*time.Millisecond),
+	).(*resourceDetector)
+
+	c, err := detector.client()
+	require.NoError(t, err)
+	client, ok := c.(*ec2metadata.EC2Metadata)
+	require.True(t, ok, "unexpected default client type %T", c)
+	assert.Equal(t, 100*time.Millisecond, client.Config.HTTPClient.Timeout)
+	assert.Equal(t, time.Second, httpClient.Timeout, "HTTP client of the user modified")
+}
+
+func TestDetectAvailableWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	c := &contextClientMock{clientMock: clientMock{available: true}}
+	r, err := NewResourceDetector(WithClient(c)).Detect(ctx)
+	assert.ErrorIs(t, err, ErrNotOnPlatform)
+	assert.Nil(t, r)
+}
+
+func TestDetectIMDSRequestFailure(t *testing.T) {
+	// IMDSv2 is required but no session token could be fetched.
+	unauthorized := awserr.NewRequestFailure(awserr.New("EC2MetadataError", "failed to make EC2Metadata request", errors.New("response error")), http.StatusUnauthorized, "test-request")
+	c := &contextClientMock{clientMock: clientMock{
+		metadata: map[string]meta{"instance-id": {err: unauthorized}},
+	}}
+	r, err := NewResourceDetector(WithClient(c)).Detect(context.Background())
+	assert.ErrorContains(t, err, "401")
+	assert.Nil(t, r)
+}
+
+func TestDetectIMDSUnreachable(t *testing.T) {
+	c := &contextClientMock{clientMock: clientMock{
+		metadata: map[string]meta{"instance-id": {err: awserr.New("RequestError", "send request failed", errors.New("dial tcp: i/o timeout"))}},
+	}}
+	r, err := NewResourceDetector(WithClient(c)).Detect(context.Background())
+	assert.ErrorIs(t, err, ErrNotOnPlatform)
+	assert.Nil(t, r)
+}
+
+// contextClientMock is a clientMock whose requests honor the cancellation
+// of the context.
+type contextClientMock struct {
+	clientMock
+}
+
+func (c *contextClientMock) GetMetadataWithContext(ctx aws.Context, p string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return c.GetMetadata(p)
+}
+
 type clientMock struct {
 	available bool
 	idDoc     func() (ec2metadata.EC2InstanceIdentityDocument, error)
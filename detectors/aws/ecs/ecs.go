@@ -46,6 +46,15 @@ var (
 	errCannotRetrieveLogsStreamMetadataV4 = errors.New("the ECS Metadata v4 did not return a AwsLogStream name")
 )
 
+// ErrNotOnPlatform is returned by the detector when the process is not
+// running on ECS, i.e. when the container metadata endpoint is not set. It
+// distinguishes an inapplicable detector from a detection failure.
+var ErrNotOnPlatform = errors.New("process is not running on ECS, the container metadata endpoint is not set")
+
+// taskKnownContainersKey is the names of the containers of the task, not
+// defined by the semantic conventions.
+const taskKnownContainersKey = attribute.Key("aws.ecs.task.known_containers")
+
 // Create interface for methods needing to be mocked.
 type detectorUtils interface {
 	getContainerName() (string, error)
@@ -57,7 +66,9 @@ type ecsDetectorUtils struct{}
 
 // resource detector collects resource information from Elastic Container Service environment.
 type resourceDetector struct {
-	utils detectorUtils
+	utils      detectorUtils
+	httpClient *http.Client
+	task       *TaskMetadata
 }
 
 // compile time assertion that ecsDetectorUtils implements detectorUtils interface.
@@ -66,10 +77,63 @@ var _ detectorUtils = (*ecsDetectorUtils)(nil)
 // compile time assertion that resource detector implements the resource.Detector interface.
 var _ resource.Detector = (*resourceDetector)(nil)
 
+type config struct {
+	httpClient *http.Client
+	task       *TaskMetadata
+}
+
+// newConfig returns an appropriately configured config.
+func newConfig(options ...Option) *config {
+	c := new(config)
+	for _, option := range options {
+		option.apply(c)
+	}
+
+	return c
+}
+
+// Option applies an ECS detector configuration option.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (fn optionFunc) apply(c *config) {
+	fn(c)
+}
+
+// WithHTTPClient sets the HTTP client used to make requests to the task
+// metadata endpoint, e.g. one with a proxy, a custom TLS configuration or a
+// transport retrying failed requests. A client with the default settings
+// of the net/http package is used if it is not set.
+func WithHTTPClient(client *http.Client) Option {
+	return optionFunc(func(c *config) {
+		c.httpClient = client
+	})
+}
+
+// WithTaskMetadata sets the TaskMetadata the metadata of the task is
+// retrieved from, so it is retrieved once for all the detectors sharing it.
+// The detector retrieves the metadata of the task once with its own
+// TaskMetadata if it is not set.
+func WithTaskMetadata(m *TaskMetadata) Option {
+	return optionFunc(func(c *config) {
+		c.task = m
+	})
+}
+
 // NewResourceDetector returns a resource detector that will detect AWS ECS resources.
-func NewResourceDetector() resource.Detector {
+func NewResourceDetector(opts ...Option) resource.Detector {
+	c := newConfig(opts...)
+	task := c.task
+	if task == nil {
+		task = NewTaskMetadata(c.httpClient)
+	}
 	return &resourceDetector{
-		utils: ecsDetectorUtils{},
+		utils:      ecsDetectorUtils{},
+		httpClient: c.httpClient,
+		task:       task,
 	}
 }
 
@@ -79,7 +143,7 @@ func (detector *resourceDetector) Detect(ctx context.Context) (*resource.Resourc
 	metadataURIV4 := os.Getenv(metadataV4EnvVar)
 
 	if len(metadataURIV3) == 0 && len(metadataURIV4) == 0 {
-		return nil, nil
+		return nil, ErrNotOnPlatform
 	}
 	hostName, err := detector.utils.getContainerName()
 	if err != nil {
@@ -97,7 +161,12 @@ func (detector *resourceDetector) Detect(ctx context.Context) (*resource.Resourc
 	}
 
 	if len(metadataURIV4) > 0 {
-		containerMetadata, err := ecsmetadata.GetContainerV4(ctx, &http.Client{})
+		httpClient := detector.httpClient
+		if httpClient == nil {
+			httpClient = &http.Client{}
+		}
+
+		containerMetadata, err := ecsmetadata.GetContainerV4(ctx, httpClient)
 		if err != nil {
 			return empty, err
 		}
@@ -105,8 +174,19 @@ func (detector *resourceDetector) Detect(ctx context.Context) (*resource.Resourc
 			attributes,
 			semconv.AWSECSContainerARN(containerMetadata.ContainerARN),
 		)
+		attributes = append(attributes, imageAttributes(containerMetadata)...)
+
+		if containerID == "" && containerMetadata.DockerID != "" {
+			// The cgroup file of Fargate tasks does not contain the
+			// container ID, use the one of the metadata endpoint.
+			attributes = append(attributes, semconv.ContainerID(containerMetadata.DockerID))
+		}
 
-		taskMetadata, err := ecsmetadata.GetTaskV4(ctx, &http.Client{})
+		task := detector.task
+		if task == nil {
+			task = NewTaskMetadata(httpClient)
+		}
+		taskMetadata, err := task.get(ctx)
 		if err != nil {
 			return empty, err
 		}
@@ -117,6 +197,11 @@ func (detector *resourceDetector) Detect(ctx context.Context) (*resource.Resourc
 			clusterArn = fmt.Sprintf("%s:cluster/%s", baseArn, clusterArn)
 		}
 
+		attributes = append(attributes, taskARNAttributes(taskMetadata.TaskARN)...)
+		if taskMetadata.AvailabilityZone != "" {
+			attributes = append(attributes, semconv.CloudAvailabilityZone(taskMetadata.AvailabilityZone))
+		}
+
 		logAttributes, err := detector.getLogsAttributes(containerMetadata)
 		if err != nil {
 			return empty, err
@@ -134,6 +219,9 @@ func (detector *resourceDetector) Detect(ctx context.Context) (*resource.Resourc
 			semconv.AWSECSTaskFamily(taskMetadata.Family),
 			semconv.AWSECSTaskRevision(taskMetadata.Revision),
 		)
+		if names := knownContainers(taskMetadata); len(names) > 0 {
+			attributes = append(attributes, taskKnownContainersKey.StringSlice(names))
+		}
 	}
 
 	return resource.NewWithAttributes(semconv.SchemaURL, attributes...), nil
@@ -191,6 +279,63 @@ func (detector *resourceDetector) getLogsAttributes(metadata *ecsmetadata.Contai
 	}, nil
 }
 
+// imageAttributes returns the attributes of the image of the container
+// described by metadata.
+func imageAttributes(metadata *ecsmetadata.ContainerMetadataV4) []attribute.KeyValue {
+	var attributes []attribute.KeyValue
+	name, tag := parseImage(metadata.Image)
+	if name != "" {
+		attributes = append(attributes, semconv.ContainerImageName(name))
+	}
+	if tag != "" {
+		attributes = append(attributes, semconv.ContainerImageTag(tag))
+	}
+	if metadata.ImageID != "" {
+		// The image digest, e.g. sha256:25f3695bedfb...
+		attributes = append(attributes, semconv.ContainerImageID(metadata.ImageID))
+	}
+	return attributes
+}
+
+// parseImage returns the name and tag of the image reference, e.g.
+// 111122223333.dkr.ecr.us-west-2.amazonaws.com/curltest:latest. The tag is
+// empty if the reference has none.
+func parseImage(image string) (name, tag string) {
+	if i := strings.Index(image, "@"); i >= 0 {
+		// Strip the digest, e.g. curltest@sha256:25f3695bedfb...
+		image = image[:i]
+	}
+	// The reference may contain a registry port, only a colon following the
+	// last path separator delimits the tag.
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		return image[:i], image[i+1:]
+	}
+	return image, ""
+}
+
+// taskARNAttributes returns the cloud account and region attributes parsed
+// from the task ARN, e.g.
+// arn:aws:ecs:us-west-2:111122223333:task/default/158d1c8083dd49d6b527399fd6414f5c.
+func taskARNAttributes(taskARN string) []attribute.KeyValue {
+	// https://docs.aws.amazon.com/general/latest/gr/aws-arns-and-namespaces.html
+	const arnRegion = 3
+	const arnAccountID = 4
+	parts := strings.Split(taskARN, ":")
+	// a valid arn should have at least 6 parts
+	if len(parts) < 6 {
+		return nil
+	}
+
+	var attributes []attribute.KeyValue
+	if parts[arnAccountID] != "" {
+		attributes = append(attributes, semconv.CloudAccountID(parts[arnAccountID]))
+	}
+	if parts[arnRegion] != "" {
+		attributes = append(attributes, semconv.CloudRegion(parts[arnRegion]))
+	}
+	return attributes
+}
+
 // returns docker container ID from default c group path.
 func (ecsUtils ecsDetectorUtils) getContainerID() (string, error) {
 	if runtime.GOOS != "linux" {
@@ -113,8 +113,8 @@ func TestReturnsIfNoEnvVars(t *testing.T) {
 	detector := &resourceDetector{utils: nil}
 	res, err := detector.Detect(context.Background())
 
-	// When not on ECS, the detector should return nil and not error.
-	assert.NoError(t, err, "failure to detect when not on platform must not be an error")
+	// When not on ECS, the detector should return nil and ErrNotOnPlatform.
+	assert.ErrorIs(t, err, ErrNotOnPlatform)
 	assert.Nil(t, res, "failure to detect should return a nil Resource to optimize merge")
 }
 
@@ -149,3 +149,47 @@ func TestLogsAttributesAlternatePartition(t *testing.T) {
 	}
 	assert.Equal(t, expectedAttributes, actualAttributes, "logs attributes are incorrect")
 }
+
+func TestParseImage(t *testing.T) {
+	tests := []struct {
+		image, name, tag string
+	}{
+		{"curltest", "curltest", ""},
+		{"curltest:latest", "curltest", "latest"},
+		{"amazon/amazon-ecs-pause:0.1.0", "amazon/amazon-ecs-pause", "0.1.0"},
+		{"registry:5000/curltest", "registry:5000/curltest", ""},
+		{"registry:5000/curltest:1.0", "registry:5000/curltest", "1.0"},
+		{"curltest:1.0@sha256:25f3695bedfb", "curltest", "1.0"},
+		{"curltest@sha256:25f3695bedfb", "curltest", ""},
+	}
+
+	for _, tt := range tests {
+		name, tag := parseImage(tt.image)
+		assert.Equal(t, tt.name, name, tt.image)
+		assert.Equal(t, tt.tag, tag, tt.image)
+	}
+}
+
+func TestTaskARNAttributes(t *testing.T) {
+	expectedAttributes := []attribute.KeyValue{
+		semconv.CloudAccountID("111122223333"),
+		semconv.CloudRegion("us-west-2"),
+	}
+	actualAttributes := taskARNAttributes("arn:aws:ecs:us-west-2:111122223333:task/default/158d1c8083dd49d6b527399fd6414f5c")
+	assert.Equal(t, expectedAttributes, actualAttributes)
+
+	assert.Empty(t, taskARNAttributes("invalid"))
+}
+
+func TestKnownContainers(t *testing.T) {
+	task := &metadata.TaskMetadataV4{
+		Containers: []metadata.ContainerMetadataV4{
+			{Name: "~internal~ecs~pause"},
+			{Name: "app"},
+			{Name: ""},
+			{Name: "envoy"},
+		},
+	}
+	assert.Equal(t, []string{"app", "envoy"}, knownContainers(task))
+	assert.Nil(t, knownContainers(&metadata.TaskMetadataV4{}))
+}
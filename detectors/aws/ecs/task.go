@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ecs // import "go.opentelemetry.io/contrib/detectors/aws/ecs"
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+
+	ecsmetadata "github.com/brunoscheufler/aws-ecs-metadata-go"
+)
+
+// internalContainerPrefix is the prefix of the names of the containers
+// managed by the ECS agent, e.g. ~internal~ecs~pause.
+const internalContainerPrefix = "~internal~ecs~"
+
+// TaskMetadata retrieves the metadata of the ECS task the process is running
+// in from the task metadata endpoint v4, and shares it across the detectors
+// it is passed to with the WithTaskMetadata option. The metadata is retrieved
+// once, when first needed, instead of once per detector.
+//
+// To share the detected resource across the processes of a task, wrap the
+// detector with the caching detector of the
+// go.opentelemetry.io/contrib/detectors/cache module and a file in a volume
+// shared by the containers of the task.
+type TaskMetadata struct {
+	httpClient *http.Client
+
+	mu   sync.Mutex
+	task *ecsmetadata.TaskMetadataV4
+}
+
+// NewTaskMetadata returns a TaskMetadata retrieving the metadata with
+// httpClient. A client with the default settings of the net/http package is
+// used if httpClient is nil.
+func NewTaskMetadata(httpClient *http.Client) *TaskMetadata {
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+	return &TaskMetadata{httpClient: httpClient}
+}
+
+// get returns the task metadata, retrieving it if it has not been yet.
+// Failed retrievals are retried by the next call.
+func (m *TaskMetadata) get(ctx context.Context) (*ecsmetadata.TaskMetadataV4, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.task != nil {
+		return m.task, nil
+	}
+	task, err := ecsmetadata.GetTaskV4(ctx, m.httpClient)
+	if err != nil {
+		return nil, err
+	}
+	m.task = task
+	return task, nil
+}
+
+// knownContainers returns the names of the containers of the task, excluding
+// the ones managed by the ECS agent.
+func knownContainers(task *ecsmetadata.TaskMetadataV4) []string {
+	var names []string
+	for _, c := range task.Containers {
+		if c.Name == "" || strings.HasPrefix(c.Name, internalContainerPrefix) {
+			continue
+		}
+		names = append(names, c.Name)
+	}
+	return names
+}
@@ -68,16 +68,23 @@ func TestDetectV4LaunchTypeEc2(t *testing.T) {
 		semconv.CloudProviderAWS,
 		semconv.CloudPlatformAWSECS,
 		semconv.ContainerName(hostname),
-		// We are not running the test in an actual container,
-		// the container id is tested with mocks of the cgroup
-		// file in the unit tests
-		semconv.ContainerID(""),
+		// We are not running the test in an actual container, the
+		// container id is read from the metadata endpoint. Reading it
+		// from the cgroup file is tested with mocks in the unit tests.
+		semconv.ContainerID("ea32192c8553fbff06c9340478a2ff089b2bb5646fb718b4ee206641c9086d66"),
+		semconv.ContainerImageName("111122223333.dkr.ecr.us-west-2.amazonaws.com/curltest"),
+		semconv.ContainerImageTag("latest"),
+		semconv.ContainerImageID("sha256:d691691e9652791a60114e67b365688d20d19940dde7c4736ea30e660d8d3553"),
+		semconv.CloudAccountID("111122223333"),
+		semconv.CloudRegion("us-west-2"),
+		semconv.CloudAvailabilityZone("us-west-2d"),
 		semconv.AWSECSContainerARN("arn:aws:ecs:us-west-2:111122223333:container/0206b271-b33f-47ab-86c6-a0ba208a70a9"),
 		semconv.AWSECSClusterARN("arn:aws:ecs:us-west-2:111122223333:cluster/default"),
 		semconv.AWSECSLaunchtypeKey.String("ec2"),
 		semconv.AWSECSTaskARN("arn:aws:ecs:us-west-2:111122223333:task/default/158d1c8083dd49d6b527399fd6414f5c"),
 		semconv.AWSECSTaskFamily("curltest"),
 		semconv.AWSECSTaskRevision("26"),
+		attribute.StringSlice("aws.ecs.task.known_containers", []string{"curl"}),
 		semconv.AWSLogGroupNames("/ecs/metadata"),
 		semconv.AWSLogGroupARNs("arn:aws:logs:us-west-2:111122223333:log-group:/ecs/metadata:*"),
 		semconv.AWSLogStreamNames("ecs/curl/8f03e41243824aea923aca126495f665"),
@@ -125,16 +132,23 @@ func TestDetectV4LaunchTypeFargate(t *testing.T) {
 		semconv.CloudProviderAWS,
 		semconv.CloudPlatformAWSECS,
 		semconv.ContainerName(hostname),
-		// We are not running the test in an actual container,
-		// the container id is tested with mocks of the cgroup
-		// file in the unit tests
-		semconv.ContainerID(""),
+		// We are not running the test in an actual container, the
+		// container id is read from the metadata endpoint as done on
+		// Fargate.
+		semconv.ContainerID("cd189a933e5849daa93386466019ab50-2495160603"),
+		semconv.ContainerImageName("111122223333.dkr.ecr.us-west-2.amazonaws.com/curltest"),
+		semconv.ContainerImageTag("latest"),
+		semconv.ContainerImageID("sha256:25f3695bedfb454a50f12d127839a68ad3caf91e451c1da073db34c542c4d2cb"),
+		semconv.CloudAccountID("111122223333"),
+		semconv.CloudRegion("us-west-2"),
+		semconv.CloudAvailabilityZone("us-west-2a"),
 		semconv.AWSECSContainerARN("arn:aws:ecs:us-west-2:111122223333:container/05966557-f16c-49cb-9352-24b3a0dcd0e1"),
 		semconv.AWSECSClusterARN("arn:aws:ecs:us-west-2:111122223333:cluster/default"),
 		semconv.AWSECSLaunchtypeKey.String("fargate"),
 		semconv.AWSECSTaskARN("arn:aws:ecs:us-west-2:111122223333:task/default/e9028f8d5d8e4f258373e7b93ce9a3c3"),
 		semconv.AWSECSTaskFamily("curltest"),
 		semconv.AWSECSTaskRevision("3"),
+		attribute.StringSlice("aws.ecs.task.known_containers", []string{"curl"}),
 		semconv.AWSLogGroupNames("/ecs/containerlogs"),
 		semconv.AWSLogGroupARNs("arn:aws:logs:us-west-2:111122223333:log-group:/ecs/containerlogs:*"),
 		semconv.AWSLogStreamNames("ecs/curl/cd189a933e5849daa93386466019ab50"),
@@ -147,3 +161,66 @@ func TestDetectV4LaunchTypeFargate(t *testing.T) {
 	assert.Equal(t, nil, err, "Detector should not fail")
 	assert.Equal(t, expectedResource, res, "Resource returned is incorrect")
 }
+
+// countingTransport counts the requests it sends.
+type countingTransport struct {
+	requests int
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests++
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// uses the HTTP client passed with the WithHTTPClient option.
+func TestDetectV4WithHTTPClient(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		file := "metadatav4-response-container-fargate.json"
+		if strings.HasSuffix(req.URL.String(), "/task") {
+			file = "metadatav4-response-task-fargate.json"
+		}
+		content, err := os.ReadFile(file)
+		if err == nil {
+			_, _ = res.Write(content)
+		}
+	}))
+	defer testServer.Close()
+
+	os.Clearenv()
+	_ = os.Setenv(metadataV4EnvVar, testServer.URL)
+
+	transport := &countingTransport{}
+	detector := ecs.NewResourceDetector(ecs.WithHTTPClient(&http.Client{Transport: transport}))
+	_, err := detector.Detect(context.Background())
+
+	assert.NoError(t, err, "Detector should not fail")
+	assert.Equal(t, 2, transport.requests, "HTTP client not used for the container and task metadata")
+}
+
+// shares the task metadata passed with the WithTaskMetadata option.
+func TestDetectV4WithTaskMetadata(t *testing.T) {
+	var taskRequests int
+	testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		file := "metadatav4-response-container-fargate.json"
+		if strings.HasSuffix(req.URL.String(), "/task") {
+			taskRequests++
+			file = "metadatav4-response-task-fargate.json"
+		}
+		content, err := os.ReadFile(file)
+		if err == nil {
+			_, _ = res.Write(content)
+		}
+	}))
+	defer testServer.Close()
+
+	os.Clearenv()
+	_ = os.Setenv(metadataV4EnvVar, testServer.URL)
+
+	task := ecs.NewTaskMetadata(nil)
+	for i := 0; i < 3; i++ {
+		detector := ecs.NewResourceDetector(ecs.WithTaskMetadata(task))
+		_, err := detector.Detect(context.Background())
+		assert.NoError(t, err, "Detector should not fail")
+	}
+	assert.Equal(t, 1, taskRequests, "task metadata not shared")
+}
@@ -16,7 +16,9 @@ package eks // import "go.opentelemetry.io/contrib/detectors/aws/eks"
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"regexp"
 	"strings"
@@ -38,9 +40,17 @@ const (
 	cwConfigmapNS     = "amazon-cloudwatch"
 	cwConfigmapName   = "cluster-info"
 	defaultCgroupPath = "/proc/self/cgroup"
-	containerIDLength = 64
+	mountinfoPath     = "/proc/self/mountinfo"
 )
 
+// ErrNotOnPlatform is returned, wrapped with the cause, by the detector
+// when the process is not running in a Kubernetes cluster. It distinguishes
+// an inapplicable detector from a detection failure.
+var ErrNotOnPlatform = errors.New("process is not running in a Kubernetes cluster")
+
+// containerIDRegexp matches the 64 hexadecimal characters of a container ID.
+var containerIDRegexp = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
 // detectorUtils is used for testing the resourceDetector by abstracting functions that rely on external systems.
 type detectorUtils interface {
 	fileExists(filename string) bool
@@ -65,9 +75,50 @@ var _ resource.Detector = (*resourceDetector)(nil)
 // Compile time assertion that eksDetectorUtils implements the detectorUtils interface.
 var _ detectorUtils = (*eksDetectorUtils)(nil)
 
+type config struct {
+	httpClient *http.Client
+}
+
+// newConfig returns an appropriately configured config.
+func newConfig(options ...Option) *config {
+	c := new(config)
+	for _, option := range options {
+		option.apply(c)
+	}
+
+	return c
+}
+
+// Option applies an EKS detector configuration option.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (fn optionFunc) apply(c *config) {
+	fn(c)
+}
+
+// WithHTTPClient sets the HTTP client used to make requests to the
+// Kubernetes API server, e.g. one with a proxy or a transport retrying
+// failed requests. The client takes precedence over the transport settings
+// of the in-cluster configuration, it needs to trust the certificate of the
+// API server and to authenticate the requests, e.g. by wrapping the client
+// returned by rest.HTTPClientFor for rest.InClusterConfig.
+//
+// A client built from the in-cluster configuration is used if it is not
+// set.
+func WithHTTPClient(client *http.Client) Option {
+	return optionFunc(func(c *config) {
+		c.httpClient = client
+	})
+}
+
 // NewResourceDetector returns a resource detector that will detect AWS EKS resources.
-func NewResourceDetector() resource.Detector {
-	utils, err := newK8sDetectorUtils()
+func NewResourceDetector(opts ...Option) resource.Detector {
+	c := newConfig(opts...)
+	utils, err := newK8sDetectorUtils(c.httpClient)
 	return &resourceDetector{utils: utils, err: err}
 }
 
@@ -130,16 +181,25 @@ func isEKS(ctx context.Context, utils detectorUtils) (bool, error) {
 	return awsAuth != nil, nil
 }
 
-// newK8sDetectorUtils creates the Kubernetes clientset.
-func newK8sDetectorUtils() (*eksDetectorUtils, error) {
+// newK8sDetectorUtils creates the Kubernetes clientset, using httpClient if
+// it is not nil.
+func newK8sDetectorUtils(httpClient *http.Client) (*eksDetectorUtils, error) {
 	// Get cluster configuration
 	confs, err := rest.InClusterConfig()
+	if errors.Is(err, rest.ErrNotInCluster) {
+		return nil, fmt.Errorf("%w: %w", ErrNotOnPlatform, err)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to create config: %w", err)
 	}
 
 	// Create clientset using generated configuration
-	clientset, err := kubernetes.NewForConfig(confs)
+	var clientset *kubernetes.Clientset
+	if httpClient != nil {
+		clientset, err = kubernetes.NewForConfigAndClient(confs, httpClient)
+	} else {
+		clientset, err = kubernetes.NewForConfig(confs)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to create clientset for Kubernetes client")
 	}
@@ -179,24 +239,72 @@ func getClusterName(ctx context.Context, utils detectorUtils) (string, error) {
 }
 
 // getContainerID returns the containerID if currently running within a container.
+//
+// The containerID is read from the cgroup file of the process. With cgroup v2
+// and cgroup namespaces this file does not contain the path of the cgroup of
+// the container, the containerID is then read from the mount points of the
+// container.
 func (eksUtils eksDetectorUtils) getContainerID() (string, error) {
 	fileData, err := os.ReadFile(defaultCgroupPath)
 	if err != nil {
 		return "", fmt.Errorf("getContainerID() error: cannot read file with path %s: %w", defaultCgroupPath, err)
 	}
-
-	// is this going to stop working with 1.20 when Docker is deprecated?
-	r, err := regexp.Compile(`^.*/docker/(.+)$`)
-	if err != nil {
-		return "", err
+	if id := containerIDFromCgroup(string(fileData)); id != "" {
+		return id, nil
 	}
 
-	// Retrieve containerID from file
-	splitData := strings.Split(strings.TrimSpace(string(fileData)), "\n")
-	for _, str := range splitData {
-		if r.MatchString(str) {
-			return str[len(str)-containerIDLength:], nil
+	fileData, err = os.ReadFile(mountinfoPath)
+	if err == nil {
+		if id := containerIDFromMountinfo(string(fileData)); id != "" {
+			return id, nil
 		}
 	}
 	return "", fmt.Errorf("getContainerID() error: cannot read containerID from file %s", defaultCgroupPath)
 }
+
+// containerIDFromCgroup returns the containerID found in the content of a
+// cgroup file, or an empty string if there is none. Each line of the file
+// has the hierarchy-ID:controller-list:cgroup-path format, e.g. for cgroup
+// v1 with Docker and for cgroup v2 with the containerd systemd driver:
+//
+//	12:memory:/kubepods/besteffort/pod4f3e.../docker/<id>
+//	0::/kubepods.slice/kubepods-besteffort.slice/kubepods-besteffort-pod4f3e....slice/cri-containerd-<id>.scope
+func containerIDFromCgroup(data string) string {
+	for _, line := range strings.Split(strings.TrimSpace(data), "\n") {
+		parts := strings.SplitN(line, ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		segments := strings.Split(parts[2], "/")
+		for i := len(segments) - 1; i >= 0; i-- {
+			// Strip the runtime prefix (e.g. docker-, cri-containerd-,
+			// crio-) and the .scope suffix of systemd cgroups.
+			segment := strings.TrimSuffix(segments[i], ".scope")
+			if j := strings.LastIndexAny(segment, "-:"); j >= 0 {
+				segment = segment[j+1:]
+			}
+			if containerIDRegexp.MatchString(segment) {
+				return segment
+			}
+		}
+	}
+	return ""
+}
+
+// containerIDFromMountinfo returns the containerID found in the content of a
+// mountinfo file, or an empty string if there is none. The containerID is
+// the one of the directory of the container files mounted in the container,
+// e.g. /var/lib/docker/containers/<id>/hostname.
+func containerIDFromMountinfo(data string) string {
+	for _, line := range strings.Split(strings.TrimSpace(data), "\n") {
+		for _, field := range strings.Fields(line) {
+			segments := strings.Split(field, "/")
+			for i := 0; i < len(segments)-1; i++ {
+				if segments[i] == "containers" && containerIDRegexp.MatchString(segments[i+1]) {
+					return segments[i+1]
+				}
+			}
+		}
+	}
+	return ""
+}
@@ -16,11 +16,13 @@ package eks
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/sdk/resource"
@@ -93,3 +95,78 @@ func TestNotEKS(t *testing.T) {
 	assert.Equal(t, resource.Empty(), r, "Resource object should be empty")
 	detectorUtils.AssertExpectations(t)
 }
+
+func TestContainerIDFromCgroup(t *testing.T) {
+	const id = "ea32192c8553fbff06c9340478a2ff089b2bb5646fb718b4ee206641c9086d66"
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{
+			name: "cgroup v1 docker",
+			data: "12:memory:/kubepods/besteffort/pod0a5c7b8d-1f1e-4bc3-9d0c-d7f8f4a3c7a1/docker/" + id + "\n" +
+				"11:cpu,cpuacct:/kubepods/besteffort/pod0a5c7b8d-1f1e-4bc3-9d0c-d7f8f4a3c7a1/docker/" + id,
+			want: id,
+		},
+		{
+			name: "cgroup v1 containerd",
+			data: "12:memory:/kubepods/burstable/pod0a5c7b8d-1f1e-4bc3-9d0c-d7f8f4a3c7a1/" + id,
+			want: id,
+		},
+		{
+			name: "cgroup v2 containerd systemd",
+			data: "0::/kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod0a5c7b8d_1f1e_4bc3_9d0c_d7f8f4a3c7a1.slice/cri-containerd-" + id + ".scope",
+			want: id,
+		},
+		{
+			name: "cgroup v2 crio",
+			data: "0::/kubepods.slice/crio-" + id + ".scope",
+			want: id,
+		},
+		{
+			name: "cgroup v2 namespace",
+			data: "0::/",
+			want: "",
+		},
+		{
+			name: "not a container",
+			data: "0::/user.slice/user-1000.slice/session-2.scope",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, containerIDFromCgroup(tt.data))
+		})
+	}
+}
+
+func TestContainerIDFromMountinfo(t *testing.T) {
+	const id = "ea32192c8553fbff06c9340478a2ff089b2bb5646fb718b4ee206641c9086d66"
+	data := "1185 1169 0:345 / / rw,relatime master:303 - overlay overlay rw\n" +
+		"1195 1185 259:1 /var/lib/docker/containers/" + id + "/resolv.conf /etc/resolv.conf rw,relatime - ext4 /dev/nvme0n1p1 rw\n" +
+		"1196 1185 259:1 /var/lib/docker/containers/" + id + "/hostname /etc/hostname rw,relatime - ext4 /dev/nvme0n1p1 rw\n"
+	assert.Equal(t, id, containerIDFromMountinfo(data))
+
+	data = "1185 1169 0:345 / / rw,relatime master:303 - overlay overlay rw\n"
+	assert.Equal(t, "", containerIDFromMountinfo(data))
+}
+
+func TestNewResourceDetectorWithHTTPClient(t *testing.T) {
+	httpClient := &http.Client{}
+	assert.Same(t, httpClient, newConfig(WithHTTPClient(httpClient)).httpClient)
+
+	// The tests do not run in a Kubernetes cluster.
+	detector := NewResourceDetector(WithHTTPClient(httpClient)).(*resourceDetector)
+	assert.ErrorIs(t, detector.err, rest.ErrNotInCluster)
+}
+
+func TestDetectNotOnPlatform(t *testing.T) {
+	// The tests do not run in a Kubernetes cluster.
+	res, err := NewResourceDetector().Detect(context.Background())
+	assert.Nil(t, res)
+	assert.ErrorIs(t, err, ErrNotOnPlatform)
+	assert.ErrorIs(t, err, rest.ErrNotInCluster)
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"os"
+	"runtime"
 	"strconv"
 
 	"go.opentelemetry.io/otel/attribute"
@@ -33,12 +34,18 @@ const (
 	lambdaFunctionVersionEnvVar = "AWS_LAMBDA_FUNCTION_VERSION"
 	lambdaLogStreamNameEnvVar   = "AWS_LAMBDA_LOG_STREAM_NAME"
 	lambdaMemoryLimitEnvVar     = "AWS_LAMBDA_FUNCTION_MEMORY_SIZE"
+	lambdaLogGroupNameEnvVar    = "AWS_LAMBDA_LOG_GROUP_NAME"
 )
 
-var (
-	empty          = resource.Empty()
-	errNotOnLambda = errors.New("process is not on Lambda, cannot detect environment variables from Lambda")
-)
+// bytesPerMiB converts the memory limit, in MiB, to bytes.
+const bytesPerMiB = 1024 * 1024
+
+var empty = resource.Empty()
+
+// ErrNotOnPlatform is returned by the detector when the process is not
+// running on AWS Lambda. It distinguishes an inapplicable detector from a
+// detection failure.
+var ErrNotOnPlatform = errors.New("process is not on Lambda, cannot detect environment variables from Lambda")
 
 // resource detector collects resource information from Lambda environment.
 type resourceDetector struct{}
@@ -56,7 +63,7 @@ func (detector *resourceDetector) Detect(context.Context) (*resource.Resource, e
 	// Lambda resources come from ENV
 	lambdaName := os.Getenv(lambdaFunctionNameEnvVar)
 	if len(lambdaName) == 0 {
-		return empty, errNotOnLambda
+		return empty, ErrNotOnPlatform
 	}
 	awsRegion := os.Getenv(awsRegionEnvVar)
 	functionVersion := os.Getenv(lambdaFunctionVersionEnvVar)
@@ -66,6 +73,7 @@ func (detector *resourceDetector) Detect(context.Context) (*resource.Resource, e
 
 	attrs := []attribute.KeyValue{
 		semconv.CloudProviderAWS,
+		semconv.CloudPlatformAWSLambda,
 		semconv.CloudRegion(awsRegion),
 		semconv.FaaSInstance(instance),
 		semconv.FaaSName(lambdaName),
@@ -75,8 +83,29 @@ func (detector *resourceDetector) Detect(context.Context) (*resource.Resource, e
 	maxMemoryStr := os.Getenv(lambdaMemoryLimitEnvVar)
 	maxMemory, err := strconv.Atoi(maxMemoryStr)
 	if err == nil {
-		attrs = append(attrs, semconv.FaaSMaxMemory(maxMemory))
+		attrs = append(attrs, semconv.FaaSMaxMemory(maxMemory*bytesPerMiB))
+	}
+
+	if logGroup := os.Getenv(lambdaLogGroupNameEnvVar); logGroup != "" {
+		attrs = append(attrs, semconv.AWSLogGroupNames(logGroup))
+	}
+
+	if arch, ok := hostArch(); ok {
+		attrs = append(attrs, arch)
 	}
 
 	return resource.NewWithAttributes(semconv.SchemaURL, attrs...), nil
 }
+
+// hostArch returns the host.arch attribute of the architectures supported by
+// Lambda.
+func hostArch() (attribute.KeyValue, bool) {
+	switch runtime.GOARCH {
+	case "amd64":
+		return semconv.HostArchAMD64, true
+	case "arm64":
+		return semconv.HostArchARM64, true
+	default:
+		return attribute.KeyValue{}, false
+	}
+}
@@ -34,14 +34,20 @@ func TestDetectSuccess(t *testing.T) {
 	_ = os.Setenv(lambdaFunctionVersionEnvVar, "$LATEST")
 	_ = os.Setenv(lambdaLogStreamNameEnvVar, "2023/01/01/[$LATEST]5d1edb9e525d486696cf01a3503487bc")
 	_ = os.Setenv(lambdaMemoryLimitEnvVar, "128")
+	_ = os.Setenv(lambdaLogGroupNameEnvVar, "/aws/lambda/testFunction")
 
 	attributes := []attribute.KeyValue{
 		semconv.CloudProviderAWS,
+		semconv.CloudPlatformAWSLambda,
 		semconv.CloudRegion("us-texas-1"),
 		semconv.FaaSName("testFunction"),
 		semconv.FaaSVersion("$LATEST"),
 		semconv.FaaSInstance("2023/01/01/[$LATEST]5d1edb9e525d486696cf01a3503487bc"),
-		semconv.FaaSMaxMemory(128),
+		semconv.FaaSMaxMemory(128 * 1024 * 1024),
+		semconv.AWSLogGroupNames("/aws/lambda/testFunction"),
+	}
+	if arch, ok := hostArch(); ok {
+		attributes = append(attributes, arch)
 	}
 	expectedResource := resource.NewWithAttributes(semconv.SchemaURL, attributes...)
 	detector := resourceDetector{}
@@ -57,6 +63,6 @@ func TestReturnsIfNoEnvVars(t *testing.T) {
 	detector := resourceDetector{}
 	res, err := detector.Detect(context.Background())
 
-	assert.Equal(t, errNotOnLambda, err)
+	assert.ErrorIs(t, err, ErrNotOnPlatform)
 	assert.Equal(t, 0, len(res.Attributes()))
 }
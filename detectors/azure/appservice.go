@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure // import "go.opentelemetry.io/contrib/detectors/azure"
+
+import (
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+// Environment variables set in App Service and Functions, see
+// https://learn.microsoft.com/en-us/azure/app-service/reference-app-settings
+const (
+	siteNameEnvVar         = "WEBSITE_SITE_NAME"
+	regionNameEnvVar       = "REGION_NAME"
+	slotNameEnvVar         = "WEBSITE_SLOT_NAME"
+	hostNameEnvVar         = "WEBSITE_HOSTNAME"
+	instanceIDEnvVar       = "WEBSITE_INSTANCE_ID"
+	ownerNameEnvVar        = "WEBSITE_OWNER_NAME"
+	resourceGroupEnvVar    = "WEBSITE_RESOURCE_GROUP"
+	functionsVersionEnvVar = "FUNCTIONS_EXTENSION_VERSION"
+)
+
+// detectAppService returns the resource of the App Service or Functions
+// site.
+func (detector *resourceDetector) detectAppService(site string) *resource.Resource {
+	attributes := []attribute.KeyValue{semconv.CloudProviderAzure}
+
+	if detector.getenv(functionsVersionEnvVar) != "" {
+		attributes = append(attributes,
+			semconv.CloudPlatformAzureFunctions,
+			semconv.FaaSName(site),
+		)
+		attributes = detector.appendEnv(attributes, semconv.FaaSInstanceKey, instanceIDEnvVar)
+	} else {
+		attributes = append(attributes,
+			semconv.CloudPlatformAzureAppService,
+			semconv.ServiceName(site),
+		)
+		attributes = detector.appendEnv(attributes, semconv.ServiceInstanceIDKey, instanceIDEnvVar)
+		attributes = detector.appendEnv(attributes, semconv.DeploymentEnvironmentKey, slotNameEnvVar)
+		attributes = detector.appendEnv(attributes, semconv.HostIDKey, hostNameEnvVar)
+	}
+
+	attributes = detector.appendEnv(attributes, semconv.CloudRegionKey, regionNameEnvVar)
+	if id := detector.siteResourceID(site); id != "" {
+		attributes = append(attributes, semconv.CloudResourceID(id))
+	}
+
+	return resource.NewWithAttributes(semconv.SchemaURL, attributes...)
+}
+
+// appendEnv appends the value of the environment variable env to
+// attributes, if set.
+func (detector *resourceDetector) appendEnv(attributes []attribute.KeyValue, key attribute.Key, env string) []attribute.KeyValue {
+	if v := detector.getenv(env); v != "" {
+		attributes = append(attributes, key.String(v))
+	}
+	return attributes
+}
+
+// siteResourceID returns the fully qualified resource ID of the site, or an
+// empty string if it cannot be determined.
+func (detector *resourceDetector) siteResourceID(site string) string {
+	// The owner name has the <subscription ID>+<resource group>-<region>webspace format.
+	owner := detector.getenv(ownerNameEnvVar)
+	subscription, _, ok := strings.Cut(owner, "+")
+	group := detector.getenv(resourceGroupEnvVar)
+	if !ok || subscription == "" || group == "" {
+		return ""
+	}
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Web/sites/%s", subscription, group, site)
+}
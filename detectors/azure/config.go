@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure // import "go.opentelemetry.io/contrib/detectors/azure"
+
+import "time"
+
+const (
+	// defaultEndpoint is the endpoint of the Azure Instance Metadata
+	// Service (IMDS).
+	defaultEndpoint = "http://169.254.169.254"
+	// defaultTimeout is the default timeout of the requests made to the
+	// IMDS. The IMDS is a local endpoint, a short timeout avoids delaying
+	// the startup of applications not running on Azure.
+	defaultTimeout = time.Second
+)
+
+type config struct {
+	endpoint string
+	timeout  time.Duration
+}
+
+// newConfig returns an appropriately configured config.
+func newConfig(options ...Option) *config {
+	c := &config{
+		endpoint: defaultEndpoint,
+		timeout:  defaultTimeout,
+	}
+	for _, option := range options {
+		option.apply(c)
+	}
+
+	return c
+}
+
+// Option applies an Azure detector configuration option.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (fn optionFunc) apply(c *config) {
+	fn(c)
+}
+
+// WithEndpoint sets the endpoint of the Azure Instance Metadata Service
+// (IMDS). The default is http://169.254.169.254.
+func WithEndpoint(endpoint string) Option {
+	return optionFunc(func(c *config) {
+		c.endpoint = endpoint
+	})
+}
+
+// WithTimeout sets the timeout of the requests made to the Azure Instance
+// Metadata Service (IMDS). The default is 1 second.
+func WithTimeout(timeout time.Duration) Option {
+	return optionFunc(func(c *config) {
+		c.timeout = timeout
+	})
+}
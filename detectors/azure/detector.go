@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package azure provides a resource detector for the Azure environments:
+// Virtual Machines, App Service, Functions and Azure Kubernetes Service (AKS).
+package azure // import "go.opentelemetry.io/contrib/detectors/azure"
+
+import (
+	"context"
+	"net/http"
+	"os"
+
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// resourceDetector collects resource information from Azure environments.
+type resourceDetector struct {
+	endpoint string
+	client   *http.Client
+	getenv   func(string) string
+}
+
+// compile time assertion that resourceDetector implements the resource.Detector interface.
+var _ resource.Detector = (*resourceDetector)(nil)
+
+// NewResourceDetector returns a resource detector that will detect Azure
+// resources.
+//
+// App Service and Functions are detected with the environment variables
+// they set. Otherwise, Virtual Machines and the nodes of AKS clusters are
+// detected by querying the Azure Instance Metadata Service (IMDS). No
+// resource is returned if the IMDS cannot be reached.
+func NewResourceDetector(opts ...Option) resource.Detector {
+	c := newConfig(opts...)
+	return &resourceDetector{
+		endpoint: c.endpoint,
+		client:   &http.Client{Timeout: c.timeout},
+		getenv:   os.Getenv,
+	}
+}
+
+// Detect detects associated resources when running in an Azure environment.
+func (detector *resourceDetector) Detect(ctx context.Context) (*resource.Resource, error) {
+	if site := detector.getenv(siteNameEnvVar); site != "" {
+		return detector.detectAppService(site), nil
+	}
+	return detector.detectVM(ctx)
+}
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+func newTestDetector(endpoint string, env map[string]string) *resourceDetector {
+	return &resourceDetector{
+		endpoint: endpoint,
+		client:   http.DefaultClient,
+		getenv:   func(key string) string { return env[key] },
+	}
+}
+
+var appServiceEnv = map[string]string{
+	siteNameEnvVar:      "my-site",
+	regionNameEnvVar:    "West Europe",
+	slotNameEnvVar:      "production",
+	hostNameEnvVar:      "my-site.azurewebsites.net",
+	instanceIDEnvVar:    "3a7f1b2c",
+	ownerNameEnvVar:     "00000000-0000-0000-0000-000000000000+my-group-WestEuropewebspace",
+	resourceGroupEnvVar: "my-group",
+}
+
+const siteResourceID = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/my-group/providers/Microsoft.Web/sites/my-site"
+
+func TestDetectAppService(t *testing.T) {
+	res, err := newTestDetector("", appServiceEnv).Detect(context.Background())
+	require.NoError(t, err)
+
+	expected := resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.CloudProviderAzure,
+		semconv.CloudPlatformAzureAppService,
+		semconv.ServiceName("my-site"),
+		semconv.ServiceInstanceID("3a7f1b2c"),
+		semconv.DeploymentEnvironment("production"),
+		semconv.HostID("my-site.azurewebsites.net"),
+		semconv.CloudRegion("West Europe"),
+		semconv.CloudResourceID(siteResourceID),
+	)
+	assert.Equal(t, expected, res)
+}
+
+func TestDetectFunctions(t *testing.T) {
+	env := map[string]string{functionsVersionEnvVar: "~4"}
+	for k, v := range appServiceEnv {
+		env[k] = v
+	}
+	res, err := newTestDetector("", env).Detect(context.Background())
+	require.NoError(t, err)
+
+	expected := resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.CloudProviderAzure,
+		semconv.CloudPlatformAzureFunctions,
+		semconv.FaaSName("my-site"),
+		semconv.FaaSInstance("3a7f1b2c"),
+		semconv.CloudRegion("West Europe"),
+		semconv.CloudResourceID(siteResourceID),
+	)
+	assert.Equal(t, expected, res)
+}
+
+func TestSiteResourceIDUnknownOwner(t *testing.T) {
+	d := newTestDetector("", map[string]string{resourceGroupEnvVar: "my-group"})
+	assert.Equal(t, "", d.siteResourceID("my-site"))
+}
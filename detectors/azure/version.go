@@ -12,12 +12,10 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package runtime_test
+package azure // import "go.opentelemetry.io/contrib/detectors/azure"
 
-// TODO(#2757): Add integration tests for the runtime instrumentation. These
-// tests depend on
-// https://github.com/open-telemetry/opentelemetry-go/issues/3031 being
-// resolved.
-//
-// The added tests will depend on the metric SDK. Therefore, they should be
-// added to a sub-directory called "test" instead of this file.
+// Version is the current release version of the Azure resource detector.
+func Version() string {
+	return "0.45.0"
+	// This string is updated by the pre_release.sh script during release
+}
@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure // import "go.opentelemetry.io/contrib/detectors/azure"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+const (
+	// computePath is the path of the compute metadata of the IMDS, see
+	// https://learn.microsoft.com/en-us/azure/virtual-machines/instance-metadata-service
+	computePath = "/metadata/instance/compute?api-version=2021-12-13&format=json"
+
+	kubernetesServiceHostEnvVar = "KUBERNETES_SERVICE_HOST"
+
+	// aksClusterNameTag is the tag of the virtual machines of AKS clusters
+	// naming their cluster.
+	aksClusterNameTag = "aks-managed-cluster-name"
+)
+
+// Attributes of Azure environments not defined by the semantic conventions.
+const (
+	// vmScaleSetNameKey is the name of the scale set of the virtual machine.
+	vmScaleSetNameKey = attribute.Key("azure.vm.scaleset.name")
+	// resourceGroupNameKey is the name of the resource group of the virtual
+	// machine.
+	resourceGroupNameKey = attribute.Key("azure.resourcegroup.name")
+)
+
+// computeMetadata is the compute metadata returned by the IMDS.
+type computeMetadata struct {
+	Location          string `json:"location"`
+	Name              string `json:"name"`
+	VMID              string `json:"vmId"`
+	VMSize            string `json:"vmSize"`
+	OSType            string `json:"osType"`
+	SubscriptionID    string `json:"subscriptionId"`
+	ResourceGroupName string `json:"resourceGroupName"`
+	ResourceID        string `json:"resourceId"`
+	VMScaleSetName    string `json:"vmScaleSetName"`
+	Zone              string `json:"zone"`
+	TagsList          []struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	} `json:"tagsList"`
+}
+
+// detectVM returns the resource of the virtual machine described by the
+// IMDS. A nil resource is returned if the IMDS cannot be reached.
+func (detector *resourceDetector) detectVM(ctx context.Context) (*resource.Resource, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, detector.endpoint+computePath, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Metadata", "true")
+
+	resp, err := detector.client.Do(req)
+	if err != nil {
+		// Not running on Azure.
+		return nil, nil
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("azure: IMDS request failed: %s", resp.Status)
+	}
+
+	var m computeMetadata
+	if err := json.NewDecoder(resp.Body).Decode(&m); err != nil {
+		return nil, fmt.Errorf("azure: invalid IMDS response: %w", err)
+	}
+
+	attributes := []attribute.KeyValue{
+		semconv.CloudProviderAzure,
+		semconv.CloudRegion(m.Location),
+		semconv.CloudAccountID(m.SubscriptionID),
+		semconv.CloudResourceID(m.ResourceID),
+		semconv.HostID(m.VMID),
+		semconv.HostName(m.Name),
+		semconv.HostType(m.VMSize),
+		semconv.OSTypeKey.String(strings.ToLower(m.OSType)),
+		resourceGroupNameKey.String(m.ResourceGroupName),
+	}
+	if m.Zone != "" {
+		attributes = append(attributes, semconv.CloudAvailabilityZone(m.Zone))
+	}
+	if m.VMScaleSetName != "" {
+		attributes = append(attributes, vmScaleSetNameKey.String(m.VMScaleSetName))
+	}
+
+	if detector.getenv(kubernetesServiceHostEnvVar) != "" {
+		attributes = append(attributes, semconv.CloudPlatformAzureAKS)
+		for _, tag := range m.TagsList {
+			if tag.Name == aksClusterNameTag {
+				attributes = append(attributes, semconv.K8SClusterName(tag.Value))
+			}
+		}
+	} else {
+		attributes = append(attributes, semconv.CloudPlatformAzureVM)
+	}
+
+	return resource.NewWithAttributes(semconv.SchemaURL, attributes...), nil
+}
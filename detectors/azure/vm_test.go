@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+const computeResponse = `{
+	"location": "westeurope",
+	"name": "aks-nodepool1-12345678-vmss000000",
+	"osType": "Linux",
+	"resourceGroupName": "MC_my-group_my-cluster_westeurope",
+	"resourceId": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/MC_my-group_my-cluster_westeurope/providers/Microsoft.Compute/virtualMachineScaleSets/aks-nodepool1-12345678-vmss/virtualMachines/0",
+	"subscriptionId": "00000000-0000-0000-0000-000000000000",
+	"tagsList": [
+		{"name": "aks-managed-cluster-name", "value": "my-cluster"},
+		{"name": "aks-managed-poolName", "value": "nodepool1"}
+	],
+	"vmId": "02aab8a4-74ef-476e-8182-f6d2ba4166a6",
+	"vmScaleSetName": "aks-nodepool1-12345678-vmss",
+	"vmSize": "Standard_DS2_v2",
+	"zone": "1"
+}`
+
+func newIMDS(t *testing.T) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata") != "true" || r.URL.Path != "/metadata/instance/compute" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(computeResponse))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestDetectVM(t *testing.T) {
+	srv := newIMDS(t)
+	res, err := newTestDetector(srv.URL, nil).Detect(context.Background())
+	require.NoError(t, err)
+
+	expected := resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.CloudProviderAzure,
+		semconv.CloudPlatformAzureVM,
+		semconv.CloudRegion("westeurope"),
+		semconv.CloudAvailabilityZone("1"),
+		semconv.CloudAccountID("00000000-0000-0000-0000-000000000000"),
+		semconv.CloudResourceID("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/MC_my-group_my-cluster_westeurope/providers/Microsoft.Compute/virtualMachineScaleSets/aks-nodepool1-12345678-vmss/virtualMachines/0"),
+		semconv.HostID("02aab8a4-74ef-476e-8182-f6d2ba4166a6"),
+		semconv.HostName("aks-nodepool1-12345678-vmss000000"),
+		semconv.HostType("Standard_DS2_v2"),
+		semconv.OSTypeLinux,
+		resourceGroupNameKey.String("MC_my-group_my-cluster_westeurope"),
+		vmScaleSetNameKey.String("aks-nodepool1-12345678-vmss"),
+	)
+	assert.Equal(t, expected, res)
+}
+
+func TestDetectAKS(t *testing.T) {
+	srv := newIMDS(t)
+	env := map[string]string{kubernetesServiceHostEnvVar: "10.0.0.1"}
+	res, err := newTestDetector(srv.URL, env).Detect(context.Background())
+	require.NoError(t, err)
+
+	platform, ok := res.Set().Value(semconv.CloudPlatformKey)
+	assert.True(t, ok)
+	assert.Equal(t, semconv.CloudPlatformAzureAKS.Value, platform)
+
+	cluster, ok := res.Set().Value(semconv.K8SClusterNameKey)
+	assert.True(t, ok)
+	assert.Equal(t, "my-cluster", cluster.AsString())
+}
+
+func TestDetectNotOnAzure(t *testing.T) {
+	srv := newIMDS(t)
+	srv.Close()
+
+	res, err := newTestDetector(srv.URL, nil).Detect(context.Background())
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+}
+
+func TestDetectIMDSError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	_, err := newTestDetector(srv.URL, nil).Detect(context.Background())
+	assert.Error(t, err)
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache // import "go.opentelemetry.io/contrib/detectors/cache"
+
+import "time"
+
+// DefaultTTL is the default duration detection results are reused for.
+const DefaultTTL = 15 * time.Minute
+
+type config struct {
+	ttl  time.Duration
+	path string
+}
+
+// newConfig returns an appropriately configured config.
+func newConfig(options ...Option) *config {
+	c := &config{ttl: DefaultTTL}
+	for _, option := range options {
+		option.apply(c)
+	}
+
+	return c
+}
+
+// Option applies a caching detector configuration option.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (fn optionFunc) apply(c *config) {
+	fn(c)
+}
+
+// WithTTL sets the duration detection results are reused for. DefaultTTL
+// is used if this option is not passed or if ttl is not positive.
+func WithTTL(ttl time.Duration) Option {
+	return optionFunc(func(c *config) {
+		if ttl <= 0 {
+			ttl = DefaultTTL
+		}
+		c.ttl = ttl
+	})
+}
+
+// WithFile persists the detection results in the file at path, so they are
+// reused across the restarts of the application. The results are only
+// cached in memory if this option is not passed.
+//
+// The file is created with permissions restricted to its owner. Each cached
+// detector needs its own file.
+func WithFile(path string) Option {
+	return optionFunc(func(c *config) {
+		c.path = path
+	})
+}
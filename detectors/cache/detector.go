@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cache provides a resource detector caching the results of another
+// detector for a time-to-live (TTL), optionally persisted in a file.
+//
+// Detectors querying metadata endpoints can take seconds to complete, which
+// delays the cold start of the application. When persisted in a file, the
+// detection results are reused across quick restarts of the application,
+// e.g. in autoscaling environments, instead of querying the endpoints again.
+package cache // import "go.opentelemetry.io/contrib/detectors/cache"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// detector caches the results of a detector.
+type detector struct {
+	detector resource.Detector
+	ttl      time.Duration
+	path     string
+	now      func() time.Time
+
+	mu     sync.Mutex
+	cached *entry
+}
+
+// compile time assertion that detector implements the resource.Detector interface.
+var _ resource.Detector = (*detector)(nil)
+
+// NewDetector returns a resource detector returning the results of d,
+// reusing them until their TTL expires.
+//
+// Only the successful results of d are cached, including the absence of
+// resource, e.g. when not running in the environment d detects. Results
+// returned with an error are returned but not cached.
+//
+// The errors reading or writing the file the results are persisted in are
+// sent to the global error handler, the detection falls back to d.
+func NewDetector(d resource.Detector, opts ...Option) resource.Detector {
+	c := newConfig(opts...)
+	return &detector{
+		detector: d,
+		ttl:      c.ttl,
+		path:     c.path,
+		now:      time.Now,
+	}
+}
+
+// Detect returns the cached results of the detector if they have not
+// expired, otherwise the ones of a new detection.
+func (d *detector) Detect(ctx context.Context) (*resource.Resource, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := d.now()
+	if d.cached == nil && d.path != "" {
+		e, err := load(d.path)
+		if err != nil {
+			otel.Handle(err)
+		}
+		d.cached = e
+	}
+	if d.cached != nil && now.Before(d.cached.Expires) {
+		return d.cached.resource(), nil
+	}
+
+	res, err := d.detector.Detect(ctx)
+	if err != nil {
+		return res, err
+	}
+
+	e, err := newEntry(res, now.Add(d.ttl))
+	d.cached = e
+	if d.path == "" {
+		return res, nil
+	}
+	if err == nil {
+		err = store(d.path, e)
+	}
+	if err != nil {
+		otel.Handle(err)
+	}
+	return res, nil
+}
@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+// countingDetector returns res and err, counting its calls.
+type countingDetector struct {
+	res   *resource.Resource
+	err   error
+	calls int
+}
+
+func (d *countingDetector) Detect(context.Context) (*resource.Resource, error) {
+	d.calls++
+	return d.res, d.err
+}
+
+type clock struct{ now time.Time }
+
+func (c *clock) Now() time.Time { return c.now }
+
+func newTestDetector(d resource.Detector, c *clock, opts ...Option) *detector {
+	det := NewDetector(d, opts...).(*detector)
+	det.now = c.Now
+	return det
+}
+
+var testResource = resource.NewWithAttributes(semconv.SchemaURL,
+	semconv.CloudProviderAWS,
+	semconv.HostID("i-1234567890abcdef0"),
+	attribute.Bool("bool", true),
+	attribute.Int64("int", 42),
+	attribute.Float64("float", 1.5),
+	attribute.BoolSlice("bools", []bool{true, false}),
+	attribute.Int64Slice("ints", []int64{1, 2}),
+	attribute.Float64Slice("floats", []float64{1.5, 2.5}),
+	attribute.StringSlice("strings", []string{"a", "b"}),
+)
+
+func TestDetectTTL(t *testing.T) {
+	d := &countingDetector{res: testResource}
+	c := &clock{now: time.Now()}
+	det := newTestDetector(d, c, WithTTL(time.Minute))
+
+	for i := 0; i < 3; i++ {
+		res, err := det.Detect(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, testResource, res)
+	}
+	assert.Equal(t, 1, d.calls)
+
+	c.now = c.now.Add(time.Minute)
+	_, err := det.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, d.calls, "expired result reused")
+}
+
+func TestDetectNoResource(t *testing.T) {
+	d := &countingDetector{}
+	det := newTestDetector(d, &clock{now: time.Now()})
+
+	for i := 0; i < 2; i++ {
+		res, err := det.Detect(context.Background())
+		require.NoError(t, err)
+		assert.Nil(t, res)
+	}
+	assert.Equal(t, 1, d.calls)
+}
+
+func TestDetectErrorNotCached(t *testing.T) {
+	errDetect := errors.New("detection failed")
+	d := &countingDetector{res: testResource, err: errDetect}
+	det := newTestDetector(d, &clock{now: time.Now()})
+
+	for i := 0; i < 2; i++ {
+		res, err := det.Detect(context.Background())
+		assert.ErrorIs(t, err, errDetect)
+		assert.Equal(t, testResource, res)
+	}
+	assert.Equal(t, 2, d.calls)
+}
+
+func TestDetectFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "resource.json")
+	c := &clock{now: time.Now()}
+
+	d := &countingDetector{res: testResource}
+	_, err := newTestDetector(d, c, WithFile(path)).Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, d.calls)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	// A restarted application reuses the persisted result.
+	restarted := &countingDetector{}
+	res, err := newTestDetector(restarted, c, WithFile(path)).Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, testResource, res)
+	assert.Equal(t, 0, restarted.calls)
+
+	// Until it expires.
+	c.now = c.now.Add(DefaultTTL)
+	res, err = newTestDetector(restarted, c, WithFile(path)).Detect(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, res)
+	assert.Equal(t, 1, restarted.calls)
+}
+
+type errorHandler struct{ errs []error }
+
+func (h *errorHandler) Handle(err error) { h.errs = append(h.errs, err) }
+
+func TestDetectInvalidFile(t *testing.T) {
+	h := &errorHandler{}
+	otel.SetErrorHandler(h)
+
+	path := filepath.Join(t.TempDir(), "resource.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"detected":true,"attributes":[{"key":"k","type":"MAP","value":1}]}`), 0o600))
+
+	d := &countingDetector{res: testResource}
+	res, err := newTestDetector(d, &clock{now: time.Now()}, WithFile(path)).Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, testResource, res)
+	assert.Equal(t, 1, d.calls)
+	assert.Len(t, h.errs, 1)
+
+	// The invalid file is replaced.
+	e, err := load(path)
+	require.NoError(t, err)
+	assert.Equal(t, testResource, e.resource())
+}
+
+func TestDetectUnencodableResource(t *testing.T) {
+	h := &errorHandler{}
+	otel.SetErrorHandler(h)
+
+	path := filepath.Join(t.TempDir(), "resource.json")
+	nan := resource.NewSchemaless(attribute.Float64("nan", math.NaN()))
+	d := &countingDetector{res: nan}
+	res, err := newTestDetector(d, &clock{now: time.Now()}, WithFile(path)).Detect(context.Background())
+	require.NoError(t, err)
+	assert.Same(t, nan, res)
+	assert.Len(t, h.errs, 1)
+
+	_, err = os.Stat(path)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
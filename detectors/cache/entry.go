@@ -0,0 +1,189 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache // import "go.opentelemetry.io/contrib/detectors/cache"
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// entry is a cached detection result.
+type entry struct {
+	// Expires is the time the entry expires at.
+	Expires time.Time `json:"expires"`
+	// Detected is whether a resource was detected.
+	Detected   bool               `json:"detected"`
+	SchemaURL  string             `json:"schema_url,omitempty"`
+	Attributes []encodedAttribute `json:"attributes,omitempty"`
+
+	// res is the detected resource, if known.
+	res *resource.Resource
+}
+
+// encodedAttribute is a resource attribute encoded with its type, so it is
+// decoded with the same type.
+type encodedAttribute struct {
+	Key   string          `json:"key"`
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+// newEntry returns the entry of res, expiring at expires. The returned
+// error reports attributes that cannot be encoded, e.g. NaN floats, the
+// returned entry can be cached but not persisted.
+func newEntry(res *resource.Resource, expires time.Time) (*entry, error) {
+	e := &entry{Expires: expires, res: res}
+	if res == nil {
+		return e, nil
+	}
+
+	e.Detected = true
+	e.SchemaURL = res.SchemaURL()
+	var errs []error
+	for iter := res.Iter(); iter.Next(); {
+		kv := iter.Attribute()
+		v, err := json.Marshal(kv.Value.AsInterface())
+		if err != nil {
+			errs = append(errs, fmt.Errorf("attribute %q: %w", kv.Key, err))
+			continue
+		}
+		e.Attributes = append(e.Attributes, encodedAttribute{
+			Key:   string(kv.Key),
+			Type:  kv.Value.Type().String(),
+			Value: v,
+		})
+	}
+	if len(errs) > 0 {
+		return e, fmt.Errorf("cache: %w", errors.Join(errs...))
+	}
+	return e, nil
+}
+
+// resource returns the resource of e, nil if none was detected.
+func (e *entry) resource() *resource.Resource {
+	if e.res != nil || !e.Detected {
+		return e.res
+	}
+	kvs := make([]attribute.KeyValue, 0, len(e.Attributes))
+	for _, a := range e.Attributes {
+		kv, err := a.decode()
+		if err != nil {
+			// Validated when the entry is loaded.
+			continue
+		}
+		kvs = append(kvs, kv)
+	}
+	return resource.NewWithAttributes(e.SchemaURL, kvs...)
+}
+
+// decode returns the attribute a encodes.
+func (a encodedAttribute) decode() (attribute.KeyValue, error) {
+	k := attribute.Key(a.Key)
+	var err error
+	switch a.Type {
+	case "BOOL":
+		var v bool
+		err = json.Unmarshal(a.Value, &v)
+		return k.Bool(v), err
+	case "INT64":
+		var v int64
+		err = json.Unmarshal(a.Value, &v)
+		return k.Int64(v), err
+	case "FLOAT64":
+		var v float64
+		err = json.Unmarshal(a.Value, &v)
+		return k.Float64(v), err
+	case "STRING":
+		var v string
+		err = json.Unmarshal(a.Value, &v)
+		return k.String(v), err
+	case "BOOLSLICE":
+		var v []bool
+		err = json.Unmarshal(a.Value, &v)
+		return k.BoolSlice(v), err
+	case "INT64SLICE":
+		var v []int64
+		err = json.Unmarshal(a.Value, &v)
+		return k.Int64Slice(v), err
+	case "FLOAT64SLICE":
+		var v []float64
+		err = json.Unmarshal(a.Value, &v)
+		return k.Float64Slice(v), err
+	case "STRINGSLICE":
+		var v []string
+		err = json.Unmarshal(a.Value, &v)
+		return k.StringSlice(v), err
+	default:
+		return attribute.KeyValue{}, fmt.Errorf("unsupported attribute type %q", a.Type)
+	}
+}
+
+// load returns the entry persisted in the file at path, nil if there is
+// none.
+func load(path string) (*entry, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cache: %w", err)
+	}
+
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, fmt.Errorf("cache: invalid file %s: %w", path, err)
+	}
+	for _, a := range e.Attributes {
+		if _, err := a.decode(); err != nil {
+			return nil, fmt.Errorf("cache: invalid file %s: attribute %q: %w", path, a.Key, err)
+		}
+	}
+	return &e, nil
+}
+
+// store persists e in the file at path. The file is replaced atomically so
+// concurrent loads never read a partially written file.
+func store(path string, e *entry) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("cache: %w", err)
+	}
+
+	// CreateTemp creates the file with 0600 permissions.
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("cache: %w", err)
+	}
+	_, err = f.Write(data)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return fmt.Errorf("cache: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package container provides a resource detector setting the container.id
+// resource attribute of processes running in a container.
+//
+// The container ID is read from the cgroup file of the process, supporting
+// the cgroup v1 and v2 layouts of Docker, containerd, CRI-O and Podman. With
+// cgroup v2 and cgroup namespaces, the cgroup file does not contain the
+// container ID which is then read from the mount points of the container.
+//
+// The detector can be composed with other resource detectors, e.g.:
+//
+//	res, err := resource.New(ctx,
+//		resource.WithDetectors(container.NewResourceDetector()),
+//		resource.WithTelemetrySDK(),
+//	)
+package container // import "go.opentelemetry.io/contrib/detectors/container"
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+const (
+	cgroupPath    = "/proc/self/cgroup"
+	mountinfoPath = "/proc/self/mountinfo"
+)
+
+// containerIDRegexp matches the 64 hexadecimal characters of a container ID.
+var containerIDRegexp = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// resourceDetector detects the ID of the container of the process.
+type resourceDetector struct {
+	readFile func(name string) ([]byte, error)
+}
+
+// compile time assertion that resourceDetector implements the resource.Detector interface.
+var _ resource.Detector = (*resourceDetector)(nil)
+
+// NewResourceDetector returns a resource detector that will detect the
+// container.id resource attribute.
+//
+// No resource is returned if the process is not running in a container or
+// if its container ID cannot be determined.
+func NewResourceDetector() resource.Detector {
+	return &resourceDetector{readFile: os.ReadFile}
+}
+
+// Detect detects the ID of the container the process is running in.
+func (detector *resourceDetector) Detect(context.Context) (*resource.Resource, error) {
+	id, err := detector.containerID()
+	if err != nil || id == "" {
+		return nil, err
+	}
+	return resource.NewWithAttributes(semconv.SchemaURL, semconv.ContainerID(id)), nil
+}
+
+// containerID returns the ID of the container of the process, or an empty
+// string if it cannot be determined.
+func (detector *resourceDetector) containerID() (string, error) {
+	data, err := detector.readFile(cgroupPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		// Not running on Linux.
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if id := fromCgroup(string(data)); id != "" {
+		return id, nil
+	}
+
+	data, err = detector.readFile(mountinfoPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return fromMountinfo(string(data)), nil
+}
+
+// fromCgroup returns the container ID found in the content of a cgroup
+// file, or an empty string if there is none. Each line of the file has the
+// hierarchy-ID:controller-list:cgroup-path format, e.g.:
+//
+//	12:memory:/docker/<id>
+//	11:cpu,cpuacct:/kubepods/besteffort/pod4f3e.../<id>
+//	0::/system.slice/docker-<id>.scope
+//	0::/kubepods.slice/kubepods-besteffort.slice/kubepods-besteffort-pod4f3e....slice/cri-containerd-<id>.scope
+//	0::/machine.slice/libpod-<id>.scope
+func fromCgroup(data string) string {
+	for _, line := range strings.Split(strings.TrimSpace(data), "\n") {
+		parts := strings.SplitN(line, ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		segments := strings.Split(parts[2], "/")
+		for i := len(segments) - 1; i >= 0; i-- {
+			// Strip the runtime prefix (e.g. docker-, cri-containerd-,
+			// crio-, libpod-) and the .scope suffix of systemd cgroups.
+			segment := strings.TrimSuffix(segments[i], ".scope")
+			if j := strings.LastIndexAny(segment, "-:"); j >= 0 {
+				segment = segment[j+1:]
+			}
+			if containerIDRegexp.MatchString(segment) {
+				return segment
+			}
+		}
+	}
+	return ""
+}
+
+// fromMountinfo returns the container ID found in the content of a
+// mountinfo file, or an empty string if there is none. The container ID is
+// the one of the directory of the container files mounted in the
+// container, e.g.:
+//
+//	/var/lib/docker/containers/<id>/hostname
+//	/var/lib/containers/storage/overlay-containers/<id>/userdata/hostname
+func fromMountinfo(data string) string {
+	for _, line := range strings.Split(strings.TrimSpace(data), "\n") {
+		for _, field := range strings.Fields(line) {
+			segments := strings.Split(field, "/")
+			for i := 0; i < len(segments)-1; i++ {
+				switch segments[i] {
+				case "containers", "overlay-containers":
+					if containerIDRegexp.MatchString(segments[i+1]) {
+						return segments[i+1]
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+const id = "ea32192c8553fbff06c9340478a2ff089b2bb5646fb718b4ee206641c9086d66"
+
+func TestFromCgroup(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{
+			name: "cgroup v1 docker",
+			data: "12:memory:/docker/" + id + "\n11:cpu,cpuacct:/docker/" + id,
+			want: id,
+		},
+		{
+			name: "cgroup v1 kubernetes",
+			data: "12:memory:/kubepods/besteffort/pod0a5c7b8d-1f1e-4bc3-9d0c-d7f8f4a3c7a1/" + id,
+			want: id,
+		},
+		{
+			name: "cgroup v2 docker",
+			data: "0::/system.slice/docker-" + id + ".scope",
+			want: id,
+		},
+		{
+			name: "cgroup v2 containerd",
+			data: "0::/kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod0a5c7b8d_1f1e_4bc3_9d0c_d7f8f4a3c7a1.slice/cri-containerd-" + id + ".scope",
+			want: id,
+		},
+		{
+			name: "cgroup v2 cri-o",
+			data: "0::/kubepods.slice/crio-" + id + ".scope",
+			want: id,
+		},
+		{
+			name: "cgroup v2 podman",
+			data: "0::/machine.slice/libpod-" + id + ".scope/container",
+			want: id,
+		},
+		{
+			name: "cgroup v2 namespace",
+			data: "0::/",
+		},
+		{
+			name: "not a container",
+			data: "0::/user.slice/user-1000.slice/session-2.scope",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, fromCgroup(tt.data))
+		})
+	}
+}
+
+func TestFromMountinfo(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{
+			name: "docker",
+			data: "1185 1169 0:345 / / rw,relatime master:303 - overlay overlay rw\n" +
+				"1196 1185 259:1 /var/lib/docker/containers/" + id + "/hostname /etc/hostname rw,relatime - ext4 /dev/nvme0n1p1 rw",
+			want: id,
+		},
+		{
+			name: "podman",
+			data: "1196 1185 0:44 /containers/storage/overlay-containers/" + id + "/userdata/hostname /etc/hostname rw - tmpfs tmpfs rw",
+			want: id,
+		},
+		{
+			name: "no container",
+			data: "1185 1169 0:345 / / rw,relatime master:303 - overlay overlay rw",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, fromMountinfo(tt.data))
+		})
+	}
+}
+
+func newTestDetector(files map[string]string) *resourceDetector {
+	return &resourceDetector{readFile: func(name string) ([]byte, error) {
+		data, ok := files[name]
+		if !ok {
+			return nil, fs.ErrNotExist
+		}
+		return []byte(data), nil
+	}}
+}
+
+func TestDetect(t *testing.T) {
+	res, err := newTestDetector(map[string]string{
+		cgroupPath: "0::/system.slice/docker-" + id + ".scope",
+	}).Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, resource.NewWithAttributes(semconv.SchemaURL, semconv.ContainerID(id)), res)
+}
+
+func TestDetectMountinfo(t *testing.T) {
+	res, err := newTestDetector(map[string]string{
+		cgroupPath:    "0::/",
+		mountinfoPath: "1196 1185 259:1 /var/lib/docker/containers/" + id + "/hostname /etc/hostname rw - ext4 /dev/sda1 rw",
+	}).Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, resource.NewWithAttributes(semconv.SchemaURL, semconv.ContainerID(id)), res)
+}
+
+func TestDetectNotInContainer(t *testing.T) {
+	res, err := newTestDetector(map[string]string{cgroupPath: "0::/"}).Detect(context.Background())
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+
+	res, err = newTestDetector(nil).Detect(context.Background())
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+}
+
+func TestDetectError(t *testing.T) {
+	errRead := errors.New("read error")
+	d := &resourceDetector{readFile: func(string) ([]byte, error) { return nil, errRead }}
+	_, err := d.Detect(context.Background())
+	assert.ErrorIs(t, err, errRead)
+}
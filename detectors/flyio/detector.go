@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package flyio provides a resource detector setting the resource attributes
+// of the Machines of Fly.io applications.
+//
+// The attributes are read from the environment variables of the Fly.io
+// runtime environment, see
+// https://fly.io/docs/machines/runtime-environment/ for more information.
+package flyio // import "go.opentelemetry.io/contrib/detectors/flyio"
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+// Environment variables of the Fly.io runtime environment.
+const (
+	appNameEnvVar        = "FLY_APP_NAME"
+	machineIDEnvVar      = "FLY_MACHINE_ID"
+	allocIDEnvVar        = "FLY_ALLOC_ID"
+	regionEnvVar         = "FLY_REGION"
+	imageRefEnvVar       = "FLY_IMAGE_REF"
+	machineVersionEnvVar = "FLY_MACHINE_VERSION"
+	processGroupEnvVar   = "FLY_PROCESS_GROUP"
+)
+
+// Attributes of Fly.io applications not defined by the semantic conventions.
+const (
+	// machineVersionKey is the version of the configuration of the Machine.
+	machineVersionKey = attribute.Key("fly.machine.version")
+	// processGroupKey is the process group of the Machine, e.g. app.
+	processGroupKey = attribute.Key("fly.process_group")
+)
+
+// cloudProviderFlyIO is the cloud.provider attribute of Fly.io.
+var cloudProviderFlyIO = semconv.CloudProviderKey.String("fly_io")
+
+// resourceDetector detects the resource attributes of Fly.io Machines.
+type resourceDetector struct{}
+
+// compile time assertion that resourceDetector implements the resource.Detector interface.
+var _ resource.Detector = (*resourceDetector)(nil)
+
+// NewResourceDetector returns a resource detector that will detect the
+// resource attributes of Fly.io Machines.
+//
+// No resource is returned if the process is not running on Fly.io.
+func NewResourceDetector() resource.Detector {
+	return &resourceDetector{}
+}
+
+// Detect detects the resource attributes of the Fly.io Machine the process
+// is running in.
+func (detector *resourceDetector) Detect(context.Context) (*resource.Resource, error) {
+	appName := os.Getenv(appNameEnvVar)
+	if appName == "" {
+		return nil, nil
+	}
+
+	attrs := []attribute.KeyValue{
+		cloudProviderFlyIO,
+		semconv.ServiceName(appName),
+	}
+	add := func(key attribute.Key, env string) {
+		if v := os.Getenv(env); v != "" {
+			attrs = append(attrs, key.String(v))
+		}
+	}
+	add(semconv.CloudRegionKey, regionEnvVar)
+	add(semconv.HostIDKey, machineIDEnvVar)
+	add(semconv.ServiceInstanceIDKey, allocIDEnvVar)
+	add(machineVersionKey, machineVersionEnvVar)
+	add(processGroupKey, processGroupEnvVar)
+
+	name, tag := parseImage(os.Getenv(imageRefEnvVar))
+	if name != "" {
+		attrs = append(attrs, semconv.ContainerImageName(name))
+	}
+	if tag != "" {
+		attrs = append(attrs, semconv.ContainerImageTag(tag))
+	}
+
+	return resource.NewWithAttributes(semconv.SchemaURL, attrs...), nil
+}
+
+// parseImage returns the name and tag of the image reference, e.g.
+// registry.fly.io/my-app:deployment-01H9RK9EYO9PGNBYAKGXSHV0PH. The tag is
+// empty if the reference has none.
+func parseImage(image string) (name, tag string) {
+	if i := strings.Index(image, "@"); i >= 0 {
+		// Strip the digest, e.g. my-app@sha256:25f3695bedfb...
+		image = image[:i]
+	}
+	// The reference may contain a registry port, only a colon following the
+	// last path separator delimits the tag.
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		return image[:i], image[i+1:]
+	}
+	return image, ""
+}
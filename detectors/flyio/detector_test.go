@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flyio
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+func TestDetect(t *testing.T) {
+	t.Setenv(appNameEnvVar, "my-app")
+	t.Setenv(machineIDEnvVar, "6e82d4e6b33587")
+	t.Setenv(allocIDEnvVar, "6e82d4e6b33587")
+	t.Setenv(regionEnvVar, "cdg")
+	t.Setenv(imageRefEnvVar, "registry.fly.io/my-app:deployment-01H9RK9EYO9PGNBYAKGXSHV0PH")
+	t.Setenv(machineVersionEnvVar, "01H9RKA7CQ6X8M3TN0N7XQ5YX8")
+	t.Setenv(processGroupEnvVar, "app")
+
+	res, err := NewResourceDetector().Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, resource.NewWithAttributes(semconv.SchemaURL,
+		cloudProviderFlyIO,
+		semconv.ServiceName("my-app"),
+		semconv.CloudRegion("cdg"),
+		semconv.HostID("6e82d4e6b33587"),
+		semconv.ServiceInstanceID("6e82d4e6b33587"),
+		machineVersionKey.String("01H9RKA7CQ6X8M3TN0N7XQ5YX8"),
+		processGroupKey.String("app"),
+		semconv.ContainerImageName("registry.fly.io/my-app"),
+		semconv.ContainerImageTag("deployment-01H9RK9EYO9PGNBYAKGXSHV0PH"),
+	), res)
+}
+
+func TestDetectNotOnFlyIO(t *testing.T) {
+	t.Setenv(appNameEnvVar, "")
+
+	res, err := NewResourceDetector().Detect(context.Background())
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+}
+
+func TestParseImage(t *testing.T) {
+	for _, tc := range []struct {
+		image, name, tag string
+	}{
+		{"", "", ""},
+		{"my-app", "my-app", ""},
+		{"registry.fly.io/my-app:v1", "registry.fly.io/my-app", "v1"},
+		{"localhost:5000/my-app", "localhost:5000/my-app", ""},
+		{"registry.fly.io/my-app:v1@sha256:25f3695bedfb", "registry.fly.io/my-app", "v1"},
+	} {
+		name, tag := parseImage(tc.image)
+		assert.Equal(t, tc.name, name, tc.image)
+		assert.Equal(t, tc.tag, tag, tc.image)
+	}
+}
@@ -16,46 +16,107 @@ package gcp // import "go.opentelemetry.io/contrib/detectors/gcp"
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
 
 	"cloud.google.com/go/compute/metadata"
 	"github.com/GoogleCloudPlatform/opentelemetry-operations-go/detectors/gcp"
 
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/sdk/resource"
 	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
 )
 
+// Attributes of GCP environments not defined by the semantic conventions.
+const (
+	// cloudRunJobExecutionKey is the name of the Cloud Run job execution.
+	cloudRunJobExecutionKey = attribute.Key("gcp.cloud_run.job.execution")
+	// cloudRunJobTaskIndexKey is the index of the Cloud Run job task.
+	cloudRunJobTaskIndexKey = attribute.Key("gcp.cloud_run.job.task_index")
+	// cloudRunJobTaskAttemptKey is the number of times the Cloud Run job
+	// task has been retried.
+	cloudRunJobTaskAttemptKey = attribute.Key("gcp.cloud_run.job.task_attempt")
+	// gkeAutopilotKey is whether the GKE cluster is an Autopilot cluster.
+	gkeAutopilotKey = attribute.Key("gcp.gke.autopilot")
+)
+
+// Environment variables of the Cloud Run jobs container contract.
+// See https://cloud.google.com/run/docs/container-contract#jobs-env-vars
+const (
+	cloudRunJobEnv         = "CLOUD_RUN_JOB"
+	cloudRunExecutionEnv   = "CLOUD_RUN_EXECUTION"
+	cloudRunTaskIndexEnv   = "CLOUD_RUN_TASK_INDEX"
+	cloudRunTaskAttemptEnv = "CLOUD_RUN_TASK_ATTEMPT"
+)
+
+// autopilotNodePrefix is the prefix of the names of the nodes of GKE
+// Autopilot clusters.
+const autopilotNodePrefix = "gk3-"
+
+// ErrNotOnPlatform is returned by the detector when the process is not
+// running on GCP, i.e. when the metadata server cannot be reached. It
+// distinguishes an inapplicable detector from a detection failure.
+var ErrNotOnPlatform = errors.New("process is not running on GCP, the metadata server cannot be reached")
+
 // NewDetector returns a resource detector which detects resource attributes on:
 // * Google Compute Engine (GCE).
-// * Google Kubernetes Engine (GKE).
+// * Google Kubernetes Engine (GKE), including Autopilot clusters.
 // * Google App Engine (GAE).
-// * Cloud Run.
+// * Cloud Run services and jobs.
 // * Cloud Functions.
-func NewDetector() resource.Detector {
-	return &detector{detector: gcp.NewDetector()}
+func NewDetector(opts ...Option) resource.Detector {
+	c := newConfig(opts...)
+	return &detector{detector: gcp.NewDetector(), failOpen: c.failOpen}
 }
 
 type detector struct {
 	detector gcpDetector
+	failOpen bool
 }
 
 // Detect detects associated resources when running on GCE, GKE, GAE,
 // Cloud Run, and Cloud functions.
 func (d *detector) Detect(ctx context.Context) (*resource.Resource, error) {
+	res, err := d.detect()
+	if d.failOpen && errors.Is(err, resource.ErrPartialResource) {
+		// Report the missing attributes without failing the detection.
+		otel.Handle(err)
+		err = nil
+	}
+	return res, err
+}
+
+func (d *detector) detect() (*resource.Resource, error) {
 	if !metadata.OnGCE() {
-		return nil, nil
+		return nil, ErrNotOnPlatform
 	}
 	b := &resourceBuilder{}
 	b.attrs = append(b.attrs, semconv.CloudProviderGCP)
 	b.add(semconv.CloudAccountIDKey, d.detector.ProjectID)
 
+	if job := os.Getenv(cloudRunJobEnv); job != "" {
+		// Cloud Run jobs are not identified by the detection library.
+		b.attrs = append(b.attrs, semconv.CloudPlatformGCPCloudRun, semconv.FaaSName(job))
+		b.add(semconv.FaaSInstanceKey, d.detector.FaaSID)
+		b.add(semconv.CloudRegionKey, d.detector.FaaSCloudRegion)
+		b.addEnv(cloudRunJobExecutionKey, cloudRunExecutionEnv)
+		b.addIntEnv(cloudRunJobTaskIndexKey, cloudRunTaskIndexEnv)
+		b.addIntEnv(cloudRunJobTaskAttemptKey, cloudRunTaskAttemptEnv)
+		return b.build()
+	}
+
 	switch d.detector.CloudPlatform() {
 	case gcp.GKE:
 		b.attrs = append(b.attrs, semconv.CloudPlatformGCPKubernetesEngine)
 		b.addZoneOrRegion(d.detector.GKEAvailabilityZoneOrRegion)
 		b.add(semconv.K8SClusterNameKey, d.detector.GKEClusterName)
 		b.add(semconv.HostIDKey, d.detector.GKEHostID)
+		// GKE nodes are the Compute Engine instances they run on.
+		b.addGKENode(d.detector.GCEInstanceName)
 	case gcp.CloudRun:
 		b.attrs = append(b.attrs, semconv.CloudPlatformGCPCloudRun)
 		b.add(semconv.FaaSNameKey, d.detector.FaaSName)
@@ -110,6 +171,39 @@ func (r *resourceBuilder) add(key attribute.Key, detect func() (string, error))
 	}
 }
 
+// addEnv adds the value of the environment variable env, if set.
+func (r *resourceBuilder) addEnv(key attribute.Key, env string) {
+	if v := os.Getenv(env); v != "" {
+		r.attrs = append(r.attrs, key.String(v))
+	}
+}
+
+// addIntEnv adds the integer value of the environment variable env, if set.
+func (r *resourceBuilder) addIntEnv(key attribute.Key, env string) {
+	v := os.Getenv(env)
+	if v == "" {
+		return
+	}
+	if i, err := strconv.Atoi(v); err == nil {
+		r.attrs = append(r.attrs, key.Int(i))
+	} else {
+		r.errs = append(r.errs, fmt.Errorf("invalid %s: %w", env, err))
+	}
+}
+
+// addGKENode adds the name of the node and whether it belongs to an
+// Autopilot cluster.
+func (r *resourceBuilder) addGKENode(detect func() (string, error)) {
+	if v, err := detect(); err == nil {
+		r.attrs = append(r.attrs,
+			semconv.K8SNodeName(v),
+			gkeAutopilotKey.Bool(strings.HasPrefix(v, autopilotNodePrefix)),
+		)
+	} else {
+		r.errs = append(r.errs, err)
+	}
+}
+
 // zoneAndRegion functions are expected to return zone, region, err.
 func (r *resourceBuilder) addZoneAndRegion(detect func() (string, string, error)) {
 	if zone, region, err := detect(); err == nil {
@@ -16,13 +16,16 @@ package gcp // import "go.opentelemetry.io/contrib/detectors/gcp"
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"testing"
 
 	"github.com/GoogleCloudPlatform/opentelemetry-operations-go/detectors/gcp"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/sdk/resource"
 	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
 )
@@ -44,6 +47,7 @@ func TestDetect(t *testing.T) {
 				projectID:           "my-project",
 				cloudPlatform:       gcp.GKE,
 				gkeHostID:           "1472385723456792345",
+				gcpGceInstanceName:  "gke-my-cluster-default-pool-1234",
 				gkeClusterName:      "my-cluster",
 				gkeAvailabilityZone: "us-central1-c",
 			}},
@@ -54,16 +58,19 @@ func TestDetect(t *testing.T) {
 				semconv.K8SClusterName("my-cluster"),
 				semconv.CloudAvailabilityZone("us-central1-c"),
 				semconv.HostID("1472385723456792345"),
+				semconv.K8SNodeName("gke-my-cluster-default-pool-1234"),
+				gkeAutopilotKey.Bool(false),
 			),
 		},
 		{
 			desc: "regional GKE cluster",
 			detector: &detector{detector: &fakeGCPDetector{
-				projectID:      "my-project",
-				cloudPlatform:  gcp.GKE,
-				gkeHostID:      "1472385723456792345",
-				gkeClusterName: "my-cluster",
-				gkeRegion:      "us-central1",
+				projectID:          "my-project",
+				cloudPlatform:      gcp.GKE,
+				gkeHostID:          "1472385723456792345",
+				gcpGceInstanceName: "gke-my-cluster-default-pool-1234",
+				gkeClusterName:     "my-cluster",
+				gkeRegion:          "us-central1",
 			}},
 			expectedResource: resource.NewWithAttributes(semconv.SchemaURL,
 				semconv.CloudProviderGCP,
@@ -72,6 +79,29 @@ func TestDetect(t *testing.T) {
 				semconv.K8SClusterName("my-cluster"),
 				semconv.CloudRegion("us-central1"),
 				semconv.HostID("1472385723456792345"),
+				semconv.K8SNodeName("gke-my-cluster-default-pool-1234"),
+				gkeAutopilotKey.Bool(false),
+			),
+		},
+		{
+			desc: "GKE Autopilot cluster",
+			detector: &detector{detector: &fakeGCPDetector{
+				projectID:          "my-project",
+				cloudPlatform:      gcp.GKE,
+				gkeHostID:          "1472385723456792345",
+				gcpGceInstanceName: "gk3-my-cluster-pool-2-1234",
+				gkeClusterName:     "my-cluster",
+				gkeRegion:          "us-central1",
+			}},
+			expectedResource: resource.NewWithAttributes(semconv.SchemaURL,
+				semconv.CloudProviderGCP,
+				semconv.CloudAccountID("my-project"),
+				semconv.CloudPlatformGCPKubernetesEngine,
+				semconv.K8SClusterName("my-cluster"),
+				semconv.CloudRegion("us-central1"),
+				semconv.HostID("1472385723456792345"),
+				semconv.K8SNodeName("gk3-my-cluster-pool-2-1234"),
+				gkeAutopilotKey.Bool(true),
 			),
 		},
 		{
@@ -218,6 +248,87 @@ func TestDetect(t *testing.T) {
 	}
 }
 
+func TestDetectCloudRunJob(t *testing.T) {
+	// Set this to ensure metadata.onGCE() returns true
+	t.Setenv("GCE_METADATA_HOST", "169.254.169.254")
+	t.Setenv("CLOUD_RUN_JOB", "my-job")
+	t.Setenv("CLOUD_RUN_EXECUTION", "my-job-x7kq2")
+	t.Setenv("CLOUD_RUN_TASK_INDEX", "3")
+	t.Setenv("CLOUD_RUN_TASK_ATTEMPT", "1")
+
+	d := &detector{detector: &fakeGCPDetector{
+		projectID: "my-project",
+		// Cloud Run jobs are detected as GCE by the detection library.
+		cloudPlatform:   gcp.GCE,
+		faaSID:          "1472385723456792345",
+		faaSCloudRegion: "us-central1",
+	}}
+	res, err := d.Detect(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.CloudProviderGCP,
+		semconv.CloudAccountID("my-project"),
+		semconv.CloudPlatformGCPCloudRun,
+		semconv.CloudRegion("us-central1"),
+		semconv.FaaSName("my-job"),
+		semconv.FaaSInstance("1472385723456792345"),
+		cloudRunJobExecutionKey.String("my-job-x7kq2"),
+		cloudRunJobTaskIndexKey.Int(3),
+		cloudRunJobTaskAttemptKey.Int(1),
+	), res)
+
+	t.Setenv("CLOUD_RUN_TASK_INDEX", "invalid")
+	_, err = d.Detect(context.Background())
+	assert.ErrorIs(t, err, resource.ErrPartialResource)
+}
+
+type errorHandler struct{ errs []error }
+
+func (h *errorHandler) Handle(err error) { h.errs = append(h.errs, err) }
+
+func TestDetectFailOpen(t *testing.T) {
+	// Set this to ensure metadata.onGCE() returns true
+	t.Setenv("GCE_METADATA_HOST", "169.254.169.254")
+	h := &errorHandler{}
+	otel.SetErrorHandler(h)
+
+	d := &detector{
+		detector: &fakeGCPDetector{
+			projectID:     "my-project",
+			cloudPlatform: gcp.GKE,
+			// The zone and region of the cluster cannot be retrieved.
+			gkeLocationErr:     errors.New("cluster-location not found"),
+			gkeClusterName:     "my-cluster",
+			gkeHostID:          "1472385723456792345",
+			gcpGceInstanceName: "gke-my-cluster-default-pool-1234",
+		},
+		failOpen: true,
+	}
+	res, err := d.Detect(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.CloudProviderGCP,
+		semconv.CloudAccountID("my-project"),
+		semconv.CloudPlatformGCPKubernetesEngine,
+		semconv.K8SClusterName("my-cluster"),
+		semconv.HostID("1472385723456792345"),
+		semconv.K8SNodeName("gke-my-cluster-default-pool-1234"),
+		gkeAutopilotKey.Bool(false),
+	), res)
+	require.Len(t, h.errs, 1)
+	assert.ErrorIs(t, h.errs[0], resource.ErrPartialResource)
+
+	d.failOpen = false
+	_, err = d.Detect(context.Background())
+	assert.ErrorIs(t, err, resource.ErrPartialResource)
+	assert.Len(t, h.errs, 1)
+}
+
+func TestNewDetectorWithFailOpen(t *testing.T) {
+	assert.True(t, NewDetector(WithFailOpen()).(*detector).failOpen)
+	assert.False(t, NewDetector().(*detector).failOpen)
+}
+
 // fakeGCPDetector implements gcpDetector and uses fake values.
 type fakeGCPDetector struct {
 	err                       error
@@ -225,9 +336,9 @@ type fakeGCPDetector struct {
 	cloudPlatform             gcp.Platform
 	gkeAvailabilityZone       string
 	gkeRegion                 string
+	gkeLocationErr            error
 	gkeClusterName            string
 	gkeHostID                 string
-	gkeHostName               string
 	faaSName                  string
 	faaSVersion               string
 	faaSID                    string
@@ -261,6 +372,9 @@ func (f *fakeGCPDetector) GKEAvailabilityZoneOrRegion() (string, gcp.LocationTyp
 	if f.err != nil {
 		return "", gcp.UndefinedLocation, f.err
 	}
+	if f.gkeLocationErr != nil {
+		return "", gcp.UndefinedLocation, f.gkeLocationErr
+	}
 	if f.gkeAvailabilityZone != "" {
 		return f.gkeAvailabilityZone, gcp.Zone, nil
 	}
@@ -281,13 +395,6 @@ func (f *fakeGCPDetector) GKEHostID() (string, error) {
 	return f.gkeHostID, nil
 }
 
-func (f *fakeGCPDetector) GKEHostName() (string, error) {
-	if f.err != nil {
-		return "", f.err
-	}
-	return f.gkeHostName, nil
-}
-
 func (f *fakeGCPDetector) FaaSName() (string, error) {
 	if f.err != nil {
 		return "", f.err
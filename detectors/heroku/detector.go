@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package heroku provides a resource detector setting the resource attributes
+// of the dynos of Heroku applications.
+//
+// The attributes are read from the environment variables set by the Heroku
+// Dyno Metadata feature, which needs to be enabled for the application:
+//
+//	heroku labs:enable runtime-dyno-metadata -a <app name>
+//
+// See https://devcenter.heroku.com/articles/dyno-metadata for more
+// information.
+package heroku // import "go.opentelemetry.io/contrib/detectors/heroku"
+
+import (
+	"context"
+	"os"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+// Environment variables set by the Dyno Metadata feature and the dyno
+// manager.
+const (
+	appIDEnvVar          = "HEROKU_APP_ID"
+	appNameEnvVar        = "HEROKU_APP_NAME"
+	dynoIDEnvVar         = "HEROKU_DYNO_ID"
+	releaseCreatedEnvVar = "HEROKU_RELEASE_CREATED_AT"
+	releaseVersionEnvVar = "HEROKU_RELEASE_VERSION"
+	slugCommitEnvVar     = "HEROKU_SLUG_COMMIT"
+	dynoEnvVar           = "DYNO"
+)
+
+// Attributes of Heroku applications not defined by the semantic conventions.
+const (
+	// appIDKey is the unique identifier of the application.
+	appIDKey = attribute.Key("heroku.app.id")
+	// releaseCommitKey is the commit hash of the source of the release.
+	releaseCommitKey = attribute.Key("heroku.release.commit")
+	// releaseCreationTimestampKey is the time the release was created.
+	releaseCreationTimestampKey = attribute.Key("heroku.release.creation_timestamp")
+	// dynoKey is the name of the dyno, e.g. web.1.
+	dynoKey = attribute.Key("heroku.dyno")
+)
+
+// cloudProviderHeroku is the cloud.provider attribute of Heroku.
+var cloudProviderHeroku = semconv.CloudProviderKey.String("heroku")
+
+// resourceDetector detects the resource attributes of Heroku dynos.
+type resourceDetector struct{}
+
+// compile time assertion that resourceDetector implements the resource.Detector interface.
+var _ resource.Detector = (*resourceDetector)(nil)
+
+// NewResourceDetector returns a resource detector that will detect the
+// resource attributes of Heroku dynos.
+//
+// No resource is returned if the process is not running on Heroku with the
+// Dyno Metadata feature enabled.
+func NewResourceDetector() resource.Detector {
+	return &resourceDetector{}
+}
+
+// Detect detects the resource attributes of the Heroku dyno the process is
+// running in.
+func (detector *resourceDetector) Detect(context.Context) (*resource.Resource, error) {
+	dynoID := os.Getenv(dynoIDEnvVar)
+	if dynoID == "" {
+		return nil, nil
+	}
+
+	attrs := []attribute.KeyValue{
+		cloudProviderHeroku,
+		semconv.ServiceInstanceID(dynoID),
+	}
+	add := func(key attribute.Key, env string) {
+		if v := os.Getenv(env); v != "" {
+			attrs = append(attrs, key.String(v))
+		}
+	}
+	add(semconv.ServiceNameKey, appNameEnvVar)
+	add(semconv.ServiceVersionKey, releaseVersionEnvVar)
+	add(appIDKey, appIDEnvVar)
+	add(releaseCommitKey, slugCommitEnvVar)
+	add(releaseCreationTimestampKey, releaseCreatedEnvVar)
+	add(dynoKey, dynoEnvVar)
+
+	return resource.NewWithAttributes(semconv.SchemaURL, attrs...), nil
+}
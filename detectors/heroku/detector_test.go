@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package heroku
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+func TestDetect(t *testing.T) {
+	t.Setenv(appIDEnvVar, "9daa2797-e49b-4624-932f-ec3f9688e3da")
+	t.Setenv(appNameEnvVar, "example-app")
+	t.Setenv(dynoIDEnvVar, "1vac4117-c29f-4312-521e-ba4d8638c1ac")
+	t.Setenv(releaseCreatedEnvVar, "2015-04-02T18:00:42Z")
+	t.Setenv(releaseVersionEnvVar, "v42")
+	t.Setenv(slugCommitEnvVar, "2c3a0b24069af49b3de35b8e8c26765c1dba9ff0")
+	t.Setenv(dynoEnvVar, "web.1")
+
+	res, err := NewResourceDetector().Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, resource.NewWithAttributes(semconv.SchemaURL,
+		cloudProviderHeroku,
+		semconv.ServiceInstanceID("1vac4117-c29f-4312-521e-ba4d8638c1ac"),
+		semconv.ServiceName("example-app"),
+		semconv.ServiceVersion("v42"),
+		appIDKey.String("9daa2797-e49b-4624-932f-ec3f9688e3da"),
+		releaseCommitKey.String("2c3a0b24069af49b3de35b8e8c26765c1dba9ff0"),
+		releaseCreationTimestampKey.String("2015-04-02T18:00:42Z"),
+		dynoKey.String("web.1"),
+	), res)
+}
+
+func TestDetectPartial(t *testing.T) {
+	t.Setenv(dynoIDEnvVar, "1vac4117-c29f-4312-521e-ba4d8638c1ac")
+	t.Setenv(appNameEnvVar, "")
+	t.Setenv(dynoEnvVar, "")
+
+	res, err := NewResourceDetector().Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, resource.NewWithAttributes(semconv.SchemaURL,
+		cloudProviderHeroku,
+		semconv.ServiceInstanceID("1vac4117-c29f-4312-521e-ba4d8638c1ac"),
+	), res)
+}
+
+func TestDetectNotOnHeroku(t *testing.T) {
+	t.Setenv(dynoIDEnvVar, "")
+
+	res, err := NewResourceDetector().Detect(context.Background())
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+}
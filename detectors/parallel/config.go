@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parallel // import "go.opentelemetry.io/contrib/detectors/parallel"
+
+import (
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// DefaultTimeout is the default timeout of each detector.
+const DefaultTimeout = 5 * time.Second
+
+// entry is a detector and its timeout.
+type entry struct {
+	detector resource.Detector
+	// timeout of the detector. The default timeout is used if it is zero.
+	timeout time.Duration
+}
+
+type config struct {
+	entries []entry
+	timeout time.Duration
+}
+
+// newConfig returns an appropriately configured config.
+func newConfig(options ...Option) *config {
+	c := &config{timeout: DefaultTimeout}
+	for _, option := range options {
+		option.apply(c)
+	}
+
+	return c
+}
+
+// Option applies a parallel detector configuration option.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (fn optionFunc) apply(c *config) {
+	fn(c)
+}
+
+// WithDetectors adds detectors run with the default timeout, see
+// WithTimeout.
+func WithDetectors(detectors ...resource.Detector) Option {
+	return optionFunc(func(c *config) {
+		for _, d := range detectors {
+			c.entries = append(c.entries, entry{detector: d})
+		}
+	})
+}
+
+// WithDetectorsTimeout adds detectors run with the passed timeout instead of
+// the default one. There is no timeout if it is negative.
+func WithDetectorsTimeout(timeout time.Duration, detectors ...resource.Detector) Option {
+	return optionFunc(func(c *config) {
+		for _, d := range detectors {
+			c.entries = append(c.entries, entry{detector: d, timeout: timeout})
+		}
+	})
+}
+
+// WithTimeout sets the default timeout of each detector. There is no
+// timeout if it is negative. DefaultTimeout is used if this option is not
+// passed or if timeout is zero.
+func WithTimeout(timeout time.Duration) Option {
+	return optionFunc(func(c *config) {
+		if timeout == 0 {
+			timeout = DefaultTimeout
+		}
+		c.timeout = timeout
+	})
+}
@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package parallel provides a resource detector running other detectors
+// concurrently, each with its own timeout, and merging their results.
+//
+// The resource detection of the SDK runs detectors sequentially: the slowest
+// detectors, e.g. querying unreachable metadata endpoints, delay the startup
+// of the application by the sum of their durations. The detector of this
+// package bounds this delay to the longest timeout of the detectors.
+package parallel // import "go.opentelemetry.io/contrib/detectors/parallel"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// ErrTimeout is returned, wrapped with the detector and
+// context.DeadlineExceeded, when a detector does not complete within its
+// timeout.
+var ErrTimeout = errors.New("resource detection timed out")
+
+// detector runs detectors concurrently.
+type detector struct {
+	entries []entry
+	timeout time.Duration
+}
+
+// compile time assertion that detector implements the resource.Detector interface.
+var _ resource.Detector = (*detector)(nil)
+
+// NewDetector returns a resource detector running the detectors passed with
+// the WithDetectors and WithDetectorsTimeout options concurrently.
+//
+// The detected resources are merged in the order the detectors are passed:
+// the attributes of the latter detectors take precedence, as when passing
+// the detectors to resource.New. A detector not completing within its
+// timeout is ignored and ErrTimeout is returned along the merged resource.
+func NewDetector(opts ...Option) resource.Detector {
+	c := newConfig(opts...)
+	return &detector{entries: c.entries, timeout: c.timeout}
+}
+
+// result is the result of a detector.
+type result struct {
+	res *resource.Resource
+	err error
+}
+
+// Detect runs the detectors concurrently and returns the merge of their
+// resources. The errors of the detectors are joined.
+func (d *detector) Detect(ctx context.Context) (*resource.Resource, error) {
+	type run struct {
+		ctx     context.Context
+		results chan result
+	}
+	runs := make([]run, len(d.entries))
+	for i, e := range d.entries {
+		dctx, cancel := d.context(ctx, e)
+		defer cancel()
+
+		// Buffered so the goroutine of a detector ignoring the cancellation
+		// of its context does not block once it completes.
+		ch := make(chan result, 1)
+		go func(dctx context.Context, e entry) {
+			res, err := e.detector.Detect(dctx)
+			ch <- result{res: res, err: err}
+		}(dctx, e)
+		runs[i] = run{ctx: dctx, results: ch}
+	}
+
+	var (
+		merged = resource.Empty()
+		errs   []error
+	)
+	for i, e := range d.entries {
+		var r result
+		select {
+		case r = <-runs[i].results:
+		case <-runs[i].ctx.Done():
+			r = d.canceled(ctx, e, runs[i].results)
+		}
+
+		if r.err != nil {
+			errs = append(errs, r.err)
+			if !errors.Is(r.err, resource.ErrPartialResource) {
+				continue
+			}
+		}
+
+		var err error
+		merged, err = resource.Merge(merged, r.res)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return merged, errors.Join(errs...)
+}
+
+// context returns the context of the detector of e, canceled after its
+// timeout.
+func (d *detector) context(ctx context.Context, e entry) (context.Context, context.CancelFunc) {
+	timeout := e.timeout
+	if timeout == 0 {
+		timeout = d.timeout
+	}
+	if timeout < 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// canceled returns the result of the detector of e whose context is
+// canceled. The result is used if the detector completed nevertheless.
+func (d *detector) canceled(ctx context.Context, e entry, results <-chan result) result {
+	select {
+	case r := <-results:
+		return r
+	default:
+	}
+	if err := ctx.Err(); err != nil {
+		return result{err: fmt.Errorf("%T: %w", e.detector, err)}
+	}
+	return result{err: fmt.Errorf("%w: %T: %w", ErrTimeout, e.detector, context.DeadlineExceeded)}
+}
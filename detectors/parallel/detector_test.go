@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parallel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+type detectorFunc func(context.Context) (*resource.Resource, error)
+
+func (f detectorFunc) Detect(ctx context.Context) (*resource.Resource, error) {
+	return f(ctx)
+}
+
+func static(kvs ...attribute.KeyValue) resource.Detector {
+	return detectorFunc(func(context.Context) (*resource.Resource, error) {
+		return resource.NewSchemaless(kvs...), nil
+	})
+}
+
+// blocking returns a detector blocking until its context is canceled.
+func blocking() resource.Detector {
+	return detectorFunc(func(ctx context.Context) (*resource.Resource, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+}
+
+// sleeping returns a detector ignoring the cancellation of its context.
+func sleeping(d time.Duration, kvs ...attribute.KeyValue) resource.Detector {
+	return detectorFunc(func(context.Context) (*resource.Resource, error) {
+		time.Sleep(d)
+		return resource.NewSchemaless(kvs...), nil
+	})
+}
+
+func TestDetectMergeOrder(t *testing.T) {
+	d := NewDetector(
+		WithDetectors(
+			sleeping(20*time.Millisecond, attribute.String("a", "1"), attribute.String("b", "1")),
+			static(attribute.String("b", "2")),
+		),
+		WithDetectorsTimeout(time.Second, static(attribute.String("c", "3"))),
+	)
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+
+	want := resource.NewSchemaless(
+		attribute.String("a", "1"),
+		attribute.String("b", "2"),
+		attribute.String("c", "3"),
+	)
+	assert.Equal(t, want, res)
+}
+
+func TestDetectConcurrently(t *testing.T) {
+	var detectors []resource.Detector
+	for i := 0; i < 10; i++ {
+		detectors = append(detectors, sleeping(50*time.Millisecond, attribute.Int(fmt.Sprint(i), i)))
+	}
+
+	start := time.Now()
+	res, err := NewDetector(WithDetectors(detectors...)).Detect(context.Background())
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), 450*time.Millisecond, "detectors not run concurrently")
+	assert.Equal(t, 10, res.Len())
+}
+
+func TestDetectTimeout(t *testing.T) {
+	d := NewDetector(
+		WithTimeout(10*time.Millisecond),
+		WithDetectors(blocking(), static(attribute.String("a", "1"))),
+		WithDetectorsTimeout(20*time.Millisecond, sleeping(time.Second, attribute.String("b", "2"))),
+	)
+
+	start := time.Now()
+	res, err := d.Detect(context.Background())
+	assert.Less(t, time.Since(start), 500*time.Millisecond, "timeout not enforced")
+	assert.ErrorIs(t, err, ErrTimeout)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, resource.NewSchemaless(attribute.String("a", "1")), res)
+}
+
+func TestDetectNoTimeout(t *testing.T) {
+	d := NewDetector(
+		WithTimeout(time.Millisecond),
+		WithDetectorsTimeout(-1, sleeping(20*time.Millisecond, attribute.String("a", "1"))),
+	)
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, resource.NewSchemaless(attribute.String("a", "1")), res)
+}
+
+func TestDetectCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := NewDetector(WithDetectors(blocking())).Detect(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.NotErrorIs(t, err, ErrTimeout)
+}
+
+func TestDetectErrors(t *testing.T) {
+	errDetect := errors.New("detection failed")
+	partial := detectorFunc(func(context.Context) (*resource.Resource, error) {
+		return resource.NewSchemaless(attribute.String("a", "1")), fmt.Errorf("%w: missing", resource.ErrPartialResource)
+	})
+	failing := detectorFunc(func(context.Context) (*resource.Resource, error) {
+		return resource.NewSchemaless(attribute.String("b", "2")), errDetect
+	})
+
+	res, err := NewDetector(WithDetectors(partial, failing)).Detect(context.Background())
+	assert.ErrorIs(t, err, resource.ErrPartialResource)
+	assert.ErrorIs(t, err, errDetect)
+	assert.Equal(t, resource.NewSchemaless(attribute.String("a", "1")), res)
+}
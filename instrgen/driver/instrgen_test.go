@@ -18,9 +18,15 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -38,18 +44,18 @@ var testcases = map[string]string{
 var failures []string
 
 func inject(t *testing.T, root string, packagePattern string) {
-	err := executeCommand("--inject-dump-ir", root, packagePattern)
+	err := executeCommand("--inject-dump-ir", root, packagePattern, options{})
 	require.NoError(t, err)
 }
 
 func TestCommands(t *testing.T) {
-	err := executeCommand("--dumpcfg", "./testdata/dummy", "./...")
+	err := executeCommand("--dumpcfg", "./testdata/dummy", "./...", options{})
 	require.NoError(t, err)
-	err = executeCommand("--rootfunctions", "./testdata/dummy", "./...")
+	err = executeCommand("--rootfunctions", "./testdata/dummy", "./...", options{})
 	require.NoError(t, err)
-	err = executeCommand("--prune", "./testdata/dummy", "./...")
+	err = executeCommand("--prune", "./testdata/dummy", "./...", options{})
 	require.NoError(t, err)
-	err = executeCommand("--inject", "./testdata/dummy", "./...")
+	err = executeCommand("--inject", "./testdata/dummy", "./...", options{})
 	require.NoError(t, err)
 	err = usage()
 	require.NoError(t, err)
@@ -73,7 +79,7 @@ func TestArgs(t *testing.T) {
 }
 
 func TestUnknownCommand(t *testing.T) {
-	err := executeCommand("unknown", "a", "b")
+	err := executeCommand("unknown", "a", "b", options{})
 	require.Error(t, err)
 }
 
@@ -115,3 +121,178 @@ func TestInstrumentation(t *testing.T) {
 		fmt.Println("FAILURE : ", f)
 	}
 }
+
+func TestParseOptions(t *testing.T) {
+	opts, err := parseOptions(nil)
+	require.NoError(t, err)
+	assert.Nil(t, opts.filter)
+	assert.Empty(t, opts.overlayDir)
+
+	opts, err = parseOptions([]string{
+		"--include-pkg=example.com/svc/...",
+		"--include-pkg=example.com/api",
+		"--exclude-pkg=example.com/svc/internal/*",
+		"--include-func=^Handle",
+		"--exclude-func=Test$",
+		"--min-size=3",
+		"--overlay-dir=build/overlay",
+		"--context-stubs",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "build/overlay", opts.overlayDir)
+	assert.True(t, opts.contextStubs)
+	filter := opts.filter
+	assert.Equal(t, []string{"example.com/svc/...", "example.com/api"}, filter.IncludePackages)
+	assert.Equal(t, []string{"example.com/svc/internal/*"}, filter.ExcludePackages)
+	assert.Equal(t, "^Handle", filter.IncludeFunctions.String())
+	assert.Equal(t, "Test$", filter.ExcludeFunctions.String())
+	assert.Equal(t, 3, filter.MinStatements)
+
+	_, err = parseOptions([]string{"--include-func=("})
+	require.Error(t, err)
+	_, err = parseOptions([]string{"--unknown"})
+	require.Error(t, err)
+	_, err = parseOptions([]string{"extra"})
+	require.Error(t, err)
+}
+
+func TestFunctionFilter(t *testing.T) {
+	src := `package p
+
+func Small() {}
+
+func (s *Server) HandleOrder() {
+	a := 1
+	if a > 0 {
+		a++
+	}
+}
+`
+	file, err := parser.ParseFile(token.NewFileSet(), "p.go", src, 0)
+	require.NoError(t, err)
+	small := file.Decls[0].(*ast.FuncDecl)
+	handle := file.Decls[1].(*ast.FuncDecl)
+	assert.Equal(t, "Server.HandleOrder", alib.FuncDeclName(handle))
+
+	testcases := []struct {
+		name   string
+		filter *alib.FunctionFilter
+		pkg    string
+		small  bool
+		handle bool
+	}{
+		{"nil", nil, "example.com/svc", true, true},
+		{"include subpackages", &alib.FunctionFilter{IncludePackages: []string{"example.com/svc/..."}}, "example.com/svc/orders", true, true},
+		{"include other", &alib.FunctionFilter{IncludePackages: []string{"example.com/api"}}, "example.com/svc", false, false},
+		{"exclude glob", &alib.FunctionFilter{ExcludePackages: []string{"example.com/*"}}, "example.com/svc", false, false},
+		{"include func", &alib.FunctionFilter{IncludeFunctions: regexp.MustCompile(`\.Handle`)}, "example.com/svc", false, true},
+		{"exclude func", &alib.FunctionFilter{ExcludeFunctions: regexp.MustCompile(`^Small$`)}, "example.com/svc", false, true},
+		{"min size", &alib.FunctionFilter{MinStatements: 3}, "example.com/svc", false, true},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.small, tc.filter.Match(tc.pkg, small))
+			assert.Equal(t, tc.handle, tc.filter.Match(tc.pkg, handle))
+		})
+	}
+}
+
+func TestInstrumentationFilter(t *testing.T) {
+	filter := &alib.FunctionFilter{ExcludeFunctions: regexp.MustCompile(`^Fibonacci$`)}
+	err := executeCommand("--inject-dump-ir", "./testdata/basic", "./...", options{filter: filter})
+	require.NoError(t, err)
+	out, err := os.ReadFile("./testdata/basic/fib.go_pass_tracing")
+	require.NoError(t, err)
+	assert.True(t, strings.Contains(string(out), `Tracer("FibonacciHelper")`))
+	assert.False(t, strings.Contains(string(out), `Tracer("Fibonacci")`))
+	_, err = Prune("./testdata/basic", "./...", false)
+	require.NoError(t, err)
+}
+
+func TestPruneCommand(t *testing.T) {
+	files := alib.SearchFiles("./testdata/basic", ".go")
+	originals := map[string][]byte{}
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		require.NoError(t, err)
+		originals[file] = content
+	}
+	t.Cleanup(func() {
+		for file, content := range originals {
+			_ = os.WriteFile(file, content, 0o644)
+		}
+	})
+
+	err := executeCommand("--inject", "./testdata/basic", "./...", options{})
+	require.NoError(t, err)
+	instrumented, err := os.ReadFile("./testdata/basic/fib.go")
+	require.NoError(t, err)
+	require.True(t, strings.Contains(string(instrumented), alib.InstrumentationMarker))
+
+	err = executeCommand("--prune", "./testdata/basic", "./...", options{})
+	require.NoError(t, err)
+	for file, content := range originals {
+		pruned, err := os.ReadFile(file)
+		require.NoError(t, err)
+		assert.Equal(t, string(content), string(pruned), file)
+	}
+
+	// pruning project without instrumentation leaves it untouched
+	pruned, err := alib.PruneFiles("./testdata/basic", "./...")
+	require.NoError(t, err)
+	assert.Empty(t, pruned)
+}
+
+func TestOverlayCommand(t *testing.T) {
+	files := alib.SearchFiles("./testdata/basic", ".go")
+	originals := map[string][]byte{}
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		require.NoError(t, err)
+		originals[file] = content
+	}
+	overlayDir := t.TempDir()
+
+	err := executeCommand("--overlay", "./testdata/basic", "./...", options{overlayDir: overlayDir})
+	require.NoError(t, err)
+	for file, content := range originals {
+		current, err := os.ReadFile(file)
+		require.NoError(t, err)
+		assert.Equal(t, string(content), string(current), file)
+	}
+
+	data, err := os.ReadFile(filepath.Join(overlayDir, overlayFileName))
+	require.NoError(t, err)
+	var ov overlay
+	require.NoError(t, json.Unmarshal(data, &ov))
+	source, err := filepath.Abs("./testdata/basic/fib.go")
+	require.NoError(t, err)
+	require.Contains(t, ov.Replace, source)
+	assert.Equal(t, filepath.Join(overlayDir, "fib.go"), ov.Replace[source])
+	instrumented, err := os.ReadFile(ov.Replace[source])
+	require.NoError(t, err)
+	assert.True(t, strings.Contains(string(instrumented), alib.InstrumentationMarker))
+}
+
+func TestContextReport(t *testing.T) {
+	analysis := makeAnalysis("./testdata/funwithoutpathtoroot", "./...", false, nil)
+	breaks, err := analysis.FindContextBreaks()
+	require.NoError(t, err)
+	require.Len(t, breaks, 1)
+	const pkgPath = "go.opentelemetry.io/contrib/instrgen/driver/testdata/funwithoutpathtoroot"
+	assert.Equal(t, pkgPath+".foo", breaks[0].Caller.Id)
+	assert.Equal(t, pkgPath+".bar", breaks[0].Callee.Id)
+	assert.Equal(t, "driver.go", filepath.Base(breaks[0].Position.Filename))
+	assert.Equal(t, 27, breaks[0].Position.Line)
+	assert.Equal(t, "func foo(ctx context.Context)", breaks[0].Stub)
+
+	var buf bytes.Buffer
+	printContextBreaks(&buf, breaks, true)
+	assert.Contains(t, buf.String(), "driver.go:27:2: "+pkgPath+".foo calls "+pkgPath+".bar")
+	assert.Contains(t, buf.String(), "stub: func foo(ctx context.Context)")
+
+	analysis = makeAnalysis("./testdata/basic", "./...", false, nil)
+	breaks, err = analysis.FindContextBreaks()
+	require.NoError(t, err)
+	assert.Empty(t, breaks)
+}
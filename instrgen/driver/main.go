@@ -16,26 +16,102 @@ package main
 
 import (
 	"errors"
+	"flag"
 	"fmt"
 	"go/ast"
 	"log"
 	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
 
 	alib "go.opentelemetry.io/contrib/instrgen/lib"
 )
 
 func usage() error {
-	fmt.Println("\nusage driver --command [path to go project] [package pattern]")
+	fmt.Println("\nusage driver --command [path to go project] [package pattern] [options]")
 	fmt.Println("\tcommand:")
 	fmt.Println("\t\tinject                                 (injects open telemetry calls into project code)")
 	fmt.Println("\t\tinject-dump-ir                         (injects open telemetry calls into project code and intermediate passes)")
-	fmt.Println("\t\tprune                                  (prune open telemetry calls")
+	fmt.Println("\t\toverlay                                (writes instrumented code and go build -overlay file, sources are not modified)")
+	fmt.Println("\t\tprune                                  (removes all generated open telemetry calls)")
+	fmt.Println("\t\tcontext-report                         (reports calls where tracing context is not available)")
 	fmt.Println("\t\tdumpcfg                                (dumps control flow graph)")
 	fmt.Println("\t\trootfunctions                          (dumps root functions)")
+	fmt.Println("\toptions:")
+	fmt.Println("\t\t--include-pkg=pattern                   (instrument only matching packages, can be repeated)")
+	fmt.Println("\t\t--exclude-pkg=pattern                   (do not instrument matching packages, can be repeated)")
+	fmt.Println("\t\t--include-func=regexp                   (instrument only functions with matching name)")
+	fmt.Println("\t\t--exclude-func=regexp                   (do not instrument functions with matching name)")
+	fmt.Println("\t\t--min-size=n                            (instrument only functions with at least n statements)")
+	fmt.Println("\t\t--overlay-dir=path                      (output directory of overlay command, defaults to .instrgen in project)")
+	fmt.Println("\t\t--context-stubs                         (context-report suggests signatures with context parameter)")
 	return nil
 }
 
-func makeAnalysis(projectPath string, packagePattern string, debug bool) *alib.PackageAnalysis {
+// patterns collects values of repeated flag.
+type patterns []string
+
+func (p *patterns) String() string {
+	return strings.Join(*p, ",")
+}
+
+func (p *patterns) Set(value string) error {
+	*p = append(*p, value)
+	return nil
+}
+
+// options passed after package pattern.
+type options struct {
+	filter       *alib.FunctionFilter
+	overlayDir   string
+	contextStubs bool
+}
+
+// parseOptions parses options passed after package pattern.
+func parseOptions(args []string) (options, error) {
+	var opts options
+	if len(args) == 0 {
+		return opts, nil
+	}
+	var includePkgs, excludePkgs patterns
+	var includeFunc, excludeFunc string
+	var minSize int
+	flags := flag.NewFlagSet("instrgen", flag.ContinueOnError)
+	flags.Var(&includePkgs, "include-pkg", "")
+	flags.Var(&excludePkgs, "exclude-pkg", "")
+	flags.StringVar(&includeFunc, "include-func", "", "")
+	flags.StringVar(&excludeFunc, "exclude-func", "", "")
+	flags.IntVar(&minSize, "min-size", 0, "")
+	flags.StringVar(&opts.overlayDir, "overlay-dir", "", "")
+	flags.BoolVar(&opts.contextStubs, "context-stubs", false, "")
+	if err := flags.Parse(args); err != nil {
+		return opts, err
+	}
+	if flags.NArg() > 0 {
+		return opts, fmt.Errorf("unexpected argument %q", flags.Arg(0))
+	}
+	filter := &alib.FunctionFilter{
+		IncludePackages: includePkgs,
+		ExcludePackages: excludePkgs,
+		MinStatements:   minSize,
+	}
+	var err error
+	if includeFunc != "" {
+		if filter.IncludeFunctions, err = regexp.Compile(includeFunc); err != nil {
+			return opts, err
+		}
+	}
+	if excludeFunc != "" {
+		if filter.ExcludeFunctions, err = regexp.Compile(excludeFunc); err != nil {
+			return opts, err
+		}
+	}
+	opts.filter = filter
+	return opts, nil
+}
+
+func makeAnalysis(projectPath string, packagePattern string, debug bool, filter *alib.FunctionFilter) *alib.PackageAnalysis {
 	var rootFunctions []alib.FuncDescriptor
 
 	interfaces := alib.FindInterfaces(projectPath, packagePattern)
@@ -55,6 +131,7 @@ func makeAnalysis(projectPath string, packagePattern string, debug bool) *alib.P
 		FuncDecls:      funcDecls,
 		Callgraph:      backwardCallGraph,
 		Interfaces:     interfaces,
+		Filter:         filter,
 		Debug:          debug,
 	}
 	return analysis
@@ -62,10 +139,20 @@ func makeAnalysis(projectPath string, packagePattern string, debug bool) *alib.P
 
 // Prune.
 func Prune(projectPath string, packagePattern string, debug bool) ([]*ast.File, error) {
-	analysis := makeAnalysis(projectPath, packagePattern, debug)
+	analysis := makeAnalysis(projectPath, packagePattern, debug, nil)
 	return analysis.Execute(&alib.OtelPruner{}, otelPrunerPassSuffix)
 }
 
+// instrument injects instrumentation into project sources in place.
+func instrument(projectPath string, packagePattern string, filter *alib.FunctionFilter) error {
+	_, err := Prune(projectPath, packagePattern, false)
+	if err != nil {
+		return err
+	}
+	analysis := makeAnalysis(projectPath, packagePattern, false, filter)
+	return ExecutePasses(analysis)
+}
+
 func makeCallGraph(projectPath string, packagePattern string) map[alib.FuncDescriptor][]alib.FuncDescriptor {
 	var funcDecls map[alib.FuncDescriptor]bool
 	var backwardCallGraph map[alib.FuncDescriptor][]alib.FuncDescriptor
@@ -110,7 +197,7 @@ func isDirectory(path string) (bool, error) {
 // decls and infer function bodies to find call to AutotelEntryPoint
 // A parent function of this call will become root of instrumentation
 // Each function call from this place will be instrumented automatically.
-func executeCommand(command string, projectPath string, packagePattern string) error {
+func executeCommand(command string, projectPath string, packagePattern string, opts options) error {
 	isDir, err := isDirectory(projectPath)
 	if !isDir {
 		_ = usage()
@@ -121,12 +208,7 @@ func executeCommand(command string, projectPath string, packagePattern string) e
 	}
 	switch command {
 	case "--inject":
-		_, err := Prune(projectPath, packagePattern, false)
-		if err != nil {
-			return err
-		}
-		analysis := makeAnalysis(projectPath, packagePattern, false)
-		err = ExecutePasses(analysis)
+		err := instrument(projectPath, packagePattern, opts.filter)
 		if err != nil {
 			return err
 		}
@@ -137,13 +219,32 @@ func executeCommand(command string, projectPath string, packagePattern string) e
 		if err != nil {
 			return err
 		}
-		analysis := makeAnalysis(projectPath, packagePattern, true)
+		analysis := makeAnalysis(projectPath, packagePattern, true, opts.filter)
 		err = ExecutePassesDumpIr(analysis)
 		if err != nil {
 			return err
 		}
 		fmt.Println("\tinstrumentation done")
 		return nil
+	case "--overlay":
+		overlayDir := opts.overlayDir
+		if overlayDir == "" {
+			overlayDir = filepath.Join(projectPath, defaultOverlayDir)
+		}
+		overlayFile, err := injectOverlay(projectPath, packagePattern, opts.filter, overlayDir)
+		if err != nil {
+			return err
+		}
+		fmt.Println("\tinstrumentation done, build with: go build -overlay", overlayFile)
+		return nil
+	case "--context-report":
+		analysis := makeAnalysis(projectPath, packagePattern, false, opts.filter)
+		breaks, err := analysis.FindContextBreaks()
+		if err != nil {
+			return err
+		}
+		printContextBreaks(os.Stdout, breaks, opts.contextStubs)
+		return nil
 	case "--dumpcfg":
 		backwardCallGraph := makeCallGraph(projectPath, packagePattern)
 		dumpCallGraph(backwardCallGraph)
@@ -153,10 +254,13 @@ func executeCommand(command string, projectPath string, packagePattern string) e
 		dumpRootFunctions(rootFunctions)
 		return nil
 	case "--prune":
-		_, err := Prune(projectPath, packagePattern, false)
+		pruned, err := alib.PruneFiles(projectPath, packagePattern)
 		if err != nil {
 			return err
 		}
+		for _, file := range pruned {
+			fmt.Println("\tpruned", file)
+		}
 		return nil
 	default:
 		return errors.New("unknown command")
@@ -164,7 +268,7 @@ func executeCommand(command string, projectPath string, packagePattern string) e
 }
 
 func checkArgs(args []string) error {
-	if len(args) != 4 {
+	if len(args) < 4 {
 		_ = usage()
 		return errors.New("wrong arguments")
 	}
@@ -177,7 +281,12 @@ func main() {
 	if err != nil {
 		return
 	}
-	err = executeCommand(os.Args[1], os.Args[2], os.Args[3])
+	opts, err := parseOptions(os.Args[4:])
+	if err != nil {
+		_ = usage()
+		log.Fatal(err)
+	}
+	err = executeCommand(os.Args[1], os.Args[2], os.Args[3], opts)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	alib "go.opentelemetry.io/contrib/instrgen/lib"
+)
+
+const (
+	// defaultOverlayDir is created in project directory.
+	// Go tool ignores directories starting with dot.
+	defaultOverlayDir = ".instrgen"
+	overlayFileName   = "overlay.json"
+)
+
+// overlay is the format of file accepted by
+// go build -overlay flag.
+type overlay struct {
+	Replace map[string]string
+}
+
+// injectOverlay instruments project and copies instrumented
+// files into overlayDir together with overlay file mapping
+// them to original sources. Original sources are restored,
+// so instrumentation is applied only at build time by
+// go build -overlay. It returns path of overlay file.
+func injectOverlay(projectPath string, packagePattern string, filter *alib.FunctionFilter, overlayDir string) (overlayFile string, err error) {
+	files, err := alib.FindGoFiles(projectPath, packagePattern)
+	if err != nil {
+		return "", err
+	}
+	originals := make(map[string][]byte, len(files))
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return "", err
+		}
+		originals[file] = content
+	}
+	defer func() {
+		for file, content := range originals {
+			err = errors.Join(err, os.WriteFile(file, content, 0o644))
+		}
+	}()
+
+	if err = instrument(projectPath, packagePattern, filter); err != nil {
+		return "", err
+	}
+
+	overlayDir, err = filepath.Abs(overlayDir)
+	if err != nil {
+		return "", err
+	}
+	projectPath, err = filepath.Abs(projectPath)
+	if err != nil {
+		return "", err
+	}
+	ov := overlay{Replace: map[string]string{}}
+	for file, content := range originals {
+		instrumented, err := os.ReadFile(file)
+		if err != nil {
+			return "", err
+		}
+		if bytes.Equal(content, instrumented) {
+			continue
+		}
+		source, err := filepath.Abs(file)
+		if err != nil {
+			return "", err
+		}
+		rel, err := filepath.Rel(projectPath, source)
+		if err != nil {
+			return "", err
+		}
+		target := filepath.Join(overlayDir, rel)
+		if err = os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return "", err
+		}
+		if err = os.WriteFile(target, instrumented, 0o644); err != nil {
+			return "", err
+		}
+		ov.Replace[source] = target
+	}
+
+	data, err := json.MarshalIndent(ov, "", "\t")
+	if err != nil {
+		return "", err
+	}
+	if err = os.MkdirAll(overlayDir, 0o755); err != nil {
+		return "", err
+	}
+	overlayFile = filepath.Join(overlayDir, overlayFileName)
+	return overlayFile, os.WriteFile(overlayFile, data, 0o644)
+}
@@ -0,0 +1,43 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+
+	alib "go.opentelemetry.io/contrib/instrgen/lib"
+)
+
+// printContextBreaks prints calls where the tracing context
+// is not available and the trace will be broken, optionally
+// with caller signatures threading the context.
+func printContextBreaks(w io.Writer, breaks []alib.ContextBreak, stubs bool) {
+	if len(breaks) == 0 {
+		fmt.Fprintln(w, "\tcontext is available in all instrumented calls")
+		return
+	}
+	fmt.Fprintf(w, "\tcontext is not available in %d instrumented calls, context.TODO() will be passed:\n", len(breaks))
+	for _, b := range breaks {
+		caller := "package level code"
+		if b.Caller.Id != "" {
+			caller = b.Caller.Id
+		}
+		fmt.Fprintf(w, "\t\t%s: %s calls %s\n", b.Position, caller, b.Callee.Id)
+		if stubs && b.Stub != "" {
+			fmt.Fprintf(w, "\t\t\tstub: %s\n", b.Stub)
+		}
+	}
+}
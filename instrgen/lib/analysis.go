@@ -36,6 +36,7 @@ type PackageAnalysis struct {
 	FuncDecls      map[FuncDescriptor]bool
 	Callgraph      map[FuncDescriptor][]FuncDescriptor
 	Interfaces     map[string]bool
+	Filter         *FunctionFilter
 	Debug          bool
 }
 
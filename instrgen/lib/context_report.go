@@ -0,0 +1,173 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lib // import "go.opentelemetry.io/contrib/instrgen/lib"
+
+import (
+	"bytes"
+	"go/ast"
+	"go/printer"
+	"go/token"
+	"sort"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// ContextBreak describes a call of an instrumented function from code that
+// has no tracing context available. context.TODO() is passed to such
+// calls, so the trace is broken at this point.
+type ContextBreak struct {
+	Position token.Position
+	// Caller is the function containing the call, empty
+	// when the call is made at package level.
+	Caller FuncDescriptor
+	Callee FuncDescriptor
+	// Stub is the signature of the caller with a stub
+	// context parameter that would let the context be
+	// threaded through it, empty at package level.
+	Stub string
+}
+
+// FindContextBreaks reports the calls of instrumented functions
+// that have no tracing context available, without modifying
+// the sources. Breaks are sorted by position.
+func (analysis *PackageAnalysis) FindContextBreaks() ([]ContextBreak, error) {
+	if len(analysis.RootFunctions) == 0 {
+		return nil, nil
+	}
+	fset := token.NewFileSet()
+	pkgs, err := getPkgs(analysis.ProjectPath, analysis.PackagePattern, fset)
+	if err != nil {
+		return nil, err
+	}
+	var breaks []ContextBreak
+	for _, pkg := range pkgs {
+		for _, node := range pkg.Syntax {
+			breaks = append(breaks, analysis.fileContextBreaks(fset, node, pkg, pkgs)...)
+		}
+	}
+	sort.Slice(breaks, func(i, j int) bool {
+		a, b := breaks[i].Position, breaks[j].Position
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		return a.Offset < b.Offset
+	})
+	return breaks, nil
+}
+
+func (analysis *PackageAnalysis) fileContextBreaks(
+	fset *token.FileSet,
+	node *ast.File,
+	pkg *packages.Package,
+	pkgs []*packages.Package,
+) []ContextBreak {
+	var breaks []ContextBreak
+	// inspect reports calls made within n by caller,
+	// which has no context when hasContext is false.
+	inspect := func(n ast.Node, caller FuncDescriptor, stub string, hasContext bool) {
+		ast.Inspect(n, func(n ast.Node) bool {
+			callExpr, ok := n.(*ast.CallExpr)
+			if !ok || hasContext {
+				return true
+			}
+			callee, ok := calleeDescriptor(callExpr, pkg)
+			if !ok || !analysis.FuncDecls[callee] {
+				return true
+			}
+			visited := map[FuncDescriptor]bool{}
+			if isPath(analysis.Callgraph, callee, analysis.RootFunctions[0], visited) {
+				breaks = append(breaks, ContextBreak{
+					Position: fset.Position(callExpr.Pos()),
+					Caller:   caller,
+					Callee:   callee,
+					Stub:     stub,
+				})
+			}
+			return true
+		})
+	}
+	for _, decl := range node.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || pkg.TypesInfo.Defs[funcDecl.Name] == nil {
+			inspect(decl, FuncDescriptor{}, "", false)
+			continue
+		}
+		pkgPath := GetPkgPathForFunction(pkg, pkgs, funcDecl, analysis.Interfaces)
+		caller := FuncDescriptor{
+			Id:              pkgPath + "." + pkg.TypesInfo.Defs[funcDecl.Name].Name(),
+			DeclType:        pkg.TypesInfo.Defs[funcDecl.Name].Type().String(),
+			CustomInjection: false,
+		}
+		visited := map[FuncDescriptor]bool{}
+		hasContext := isPath(analysis.Callgraph, caller, analysis.RootFunctions[0], visited)
+		inspect(funcDecl, caller, stubSignature(fset, funcDecl), hasContext)
+	}
+	return breaks
+}
+
+// calleeDescriptor resolves the function called by callExpr
+// the same way the call graph is built.
+func calleeDescriptor(callExpr *ast.CallExpr, pkg *packages.Package) (FuncDescriptor, bool) {
+	switch fun := callExpr.Fun.(type) {
+	case *ast.Ident:
+		if pkg.TypesInfo.Uses[fun] == nil {
+			return FuncDescriptor{}, false
+		}
+		pkgPath := GetPkgNameFromUsesTable(pkg, fun)
+		return FuncDescriptor{
+			Id:       pkgPath + "." + pkg.TypesInfo.Uses[fun].Name(),
+			DeclType: pkg.TypesInfo.Uses[fun].Type().String(),
+		}, true
+	case *ast.SelectorExpr:
+		if pkg.TypesInfo.Uses[fun.Sel] == nil {
+			return FuncDescriptor{}, false
+		}
+		pkgPath := GetPkgNameFromUsesTable(pkg, fun.Sel)
+		if fun.X != nil {
+			pkgPath = GetSelectorPkgPath(fun, pkg, pkgPath)
+		}
+		return FuncDescriptor{
+			Id:       pkgPath + "." + pkg.TypesInfo.Uses[fun.Sel].Name(),
+			DeclType: pkg.TypesInfo.Uses[fun.Sel].Type().String(),
+		}, true
+	}
+	return FuncDescriptor{}, false
+}
+
+// stubSignature returns the signature of funcDecl with
+// a leading ctx context.Context parameter.
+func stubSignature(fset *token.FileSet, funcDecl *ast.FuncDecl) string {
+	ctxField := &ast.Field{
+		Names: []*ast.Ident{{Name: "ctx"}},
+		Type: &ast.SelectorExpr{
+			X:   &ast.Ident{Name: "context"},
+			Sel: &ast.Ident{Name: "Context"},
+		},
+	}
+	funcType := *funcDecl.Type
+	funcType.Params = &ast.FieldList{
+		List: append([]*ast.Field{ctxField}, funcDecl.Type.Params.List...),
+	}
+	stub := &ast.FuncDecl{
+		Recv: funcDecl.Recv,
+		Name: funcDecl.Name,
+		Type: &funcType,
+	}
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, fset, stub); err != nil {
+		return ""
+	}
+	return buf.String()
+}
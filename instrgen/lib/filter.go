@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lib // import "go.opentelemetry.io/contrib/instrgen/lib"
+
+import (
+	"go/ast"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// FunctionFilter selects functions that are instrumented.
+// Root functions are always instrumented as they set up tracing.
+// Functions that do not match the filter but are part of the
+// call graph only propagate the context to their callees.
+type FunctionFilter struct {
+	// IncludePackages are package path patterns. When set,
+	// only functions from matching packages are instrumented.
+	// A pattern is either a path.Match pattern or a package
+	// path followed by "/..." that matches the package and
+	// all its subpackages.
+	IncludePackages []string
+	// ExcludePackages are package path patterns of packages
+	// that are not instrumented.
+	ExcludePackages []string
+	// IncludeFunctions, when set, instruments only functions
+	// whose name matches. Methods are named Type.Method.
+	IncludeFunctions *regexp.Regexp
+	// ExcludeFunctions prevents functions whose name matches
+	// from being instrumented.
+	ExcludeFunctions *regexp.Regexp
+	// MinStatements is the minimal number of statements
+	// a function body must contain to be instrumented.
+	MinStatements int
+}
+
+// Match tells whether function declared in package pkgPath
+// should be instrumented.
+func (f *FunctionFilter) Match(pkgPath string, decl *ast.FuncDecl) bool {
+	if f == nil {
+		return true
+	}
+	if len(f.IncludePackages) > 0 && !matchAnyPackage(f.IncludePackages, pkgPath) {
+		return false
+	}
+	if matchAnyPackage(f.ExcludePackages, pkgPath) {
+		return false
+	}
+	name := FuncDeclName(decl)
+	if f.IncludeFunctions != nil && !f.IncludeFunctions.MatchString(name) {
+		return false
+	}
+	if f.ExcludeFunctions != nil && f.ExcludeFunctions.MatchString(name) {
+		return false
+	}
+	return countStmts(decl.Body) >= f.MinStatements
+}
+
+// FuncDeclName returns name of function declaration,
+// methods are named Type.Method.
+func FuncDeclName(decl *ast.FuncDecl) string {
+	if decl.Recv == nil || len(decl.Recv.List) == 0 {
+		return decl.Name.Name
+	}
+	recv := decl.Recv.List[0].Type
+	for {
+		switch t := recv.(type) {
+		case *ast.StarExpr:
+			recv = t.X
+			continue
+		case *ast.IndexExpr:
+			recv = t.X
+			continue
+		case *ast.IndexListExpr:
+			recv = t.X
+			continue
+		case *ast.Ident:
+			return t.Name + "." + decl.Name.Name
+		}
+		return decl.Name.Name
+	}
+}
+
+func matchAnyPackage(patterns []string, pkgPath string) bool {
+	for _, pattern := range patterns {
+		if matchPackage(pattern, pkgPath) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchPackage(pattern string, pkgPath string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "/..."); ok {
+		return pkgPath == prefix || strings.HasPrefix(pkgPath, prefix+"/")
+	}
+	matched, err := path.Match(pattern, pkgPath)
+	return err == nil && matched
+}
+
+// countStmts returns number of statements in body
+// including nested ones.
+func countStmts(body *ast.BlockStmt) int {
+	if body == nil {
+		return 0
+	}
+	count := 0
+	ast.Inspect(body, func(n ast.Node) bool {
+		if _, ok := n.(*ast.BlockStmt); ok {
+			return true
+		}
+		if _, ok := n.(ast.Stmt); ok {
+			count++
+		}
+		return true
+	})
+	return count
+}
@@ -278,6 +278,37 @@ func makeSpanStmts(name string, paramName string) []ast.Stmt {
 	return stmts
 }
 
+func makeContextStmts(paramName string) []ast.Stmt {
+	s1 := &ast.AssignStmt{
+		Lhs: []ast.Expr{
+			&ast.Ident{
+				Name: "__atel_child_tracing_ctx",
+			},
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.Ident{
+				Name: paramName,
+			},
+		},
+	}
+	s2 := &ast.AssignStmt{
+		Lhs: []ast.Expr{
+			&ast.Ident{
+				Name: "_",
+			},
+		},
+		Tok: token.ASSIGN,
+		Rhs: []ast.Expr{
+			&ast.Ident{
+				Name: "__atel_child_tracing_ctx",
+			},
+		},
+	}
+	stmts := []ast.Stmt{s1, s2}
+	return stmts
+}
+
 // Execute.
 func (pass *InstrumentationPass) Execute(
 	node *ast.File,
@@ -316,6 +347,13 @@ func (pass *InstrumentationPass) Execute(
 				visited := map[FuncDescriptor]bool{}
 				fmt.Println("\t\t\tInstrumentation FuncDecl:", fundId, pkg.TypesInfo.Defs[x.Name].Type().String())
 				if isPath(analysis.Callgraph, fun, root, visited) && fun.TypeHash() != root.TypeHash() {
+					if !analysis.Filter.Match(pkg.PkgPath, x) {
+						// function is filtered out, but it still
+						// has to pass context to its callees
+						fmt.Println("\t\t\tInstrumentation skipped FuncDecl:", fundId)
+						x.Body.List = append(makeContextStmts("__atel_tracing_ctx"), x.Body.List...)
+						return false
+					}
 					x.Body.List = append(makeSpanStmts(x.Name.Name, "__atel_tracing_ctx"), x.Body.List...)
 					addContext = true
 					addImports = true
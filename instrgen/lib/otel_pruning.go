@@ -40,7 +40,7 @@ func inspectFuncContent(fType *ast.FuncType, fBody *ast.BlockStmt) {
 	for index := 0; index < len(fType.Params.List); index++ {
 		param := fType.Params.List[index]
 		for _, ident := range param.Names {
-			if strings.Contains(ident.Name, "__atel_") {
+			if strings.Contains(ident.Name, InstrumentationMarker) {
 				fType.Params.List = removeField(fType.Params.List, index)
 				index--
 			}
@@ -51,13 +51,14 @@ func inspectFuncContent(fType *ast.FuncType, fBody *ast.BlockStmt) {
 		switch bodyStmt := stmt.(type) {
 		case *ast.AssignStmt:
 			if ident, ok := bodyStmt.Lhs[0].(*ast.Ident); ok {
-				if strings.Contains(ident.Name, "__atel_") {
+				if strings.Contains(ident.Name, InstrumentationMarker) {
 					fBody.List = removeStmt(fBody.List, index)
 					index--
+					continue
 				}
 			}
 			if ident, ok := bodyStmt.Rhs[0].(*ast.Ident); ok {
-				if strings.Contains(ident.Name, "__atel_") {
+				if strings.Contains(ident.Name, InstrumentationMarker) {
 					fBody.List = removeStmt(fBody.List, index)
 					index--
 				}
@@ -82,7 +83,7 @@ func inspectFuncContent(fType *ast.FuncType, fBody *ast.BlockStmt) {
 					}
 				}
 				if ident, ok := sel.X.(*ast.Ident); ok {
-					if strings.Contains(ident.Name, "__atel_") {
+					if strings.Contains(ident.Name, InstrumentationMarker) {
 						fBody.List = removeStmt(fBody.List, index)
 						index--
 					}
@@ -107,7 +108,7 @@ func (pass *OtelPruner) Execute(
 		case *ast.CallExpr:
 			for argIndex := 0; argIndex < len(x.Args); argIndex++ {
 				if ident, ok := x.Args[argIndex].(*ast.Ident); ok {
-					if strings.Contains(ident.Name, "__atel_") {
+					if strings.Contains(ident.Name, InstrumentationMarker) {
 						x.Args = removeExpr(x.Args, argIndex)
 						argIndex--
 					}
@@ -117,7 +118,7 @@ func (pass *OtelPruner) Execute(
 				if c, ok := x.Args[argIndex].(*ast.CallExpr); ok {
 					if sel, ok := c.Fun.(*ast.SelectorExpr); ok {
 						if ident, ok := sel.X.(*ast.Ident); ok {
-							if strings.Contains(ident.Name, "__atel_") {
+							if strings.Contains(ident.Name, InstrumentationMarker) {
 								x.Args = removeExpr(x.Args, argIndex)
 								argIndex--
 							}
@@ -139,7 +140,7 @@ func (pass *OtelPruner) Execute(
 				}
 				for argIndex := 0; argIndex < len(funcType.Params.List); argIndex++ {
 					for _, ident := range funcType.Params.List[argIndex].Names {
-						if strings.Contains(ident.Name, "__atel_") {
+						if strings.Contains(ident.Name, InstrumentationMarker) {
 							funcType.Params.List = removeField(funcType.Params.List, argIndex)
 							argIndex--
 						}
@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lib // import "go.opentelemetry.io/contrib/instrgen/lib"
+
+import (
+	"bytes"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// InstrumentationMarker prefixes all identifiers
+// generated by instrgen. Its presence in a file tells
+// that file contains generated instrumentation.
+const InstrumentationMarker = "__atel_"
+
+// PruneFiles removes generated instrumentation from go files
+// of packages matching packagePattern in projectPath.
+// Pattern is a directory relative to projectPath, optionally
+// followed by "..." to include all subdirectories, eg. "./...".
+// Unlike OtelPruner pass, it works on syntax only, so it does not
+// require project to build nor to contain an entry point.
+// Files without instrumentation markers are left untouched.
+// It returns the list of modified files.
+func PruneFiles(projectPath string, packagePattern string) ([]string, error) {
+	files, err := FindGoFiles(projectPath, packagePattern)
+	if err != nil {
+		return nil, err
+	}
+	var pruned []string
+	for _, file := range files {
+		changed, err := pruneFile(file)
+		if err != nil {
+			return pruned, err
+		}
+		if changed {
+			pruned = append(pruned, file)
+		}
+	}
+	return pruned, nil
+}
+
+// FindGoFiles returns go files of packages matching packagePattern
+// in projectPath. See PruneFiles for pattern format.
+func FindGoFiles(projectPath string, packagePattern string) ([]string, error) {
+	dir, recursive := strings.CutSuffix(packagePattern, "...")
+	root := filepath.Join(projectPath, filepath.FromSlash(dir))
+	var files []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path == root {
+				return nil
+			}
+			if !recursive || skipDir(d.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) == ".go" {
+			files = append(files, path)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// skipDir tells whether directory is ignored
+// by go tool when matching package patterns.
+func skipDir(name string) bool {
+	return name == "testdata" || name == "vendor" ||
+		strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")
+}
+
+func pruneFile(path string) (bool, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+	if !bytes.Contains(src, []byte(InstrumentationMarker)) {
+		return false, nil
+	}
+	fset := token.NewFileSet()
+	fileNode, err := parser.ParseFile(fset, path, src, parser.ParseComments)
+	if err != nil {
+		return false, err
+	}
+	imports := (&OtelPruner{}).Execute(fileNode, nil, nil, nil)
+	addImports(imports, fset, fileNode)
+	var out bytes.Buffer
+	if err = printer.Fprint(&out, fset, fileNode); err != nil {
+		return false, err
+	}
+	if bytes.Equal(src, out.Bytes()) {
+		return false, nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return false, err
+	}
+	return true, os.WriteFile(path, out.Bytes(), info.Mode())
+}
@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelsql // import "go.opentelemetry.io/contrib/instrumentation/database/sql/otelsql"
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "go.opentelemetry.io/contrib/instrumentation/database/sql/otelsql"
+
+// config is used to configure the database/sql instrumentation.
+type config struct {
+	TracerProvider trace.TracerProvider
+
+	Tracer trace.Tracer
+
+	MeterProvider metric.MeterProvider
+
+	Meter metric.Meter
+
+	Attributes []attribute.KeyValue
+
+	StatementAttributeDisabled bool
+
+	StatementSanitizer StatementSanitizer
+}
+
+// newConfig returns a config with all Options set.
+func newConfig(opts ...Option) *config {
+	cfg := &config{
+		TracerProvider:     otel.GetTracerProvider(),
+		MeterProvider:      otel.GetMeterProvider(),
+		StatementSanitizer: SanitizeStatement,
+	}
+	for _, opt := range opts {
+		opt.apply(cfg)
+	}
+
+	cfg.Tracer = cfg.TracerProvider.Tracer(
+		instrumentationName,
+		trace.WithInstrumentationVersion(Version()),
+	)
+	cfg.Meter = cfg.MeterProvider.Meter(
+		instrumentationName,
+		metric.WithInstrumentationVersion(Version()),
+	)
+	return cfg
+}
+
+// Option specifies instrumentation configuration options.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (o optionFunc) apply(c *config) {
+	o(c)
+}
+
+// WithTracerProvider specifies a tracer provider to use for creating a tracer.
+// If none is specified, the global provider is used.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return optionFunc(func(cfg *config) {
+		if provider != nil {
+			cfg.TracerProvider = provider
+		}
+	})
+}
+
+// WithMeterProvider specifies a meter provider to use for creating a meter.
+// If none is specified, the global provider is used.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return optionFunc(func(cfg *config) {
+		if provider != nil {
+			cfg.MeterProvider = provider
+		}
+	})
+}
+
+// WithAttributes specifies attributes added to every span and metric, e.g.
+// the db.system and db.name attributes identifying the database.
+func WithAttributes(attrs ...attribute.KeyValue) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.Attributes = append(cfg.Attributes, attrs...)
+	})
+}
+
+// WithStatementAttributeDisabled specifies if the SQL statement is added as
+// the db.statement attribute to spans or not. The statement is added by
+// default, sanitized by the StatementSanitizer set with
+// WithStatementSanitizer.
+func WithStatementAttributeDisabled(disabled bool) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.StatementAttributeDisabled = disabled
+	})
+}
+
+// WithStatementSanitizer specifies the StatementSanitizer returning the
+// db.statement attribute value of a SQL statement. If none is specified,
+// SanitizeStatement is used, which replaces literal values by "?".
+func WithStatementSanitizer(sanitizer StatementSanitizer) Option {
+	return optionFunc(func(cfg *config) {
+		if sanitizer != nil {
+			cfg.StatementSanitizer = sanitizer
+		}
+	})
+}
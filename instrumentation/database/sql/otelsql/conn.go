@@ -0,0 +1,216 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelsql // import "go.opentelemetry.io/contrib/instrumentation/database/sql/otelsql"
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"time"
+)
+
+var (
+	errNonDefaultIsolation = errors.New("sql: driver does not support non-default isolation level")
+	errReadOnly            = errors.New("sql: driver does not support read-only transactions")
+	errNamedParameters     = errors.New("sql: driver does not support the use of Named Parameters")
+)
+
+// otelConn is an instrumented driver.Conn. It implements all the optional
+// interfaces of a connection, and falls back to the behavior of database/sql
+// for those the wrapped connection does not implement.
+type otelConn struct {
+	conn driver.Conn
+	cfg  *config
+}
+
+var (
+	_ driver.Conn               = (*otelConn)(nil)
+	_ driver.ConnBeginTx        = (*otelConn)(nil)
+	_ driver.ConnPrepareContext = (*otelConn)(nil)
+	_ driver.ExecerContext      = (*otelConn)(nil)
+	_ driver.QueryerContext     = (*otelConn)(nil)
+	_ driver.Pinger             = (*otelConn)(nil)
+	_ driver.SessionResetter    = (*otelConn)(nil)
+	_ driver.Validator          = (*otelConn)(nil)
+	_ driver.NamedValueChecker  = (*otelConn)(nil)
+)
+
+func newConn(conn driver.Conn, cfg *config) *otelConn {
+	return &otelConn{conn: conn, cfg: cfg}
+}
+
+// Prepare returns a prepared statement, bound to this connection.
+func (c *otelConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+// PrepareContext returns a prepared statement, bound to this connection.
+func (c *otelConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	ctx, span := c.cfg.start(ctx, spanPrepare, query)
+	var (
+		stmt driver.Stmt
+		err  error
+	)
+	if pc, ok := c.conn.(driver.ConnPrepareContext); ok {
+		stmt, err = pc.PrepareContext(ctx, query)
+	} else if err = ctx.Err(); err == nil {
+		stmt, err = c.conn.Prepare(query)
+	}
+	endSpan(span, err)
+	if err != nil {
+		return nil, err
+	}
+	return newStmt(stmt, c, query), nil
+}
+
+// Close closes the connection.
+func (c *otelConn) Close() error {
+	return c.conn.Close()
+}
+
+// Begin starts and returns a new transaction.
+func (c *otelConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+// BeginTx starts and returns a new transaction.
+func (c *otelConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	parent := ctx
+	ctx, span := c.cfg.start(ctx, spanBeginTx, "")
+	var (
+		tx  driver.Tx
+		err error
+	)
+	if bt, ok := c.conn.(driver.ConnBeginTx); ok {
+		tx, err = bt.BeginTx(ctx, opts)
+	} else {
+		switch {
+		case opts.Isolation != driver.IsolationLevel(0):
+			err = errNonDefaultIsolation
+		case opts.ReadOnly:
+			err = errReadOnly
+		default:
+			err = ctx.Err()
+		}
+		if err == nil {
+			tx, err = c.conn.Begin() // nolint:staticcheck // Fallback of database/sql.
+		}
+	}
+	endSpan(span, err)
+	if err != nil {
+		return nil, err
+	}
+	return newTx(parent, tx, c.cfg), nil
+}
+
+// ExecContext executes a query that doesn't return rows. It returns
+// driver.ErrSkip if the wrapped connection does not support it.
+func (c *otelConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	var (
+		res driver.Result
+		err error
+	)
+	start := time.Now()
+	switch e := c.conn.(type) {
+	case driver.ExecerContext:
+		res, err = e.ExecContext(ctx, query, args)
+	case driver.Execer: // nolint:staticcheck // Fallback of database/sql.
+		var values []driver.Value
+		if values, err = namedValueToValue(args); err == nil {
+			if err = ctx.Err(); err == nil {
+				res, err = e.Exec(query, values)
+			}
+		}
+	default:
+		return nil, driver.ErrSkip
+	}
+	c.cfg.record(ctx, spanConnExec, query, start, err)
+	return res, err
+}
+
+// QueryContext executes a query that may return rows. It returns
+// driver.ErrSkip if the wrapped connection does not support it.
+func (c *otelConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	var (
+		rows driver.Rows
+		err  error
+	)
+	start := time.Now()
+	switch q := c.conn.(type) {
+	case driver.QueryerContext:
+		rows, err = q.QueryContext(ctx, query, args)
+	case driver.Queryer: // nolint:staticcheck // Fallback of database/sql.
+		var values []driver.Value
+		if values, err = namedValueToValue(args); err == nil {
+			if err = ctx.Err(); err == nil {
+				rows, err = q.Query(query, values)
+			}
+		}
+	default:
+		return nil, driver.ErrSkip
+	}
+	c.cfg.record(ctx, spanConnQuery, query, start, err)
+	return rows, err
+}
+
+// Ping verifies the connection to the database is still alive.
+func (c *otelConn) Ping(ctx context.Context) error {
+	p, ok := c.conn.(driver.Pinger)
+	if !ok {
+		return nil
+	}
+	ctx, span := c.cfg.start(ctx, spanPing, "")
+	err := p.Ping(ctx)
+	endSpan(span, err)
+	return err
+}
+
+// ResetSession is called prior to executing a query on the connection if the
+// connection has been used before.
+func (c *otelConn) ResetSession(ctx context.Context) error {
+	if r, ok := c.conn.(driver.SessionResetter); ok {
+		return r.ResetSession(ctx)
+	}
+	return nil
+}
+
+// IsValid is called prior to placing the connection into the connection pool.
+func (c *otelConn) IsValid() bool {
+	if v, ok := c.conn.(driver.Validator); ok {
+		return v.IsValid()
+	}
+	return true
+}
+
+// CheckNamedValue is called before passing arguments to the driver.
+func (c *otelConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if nvc, ok := c.conn.(driver.NamedValueChecker); ok {
+		return nvc.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+// namedValueToValue converts args for the deprecated driver interfaces not
+// supporting named parameters.
+func namedValueToValue(args []driver.NamedValue) ([]driver.Value, error) {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		if arg.Name != "" {
+			return nil, errNamedParameters
+		}
+		values[i] = arg.Value
+	}
+	return values, nil
+}
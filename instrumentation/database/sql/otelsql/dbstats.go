@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelsql // import "go.opentelemetry.io/contrib/instrumentation/database/sql/otelsql"
+
+import (
+	"context"
+	"database/sql"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Connection pool metric names.
+const (
+	connectionsUsage        = "db.client.connections.usage"         // Int64ObservableUpDownCounter
+	connectionsMax          = "db.client.connections.max"           // Int64ObservableUpDownCounter
+	connectionsWaits        = "db.client.connections.waits"         // Int64ObservableCounter
+	connectionsWaitDuration = "db.client.connections.wait_duration" // Float64ObservableCounter
+	connectionsClosed       = "db.client.connections.closed"        // Int64ObservableCounter
+)
+
+// Connection pool metric attribute keys.
+const (
+	stateKey  = attribute.Key("state")
+	reasonKey = attribute.Key("reason")
+)
+
+// RegisterDBStatsMetrics registers callbacks reporting the statistics of the
+// connection pool of db, as returned by its Stats method, as the following
+// metrics:
+//
+//   - db.client.connections.usage: the number of idle and used connections.
+//   - db.client.connections.max: the maximum number of open connections.
+//   - db.client.connections.waits: the number of connections waited for.
+//   - db.client.connections.wait_duration: the time spent waiting for
+//     connections.
+//   - db.client.connections.closed: the number of connections closed by
+//     reason (max_idle, max_idle_time or max_lifetime).
+//
+// The attributes set with WithAttributes are added to the metrics. The
+// returned Registration unregisters the callbacks.
+func RegisterDBStatsMetrics(db *sql.DB, opts ...Option) (metric.Registration, error) {
+	cfg := newConfig(opts...)
+	meter := cfg.Meter
+
+	usage, err := meter.Int64ObservableUpDownCounter(
+		connectionsUsage,
+		metric.WithUnit("{connection}"),
+		metric.WithDescription("The number of connections that are currently in the state described by the state attribute."),
+	)
+	if err != nil {
+		return nil, err
+	}
+	max, err := meter.Int64ObservableUpDownCounter(
+		connectionsMax,
+		metric.WithUnit("{connection}"),
+		metric.WithDescription("The maximum number of open connections allowed."),
+	)
+	if err != nil {
+		return nil, err
+	}
+	waits, err := meter.Int64ObservableCounter(
+		connectionsWaits,
+		metric.WithUnit("{wait}"),
+		metric.WithDescription("The total number of connections waited for."),
+	)
+	if err != nil {
+		return nil, err
+	}
+	waitDuration, err := meter.Float64ObservableCounter(
+		connectionsWaitDuration,
+		metric.WithUnit("s"),
+		metric.WithDescription("The total time blocked waiting for a new connection."),
+	)
+	if err != nil {
+		return nil, err
+	}
+	closed, err := meter.Int64ObservableCounter(
+		connectionsClosed,
+		metric.WithUnit("{connection}"),
+		metric.WithDescription("The total number of connections closed for the reason described by the reason attribute."),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	withAttrs := func(extra ...attribute.KeyValue) metric.ObserveOption {
+		kvs := make([]attribute.KeyValue, 0, len(cfg.Attributes)+len(extra))
+		kvs = append(kvs, cfg.Attributes...)
+		return metric.WithAttributeSet(attribute.NewSet(append(kvs, extra...)...))
+	}
+	var (
+		pool        = withAttrs()
+		idle        = withAttrs(stateKey.String("idle"))
+		used        = withAttrs(stateKey.String("used"))
+		maxIdle     = withAttrs(reasonKey.String("max_idle"))
+		maxIdleTime = withAttrs(reasonKey.String("max_idle_time"))
+		maxLifetime = withAttrs(reasonKey.String("max_lifetime"))
+	)
+
+	return meter.RegisterCallback(
+		func(_ context.Context, o metric.Observer) error {
+			stats := db.Stats()
+			o.ObserveInt64(usage, int64(stats.Idle), idle)
+			o.ObserveInt64(usage, int64(stats.InUse), used)
+			o.ObserveInt64(max, int64(stats.MaxOpenConnections), pool)
+			o.ObserveInt64(waits, stats.WaitCount, pool)
+			o.ObserveFloat64(waitDuration, stats.WaitDuration.Seconds(), pool)
+			o.ObserveInt64(closed, stats.MaxIdleClosed, maxIdle)
+			o.ObserveInt64(closed, stats.MaxIdleTimeClosed, maxIdleTime)
+			o.ObserveInt64(closed, stats.MaxLifetimeClosed, maxLifetime)
+			return nil
+		},
+		usage, max, waits, waitDuration, closed,
+	)
+}
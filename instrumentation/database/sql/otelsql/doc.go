@@ -0,0 +1,25 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package otelsql instruments database/sql.
+//
+// The drivers, connectors and connections returned by the functions of this
+// package create spans for the connections to the database, the queries and
+// statements they run and the transactions they perform. The statements are
+// added as the db.statement attribute with their literal values replaced by
+// "?", see WithStatementSanitizer.
+//
+// RegisterDBStatsMetrics reports the statistics of the connection pool of a
+// sql.DB as the db.client.connections.* metrics.
+package otelsql // import "go.opentelemetry.io/contrib/instrumentation/database/sql/otelsql"
@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelsql // import "go.opentelemetry.io/contrib/instrumentation/database/sql/otelsql"
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+)
+
+// Open opens a database specified by its database driver name and a
+// driver-specific data source name, as sql.Open does, and instruments it.
+func Open(driverName, dataSourceName string, opts ...Option) (*sql.DB, error) {
+	// sql.Open is only used to look up the registered driver, it does not
+	// connect to the database.
+	db, err := sql.Open(driverName, dataSourceName)
+	if err != nil {
+		return nil, err
+	}
+	d := db.Driver()
+	if err = db.Close(); err != nil {
+		return nil, err
+	}
+
+	connector, err := WrapDriver(d, opts...).(driver.DriverContext).OpenConnector(dataSourceName)
+	if err != nil {
+		return nil, err
+	}
+	return sql.OpenDB(connector), nil
+}
+
+// OpenDB opens a database using the connector, as sql.OpenDB does, and
+// instruments it.
+func OpenDB(c driver.Connector, opts ...Option) *sql.DB {
+	return sql.OpenDB(WrapConnector(c, opts...))
+}
+
+// WrapDriver returns d instrumented. The returned driver can be registered
+// with sql.Register.
+func WrapDriver(d driver.Driver, opts ...Option) driver.Driver {
+	return &otelDriver{driver: d, cfg: newConfig(opts...)}
+}
+
+// WrapConnector returns c instrumented.
+func WrapConnector(c driver.Connector, opts ...Option) driver.Connector {
+	d := &otelDriver{driver: c.Driver(), cfg: newConfig(opts...)}
+	return &otelConnector{connector: c, driver: d}
+}
+
+type otelDriver struct {
+	driver driver.Driver
+	cfg    *config
+}
+
+var (
+	_ driver.Driver        = (*otelDriver)(nil)
+	_ driver.DriverContext = (*otelDriver)(nil)
+)
+
+// Open returns a new instrumented connection to the database.
+func (d *otelDriver) Open(name string) (driver.Conn, error) {
+	_, span := d.cfg.start(context.Background(), spanConnect, "")
+	conn, err := d.driver.Open(name)
+	endSpan(span, err)
+	if err != nil {
+		return nil, err
+	}
+	return newConn(conn, d.cfg), nil
+}
+
+// OpenConnector returns an instrumented connector of the database.
+func (d *otelDriver) OpenConnector(name string) (driver.Connector, error) {
+	if dc, ok := d.driver.(driver.DriverContext); ok {
+		c, err := dc.OpenConnector(name)
+		if err != nil {
+			return nil, err
+		}
+		return &otelConnector{connector: c, driver: d}, nil
+	}
+	return &otelConnector{connector: dsnConnector{dsn: name, driver: d.driver}, driver: d}, nil
+}
+
+type otelConnector struct {
+	connector driver.Connector
+	driver    *otelDriver
+}
+
+var (
+	_ driver.Connector = (*otelConnector)(nil)
+	_ io.Closer        = (*otelConnector)(nil)
+)
+
+// Connect returns a new instrumented connection to the database.
+func (c *otelConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	ctx, span := c.driver.cfg.start(ctx, spanConnect, "")
+	conn, err := c.connector.Connect(ctx)
+	endSpan(span, err)
+	if err != nil {
+		return nil, err
+	}
+	return newConn(conn, c.driver.cfg), nil
+}
+
+// Driver returns the instrumented driver of the connector.
+func (c *otelConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// Close closes the connector if it implements io.Closer.
+func (c *otelConnector) Close() error {
+	if closer, ok := c.connector.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// dsnConnector is the connector of a driver that does not implement
+// driver.DriverContext, as used by sql.Open.
+type dsnConnector struct {
+	dsn    string
+	driver driver.Driver
+}
+
+func (c dsnConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.dsn)
+}
+
+func (c dsnConnector) Driver() driver.Driver {
+	return c.driver
+}
@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelsql_test
+
+import (
+	"context"
+
+	"go.opentelemetry.io/contrib/instrumentation/database/sql/otelsql"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+func Example() {
+	// Open the database with a registered driver, e.g. "postgres".
+	db, err := otelsql.Open("postgres", "postgres://localhost/example",
+		otelsql.WithAttributes(semconv.DBSystemPostgreSQL),
+	)
+	if err != nil {
+		panic(err)
+	}
+	defer db.Close()
+
+	reg, err := otelsql.RegisterDBStatsMetrics(db, otelsql.WithAttributes(semconv.DBSystemPostgreSQL))
+	if err != nil {
+		panic(err)
+	}
+	defer func() { _ = reg.Unregister() }()
+
+	rows, err := db.QueryContext(context.Background(), "SELECT name FROM users WHERE id = $1", 42)
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+}
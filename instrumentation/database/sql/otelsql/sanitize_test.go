@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelsql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitizeStatement(t *testing.T) {
+	testCases := []struct {
+		query, want string
+	}{
+		{
+			query: "SELECT * FROM users WHERE id = 42",
+			want:  "SELECT * FROM users WHERE id = ?",
+		},
+		{
+			query: "SELECT * FROM users WHERE name = 'O''Brien' AND score > 1.5",
+			want:  "SELECT * FROM users WHERE name = ? AND score > ?",
+		},
+		{
+			query: `INSERT INTO "table1" (col2, "it's") VALUES ($1, ?, 'a', 0x1F)`,
+			want:  `INSERT INTO "table1" (col2, "it's") VALUES ($1, ?, ?, ?)`,
+		},
+		{
+			query: "SELECT `col1` FROM t2 LIMIT 10",
+			want:  "SELECT `col1` FROM t2 LIMIT ?",
+		},
+		{
+			query: "SELECT 'unterminated",
+			want:  "SELECT ?",
+		},
+		{
+			query: "SELECT 'héllo', ünïcode1 FROM t",
+			want:  "SELECT ?, ünïcode1 FROM t",
+		},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.want, SanitizeStatement(tc.query), tc.query)
+	}
+}
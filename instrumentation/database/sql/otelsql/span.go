@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelsql // import "go.opentelemetry.io/contrib/instrumentation/database/sql/otelsql"
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Span names.
+const (
+	spanConnect    = "sql.connect"
+	spanPing       = "sql.conn.ping"
+	spanPrepare    = "sql.conn.prepare"
+	spanConnExec   = "sql.conn.exec"
+	spanConnQuery  = "sql.conn.query"
+	spanBeginTx    = "sql.conn.begin_tx"
+	spanStmtExec   = "sql.stmt.exec"
+	spanStmtQuery  = "sql.stmt.query"
+	spanTxCommit   = "sql.tx.commit"
+	spanTxRollback = "sql.tx.rollback"
+)
+
+// start starts a client span named name. The query, if any, is added as the
+// db.statement attribute unless disabled.
+func (c *config) start(ctx context.Context, name, query string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	opts = append(opts,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(c.Attributes...),
+	)
+	if query != "" && !c.StatementAttributeDisabled {
+		opts = append(opts, trace.WithAttributes(semconv.DBStatement(c.StatementSanitizer(query))))
+	}
+	return c.Tracer.Start(ctx, name, opts...)
+}
+
+// record records a span named name that started at start and ends now, if
+// err is not driver.ErrSkip. It is used for the operations a driver may skip,
+// which database/sql then performs by other means, e.g. with a prepared
+// statement.
+func (c *config) record(ctx context.Context, name, query string, start time.Time, err error) {
+	if errors.Is(err, driver.ErrSkip) {
+		return
+	}
+	_, span := c.start(ctx, name, query, trace.WithTimestamp(start))
+	setError(span, err)
+	span.End()
+}
+
+// endSpan ends span, recording err if not nil.
+func endSpan(span trace.Span, err error) {
+	setError(span, err)
+	span.End()
+}
+
+func setError(span trace.Span, err error) {
+	if err == nil || errors.Is(err, driver.ErrSkip) {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelsql // import "go.opentelemetry.io/contrib/instrumentation/database/sql/otelsql"
+
+import (
+	"context"
+	"database/sql/driver"
+)
+
+// otelStmt is an instrumented driver.Stmt.
+type otelStmt struct {
+	stmt  driver.Stmt
+	conn  *otelConn
+	query string
+}
+
+var (
+	_ driver.Stmt              = (*otelStmt)(nil)
+	_ driver.StmtExecContext   = (*otelStmt)(nil)
+	_ driver.StmtQueryContext  = (*otelStmt)(nil)
+	_ driver.NamedValueChecker = (*otelStmt)(nil)
+	_ driver.ColumnConverter   = (*otelStmt)(nil) // nolint:staticcheck // Forwarded to the wrapped statement.
+)
+
+func newStmt(stmt driver.Stmt, conn *otelConn, query string) *otelStmt {
+	return &otelStmt{stmt: stmt, conn: conn, query: query}
+}
+
+// Close closes the statement.
+func (s *otelStmt) Close() error {
+	return s.stmt.Close()
+}
+
+// NumInput returns the number of placeholder parameters.
+func (s *otelStmt) NumInput() int {
+	return s.stmt.NumInput()
+}
+
+// Exec executes a query that doesn't return rows.
+func (s *otelStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.stmt.Exec(args) // nolint:staticcheck // Forwarded to the wrapped statement.
+}
+
+// Query executes a query that may return rows.
+func (s *otelStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.stmt.Query(args) // nolint:staticcheck // Forwarded to the wrapped statement.
+}
+
+// ExecContext executes a query that doesn't return rows.
+func (s *otelStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	ctx, span := s.conn.cfg.start(ctx, spanStmtExec, s.query)
+	var (
+		res driver.Result
+		err error
+	)
+	if e, ok := s.stmt.(driver.StmtExecContext); ok {
+		res, err = e.ExecContext(ctx, args)
+	} else {
+		var values []driver.Value
+		if values, err = namedValueToValue(args); err == nil {
+			if err = ctx.Err(); err == nil {
+				res, err = s.stmt.Exec(values) // nolint:staticcheck // Fallback of database/sql.
+			}
+		}
+	}
+	endSpan(span, err)
+	return res, err
+}
+
+// QueryContext executes a query that may return rows.
+func (s *otelStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	ctx, span := s.conn.cfg.start(ctx, spanStmtQuery, s.query)
+	var (
+		rows driver.Rows
+		err  error
+	)
+	if q, ok := s.stmt.(driver.StmtQueryContext); ok {
+		rows, err = q.QueryContext(ctx, args)
+	} else {
+		var values []driver.Value
+		if values, err = namedValueToValue(args); err == nil {
+			if err = ctx.Err(); err == nil {
+				rows, err = s.stmt.Query(values) // nolint:staticcheck // Fallback of database/sql.
+			}
+		}
+	}
+	endSpan(span, err)
+	return rows, err
+}
+
+// CheckNamedValue is called before passing arguments to the driver. As
+// database/sql does, the checker of the connection is used if the wrapped
+// statement does not implement driver.NamedValueChecker.
+func (s *otelStmt) CheckNamedValue(nv *driver.NamedValue) error {
+	if nvc, ok := s.stmt.(driver.NamedValueChecker); ok {
+		return nvc.CheckNamedValue(nv)
+	}
+	return s.conn.CheckNamedValue(nv)
+}
+
+// ColumnConverter returns the ValueConverter of the wrapped statement for the
+// column at index idx, or the default one of database/sql.
+func (s *otelStmt) ColumnConverter(idx int) driver.ValueConverter {
+	if cc, ok := s.stmt.(driver.ColumnConverter); ok { // nolint:staticcheck // Forwarded to the wrapped statement.
+		return cc.ColumnConverter(idx)
+	}
+	return driver.DefaultParameterConverter
+}
@@ -0,0 +1,285 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/instrumentation/database/sql/otelsql"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+const (
+	fullDriverName    = "otelsql-test-full"
+	minimalDriverName = "otelsql-test-minimal"
+)
+
+var errQuery = errors.New("query failed")
+
+func init() {
+	sql.Register(fullDriverName, fakeDriver{full: true})
+	sql.Register(minimalDriverName, fakeDriver{})
+}
+
+// fakeDriver returns connections implementing only driver.Conn, or all of
+// the optional interfaces of database/sql if full is set.
+type fakeDriver struct {
+	full bool
+}
+
+func (d fakeDriver) Open(string) (driver.Conn, error) {
+	if d.full {
+		return &fullConn{}, nil
+	}
+	return &fakeConn{}, nil
+}
+
+type fakeConn struct{}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{query: query}, nil
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) { return fakeTx{}, nil }
+
+type fullConn struct {
+	fakeConn
+}
+
+func (c *fullConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	if query == "fail" {
+		return nil, errQuery
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (c *fullConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	if query == "fail" {
+		return nil, errQuery
+	}
+	return &fakeRows{}, nil
+}
+
+func (c *fullConn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	return fakeTx{}, nil
+}
+
+func (c *fullConn) Ping(context.Context) error { return nil }
+
+type fakeStmt struct {
+	query string
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &fakeRows{}, nil
+}
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return nil }
+
+type fakeRows struct {
+	done bool
+}
+
+func (r *fakeRows) Columns() []string { return []string{"n"} }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = int64(1)
+	return nil
+}
+
+func openDB(t *testing.T, driverName string, opts ...otelsql.Option) (*sql.DB, *tracetest.SpanRecorder) {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	opts = append(opts, otelsql.WithTracerProvider(tp))
+	db, err := otelsql.Open(driverName, "", opts...)
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, db.Close()) })
+	return db, sr
+}
+
+func spanNames(sr *tracetest.SpanRecorder) []string {
+	var names []string
+	for _, s := range sr.Ended() {
+		names = append(names, s.Name())
+	}
+	return names
+}
+
+func TestQuery(t *testing.T) {
+	db, sr := openDB(t, fullDriverName, otelsql.WithAttributes(semconv.DBSystemPostgreSQL))
+
+	var n int
+	require.NoError(t, db.QueryRowContext(context.Background(), "SELECT n FROM t WHERE name = 'secret'").Scan(&n))
+	assert.Equal(t, 1, n)
+
+	assert.Equal(t, []string{"sql.connect", "sql.conn.query"}, spanNames(sr))
+	span := sr.Ended()[1]
+	assert.Contains(t, span.Attributes(), semconv.DBSystemPostgreSQL)
+	assert.Contains(t, span.Attributes(), semconv.DBStatement("SELECT n FROM t WHERE name = ?"))
+	assert.Equal(t, codes.Unset, span.Status().Code)
+}
+
+func TestExecError(t *testing.T) {
+	db, sr := openDB(t, fullDriverName)
+
+	_, err := db.ExecContext(context.Background(), "fail")
+	require.ErrorIs(t, err, errQuery)
+
+	assert.Equal(t, []string{"sql.connect", "sql.conn.exec"}, spanNames(sr))
+	span := sr.Ended()[1]
+	assert.Equal(t, codes.Error, span.Status().Code)
+	assert.Equal(t, errQuery.Error(), span.Status().Description)
+}
+
+func TestPreparedStatementFallback(t *testing.T) {
+	db, sr := openDB(t, minimalDriverName)
+
+	_, err := db.ExecContext(context.Background(), "DELETE FROM t WHERE id = 1")
+	require.NoError(t, err)
+
+	// The connection does not support executing queries directly,
+	// database/sql prepares them.
+	assert.Equal(t, []string{"sql.connect", "sql.conn.prepare", "sql.stmt.exec"}, spanNames(sr))
+	for _, s := range sr.Ended()[1:] {
+		assert.Contains(t, s.Attributes(), semconv.DBStatement("DELETE FROM t WHERE id = ?"))
+	}
+}
+
+func TestTransaction(t *testing.T) {
+	for _, driverName := range []string{fullDriverName, minimalDriverName} {
+		t.Run(driverName, func(t *testing.T) {
+			db, sr := openDB(t, driverName)
+
+			tx, err := db.BeginTx(context.Background(), nil)
+			require.NoError(t, err)
+			require.NoError(t, tx.Commit())
+
+			tx, err = db.BeginTx(context.Background(), nil)
+			require.NoError(t, err)
+			require.NoError(t, tx.Rollback())
+
+			assert.Equal(t, []string{
+				"sql.connect",
+				"sql.conn.begin_tx", "sql.tx.commit",
+				"sql.conn.begin_tx", "sql.tx.rollback",
+			}, spanNames(sr))
+		})
+	}
+}
+
+func TestUnsupportedTransactionOptions(t *testing.T) {
+	db, _ := openDB(t, minimalDriverName)
+
+	_, err := db.BeginTx(context.Background(), &sql.TxOptions{ReadOnly: true})
+	assert.Error(t, err)
+}
+
+func TestStatementAttributeDisabled(t *testing.T) {
+	db, sr := openDB(t, fullDriverName, otelsql.WithStatementAttributeDisabled(true))
+
+	_, err := db.ExecContext(context.Background(), "DELETE FROM t")
+	require.NoError(t, err)
+
+	require.Len(t, sr.Ended(), 2)
+	for _, kv := range sr.Ended()[1].Attributes() {
+		assert.NotEqual(t, semconv.DBStatementKey, kv.Key)
+	}
+}
+
+func TestStatementSanitizer(t *testing.T) {
+	db, sr := openDB(t, fullDriverName, otelsql.WithStatementSanitizer(func(string) string {
+		return "redacted"
+	}))
+
+	_, err := db.ExecContext(context.Background(), "DELETE FROM t")
+	require.NoError(t, err)
+
+	require.Len(t, sr.Ended(), 2)
+	assert.Contains(t, sr.Ended()[1].Attributes(), semconv.DBStatement("redacted"))
+}
+
+func TestDBStatsMetrics(t *testing.T) {
+	db, _ := openDB(t, fullDriverName)
+	db.SetMaxOpenConns(5)
+	require.NoError(t, db.Ping())
+
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	reg, err := otelsql.RegisterDBStatsMetrics(db,
+		otelsql.WithMeterProvider(mp),
+		otelsql.WithAttributes(semconv.DBSystemPostgreSQL),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, reg.Unregister()) })
+
+	rm := metricdata.ResourceMetrics{}
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	assert.Equal(t, "go.opentelemetry.io/contrib/instrumentation/database/sql/otelsql", rm.ScopeMetrics[0].Scope.Name)
+
+	values := make(map[string]map[attribute.Set]int64)
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		sum, ok := m.Data.(metricdata.Sum[int64])
+		if !ok {
+			continue
+		}
+		values[m.Name] = make(map[attribute.Set]int64)
+		for _, dp := range sum.DataPoints {
+			values[m.Name][dp.Attributes] = dp.Value
+		}
+	}
+
+	system := semconv.DBSystemPostgreSQL
+	assert.Equal(t, map[attribute.Set]int64{
+		attribute.NewSet(system, attribute.String("state", "idle")): 1,
+		attribute.NewSet(system, attribute.String("state", "used")): 0,
+	}, values["db.client.connections.usage"])
+	assert.Equal(t, map[attribute.Set]int64{
+		attribute.NewSet(system): 5,
+	}, values["db.client.connections.max"])
+	assert.Contains(t, values, "db.client.connections.waits")
+	assert.Contains(t, values, "db.client.connections.closed")
+}
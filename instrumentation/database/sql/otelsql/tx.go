@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelsql // import "go.opentelemetry.io/contrib/instrumentation/database/sql/otelsql"
+
+import (
+	"context"
+	"database/sql/driver"
+)
+
+// otelTx is an instrumented driver.Tx. The spans of the commit and rollback
+// of a transaction have the same parent as the span of its beginning.
+type otelTx struct {
+	ctx context.Context
+	tx  driver.Tx
+	cfg *config
+}
+
+var _ driver.Tx = (*otelTx)(nil)
+
+func newTx(ctx context.Context, tx driver.Tx, cfg *config) *otelTx {
+	return &otelTx{ctx: ctx, tx: tx, cfg: cfg}
+}
+
+// Commit commits the transaction.
+func (t *otelTx) Commit() error {
+	_, span := t.cfg.start(t.ctx, spanTxCommit, "")
+	err := t.tx.Commit()
+	endSpan(span, err)
+	return err
+}
+
+// Rollback aborts the transaction.
+func (t *otelTx) Rollback() error {
+	_, span := t.cfg.start(t.ctx, spanTxRollback, "")
+	err := t.tx.Rollback()
+	endSpan(span, err)
+	return err
+}
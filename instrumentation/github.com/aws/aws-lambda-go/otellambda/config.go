@@ -91,6 +91,21 @@ type config struct {
 	// The default value of Propagator the global otel Propagator
 	// returned by otel.GetTextMapPropagator()
 	Propagator propagation.TextMapPropagator
+
+	// MeterFlusher is the mechanism used to flush any unexported metrics
+	// at the end of each Lambda Invocation. The default value of
+	// MeterFlusher is a noop Flusher.
+	MeterFlusher Flusher
+
+	// LoggerFlusher is the mechanism used to flush any unexported log
+	// records at the end of each Lambda Invocation. The default value of
+	// LoggerFlusher is a noop Flusher.
+	LoggerFlusher Flusher
+
+	// XRayEnvPropagation defines if and when the parent is extracted from
+	// the X-Ray trace header Lambda provides to the invocation. The default
+	// value of XRayEnvPropagation is XRayEnvPropagationDisabled.
+	XRayEnvPropagation XRayEnvPropagation
 }
 
 // WithTracerProvider configures the TracerProvider used by the
@@ -110,6 +125,26 @@ func WithFlusher(flusher Flusher) Option {
 	})
 }
 
+// WithMeterFlusher sets the flusher used to flush metrics at the end of each
+// invocation, e.g. the MeterProvider of the OpenTelemetry SDK.
+//
+// By default, metrics are not flushed.
+func WithMeterFlusher(flusher Flusher) Option {
+	return optionFunc(func(c *config) {
+		c.MeterFlusher = flusher
+	})
+}
+
+// WithLoggerFlusher sets the flusher used to flush log records at the end of
+// each invocation, e.g. the LoggerProvider of the OpenTelemetry SDK.
+//
+// By default, log records are not flushed.
+func WithLoggerFlusher(flusher Flusher) Option {
+	return optionFunc(func(c *config) {
+		c.LoggerFlusher = flusher
+	})
+}
+
 // WithEventToCarrier sets the used EventToCarrier.
 func WithEventToCarrier(eventToCarrier EventToCarrier) Option {
 	return optionFunc(func(c *config) {
@@ -125,3 +160,16 @@ func WithPropagator(propagator propagation.TextMapPropagator) Option {
 		c.Propagator = propagator
 	})
 }
+
+// WithXRayEnvPropagation configures if and when the parent of the invocation
+// span is extracted from the X-Ray trace header Lambda provides to the
+// invocation (the lambda-runtime-trace-id header, also exposed as the
+// _X_AMZN_TRACE_ID environment variable).
+//
+// By default, XRayEnvPropagationDisabled is used and the parent is only
+// extracted from the event payload.
+func WithXRayEnvPropagation(mode XRayEnvPropagation) Option {
+	return optionFunc(func(c *config) {
+		c.XRayEnvPropagation = mode
+	})
+}
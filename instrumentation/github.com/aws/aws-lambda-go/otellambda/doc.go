@@ -19,4 +19,9 @@
 //
 // lambda.Start(<user function>) entrypoint: lambda.Start(otellambda.InstrumentHandler(<user function>))
 // lambda.StartHandler(<user Handler>) entrypoint: lambda.StartHandler(otellambda.WrapHandler(<user Handler>))
+//
+// Handlers instrumented with InstrumentHandler may stream their response by
+// returning an io.Reader. The invocation span of a streamed response is ended,
+// and telemetry flushed, once the Lambda runtime has finished reading the
+// response instead of when the handler returns.
 package otellambda // import "go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-lambda-go/otellambda"
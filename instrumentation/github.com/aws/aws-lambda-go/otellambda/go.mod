@@ -5,6 +5,7 @@ go 1.20
 require (
 	github.com/aws/aws-lambda-go v1.41.0
 	github.com/stretchr/testify v1.8.4
+	go.opentelemetry.io/contrib/propagators/aws v1.20.0
 	go.opentelemetry.io/otel v1.19.0
 	go.opentelemetry.io/otel/trace v1.19.0
 )
@@ -15,5 +16,9 @@ require (
 	github.com/go-logr/stdr v1.2.2 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	go.opentelemetry.io/otel/metric v1.19.0 // indirect
+	go.opentelemetry.io/otel/sdk v1.19.0 // indirect
+	golang.org/x/sys v0.12.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+replace go.opentelemetry.io/contrib/propagators/aws => ../../../../../propagators/aws
@@ -19,6 +19,8 @@ import (
 	"log"
 	"os"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/aws/aws-lambda-go/lambdacontext"
 
@@ -32,12 +34,23 @@ const (
 	tracerName = "go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-lambda-go/otellambda"
 )
 
+// initDurationKey is the attribute key of the duration, in seconds, between
+// the initialization of the execution environment and the start of its first
+// invocation.
+const initDurationKey = attribute.Key("aws.lambda.init_duration")
+
 var errorLogger = log.New(log.Writer(), "OTel Lambda Error: ", 0)
 
+// initTime approximates the time the execution environment was initialized.
+var initTime = time.Now()
+
 type instrumentor struct {
 	configuration config
 	resAttrs      []attribute.KeyValue
 	tracer        trace.Tracer
+	// coldStart is true until the first invocation has started. It is a
+	// pointer as the instrumentor is copied by value for each invocation.
+	coldStart *atomic.Bool
 }
 
 func newInstrumentor(opts ...Option) instrumentor {
@@ -46,26 +59,33 @@ func newInstrumentor(opts ...Option) instrumentor {
 		Flusher:        &noopFlusher{},
 		EventToCarrier: emptyEventToCarrier,
 		Propagator:     otel.GetTextMapPropagator(),
+		MeterFlusher:   &noopFlusher{},
+		LoggerFlusher:  &noopFlusher{},
 	}
 	for _, opt := range opts {
 		opt.apply(&cfg)
 	}
 
+	coldStart := new(atomic.Bool)
+	coldStart.Store(true)
+
 	return instrumentor{
 		configuration: cfg,
 		tracer:        cfg.TracerProvider.Tracer(tracerName, trace.WithInstrumentationVersion(Version())),
 		resAttrs:      []attribute.KeyValue{},
+		coldStart:     coldStart,
 	}
 }
 
-// Logic to start OTel Tracing.
-func (i *instrumentor) tracingBegin(ctx context.Context, eventJSON []byte) (context.Context, trace.Span) {
+// Logic to start OTel Tracing. The returned trigger identifies the source of
+// the event so the response can be described accordingly.
+func (i *instrumentor) tracingBegin(ctx context.Context, eventJSON []byte) (context.Context, trace.Span, trigger) {
 	// Add trace id to context
-	mc := i.configuration.EventToCarrier(eventJSON)
-	ctx = i.configuration.Propagator.Extract(ctx, mc)
+	ctx = i.extractParent(ctx, eventJSON)
 
 	var span trace.Span
 	spanName := os.Getenv("AWS_LAMBDA_FUNCTION_NAME")
+	start := time.Now()
 
 	var attributes []attribute.KeyValue
 	lc, ok := lambdacontext.FromContext(ctx)
@@ -91,9 +111,33 @@ func (i *instrumentor) tracingBegin(ctx context.Context, eventJSON []byte) (cont
 		attributes = append(attributes, i.resAttrs...)
 	}
 
-	ctx, span = i.tracer.Start(ctx, spanName, trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(attributes...))
+	attributes = append(attributes, i.coldStartAttributes(start)...)
+
+	event, t := parseEvent(eventJSON)
+	attributes = append(attributes, triggerAttributes(event, t)...)
+
+	opts := []trace.SpanStartOption{trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(attributes...)}
+	if t == triggerSQS {
+		opts = append(opts, trace.WithLinks(sqsLinks(event, i.configuration.Propagator)...))
+	}
+
+	ctx, span = i.tracer.Start(ctx, spanName, opts...)
+
+	return ctx, span, t
+}
 
-	return ctx, span
+// coldStartAttributes returns the attributes describing whether the
+// invocation starting at start is the first one of the execution environment.
+func (i *instrumentor) coldStartAttributes(start time.Time) []attribute.KeyValue {
+	coldStart := i.coldStart.Swap(false)
+	attributes := []attribute.KeyValue{semconv.FaaSColdstart(coldStart)}
+	if coldStart {
+		attributes = append(attributes, initDurationKey.Float64(start.Sub(initTime).Seconds()))
+	}
+	if instance := os.Getenv("AWS_LAMBDA_LOG_STREAM_NAME"); instance != "" {
+		attributes = append(attributes, semconv.FaaSInstance(instance))
+	}
+	return attributes
 }
 
 // Logic to wrap up OTel Tracing.
@@ -105,4 +149,14 @@ func (i *instrumentor) tracingEnd(ctx context.Context, span trace.Span) {
 	if err != nil {
 		errorLogger.Println("failed to force a flush, lambda may freeze before instrumentation exported: ", err)
 	}
+
+	// metrics and logs are flushed for the same reason
+	err = i.configuration.MeterFlusher.ForceFlush(ctx)
+	if err != nil {
+		errorLogger.Println("failed to force a flush of metrics, lambda may freeze before metrics exported: ", err)
+	}
+	err = i.configuration.LoggerFlusher.ForceFlush(ctx)
+	if err != nil {
+		errorLogger.Println("failed to force a flush of logs, lambda may freeze before logs exported: ", err)
+	}
 }
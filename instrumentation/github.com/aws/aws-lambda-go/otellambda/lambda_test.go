@@ -22,11 +22,15 @@ import (
 	"os"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-lambda-go/lambda"
 	"github.com/aws/aws-lambda-go/lambda/messages"
 	"github.com/aws/aws-lambda-go/lambdacontext"
 	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
 )
 
 var (
@@ -295,3 +299,25 @@ func BenchmarkWrapHandler(b *testing.B) {
 		_, _ = wrapped.Invoke(mockContext, []byte{0})
 	}
 }
+
+func TestColdStartAttributes(t *testing.T) {
+	setEnvVars()
+
+	i := newInstrumentor()
+	start := initTime.Add(2 * time.Second)
+
+	attrs := i.coldStartAttributes(start)
+	assert.Equal(t, []attribute.KeyValue{
+		semconv.FaaSColdstart(true),
+		initDurationKey.Float64(2),
+		semconv.FaaSInstance("2023/01/01/[$LATEST]5d1edb9e525d486696cf01a3503487bc"),
+	}, attrs)
+
+	// Copies of the instrumentor share the cold start state.
+	cp := i
+	attrs = cp.coldStartAttributes(start)
+	assert.Equal(t, []attribute.KeyValue{
+		semconv.FaaSColdstart(false),
+		semconv.FaaSInstance("2023/01/01/[$LATEST]5d1edb9e525d486696cf01a3503487bc"),
+	}, attrs)
+}
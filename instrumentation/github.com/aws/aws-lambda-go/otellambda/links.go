@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otellambda // import "go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-lambda-go/otellambda"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// awsTraceHeaderAttribute is the SQS system attribute holding the X-Ray trace
+// header of the producer of a message.
+const awsTraceHeaderAttribute = "AWSTraceHeader"
+
+// xrayTraceHeader is the name of the X-Ray trace header.
+const xrayTraceHeader = "X-Amzn-Trace-Id"
+
+// sqsLinks returns a link to the producer of each record of an SQS batch. The
+// trace context of a record is extracted by propagator from the string message
+// attributes of the record and from its AWSTraceHeader system attribute.
+// Records without a valid trace context are not linked.
+func sqsLinks(e *lambdaEvent, propagator propagation.TextMapPropagator) []trace.Link {
+	var links []trace.Link
+	for _, r := range e.Records {
+		carrier := propagation.MapCarrier{}
+		for k, v := range r.MessageAttributes {
+			if v.StringValue != nil {
+				carrier[k] = *v.StringValue
+			}
+		}
+		if h, ok := r.Attributes[awsTraceHeaderAttribute]; ok {
+			carrier[xrayTraceHeader] = h
+		}
+		if len(carrier) == 0 {
+			continue
+		}
+
+		sc := trace.SpanContextFromContext(propagator.Extract(context.Background(), carrier))
+		if !sc.IsValid() {
+			continue
+		}
+		links = append(links, trace.Link{
+			SpanContext: sc,
+			Attributes:  []attribute.KeyValue{semconv.MessagingMessageID(r.MessageID)},
+		})
+	}
+	return links
+}
@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otellambda
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestSQSLinks(t *testing.T) {
+	event := []byte(`{"Records": [
+		{
+			"messageId": "1",
+			"eventSource": "aws:sqs",
+			"messageAttributes": {
+				"traceparent": {"stringValue": "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01", "dataType": "String"}
+			}
+		},
+		{
+			"messageId": "2",
+			"eventSource": "aws:sqs",
+			"messageAttributes": {
+				"other": {"stringValue": "value", "dataType": "String"}
+			}
+		},
+		{
+			"messageId": "3",
+			"eventSource": "aws:sqs"
+		},
+		{
+			"messageId": "4",
+			"eventSource": "aws:sqs",
+			"messageAttributes": {
+				"traceparent": {"stringValue": "00-1af7651916cd43dd8448eb211c80319c-c7ad6b7169203331-00", "dataType": "String"}
+			}
+		}
+	]}`)
+
+	e, tr := parseEvent(event)
+	require.Equal(t, triggerSQS, tr)
+
+	links := sqsLinks(e, propagation.TraceContext{})
+	require.Len(t, links, 2)
+
+	traceID, _ := trace.TraceIDFromHex("0af7651916cd43dd8448eb211c80319c")
+	spanID, _ := trace.SpanIDFromHex("b7ad6b7169203331")
+	assert.Equal(t, traceID, links[0].SpanContext.TraceID())
+	assert.Equal(t, spanID, links[0].SpanContext.SpanID())
+	assert.True(t, links[0].SpanContext.IsSampled())
+	assert.True(t, links[0].SpanContext.IsRemote())
+	assert.Equal(t, []attribute.KeyValue{semconv.MessagingMessageID("1")}, links[0].Attributes)
+
+	traceID, _ = trace.TraceIDFromHex("1af7651916cd43dd8448eb211c80319c")
+	assert.Equal(t, traceID, links[1].SpanContext.TraceID())
+	assert.False(t, links[1].SpanContext.IsSampled())
+	assert.Equal(t, []attribute.KeyValue{semconv.MessagingMessageID("4")}, links[1].Attributes)
+}
@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otellambda // import "go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-lambda-go/otellambda"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"sync"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// contentTyper is implemented by streamed responses that define the content
+// type sent to the Lambda runtime.
+type contentTyper interface {
+	ContentType() string
+}
+
+// streamedResponse returns the io.Reader the Lambda runtime streams as the
+// response of a handler. The runtime streams a response that implements
+// io.Reader unless it is serializable to a non-empty JSON object.
+func streamedResponse(response []reflect.Value) (io.Reader, bool) {
+	if len(response) < 2 {
+		return nil, false
+	}
+	if err, ok := response[len(response)-1].Interface().(error); ok && err != nil {
+		return nil, false
+	}
+	r, ok := response[0].Interface().(io.Reader)
+	if !ok || r == nil {
+		return nil, false
+	}
+	b, err := json.Marshal(r)
+	if err != nil || bytes.HasPrefix(b, []byte("{}")) {
+		return r, true
+	}
+	return nil, false
+}
+
+// streamingResponse wraps a streamed response so the invocation span is ended
+// and telemetry is flushed once the runtime is done streaming it, rather than
+// when the handler returns.
+type streamingResponse struct {
+	reader io.Reader
+
+	ctx          context.Context
+	span         trace.Span
+	instrumentor *instrumentor
+	endOnce      sync.Once
+}
+
+// contentTypeStreamingResponse is a streamingResponse for a response that
+// defines its content type.
+type contentTypeStreamingResponse struct {
+	*streamingResponse
+}
+
+// newStreamingResponse returns r wrapped so the invocation span is ended when
+// it is fully read or closed.
+func (i *instrumentor) newStreamingResponse(ctx context.Context, span trace.Span, r io.Reader) io.ReadCloser {
+	sr := &streamingResponse{reader: r, ctx: ctx, span: span, instrumentor: i}
+	if _, ok := r.(contentTyper); ok {
+		return contentTypeStreamingResponse{sr}
+	}
+	return sr
+}
+
+// Read reads from the wrapped response. Errors other than io.EOF are recorded
+// on the invocation span, which is ended once the response is fully read.
+func (r *streamingResponse) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if err != nil {
+		if !errors.Is(err, io.EOF) {
+			r.span.RecordError(err)
+			r.span.SetStatus(codes.Error, err.Error())
+		}
+		r.end()
+	}
+	return n, err
+}
+
+// Close closes the wrapped response, if it is an io.Closer, and ends the
+// invocation span if it has not already been ended.
+func (r *streamingResponse) Close() error {
+	var err error
+	if c, ok := r.reader.(io.Closer); ok {
+		err = c.Close()
+	}
+	r.end()
+	return err
+}
+
+func (r *streamingResponse) end() {
+	r.endOnce.Do(func() {
+		r.instrumentor.tracingEnd(r.ctx, r.span)
+	})
+}
+
+// ContentType returns the content type defined by the wrapped response.
+func (r contentTypeStreamingResponse) ContentType() string {
+	return r.reader.(contentTyper).ContentType()
+}
@@ -18,9 +18,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"reflect"
+	"runtime"
 	"strconv"
 	"strings"
 	"sync"
@@ -140,31 +142,47 @@ var (
 			attribute.String("faas.invocation_id", "123"),
 			attribute.String("aws.lambda.invoked_arn", "arn:partition:service:region:account-id:resource-type:resource-id"),
 			attribute.String("cloud.account.id", "account-id"),
-		},
-		Events:            nil,
-		Links:             nil,
-		Status:            sdktrace.Status{},
-		DroppedAttributes: 0,
-		DroppedEvents:     0,
-		DroppedLinks:      0,
-		ChildSpanCount:    0,
-		Resource: resource.NewWithAttributes(semconv.SchemaURL,
-			attribute.String("cloud.provider", "aws"),
-			attribute.String("cloud.region", "us-texas-1"),
-			attribute.String("faas.name", "testFunction"),
-			attribute.String("faas.version", "$LATEST"),
+			attribute.Bool("faas.coldstart", true),
+			attribute.Float64("aws.lambda.init_duration", 0),
 			attribute.String("faas.instance", "2023/01/01/[$LATEST]5d1edb9e525d486696cf01a3503487bc"),
-			attribute.Int("faas.max_memory", 128)),
+		},
+		Events:                 nil,
+		Links:                  nil,
+		Status:                 sdktrace.Status{},
+		DroppedAttributes:      0,
+		DroppedEvents:          0,
+		DroppedLinks:           0,
+		ChildSpanCount:         0,
+		Resource:               expectedResource(),
 		InstrumentationLibrary: instrumentation.Library{Name: "go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-lambda-go/otellambda", Version: otellambda.Version()},
 	}
 )
 
+// expectedResource returns the resource detected by the Lambda resource
+// detector with the environment variables of setEnvVars.
+func expectedResource() *resource.Resource {
+	attrs := []attribute.KeyValue{
+		attribute.String("cloud.provider", "aws"),
+		attribute.String("cloud.platform", "aws_lambda"),
+		attribute.String("cloud.region", "us-texas-1"),
+		attribute.String("faas.name", "testFunction"),
+		attribute.String("faas.version", "$LATEST"),
+		attribute.String("faas.instance", "2023/01/01/[$LATEST]5d1edb9e525d486696cf01a3503487bc"),
+		attribute.Int("faas.max_memory", 128*1024*1024),
+	}
+	// The architecture is only detected on the ones supported by Lambda.
+	if runtime.GOARCH == "amd64" || runtime.GOARCH == "arm64" {
+		attrs = append(attrs, attribute.String("host.arch", runtime.GOARCH))
+	}
+	return resource.NewWithAttributes(semconv.SchemaURL, attrs...)
+}
+
 func assertStubEqualsIgnoreTime(t *testing.T, expected tracetest.SpanStub, actual tracetest.SpanStub) {
 	assert.Equal(t, expected.Name, actual.Name)
 	assert.Equal(t, expected.SpanContext, actual.SpanContext)
 	assert.Equal(t, expected.Parent, actual.Parent)
 	assert.Equal(t, expected.SpanKind, actual.SpanKind)
-	assert.Equal(t, expected.Attributes, actual.Attributes)
+	assert.Equal(t, expected.Attributes, ignoreInitDuration(actual.Attributes))
 	assert.Equal(t, expected.Events, actual.Events)
 	assert.Equal(t, expected.Links, actual.Links)
 	assert.Equal(t, expected.Status, actual.Status)
@@ -176,6 +194,19 @@ func assertStubEqualsIgnoreTime(t *testing.T, expected tracetest.SpanStub, actua
 	assert.Equal(t, expected.InstrumentationLibrary, actual.InstrumentationLibrary)
 }
 
+// ignoreInitDuration returns attrs with the value of the initialization
+// duration, which depends on the time the test is run, set to zero.
+func ignoreInitDuration(attrs []attribute.KeyValue) []attribute.KeyValue {
+	out := make([]attribute.KeyValue, len(attrs))
+	for i, attr := range attrs {
+		if attr.Key == "aws.lambda.init_duration" {
+			attr = attr.Key.Float64(0)
+		}
+		out[i] = attr
+	}
+	return out
+}
+
 func TestInstrumentHandlerTracing(t *testing.T) {
 	setEnvVars()
 	tp, memExporter := initMockTracerProvider()
@@ -332,21 +363,18 @@ var (
 			attribute.String("faas.invocation_id", "123"),
 			attribute.String("aws.lambda.invoked_arn", "arn:partition:service:region:account-id:resource-type:resource-id"),
 			attribute.String("cloud.account.id", "account-id"),
-		},
-		Events:            nil,
-		Links:             nil,
-		Status:            sdktrace.Status{},
-		DroppedAttributes: 0,
-		DroppedEvents:     0,
-		DroppedLinks:      0,
-		ChildSpanCount:    0,
-		Resource: resource.NewWithAttributes(semconv.SchemaURL,
-			attribute.String("cloud.provider", "aws"),
-			attribute.String("cloud.region", "us-texas-1"),
-			attribute.String("faas.name", "testFunction"),
-			attribute.String("faas.version", "$LATEST"),
+			attribute.Bool("faas.coldstart", true),
+			attribute.Float64("aws.lambda.init_duration", 0),
 			attribute.String("faas.instance", "2023/01/01/[$LATEST]5d1edb9e525d486696cf01a3503487bc"),
-			attribute.Int("faas.max_memory", 128)),
+		},
+		Events:                 nil,
+		Links:                  nil,
+		Status:                 sdktrace.Status{},
+		DroppedAttributes:      0,
+		DroppedEvents:          0,
+		DroppedLinks:           0,
+		ChildSpanCount:         0,
+		Resource:               expectedResource(),
 		InstrumentationLibrary: instrumentation.Library{Name: "go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-lambda-go/otellambda", Version: otellambda.Version()},
 	}
 )
@@ -404,3 +432,155 @@ func TestWrapHandlerTracingWithMockPropagator(t *testing.T) {
 	stub := memExporter.GetSpans()[0]
 	assertStubEqualsIgnoreTime(t, mockPropagatorTestsExpectedSpanStub, stub)
 }
+
+func TestWrapHandlerTracingHTTPTrigger(t *testing.T) {
+	setEnvVars()
+	tp, memExporter := initMockTracerProvider()
+
+	customerHandler := func(event map[string]interface{}) (map[string]interface{}, error) {
+		return map[string]interface{}{"statusCode": 404}, nil
+	}
+
+	wrapped := otellambda.WrapHandler(lambda.NewHandler(customerHandler), otellambda.WithTracerProvider(tp))
+	payload := []byte(`{"httpMethod":"GET","resource":"/users/{id}","path":"/users/42","requestContext":{}}`)
+	_, err := wrapped.Invoke(mockContext, payload)
+	assert.NoError(t, err)
+
+	assert.Len(t, memExporter.GetSpans(), 1)
+	attrs := memExporter.GetSpans()[0].Attributes
+	assert.Contains(t, attrs, semconv.FaaSTriggerHTTP)
+	assert.Contains(t, attrs, semconv.HTTPRoute("/users/{id}"))
+	assert.Contains(t, attrs, semconv.HTTPResponseStatusCode(404))
+}
+
+func TestInstrumentHandlerTracingHTTPTrigger(t *testing.T) {
+	setEnvVars()
+	tp, memExporter := initMockTracerProvider()
+
+	customerHandler := func(event map[string]interface{}) (map[string]interface{}, error) {
+		return map[string]interface{}{"statusCode": 500}, nil
+	}
+
+	wrapped := otellambda.InstrumentHandler(customerHandler, otellambda.WithTracerProvider(tp))
+	var event interface{}
+	_ = json.Unmarshal([]byte(`{"httpMethod":"POST","resource":"/users","path":"/users","requestContext":{}}`), &event)
+	resp := reflect.ValueOf(wrapped).Call([]reflect.Value{reflect.ValueOf(mockContext), reflect.ValueOf(event)})
+	assert.Len(t, resp, 2)
+	assert.Nil(t, resp[1].Interface())
+
+	assert.Len(t, memExporter.GetSpans(), 1)
+	attrs := memExporter.GetSpans()[0].Attributes
+	assert.Contains(t, attrs, semconv.FaaSTriggerHTTP)
+	assert.Contains(t, attrs, semconv.HTTPRequestMethodKey.String("POST"))
+	assert.Contains(t, attrs, semconv.HTTPResponseStatusCode(500))
+}
+
+func TestWrapHandlerTracingSQSLinks(t *testing.T) {
+	setEnvVars()
+	tp, memExporter := initMockTracerProvider()
+
+	wrapped := otellambda.WrapHandler(emptyHandler{}, otellambda.WithTracerProvider(tp), otellambda.WithPropagator(xray.Propagator{}))
+	payload := []byte(`{"Records": [
+		{"messageId": "a", "eventSource": "aws:sqs", "attributes": {"AWSTraceHeader": "Root=1-5759e988-bd862e3fe1be46a994272793;Parent=53995c3f42cd8ad8;Sampled=1"}},
+		{"messageId": "b", "eventSource": "aws:sqs", "attributes": {"AWSTraceHeader": "Root=1-6759e988-bd862e3fe1be46a994272793;Parent=63995c3f42cd8ad8;Sampled=0"}}
+	]}`)
+	_, err := wrapped.Invoke(mockContext, payload)
+	assert.NoError(t, err)
+
+	assert.Len(t, memExporter.GetSpans(), 1)
+	links := memExporter.GetSpans()[0].Links
+	assert.Len(t, links, 2)
+
+	traceID, _ := trace.TraceIDFromHex("5759e988bd862e3fe1be46a994272793")
+	spanID, _ := trace.SpanIDFromHex("53995c3f42cd8ad8")
+	assert.Equal(t, traceID, links[0].SpanContext.TraceID())
+	assert.Equal(t, spanID, links[0].SpanContext.SpanID())
+	assert.Equal(t, []attribute.KeyValue{semconv.MessagingMessageID("a")}, links[0].Attributes)
+
+	traceID, _ = trace.TraceIDFromHex("6759e988bd862e3fe1be46a994272793")
+	assert.Equal(t, traceID, links[1].SpanContext.TraceID())
+	assert.Equal(t, []attribute.KeyValue{semconv.MessagingMessageID("b")}, links[1].Attributes)
+}
+
+type contentTypeReader struct {
+	*strings.Reader
+}
+
+func (contentTypeReader) ContentType() string { return "text/plain" }
+
+func TestInstrumentHandlerTracingStreamingResponse(t *testing.T) {
+	setEnvVars()
+	tp, memExporter := initMockTracerProvider()
+
+	customerHandler := func() (io.Reader, error) {
+		return contentTypeReader{strings.NewReader("hello world")}, nil
+	}
+
+	flusher := mockFlusher{}
+	wrapped := otellambda.InstrumentHandler(customerHandler, otellambda.WithTracerProvider(tp), otellambda.WithFlusher(&flusher))
+	resp := reflect.ValueOf(wrapped).Call([]reflect.Value{reflect.ValueOf(mockContext)})
+	assert.Len(t, resp, 2)
+	assert.Nil(t, resp[1].Interface())
+
+	assert.Len(t, memExporter.GetSpans(), 0, "span ended before the response is streamed")
+	assert.Equal(t, 0, flusher.flushCount)
+
+	r, ok := resp[0].Interface().(io.ReadCloser)
+	if !assert.True(t, ok) {
+		return
+	}
+	ct, ok := r.(interface{ ContentType() string })
+	assert.True(t, ok)
+	assert.Equal(t, "text/plain", ct.ContentType())
+
+	b, err := io.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello world", string(b))
+	assert.NoError(t, r.Close())
+
+	assert.Len(t, memExporter.GetSpans(), 1)
+	assert.Equal(t, 1, flusher.flushCount)
+}
+
+func TestInstrumentHandlerTracingJSONReaderResponse(t *testing.T) {
+	setEnvVars()
+	tp, memExporter := initMockTracerProvider()
+
+	type jsonReader struct {
+		io.Reader `json:"-"`
+		Message   string
+	}
+	customerHandler := func() (io.Reader, error) {
+		return jsonReader{Reader: strings.NewReader("ignored"), Message: "hello"}, nil
+	}
+
+	wrapped := otellambda.InstrumentHandler(customerHandler, otellambda.WithTracerProvider(tp))
+	resp := reflect.ValueOf(wrapped).Call([]reflect.Value{reflect.ValueOf(mockContext)})
+	assert.Len(t, resp, 2)
+	assert.Nil(t, resp[1].Interface())
+
+	// Responses serialized to JSON by the runtime are not streamed.
+	assert.Len(t, memExporter.GetSpans(), 1)
+}
+
+func TestWrapHandlerTracingWithMeterAndLoggerFlushers(t *testing.T) {
+	setEnvVars()
+	tp, memExporter := initMockTracerProvider()
+
+	traceFlusher, meterFlusher, loggerFlusher := mockFlusher{}, mockFlusher{}, mockFlusher{}
+	wrapped := otellambda.WrapHandler(emptyHandler{},
+		otellambda.WithTracerProvider(tp),
+		otellambda.WithFlusher(&traceFlusher),
+		otellambda.WithMeterFlusher(&meterFlusher),
+		otellambda.WithLoggerFlusher(&loggerFlusher))
+
+	for i := 0; i < 2; i++ {
+		_, err := wrapped.Invoke(mockContext, []byte{})
+		assert.NoError(t, err)
+	}
+
+	assert.Len(t, memExporter.GetSpans(), 2)
+	assert.Equal(t, 2, traceFlusher.flushCount)
+	assert.Equal(t, 2, meterFlusher.flushCount)
+	assert.Equal(t, 2, loggerFlusher.flushCount)
+}
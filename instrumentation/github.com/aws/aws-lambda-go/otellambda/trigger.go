@@ -0,0 +1,245 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otellambda // import "go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-lambda-go/otellambda"
+
+import (
+	"encoding/json"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+// trigger is the kind of source that invoked the function.
+type trigger int
+
+const (
+	triggerUnknown trigger = iota
+	triggerAPIGateway
+	triggerAPIGatewayV2
+	triggerALB
+	triggerSQS
+	triggerS3
+	triggerEventBridge
+)
+
+// isHTTP reports whether the function was invoked to serve an HTTP request.
+func (t trigger) isHTTP() bool {
+	return t == triggerAPIGateway || t == triggerAPIGatewayV2 || t == triggerALB
+}
+
+// lambdaEvent holds the fields of the events sent by the supported triggers
+// needed to identify them. Unmarshaling into this single type avoids decoding
+// the payload once per supported trigger.
+type lambdaEvent struct {
+	// API Gateway REST API and ALB fields.
+	HTTPMethod string `json:"httpMethod"`
+	Resource   string `json:"resource"`
+	Path       string `json:"path"`
+
+	// API Gateway HTTP API (payload format version 2.0) fields.
+	Version  string `json:"version"`
+	RouteKey string `json:"routeKey"`
+	RawPath  string `json:"rawPath"`
+
+	RequestContext *struct {
+		HTTP *struct {
+			Method string `json:"method"`
+		} `json:"http"`
+		ELB *struct {
+			TargetGroupArn string `json:"targetGroupArn"`
+		} `json:"elb"`
+	} `json:"requestContext"`
+
+	// SQS and S3 fields.
+	Records []eventRecord `json:"Records"`
+
+	// EventBridge fields.
+	DetailType string `json:"detail-type"`
+	Source     string `json:"source"`
+	Time       string `json:"time"`
+}
+
+type eventRecord struct {
+	EventSource    string `json:"eventSource"`
+	EventSourceARN string `json:"eventSourceARN"`
+	EventName      string `json:"eventName"`
+	EventTime      string `json:"eventTime"`
+
+	// SQS message fields.
+	MessageID         string                         `json:"messageId"`
+	Attributes        map[string]string              `json:"attributes"`
+	MessageAttributes map[string]sqsMessageAttribute `json:"messageAttributes"`
+
+	// S3 object fields.
+	S3 *struct {
+		Bucket struct {
+			Name string `json:"name"`
+		} `json:"bucket"`
+		Object struct {
+			Key string `json:"key"`
+		} `json:"object"`
+	} `json:"s3"`
+}
+
+type sqsMessageAttribute struct {
+	StringValue *string `json:"stringValue"`
+}
+
+// parseEvent decodes eventJSON and identifies the trigger that sent it. An
+// event that cannot be decoded or that is not sent by a supported trigger is
+// returned with triggerUnknown.
+func parseEvent(eventJSON []byte) (*lambdaEvent, trigger) {
+	if len(eventJSON) == 0 || eventJSON[0] != '{' {
+		return nil, triggerUnknown
+	}
+	var e lambdaEvent
+	if err := json.Unmarshal(eventJSON, &e); err != nil {
+		return nil, triggerUnknown
+	}
+
+	switch {
+	case e.RequestContext != nil && e.RequestContext.ELB != nil:
+		return &e, triggerALB
+	case e.Version == "2.0" && e.RequestContext != nil && e.RequestContext.HTTP != nil:
+		return &e, triggerAPIGatewayV2
+	case e.HTTPMethod != "" && e.RequestContext != nil:
+		return &e, triggerAPIGateway
+	case len(e.Records) > 0 && e.Records[0].EventSource == "aws:sqs":
+		return &e, triggerSQS
+	case len(e.Records) > 0 && e.Records[0].EventSource == "aws:s3":
+		return &e, triggerS3
+	case e.DetailType != "" && e.Source != "":
+		return &e, triggerEventBridge
+	}
+	return &e, triggerUnknown
+}
+
+// triggerAttributes returns the attributes describing the trigger t that sent
+// the event e.
+func triggerAttributes(e *lambdaEvent, t trigger) []attribute.KeyValue {
+	switch t {
+	case triggerAPIGateway:
+		attrs := []attribute.KeyValue{
+			semconv.FaaSTriggerHTTP,
+			semconv.HTTPRequestMethodKey.String(e.HTTPMethod),
+		}
+		if e.Resource != "" {
+			attrs = append(attrs, semconv.HTTPRoute(e.Resource))
+		}
+		if e.Path != "" {
+			attrs = append(attrs, semconv.URLPath(e.Path))
+		}
+		return attrs
+	case triggerAPIGatewayV2:
+		attrs := []attribute.KeyValue{
+			semconv.FaaSTriggerHTTP,
+			semconv.HTTPRequestMethodKey.String(e.RequestContext.HTTP.Method),
+		}
+		// The route key is formatted as "<METHOD> <route>" or "$default".
+		if _, route, ok := strings.Cut(e.RouteKey, " "); ok {
+			attrs = append(attrs, semconv.HTTPRoute(route))
+		}
+		if e.RawPath != "" {
+			attrs = append(attrs, semconv.URLPath(e.RawPath))
+		}
+		return attrs
+	case triggerALB:
+		attrs := []attribute.KeyValue{
+			semconv.FaaSTriggerHTTP,
+			semconv.HTTPRequestMethodKey.String(e.HTTPMethod),
+		}
+		if e.Path != "" {
+			attrs = append(attrs, semconv.URLPath(e.Path))
+		}
+		return attrs
+	case triggerSQS:
+		attrs := []attribute.KeyValue{
+			semconv.FaaSTriggerPubsub,
+			semconv.MessagingSystem("AmazonSQS"),
+			semconv.MessagingOperationProcess,
+			semconv.MessagingBatchMessageCount(len(e.Records)),
+		}
+		if queue := arnResource(e.Records[0].EventSourceARN); queue != "" {
+			attrs = append(attrs, semconv.MessagingDestinationName(queue))
+		}
+		return attrs
+	case triggerS3:
+		r := e.Records[0]
+		attrs := []attribute.KeyValue{semconv.FaaSTriggerDatasource}
+		if r.S3 != nil {
+			attrs = append(attrs,
+				semconv.FaaSDocumentCollection(r.S3.Bucket.Name),
+				semconv.FaaSDocumentName(r.S3.Object.Key),
+			)
+		}
+		if op, ok := s3DocumentOperation(r.EventName); ok {
+			attrs = append(attrs, op)
+		}
+		if r.EventTime != "" {
+			attrs = append(attrs, semconv.FaaSDocumentTime(r.EventTime))
+		}
+		return attrs
+	case triggerEventBridge:
+		// Scheduled rules send events with this detail type.
+		if e.DetailType == "Scheduled Event" {
+			attrs := []attribute.KeyValue{semconv.FaaSTriggerTimer}
+			if e.Time != "" {
+				attrs = append(attrs, semconv.FaaSTime(e.Time))
+			}
+			return attrs
+		}
+		return []attribute.KeyValue{semconv.FaaSTriggerPubsub}
+	}
+	return nil
+}
+
+// httpResponseAttributes returns the attributes describing the response of a
+// function invoked by an HTTP trigger. Both API Gateway and ALB expect the
+// status code in the statusCode field of the response.
+func httpResponseAttributes(responseJSON []byte) []attribute.KeyValue {
+	var resp struct {
+		StatusCode int `json:"statusCode"`
+	}
+	if len(responseJSON) == 0 || responseJSON[0] != '{' {
+		return nil
+	}
+	if err := json.Unmarshal(responseJSON, &resp); err != nil || resp.StatusCode == 0 {
+		return nil
+	}
+	return []attribute.KeyValue{semconv.HTTPResponseStatusCode(resp.StatusCode)}
+}
+
+// s3DocumentOperation maps the S3 event name to the faas.document.operation
+// attribute.
+func s3DocumentOperation(eventName string) (attribute.KeyValue, bool) {
+	switch {
+	case strings.HasPrefix(eventName, "ObjectCreated:"):
+		return semconv.FaaSDocumentOperationInsert, true
+	case strings.HasPrefix(eventName, "ObjectRemoved:"):
+		return semconv.FaaSDocumentOperationDelete, true
+	}
+	return attribute.KeyValue{}, false
+}
+
+// arnResource returns the resource part of an ARN, e.g. the queue name of an
+// SQS queue ARN.
+func arnResource(arn string) string {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) < 6 {
+		return ""
+	}
+	return parts[5]
+}
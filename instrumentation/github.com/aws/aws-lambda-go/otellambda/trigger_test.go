@@ -0,0 +1,180 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otellambda
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+func TestTriggerAttributes(t *testing.T) {
+	testCases := []struct {
+		name    string
+		event   string
+		trigger trigger
+		want    []attribute.KeyValue
+	}{
+		{
+			name:    "empty",
+			event:   "",
+			trigger: triggerUnknown,
+		},
+		{
+			name:    "not an object",
+			event:   `"Lambda"`,
+			trigger: triggerUnknown,
+		},
+		{
+			name:    "custom event",
+			event:   `{"Custom":9001}`,
+			trigger: triggerUnknown,
+		},
+		{
+			name: "API Gateway REST API",
+			event: `{
+				"resource": "/users/{id}",
+				"path": "/users/42",
+				"httpMethod": "GET",
+				"requestContext": {"stage": "prod"}
+			}`,
+			trigger: triggerAPIGateway,
+			want: []attribute.KeyValue{
+				semconv.FaaSTriggerHTTP,
+				semconv.HTTPRequestMethodKey.String("GET"),
+				semconv.HTTPRoute("/users/{id}"),
+				semconv.URLPath("/users/42"),
+			},
+		},
+		{
+			name: "API Gateway HTTP API",
+			event: `{
+				"version": "2.0",
+				"routeKey": "POST /users/{id}",
+				"rawPath": "/users/42",
+				"requestContext": {"http": {"method": "POST"}}
+			}`,
+			trigger: triggerAPIGatewayV2,
+			want: []attribute.KeyValue{
+				semconv.FaaSTriggerHTTP,
+				semconv.HTTPRequestMethodKey.String("POST"),
+				semconv.HTTPRoute("/users/{id}"),
+				semconv.URLPath("/users/42"),
+			},
+		},
+		{
+			name: "API Gateway HTTP API default route",
+			event: `{
+				"version": "2.0",
+				"routeKey": "$default",
+				"rawPath": "/",
+				"requestContext": {"http": {"method": "GET"}}
+			}`,
+			trigger: triggerAPIGatewayV2,
+			want: []attribute.KeyValue{
+				semconv.FaaSTriggerHTTP,
+				semconv.HTTPRequestMethodKey.String("GET"),
+				semconv.URLPath("/"),
+			},
+		},
+		{
+			name: "ALB",
+			event: `{
+				"httpMethod": "PUT",
+				"path": "/items",
+				"requestContext": {"elb": {"targetGroupArn": "arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/tg/1"}}
+			}`,
+			trigger: triggerALB,
+			want: []attribute.KeyValue{
+				semconv.FaaSTriggerHTTP,
+				semconv.HTTPRequestMethodKey.String("PUT"),
+				semconv.URLPath("/items"),
+			},
+		},
+		{
+			name: "SQS",
+			event: `{"Records": [
+				{"messageId": "1", "eventSource": "aws:sqs", "eventSourceARN": "arn:aws:sqs:us-east-1:123456789012:my-queue"},
+				{"messageId": "2", "eventSource": "aws:sqs", "eventSourceARN": "arn:aws:sqs:us-east-1:123456789012:my-queue"}
+			]}`,
+			trigger: triggerSQS,
+			want: []attribute.KeyValue{
+				semconv.FaaSTriggerPubsub,
+				semconv.MessagingSystem("AmazonSQS"),
+				semconv.MessagingOperationProcess,
+				semconv.MessagingBatchMessageCount(2),
+				semconv.MessagingDestinationName("my-queue"),
+			},
+		},
+		{
+			name: "S3",
+			event: `{"Records": [{
+				"eventSource": "aws:s3",
+				"eventName": "ObjectCreated:Put",
+				"eventTime": "2023-01-01T00:00:00.000Z",
+				"s3": {"bucket": {"name": "my-bucket"}, "object": {"key": "path/to/object"}}
+			}]}`,
+			trigger: triggerS3,
+			want: []attribute.KeyValue{
+				semconv.FaaSTriggerDatasource,
+				semconv.FaaSDocumentCollection("my-bucket"),
+				semconv.FaaSDocumentName("path/to/object"),
+				semconv.FaaSDocumentOperationInsert,
+				semconv.FaaSDocumentTime("2023-01-01T00:00:00.000Z"),
+			},
+		},
+		{
+			name: "EventBridge scheduled event",
+			event: `{
+				"detail-type": "Scheduled Event",
+				"source": "aws.events",
+				"time": "2023-01-01T00:00:00Z"
+			}`,
+			trigger: triggerEventBridge,
+			want: []attribute.KeyValue{
+				semconv.FaaSTriggerTimer,
+				semconv.FaaSTime("2023-01-01T00:00:00Z"),
+			},
+		},
+		{
+			name: "EventBridge event",
+			event: `{
+				"detail-type": "Order Placed",
+				"source": "com.example.orders"
+			}`,
+			trigger: triggerEventBridge,
+			want:    []attribute.KeyValue{semconv.FaaSTriggerPubsub},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			e, got := parseEvent([]byte(tc.event))
+			assert.Equal(t, tc.trigger, got)
+			assert.Equal(t, tc.want, triggerAttributes(e, got))
+		})
+	}
+}
+
+func TestHTTPResponseAttributes(t *testing.T) {
+	assert.Equal(t, []attribute.KeyValue{semconv.HTTPResponseStatusCode(404)}, httpResponseAttributes([]byte(`{"statusCode":404,"body":"not found"}`)))
+	assert.Nil(t, httpResponseAttributes([]byte(`{"body":"no status"}`)))
+	assert.Nil(t, httpResponseAttributes([]byte(`"hello"`)))
+	assert.Nil(t, httpResponseAttributes(nil))
+}
@@ -33,7 +33,7 @@ var _ lambda.Handler = wrappedHandler{}
 
 // Invoke adds OTel span surrounding customer Handler invocation.
 func (h wrappedHandler) Invoke(ctx context.Context, payload []byte) ([]byte, error) {
-	ctx, span := h.instrumentor.tracingBegin(ctx, payload)
+	ctx, span, t := h.instrumentor.tracingBegin(ctx, payload)
 	defer h.instrumentor.tracingEnd(ctx, span)
 
 	response, err := h.handler.Invoke(ctx, payload)
@@ -41,6 +41,10 @@ func (h wrappedHandler) Invoke(ctx context.Context, payload []byte) ([]byte, err
 		return nil, err
 	}
 
+	if t.isHTTP() {
+		span.SetAttributes(httpResponseAttributes(response)...)
+	}
+
 	return response, nil
 }
 
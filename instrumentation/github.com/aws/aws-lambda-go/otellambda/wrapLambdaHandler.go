@@ -151,8 +151,14 @@ func InstrumentHandler(handlerFunc interface{}, options ...Option) interface{} {
 // Adds OTel span surrounding customer handler call.
 func (whf *wrappedHandlerFunction) wrapper(handlerFunc interface{}) func(ctx context.Context, eventJSON []byte, event interface{}, takesContext bool) []reflect.Value {
 	return func(ctx context.Context, eventJSON []byte, event interface{}, takesContext bool) []reflect.Value {
-		ctx, span := whf.instrumentor.tracingBegin(ctx, eventJSON)
-		defer whf.instrumentor.tracingEnd(ctx, span)
+		ctx, span, t := whf.instrumentor.tracingBegin(ctx, eventJSON)
+		streaming := false
+		defer func() {
+			// A streamed response ends the span once it has been streamed.
+			if !streaming {
+				whf.instrumentor.tracingEnd(ctx, span)
+			}
+		}()
 
 		handler := reflect.ValueOf(handlerFunc)
 		var args []reflect.Value
@@ -165,6 +171,20 @@ func (whf *wrappedHandlerFunction) wrapper(handlerFunc interface{}) func(ctx con
 
 		response := handler.Call(args)
 
+		if r, ok := streamedResponse(response); ok {
+			streaming = true
+			response[0] = reflect.ValueOf(whf.instrumentor.newStreamingResponse(ctx, span, r))
+			return response
+		}
+
+		// The response of an HTTP trigger is a struct with the status code,
+		// it is only encoded when it needs to be inspected.
+		if t.isHTTP() && len(response) > 1 {
+			if responseJSON, err := json.Marshal(response[0].Interface()); err == nil {
+				span.SetAttributes(httpResponseAttributes(responseJSON)...)
+			}
+		}
+
 		return response
 	}
 }
@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otellambda // import "go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-lambda-go/otellambda"
+
+import (
+	"context"
+	"os"
+
+	"go.opentelemetry.io/contrib/propagators/aws/xray"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// XRayEnvPropagation defines how the X-Ray trace header Lambda provides to an
+// invocation is used to determine the parent of the invocation span.
+type XRayEnvPropagation int
+
+const (
+	// XRayEnvPropagationDisabled ignores the X-Ray trace header. The parent
+	// is only extracted from the event payload.
+	XRayEnvPropagationDisabled XRayEnvPropagation = iota
+	// XRayEnvPropagationFallback extracts the parent from the event payload
+	// and only uses the X-Ray trace header when no parent is found in the
+	// payload.
+	XRayEnvPropagationFallback
+	// XRayEnvPropagationPreferred extracts the parent from the X-Ray trace
+	// header and only uses the event payload when the header holds no valid
+	// parent.
+	XRayEnvPropagationPreferred
+)
+
+// xrayContextKey is the key the Lambda runtime uses to store the X-Ray trace
+// header of the invocation in the context.
+const xrayContextKey = "x-amzn-trace-id"
+
+// xrayEnvVar is the environment variable the Lambda runtime sets to the X-Ray
+// trace header of the current invocation.
+const xrayEnvVar = "_X_AMZN_TRACE_ID"
+
+// xrayTraceHeaderValue returns the X-Ray trace header of the invocation. The
+// value stored by the runtime in ctx is preferred to the environment variable
+// as it is set per invocation.
+func xrayTraceHeaderValue(ctx context.Context) string {
+	if v, ok := ctx.Value(xrayContextKey).(string); ok && v != "" {
+		return v
+	}
+	return os.Getenv(xrayEnvVar)
+}
+
+// extractXRayEnv returns ctx with the parent found in the X-Ray trace header
+// of the invocation. The returned bool is false if no valid parent is found.
+func extractXRayEnv(ctx context.Context) (context.Context, bool) {
+	header := xrayTraceHeaderValue(ctx)
+	if header == "" {
+		return ctx, false
+	}
+	carrier := propagation.HeaderCarrier{}
+	carrier.Set(xrayTraceHeader, header)
+	return extracted(ctx, xray.Propagator{}.Extract(ctx, carrier))
+}
+
+// extracted returns extractedCtx and if a parent has been extracted into it
+// from ctx.
+func extracted(ctx, extractedCtx context.Context) (context.Context, bool) {
+	sc := trace.SpanContextFromContext(extractedCtx)
+	if !sc.IsValid() || sc.Equal(trace.SpanContextFromContext(ctx)) {
+		return ctx, false
+	}
+	return extractedCtx, true
+}
+
+// extractParent returns ctx with the parent of the invocation span extracted
+// from eventJSON and, depending on the configured XRayEnvPropagation, from
+// the X-Ray trace header of the invocation.
+func (i *instrumentor) extractParent(ctx context.Context, eventJSON []byte) context.Context {
+	fromPayload := func() (context.Context, bool) {
+		mc := i.configuration.EventToCarrier(eventJSON)
+		return extracted(ctx, i.configuration.Propagator.Extract(ctx, mc))
+	}
+
+	switch i.configuration.XRayEnvPropagation {
+	case XRayEnvPropagationFallback:
+		if pCtx, ok := fromPayload(); ok {
+			return pCtx
+		}
+		if xCtx, ok := extractXRayEnv(ctx); ok {
+			return xCtx
+		}
+	case XRayEnvPropagationPreferred:
+		if xCtx, ok := extractXRayEnv(ctx); ok {
+			return xCtx
+		}
+		if pCtx, ok := fromPayload(); ok {
+			return pCtx
+		}
+	default:
+		mc := i.configuration.EventToCarrier(eventJSON)
+		return i.configuration.Propagator.Extract(ctx, mc)
+	}
+	return ctx
+}
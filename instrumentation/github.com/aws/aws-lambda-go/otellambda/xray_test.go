@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otellambda
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	payloadTraceID = "0af7651916cd43dd8448eb211c80319c"
+	envTraceID     = "5759e988bd862e3fe1be46a994272793"
+)
+
+func traceparentCarrier(eventJSON []byte) propagation.TextMapCarrier {
+	if len(eventJSON) == 0 {
+		return propagation.MapCarrier{}
+	}
+	return propagation.MapCarrier{"traceparent": string(eventJSON)}
+}
+
+func TestExtractParent(t *testing.T) {
+	setEnvVars()
+
+	payload := []byte("00-" + payloadTraceID + "-b7ad6b7169203331-01")
+
+	testCases := []struct {
+		name    string
+		mode    XRayEnvPropagation
+		payload []byte
+		want    string
+	}{
+		{"disabled with payload", XRayEnvPropagationDisabled, payload, payloadTraceID},
+		{"disabled without payload", XRayEnvPropagationDisabled, nil, ""},
+		{"fallback with payload", XRayEnvPropagationFallback, payload, payloadTraceID},
+		{"fallback without payload", XRayEnvPropagationFallback, nil, envTraceID},
+		{"preferred with payload", XRayEnvPropagationPreferred, payload, envTraceID},
+		{"preferred without payload", XRayEnvPropagationPreferred, nil, envTraceID},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			i := newInstrumentor(
+				WithPropagator(propagation.TraceContext{}),
+				WithEventToCarrier(traceparentCarrier),
+				WithXRayEnvPropagation(tc.mode),
+			)
+			sc := trace.SpanContextFromContext(i.extractParent(context.Background(), tc.payload))
+			if tc.want == "" {
+				assert.False(t, sc.IsValid())
+				return
+			}
+			assert.Equal(t, tc.want, sc.TraceID().String())
+		})
+	}
+}
+
+func TestXRayTraceHeaderValuePrefersContext(t *testing.T) {
+	setEnvVars()
+
+	header := "Root=1-6759e988-bd862e3fe1be46a994272793;Parent=53995c3f42cd8ad8;Sampled=1"
+	//nolint:staticcheck // The Lambda runtime uses a string key.
+	ctx := context.WithValue(context.Background(), xrayContextKey, header)
+	assert.Equal(t, header, xrayTraceHeaderValue(ctx))
+
+	ctx, ok := extractXRayEnv(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "6759e988bd862e3fe1be46a994272793", trace.SpanContextFromContext(ctx).TraceID().String())
+}
+
+func TestExtractXRayEnvMissing(t *testing.T) {
+	t.Setenv(xrayEnvVar, "")
+
+	ctx, ok := extractXRayEnv(context.Background())
+	assert.False(t, ok)
+	assert.False(t, trace.SpanContextFromContext(ctx).IsValid())
+}
@@ -106,6 +106,9 @@ var (
 				{Key: "faas.invocation_id", Value: &v1common.AnyValue{Value: &v1common.AnyValue_StringValue{StringValue: "123"}}},
 				{Key: "aws.lambda.invoked_arn", Value: &v1common.AnyValue{Value: &v1common.AnyValue_StringValue{StringValue: "arn:partition:service:region:account-id:resource-type:resource-id"}}},
 				{Key: "cloud.account.id", Value: &v1common.AnyValue{Value: &v1common.AnyValue_StringValue{StringValue: "account-id"}}},
+				{Key: "faas.coldstart", Value: &v1common.AnyValue{Value: &v1common.AnyValue_BoolValue{BoolValue: true}}},
+				{Key: "aws.lambda.init_duration", Value: &v1common.AnyValue{Value: &v1common.AnyValue_DoubleValue{DoubleValue: 0}}},
+				{Key: "faas.instance", Value: &v1common.AnyValue{Value: &v1common.AnyValue_StringValue{StringValue: "2023/01/01/[$LATEST]5d1edb9e525d486696cf01a3503487bc"}}},
 			},
 			DroppedAttributesCount: 0,
 			Events:                 nil,
@@ -118,14 +121,15 @@ var (
 	}
 
 	expectedSpanResource = v1resource.Resource{
-		Attributes: []*v1common.KeyValue{
+		Attributes: append([]*v1common.KeyValue{
+			{Key: "cloud.platform", Value: &v1common.AnyValue{Value: &v1common.AnyValue_StringValue{StringValue: "aws_lambda"}}},
 			{Key: "cloud.provider", Value: &v1common.AnyValue{Value: &v1common.AnyValue_StringValue{StringValue: "aws"}}},
 			{Key: "cloud.region", Value: &v1common.AnyValue{Value: &v1common.AnyValue_StringValue{StringValue: "us-texas-1"}}},
 			{Key: "faas.instance", Value: &v1common.AnyValue{Value: &v1common.AnyValue_StringValue{StringValue: "2023/01/01/[$LATEST]5d1edb9e525d486696cf01a3503487bc"}}},
-			{Key: "faas.max_memory", Value: &v1common.AnyValue{Value: &v1common.AnyValue_IntValue{IntValue: 128}}},
+			{Key: "faas.max_memory", Value: &v1common.AnyValue{Value: &v1common.AnyValue_IntValue{IntValue: 128 * 1024 * 1024}}},
 			{Key: "faas.name", Value: &v1common.AnyValue{Value: &v1common.AnyValue_StringValue{StringValue: "testFunction"}}},
 			{Key: "faas.version", Value: &v1common.AnyValue{Value: &v1common.AnyValue_StringValue{StringValue: "$LATEST"}}},
-		},
+		}, expectedHostArch()...),
 		DroppedAttributesCount: 0,
 	}
 
@@ -136,17 +140,39 @@ var (
 	}
 )
 
+// expectedHostArch returns the host.arch attribute detected by the Lambda
+// resource detector, only on the architectures supported by Lambda.
+func expectedHostArch() []*v1common.KeyValue {
+	if runtime.GOARCH != "amd64" && runtime.GOARCH != "arm64" {
+		return nil
+	}
+	return []*v1common.KeyValue{
+		{Key: "host.arch", Value: &v1common.AnyValue{Value: &v1common.AnyValue_StringValue{StringValue: runtime.GOARCH}}},
+	}
+}
+
 func assertResourceEquals(t *testing.T, expected *v1resource.Resource, actual *v1resource.Resource) {
-	assert.Len(t, actual.Attributes, 6)
-	assert.Equal(t, expected.Attributes[0].String(), actual.Attributes[0].String())
-	assert.Equal(t, expected.Attributes[1].String(), actual.Attributes[1].String())
-	assert.Equal(t, expected.Attributes[2].String(), actual.Attributes[2].String())
-	assert.Equal(t, expected.Attributes[3].String(), actual.Attributes[3].String())
-	assert.Equal(t, expected.Attributes[4].String(), actual.Attributes[4].String())
-	assert.Equal(t, expected.Attributes[5].String(), actual.Attributes[5].String())
+	if assert.Len(t, actual.Attributes, len(expected.Attributes)) {
+		for i := range expected.Attributes {
+			assert.Equal(t, expected.Attributes[i].String(), actual.Attributes[i].String())
+		}
+	}
 	assert.Equal(t, expected.DroppedAttributesCount, actual.DroppedAttributesCount)
 }
 
+// ignoreInitDuration returns attrs with the value of the initialization
+// duration, which depends on the time the test is run, set to zero.
+func ignoreInitDuration(attrs []*v1common.KeyValue) []*v1common.KeyValue {
+	out := make([]*v1common.KeyValue, len(attrs))
+	for i, attr := range attrs {
+		if attr.Key == "aws.lambda.init_duration" {
+			attr = &v1common.KeyValue{Key: attr.Key, Value: &v1common.AnyValue{Value: &v1common.AnyValue_DoubleValue{DoubleValue: 0}}}
+		}
+		out[i] = attr
+	}
+	return out
+}
+
 // ignore timestamps and SpanID since time is obviously variable,
 // and SpanID is randomized when using xray IDGenerator.
 func assertSpanEqualsIgnoreTimeAndSpanID(t *testing.T, expected *v1trace.ResourceSpans, actual *v1trace.ResourceSpans) {
@@ -157,7 +183,7 @@ func assertSpanEqualsIgnoreTimeAndSpanID(t *testing.T, expected *v1trace.Resourc
 	assert.Equal(t, expectedSpan.Name, actualSpan.Name)
 	assert.Equal(t, expectedSpan.ParentSpanId, actualSpan.ParentSpanId)
 	assert.Equal(t, expectedSpan.Kind, actualSpan.Kind)
-	assert.Equal(t, expectedSpan.Attributes, actualSpan.Attributes)
+	assert.Equal(t, expectedSpan.Attributes, ignoreInitDuration(actualSpan.Attributes))
 	assert.Equal(t, expectedSpan.Events, actualSpan.Events)
 	assert.Equal(t, expectedSpan.Links, actualSpan.Links)
 	assert.Equal(t, expectedSpan.Status, actualSpan.Status)
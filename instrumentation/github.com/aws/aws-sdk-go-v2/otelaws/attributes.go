@@ -34,8 +34,9 @@ const (
 )
 
 var servicemap = map[string]AttributeSetter{
-	dynamodb.ServiceID: DynamoDBAttributeSetter,
-	sqs.ServiceID:      SQSAttributeSetter,
+	bedrockRuntimeServiceID: BedrockAttributeSetter,
+	dynamodb.ServiceID:      DynamoDBAttributeSetter,
+	sqs.ServiceID:           SQSAttributeSetter,
 }
 
 // SystemAttr return the AWS RPC system attribute.
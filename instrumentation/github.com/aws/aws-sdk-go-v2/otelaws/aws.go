@@ -36,6 +36,9 @@ const (
 
 type spanTimestampKey struct{}
 
+// suppressedKey marks a context of an operation that is not instrumented.
+type suppressedKey struct{}
+
 // AttributeSetter returns an array of KeyValue pairs, it can be used to set custom attributes.
 type AttributeSetter func(context.Context, middleware.InitializeInput) []attribute.KeyValue
 
@@ -43,6 +46,7 @@ type otelMiddlewares struct {
 	tracer          trace.Tracer
 	propagator      propagation.TextMapPropagator
 	attributeSetter []AttributeSetter
+	instrumented    func(serviceID, operation string) bool
 }
 
 func (m otelMiddlewares) initializeMiddlewareBefore(stack *middleware.Stack) error {
@@ -63,6 +67,10 @@ func (m otelMiddlewares) initializeMiddlewareAfter(stack *middleware.Stack) erro
 	) {
 		serviceID := v2Middleware.GetServiceID(ctx)
 		operation := v2Middleware.GetOperationName(ctx)
+		if m.instrumented != nil && !m.instrumented(serviceID, operation) {
+			ctx = context.WithValue(ctx, suppressedKey{}, true)
+			return next.HandleInitialize(ctx, in)
+		}
 		region := v2Middleware.GetRegion(ctx)
 
 		attributes := []attribute.KeyValue{
@@ -99,6 +107,10 @@ func (m otelMiddlewares) deserializeMiddleware(stack *middleware.Stack) error {
 		out middleware.DeserializeOutput, metadata middleware.Metadata, err error,
 	) {
 		out, metadata, err = next.HandleDeserialize(ctx, in)
+		if ctx.Value(suppressedKey{}) != nil {
+			// No span has been started for this operation.
+			return out, metadata, err
+		}
 		resp, ok := out.RawResponse.(*smithyhttp.Response)
 		if !ok {
 			// No raw response to wrap with.
@@ -108,6 +120,10 @@ func (m otelMiddlewares) deserializeMiddleware(stack *middleware.Stack) error {
 		span := trace.SpanFromContext(ctx)
 		span.SetAttributes(semconv.HTTPStatusCode(resp.StatusCode))
 
+		if v2Middleware.GetServiceID(ctx) == bedrockRuntimeServiceID {
+			span.SetAttributes(bedrockResponseAttributes(resp.Header, out.Result)...)
+		}
+
 		requestID, ok := v2Middleware.GetRequestIDMetadata(metadata)
 		if ok {
 			span.SetAttributes(RequestIDAttr(requestID))
@@ -164,6 +180,7 @@ func AppendMiddlewares(apiOptions *[]func(*middleware.Stack) error, opts ...Opti
 			trace.WithInstrumentationVersion(Version())),
 		propagator:      cfg.TextMapPropagator,
 		attributeSetter: cfg.AttributeSetter,
+		instrumented:    cfg.instrumented,
 	}
 	*apiOptions = append(*apiOptions, m.initializeMiddlewareBefore, m.initializeMiddlewareAfter, m.finalizeMiddleware, m.deserializeMiddleware)
 }
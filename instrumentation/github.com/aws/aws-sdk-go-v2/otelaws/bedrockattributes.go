@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelaws // import "go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strconv"
+
+	v2Middleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// GenAI attributes.
+const (
+	GenAISystemKey            attribute.Key = "gen_ai.system"
+	GenAIRequestModelKey      attribute.Key = "gen_ai.request.model"
+	GenAIUsageInputTokensKey  attribute.Key = "gen_ai.usage.input_tokens"
+	GenAIUsageOutputTokensKey attribute.Key = "gen_ai.usage.output_tokens"
+	BedrockStreamingKey       attribute.Key = "aws.bedrock.streaming"
+	BedrockSystemVal          string        = "aws.bedrock"
+)
+
+// bedrockRuntimeServiceID is the service ID of the Bedrock Runtime client. It
+// is defined here to avoid a dependency on the bedrockruntime module.
+const bedrockRuntimeServiceID = "Bedrock Runtime"
+
+// Header fields Bedrock Runtime uses to report the token usage of an
+// InvokeModel operation.
+const (
+	bedrockInputTokenCountHeader  = "X-Amzn-Bedrock-Input-Token-Count"
+	bedrockOutputTokenCountHeader = "X-Amzn-Bedrock-Output-Token-Count"
+)
+
+// bedrockStreamingOperations are the Bedrock Runtime operations that respond
+// with an event stream.
+var bedrockStreamingOperations = map[string]bool{
+	"InvokeModelWithResponseStream": true,
+	"ConverseStream":                true,
+}
+
+// BedrockAttributeSetter sets Bedrock Runtime specific attributes following
+// the GenAI semantic conventions.
+func BedrockAttributeSetter(ctx context.Context, in middleware.InitializeInput) []attribute.KeyValue {
+	bedrockAttributes := []attribute.KeyValue{GenAISystemKey.String(BedrockSystemVal)}
+
+	if modelID, ok := stringField(in.Parameters, "ModelId"); ok {
+		bedrockAttributes = append(bedrockAttributes, GenAIRequestModelKey.String(modelID))
+	}
+
+	operation := v2Middleware.GetOperationName(ctx)
+	bedrockAttributes = append(bedrockAttributes, BedrockStreamingKey.Bool(bedrockStreamingOperations[operation]))
+
+	return bedrockAttributes
+}
+
+// bedrockResponseAttributes returns the token usage attributes of a Bedrock
+// Runtime response. The usage is read from the response header fields of
+// InvokeModel and from the Usage field of the Converse output.
+func bedrockResponseAttributes(header http.Header, result interface{}) []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+
+	if n, err := strconv.ParseInt(header.Get(bedrockInputTokenCountHeader), 10, 64); err == nil {
+		attrs = append(attrs, GenAIUsageInputTokensKey.Int64(n))
+	}
+	if n, err := strconv.ParseInt(header.Get(bedrockOutputTokenCountHeader), 10, 64); err == nil {
+		attrs = append(attrs, GenAIUsageOutputTokensKey.Int64(n))
+	}
+	if len(attrs) > 0 {
+		return attrs
+	}
+
+	usage := fieldValue(reflect.ValueOf(result), "Usage")
+	if n, ok := intField(usage, "InputTokens"); ok {
+		attrs = append(attrs, GenAIUsageInputTokensKey.Int64(n))
+	}
+	if n, ok := intField(usage, "OutputTokens"); ok {
+		attrs = append(attrs, GenAIUsageOutputTokensKey.Int64(n))
+	}
+	return attrs
+}
+
+// fieldValue returns the value of the named field of the struct rv refers to.
+// The returned value is invalid if rv does not refer to a struct or the struct
+// has no such field.
+func fieldValue(rv reflect.Value, name string) reflect.Value {
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return reflect.Value{}
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+	return rv.FieldByName(name)
+}
+
+func stringField(v interface{}, name string) (string, bool) {
+	f := reflect.Indirect(fieldValue(reflect.ValueOf(v), name))
+	if !f.IsValid() || f.Kind() != reflect.String {
+		return "", false
+	}
+	return f.String(), true
+}
+
+func intField(v reflect.Value, name string) (int64, bool) {
+	f := reflect.Indirect(fieldValue(v, name))
+	if !f.IsValid() {
+		return 0, false
+	}
+	switch f.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return f.Int(), true
+	}
+	return 0, false
+}
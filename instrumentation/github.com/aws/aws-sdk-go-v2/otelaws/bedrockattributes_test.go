@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelaws
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// The types below mirror the shape of the bedrockruntime types the
+// attribute setter inspects.
+type invokeModelInput struct {
+	ModelId *string
+}
+
+type tokenUsage struct {
+	InputTokens  *int32
+	OutputTokens *int32
+	TotalTokens  *int32
+}
+
+type converseOutput struct {
+	Usage *tokenUsage
+}
+
+func TestBedrockAttributeSetter(t *testing.T) {
+	input := middleware.InitializeInput{
+		Parameters: &invokeModelInput{
+			ModelId: aws.String("anthropic.claude-v2"),
+		},
+	}
+
+	attributes := BedrockAttributeSetter(context.TODO(), input)
+
+	assert.Contains(t, attributes, GenAISystemKey.String(BedrockSystemVal))
+	assert.Contains(t, attributes, GenAIRequestModelKey.String("anthropic.claude-v2"))
+	assert.Contains(t, attributes, BedrockStreamingKey.Bool(false))
+}
+
+func TestBedrockAttributeSetterNoModel(t *testing.T) {
+	attributes := BedrockAttributeSetter(context.TODO(), middleware.InitializeInput{Parameters: &invokeModelInput{}})
+
+	assert.Equal(t, []string{string(GenAISystemKey), string(BedrockStreamingKey)}, keys(attributes))
+}
+
+func TestBedrockResponseAttributesHeader(t *testing.T) {
+	header := http.Header{}
+	header.Set(bedrockInputTokenCountHeader, "12")
+	header.Set(bedrockOutputTokenCountHeader, "34")
+
+	attributes := bedrockResponseAttributes(header, nil)
+
+	assert.Contains(t, attributes, GenAIUsageInputTokensKey.Int64(12))
+	assert.Contains(t, attributes, GenAIUsageOutputTokensKey.Int64(34))
+}
+
+func TestBedrockResponseAttributesUsage(t *testing.T) {
+	result := &converseOutput{
+		Usage: &tokenUsage{
+			InputTokens:  aws.Int32(5),
+			OutputTokens: aws.Int32(7),
+		},
+	}
+
+	attributes := bedrockResponseAttributes(http.Header{}, result)
+
+	assert.Contains(t, attributes, GenAIUsageInputTokensKey.Int64(5))
+	assert.Contains(t, attributes, GenAIUsageOutputTokensKey.Int64(7))
+}
+
+func TestBedrockResponseAttributesEmpty(t *testing.T) {
+	assert.Empty(t, bedrockResponseAttributes(http.Header{}, nil))
+	assert.Empty(t, bedrockResponseAttributes(http.Header{}, &converseOutput{}))
+	assert.Empty(t, bedrockResponseAttributes(http.Header{}, "not a struct"))
+}
+
+func keys(attrs []attribute.KeyValue) []string {
+	out := make([]string, len(attrs))
+	for i, a := range attrs {
+		out[i] = string(a.Key)
+	}
+	return out
+}
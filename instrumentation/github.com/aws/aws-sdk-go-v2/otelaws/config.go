@@ -20,9 +20,31 @@ import (
 )
 
 type config struct {
-	TracerProvider    trace.TracerProvider
-	TextMapPropagator propagation.TextMapPropagator
-	AttributeSetter   []AttributeSetter
+	TracerProvider     trace.TracerProvider
+	TextMapPropagator  propagation.TextMapPropagator
+	AttributeSetter    []AttributeSetter
+	Services           map[string]struct{}
+	SuppressedServices map[string]struct{}
+}
+
+// instrumented reports whether the operation of the AWS service identified by
+// serviceID is instrumented according to the configured WithServices and
+// WithSuppressedServices options.
+func (c config) instrumented(serviceID, operation string) bool {
+	name := spanName(serviceID, operation)
+	if len(c.Services) > 0 && !contains(c.Services, serviceID, name) {
+		return false
+	}
+	return !contains(c.SuppressedServices, serviceID, name)
+}
+
+func contains(set map[string]struct{}, keys ...string) bool {
+	for _, k := range keys {
+		if _, ok := set[k]; ok {
+			return true
+		}
+	}
+	return false
 }
 
 // Option applies an option value.
@@ -65,3 +87,35 @@ func WithAttributeSetter(attributesetters ...AttributeSetter) Option {
 		cfg.AttributeSetter = append(cfg.AttributeSetter, attributesetters...)
 	})
 }
+
+// WithServices restricts the instrumentation to the AWS services with the
+// passed service IDs, e.g. "DynamoDB". A single operation of a service is
+// selected with its "<service ID>.<operation>" span name, e.g.
+// "SQS.SendMessage".
+//
+// By default, all services are instrumented.
+func WithServices(serviceIDs ...string) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.Services = appendSet(cfg.Services, serviceIDs)
+	})
+}
+
+// WithSuppressedServices disables the instrumentation of the AWS services
+// with the passed service IDs. A single operation of a service is suppressed
+// with its "<service ID>.<operation>" span name, e.g.
+// "CloudWatch.PutMetricData". Suppression takes precedence over WithServices.
+func WithSuppressedServices(serviceIDs ...string) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.SuppressedServices = appendSet(cfg.SuppressedServices, serviceIDs)
+	})
+}
+
+func appendSet(set map[string]struct{}, keys []string) map[string]struct{} {
+	if set == nil {
+		set = make(map[string]struct{}, len(keys))
+	}
+	for _, k := range keys {
+		set[k] = struct{}{}
+	}
+	return set
+}
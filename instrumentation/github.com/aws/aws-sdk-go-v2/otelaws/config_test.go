@@ -31,3 +31,86 @@ func TestWithTextMapPropagator(t *testing.T) {
 
 	assert.Equal(t, cfg.TextMapPropagator, propagator)
 }
+
+func TestInstrumentedServices(t *testing.T) {
+	testCases := []struct {
+		name      string
+		opts      []Option
+		serviceID string
+		operation string
+		want      bool
+	}{
+		{
+			name:      "default",
+			serviceID: "CloudWatch",
+			operation: "PutMetricData",
+			want:      true,
+		},
+		{
+			name:      "allowed service",
+			opts:      []Option{WithServices("DynamoDB", "SQS")},
+			serviceID: "SQS",
+			operation: "SendMessage",
+			want:      true,
+		},
+		{
+			name:      "service not allowed",
+			opts:      []Option{WithServices("DynamoDB", "SQS")},
+			serviceID: "S3",
+			operation: "GetObject",
+			want:      false,
+		},
+		{
+			name:      "allowed operation",
+			opts:      []Option{WithServices("SQS.SendMessage")},
+			serviceID: "SQS",
+			operation: "SendMessage",
+			want:      true,
+		},
+		{
+			name:      "operation not allowed",
+			opts:      []Option{WithServices("SQS.SendMessage")},
+			serviceID: "SQS",
+			operation: "ReceiveMessage",
+			want:      false,
+		},
+		{
+			name:      "suppressed service",
+			opts:      []Option{WithSuppressedServices("CloudWatch")},
+			serviceID: "CloudWatch",
+			operation: "GetMetricData",
+			want:      false,
+		},
+		{
+			name:      "suppressed operation",
+			opts:      []Option{WithSuppressedServices("CloudWatch.PutMetricData")},
+			serviceID: "CloudWatch",
+			operation: "PutMetricData",
+			want:      false,
+		},
+		{
+			name:      "operation of suppressed operation service",
+			opts:      []Option{WithSuppressedServices("CloudWatch.PutMetricData")},
+			serviceID: "CloudWatch",
+			operation: "GetMetricData",
+			want:      true,
+		},
+		{
+			name:      "suppression takes precedence",
+			opts:      []Option{WithServices("CloudWatch"), WithSuppressedServices("CloudWatch.PutMetricData")},
+			serviceID: "CloudWatch",
+			operation: "PutMetricData",
+			want:      false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := config{}
+			for _, opt := range tc.opts {
+				opt.apply(&cfg)
+			}
+			assert.Equal(t, tc.want, cfg.instrumented(tc.serviceID, tc.operation))
+		})
+	}
+}
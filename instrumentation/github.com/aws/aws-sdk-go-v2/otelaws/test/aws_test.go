@@ -155,3 +155,61 @@ func TestAppendMiddlewares(t *testing.T) {
 		srv.Close()
 	}
 }
+
+func TestSuppressedServices(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+		<ChangeResourceRecordSetsResponse>
+			<ChangeInfo>
+			<Comment>mockComment</Comment>
+			<Id>mockID</Id>
+		</ChangeInfo>
+		</ChangeResourceRecordSetsResponse>`))
+			if err != nil {
+				t.Fatal(err)
+			}
+		}))
+	defer srv.Close()
+
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	svc := route53.NewFromConfig(aws.Config{
+		Region: "us-east-1",
+		EndpointResolverWithOptions: aws.EndpointResolverWithOptionsFunc(
+			func(service, region string, _ ...interface{}) (aws.Endpoint, error) {
+				return aws.Endpoint{
+					URL:         srv.URL,
+					SigningName: "route53",
+				}, nil
+			},
+		),
+		Retryer: func() aws.Retryer {
+			return aws.NopRetryer{}
+		},
+	})
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+	_, err := svc.ChangeResourceRecordSets(ctx, &route53.ChangeResourceRecordSetsInput{
+		ChangeBatch: &types.ChangeBatch{
+			Changes: []types.Change{},
+			Comment: aws.String("mock"),
+		},
+		HostedZoneId: aws.String("zone"),
+	}, func(options *route53.Options) {
+		otelaws.AppendMiddlewares(
+			&options.APIOptions,
+			otelaws.WithTracerProvider(provider),
+			otelaws.WithSuppressedServices("Route 53"),
+		)
+	})
+	require.NoError(t, err)
+	parent.End()
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "parent", spans[0].Name())
+	assert.Empty(t, spans[0].Attributes())
+}
@@ -17,6 +17,7 @@ package otelmux // import "go.opentelemetry.io/contrib/instrumentation/github.co
 import (
 	"net/http"
 
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	oteltrace "go.opentelemetry.io/otel/trace"
 )
@@ -24,11 +25,14 @@ import (
 // config is used to configure the mux middleware.
 type config struct {
 	TracerProvider    oteltrace.TracerProvider
+	MeterProvider     metric.MeterProvider
 	Propagators       propagation.TextMapPropagator
 	spanNameFormatter func(string, *http.Request) string
 	PublicEndpoint    bool
 	PublicEndpointFn  func(*http.Request) bool
 	Filters           []Filter
+	RouteFilters      []RouteFilter
+	RecoverPanics     bool
 }
 
 // Option specifies instrumentation configuration options.
@@ -46,6 +50,13 @@ func (o optionFunc) apply(c *config) {
 // be traced. A Filter must return true if the request should be traced.
 type Filter func(*http.Request) bool
 
+// RouteFilter is a predicate used to determine whether a request handled by
+// the route matching the route template, and with the HTTP method, should be
+// traced and measured. The route template is empty if no route matched the
+// request. A RouteFilter must return true if the request should be traced and
+// measured.
+type RouteFilter func(route, method string) bool
+
 // WithPublicEndpoint configures the Handler to link the span with an incoming
 // span context. If this option is not provided, then the association is a child
 // association instead of a link.
@@ -87,6 +98,16 @@ func WithTracerProvider(provider oteltrace.TracerProvider) Option {
 	})
 }
 
+// WithMeterProvider specifies a meter provider to use for creating a meter.
+// If none is specified, the global provider is used.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return optionFunc(func(cfg *config) {
+		if provider != nil {
+			cfg.MeterProvider = provider
+		}
+	})
+}
+
 // WithSpanNameFormatter specifies a function to use for generating a custom span
 // name. By default, the route name (path template or regexp) is used. The route
 // name is provided so you can use it in the span name without needing to
@@ -108,3 +129,26 @@ func WithFilter(f Filter) Option {
 		c.Filters = append(c.Filters, f)
 	})
 }
+
+// WithRouteFilter adds a route filter to the list of route filters used by
+// the handler. If any route filter indicates to exclude a request then the
+// request will neither be traced nor measured. Route filters are invoked once
+// the route of the request is matched, after the filters added with
+// WithFilter.
+func WithRouteFilter(f RouteFilter) Option {
+	return optionFunc(func(c *config) {
+		c.RouteFilters = append(c.RouteFilters, f)
+	})
+}
+
+// WithRecoverPanics specifies if panics of the handler are recovered. A panic
+// always sets the span status to Error, is recorded as an exception event,
+// and is measured as a request answered with status 500. If enabled is true,
+// the panic then stops at the middleware, which records its stack trace and
+// responds with status 500 if the handler did not write a response yet.
+// Otherwise the panic is propagated to the caller of the middleware.
+func WithRecoverPanics(enabled bool) Option {
+	return optionFunc(func(c *config) {
+		c.RecoverPanics = enabled
+	})
+}
@@ -18,12 +18,15 @@ import (
 	"fmt"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/felixge/httpsnoop"
 	"github.com/gorilla/mux"
 
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux/internal/semconvutil"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
 	"go.opentelemetry.io/otel/trace"
@@ -31,6 +34,10 @@ import (
 
 const (
 	tracerName = "go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux"
+
+	// serverDuration is the name of the histogram of the duration, in
+	// milliseconds, of the requests handled by the middleware.
+	serverDuration = "http.server.duration"
 )
 
 // Middleware sets up a handler to start tracing the incoming
@@ -48,6 +55,21 @@ func Middleware(service string, opts ...Option) mux.MiddlewareFunc {
 		tracerName,
 		trace.WithInstrumentationVersion(Version()),
 	)
+	if cfg.MeterProvider == nil {
+		cfg.MeterProvider = otel.GetMeterProvider()
+	}
+	meter := cfg.MeterProvider.Meter(
+		tracerName,
+		metric.WithInstrumentationVersion(Version()),
+	)
+	duration, err := meter.Float64Histogram(
+		serverDuration,
+		metric.WithUnit("ms"),
+		metric.WithDescription("Measures the duration of inbound HTTP requests."),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
 	if cfg.Propagators == nil {
 		cfg.Propagators = otel.GetTextMapPropagator()
 	}
@@ -59,12 +81,15 @@ func Middleware(service string, opts ...Option) mux.MiddlewareFunc {
 		return traceware{
 			service:           service,
 			tracer:            tracer,
+			duration:          duration,
 			propagators:       cfg.Propagators,
 			handler:           handler,
 			spanNameFormatter: cfg.spanNameFormatter,
 			publicEndpoint:    cfg.PublicEndpoint,
 			publicEndpointFn:  cfg.PublicEndpointFn,
 			filters:           cfg.Filters,
+			routeFilters:      cfg.RouteFilters,
+			recoverPanics:     cfg.RecoverPanics,
 		}
 	}
 }
@@ -72,12 +97,15 @@ func Middleware(service string, opts ...Option) mux.MiddlewareFunc {
 type traceware struct {
 	service           string
 	tracer            trace.Tracer
+	duration          metric.Float64Histogram
 	propagators       propagation.TextMapPropagator
 	handler           http.Handler
 	spanNameFormatter func(string, *http.Request) string
 	publicEndpoint    bool
 	publicEndpointFn  func(*http.Request) bool
 	filters           []Filter
+	routeFilters      []RouteFilter
+	recoverPanics     bool
 }
 
 type recordingResponseWriter struct {
@@ -127,7 +155,7 @@ func putRRW(rrw *recordingResponseWriter) {
 func defaultSpanNameFunc(routeName string, _ *http.Request) string { return routeName }
 
 // ServeHTTP implements the http.Handler interface. It does the actual
-// tracing of the request.
+// tracing and measuring of the request.
 func (tw traceware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	for _, f := range tw.filters {
 		if !f(r) {
@@ -137,7 +165,6 @@ func (tw traceware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	ctx := tw.propagators.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
 	routeStr := ""
 	route := mux.CurrentRoute(r)
 	if route != nil {
@@ -150,6 +177,15 @@ func (tw traceware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 	}
+	for _, f := range tw.routeFilters {
+		if !f(routeStr, r.Method) {
+			tw.handler.ServeHTTP(w, r)
+			return
+		}
+	}
+
+	start := time.Now()
+	ctx := tw.propagators.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
 
 	opts := []trace.SpanStartOption{
 		trace.WithAttributes(semconvutil.HTTPServerRequest(tw.service, r)...),
@@ -164,11 +200,13 @@ func (tw traceware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	metricAttrs := semconvutil.HTTPServerRequestMetrics(tw.service, r)
 	if routeStr == "" {
 		routeStr = fmt.Sprintf("HTTP %s route not found", r.Method)
 	} else {
 		rAttr := semconv.HTTPRoute(routeStr)
 		opts = append(opts, trace.WithAttributes(rAttr))
+		metricAttrs = append(metricAttrs, rAttr)
 	}
 	spanName := tw.spanNameFormatter(routeStr, r)
 	ctx, span := tw.tracer.Start(ctx, spanName, opts...)
@@ -176,9 +214,42 @@ func (tw traceware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	r2 := r.WithContext(ctx)
 	rrw := getRRW(w)
 	defer putRRW(rrw)
-	tw.handler.ServeHTTP(rrw.writer, r2)
-	if rrw.status > 0 {
-		span.SetAttributes(semconv.HTTPStatusCode(rrw.status))
+
+	end := func(status int) {
+		if status > 0 {
+			span.SetAttributes(semconv.HTTPStatusCode(status))
+			metricAttrs = append(metricAttrs, semconv.HTTPStatusCode(status))
+		}
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsed := float64(time.Since(start)) / float64(time.Millisecond)
+		tw.duration.Record(ctx, elapsed, metric.WithAttributes(metricAttrs...))
 	}
+	defer func() {
+		rec := recover()
+		if rec == nil {
+			return
+		}
+		span.SetStatus(codes.Error, fmt.Sprint(rec))
+		end(http.StatusInternalServerError)
+		if !tw.recoverPanics || rec == http.ErrAbortHandler {
+			// Ending the span while panicking records the exception.
+			panic(rec)
+		}
+		span.RecordError(panicError(rec), trace.WithStackTrace(true))
+		if !rrw.written {
+			rrw.writer.WriteHeader(http.StatusInternalServerError)
+		}
+	}()
+
+	tw.handler.ServeHTTP(rrw.writer, r2)
 	span.SetStatus(semconvutil.HTTPServerStatus(rrw.status))
+	end(rrw.status)
+}
+
+// panicError returns the error of the value passed to panic.
+func panicError(rec interface{}) error {
+	if err, ok := rec.(error); ok {
+		return err
+	}
+	return fmt.Errorf("panic: %v", rec)
 }
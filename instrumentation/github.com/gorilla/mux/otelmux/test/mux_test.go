@@ -29,6 +29,8 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.opentelemetry.io/otel/trace"
@@ -293,3 +295,125 @@ func TestWithPublicEndpointFn(t *testing.T) {
 		})
 	}
 }
+
+func TestRouteFilter(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider()
+	provider.RegisterSpanProcessor(sr)
+
+	router := mux.NewRouter()
+	router.Use(otelmux.Middleware("foobar",
+		otelmux.WithTracerProvider(provider),
+		otelmux.WithRouteFilter(func(route, method string) bool {
+			return !(route == "/user/{id}" && method == http.MethodDelete)
+		}),
+	))
+	router.HandleFunc("/user/{id}", ok)
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("DELETE", "/user/123", nil))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/user/123", nil))
+
+	require.Len(t, sr.Ended(), 1)
+	assert.Contains(t, sr.Ended()[0].Attributes(), attribute.String("http.method", "GET"))
+}
+
+func TestMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	router := mux.NewRouter()
+	router.Use(otelmux.Middleware("foobar",
+		otelmux.WithMeterProvider(provider),
+		otelmux.WithRouteFilter(func(route, _ string) bool {
+			return route != "/health"
+		}),
+	))
+	router.HandleFunc("/user/{id:[0-9]+}", ok)
+	router.HandleFunc("/health", ok)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/user/123", nil))
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/user/456", nil))
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/health", nil))
+
+	rm := metricdata.ResourceMetrics{}
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	sm := rm.ScopeMetrics[0]
+	assert.Equal(t, "go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux", sm.Scope.Name)
+	assert.Equal(t, otelmux.Version(), sm.Scope.Version)
+	require.Len(t, sm.Metrics, 1)
+
+	m := sm.Metrics[0]
+	assert.Equal(t, "http.server.duration", m.Name)
+	assert.Equal(t, "ms", m.Unit)
+	require.IsType(t, metricdata.Histogram[float64]{}, m.Data)
+	hist := m.Data.(metricdata.Histogram[float64])
+	require.Len(t, hist.DataPoints, 1)
+	dp := hist.DataPoints[0]
+	assert.Equal(t, uint64(2), dp.Count)
+	route, ok := dp.Attributes.Value("http.route")
+	assert.True(t, ok)
+	assert.Equal(t, "/user/{id:[0-9]+}", route.AsString())
+	status, ok := dp.Attributes.Value("http.status_code")
+	assert.True(t, ok)
+	assert.Equal(t, int64(http.StatusOK), status.AsInt64())
+	method, ok := dp.Attributes.Value("http.method")
+	assert.True(t, ok)
+	assert.Equal(t, "GET", method.AsString())
+}
+
+func TestPanic(t *testing.T) {
+	for _, recoverPanics := range []bool{false, true} {
+		t.Run(fmt.Sprintf("recover=%v", recoverPanics), func(t *testing.T) {
+			sr := tracetest.NewSpanRecorder()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+			reader := sdkmetric.NewManualReader()
+			mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+			router := mux.NewRouter()
+			router.Use(otelmux.Middleware("foobar",
+				otelmux.WithTracerProvider(tp),
+				otelmux.WithMeterProvider(mp),
+				otelmux.WithRecoverPanics(recoverPanics),
+			))
+			router.HandleFunc("/user/{id}", func(http.ResponseWriter, *http.Request) {
+				panic("boom")
+			})
+
+			w := httptest.NewRecorder()
+			serve := func() { router.ServeHTTP(w, httptest.NewRequest("GET", "/user/123", nil)) }
+			if recoverPanics {
+				assert.NotPanics(t, serve)
+				assert.Equal(t, http.StatusInternalServerError, w.Code)
+			} else {
+				assert.PanicsWithValue(t, "boom", serve)
+			}
+
+			spans := sr.Ended()
+			require.Len(t, spans, 1)
+			span := spans[0]
+			assert.Equal(t, codes.Error, span.Status().Code)
+			assert.Equal(t, "boom", span.Status().Description)
+			assert.Contains(t, span.Attributes(), attribute.Int("http.status_code", http.StatusInternalServerError))
+			require.Len(t, span.Events(), 1)
+			assert.Equal(t, "exception", span.Events()[0].Name)
+			attrs := attribute.NewSet(span.Events()[0].Attributes...)
+			msg, _ := attrs.Value("exception.message")
+			assert.Contains(t, msg.AsString(), "boom")
+			if recoverPanics {
+				assert.True(t, attrs.HasValue("exception.stacktrace"))
+			}
+
+			rm := metricdata.ResourceMetrics{}
+			require.NoError(t, reader.Collect(context.Background(), &rm))
+			require.Len(t, rm.ScopeMetrics, 1)
+			require.Len(t, rm.ScopeMetrics[0].Metrics, 1)
+			hist := rm.ScopeMetrics[0].Metrics[0].Data.(metricdata.Histogram[float64])
+			require.Len(t, hist.DataPoints, 1)
+			status, ok := hist.DataPoints[0].Attributes.Value("http.status_code")
+			assert.True(t, ok)
+			assert.Equal(t, int64(http.StatusInternalServerError), status.AsInt64())
+		})
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelgrpcgateway // import "go.opentelemetry.io/contrib/instrumentation/github.com/grpc-ecosystem/grpc-gateway/v2/otelgrpcgateway"
+
+import (
+	"net/textproto"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// config is a group of options for this instrumentation.
+type config struct {
+	Propagators    propagation.TextMapPropagator
+	BaggageHeaders []string
+	ErrorHandler   runtime.ErrorHandlerFunc
+}
+
+// Option applies an option value for a config.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (o optionFunc) apply(c *config) {
+	o(c)
+}
+
+// newConfig returns a config configured with all the passed Options.
+func newConfig(opts []Option) *config {
+	c := &config{
+		Propagators:  otel.GetTextMapPropagator(),
+		ErrorHandler: runtime.DefaultHTTPErrorHandler,
+	}
+	for _, o := range opts {
+		o.apply(c)
+	}
+	return c
+}
+
+// WithPropagators specifies propagators to use for injecting the span
+// context and baggage into the outgoing gRPC metadata. If none are
+// specified, the global propagator is used.
+func WithPropagators(propagators propagation.TextMapPropagator) Option {
+	return optionFunc(func(c *config) {
+		if propagators != nil {
+			c.Propagators = propagators
+		}
+	})
+}
+
+// WithBaggageHeaders specifies the incoming HTTP headers Middleware adds to
+// the request baggage. Each header present on a request is added as a
+// baggage member keyed by the lower-cased header name. No headers are
+// forwarded by default.
+func WithBaggageHeaders(headers ...string) Option {
+	return optionFunc(func(c *config) {
+		for _, h := range headers {
+			c.BaggageHeaders = append(c.BaggageHeaders, textproto.CanonicalMIMEHeaderKey(h))
+		}
+	})
+}
+
+// WithErrorHandler specifies the error handler called after the gRPC status
+// of a failed call has been recorded on the HTTP span. If none is specified,
+// runtime.DefaultHTTPErrorHandler is used.
+func WithErrorHandler(fn runtime.ErrorHandlerFunc) Option {
+	return optionFunc(func(c *config) {
+		if fn != nil {
+			c.ErrorHandler = fn
+		}
+	})
+}
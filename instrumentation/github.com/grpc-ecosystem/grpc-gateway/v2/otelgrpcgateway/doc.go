@@ -0,0 +1,32 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package otelgrpcgateway provides OpenTelemetry instrumentation for the
+// github.com/grpc-ecosystem/grpc-gateway/v2 module.
+//
+// The gateway translates an inbound HTTP request into an outbound gRPC call.
+// Without this package the HTTP span started for the inbound request (for
+// example by otelhttp) and the spans of the gRPC call are recorded in
+// separate, unrelated traces. The ServeMux options returned by
+// ServeMuxOptions propagate the HTTP span to the gRPC backend, annotate it
+// with the gRPC method and route being served, and map the gRPC status
+// returned by the backend back onto it. Middleware forwards selected
+// incoming HTTP headers as baggage.
+//
+// The HTTP span itself is not created by this package. Wrap the gateway
+// handler with otelhttp to start it:
+//
+//	mux := runtime.NewServeMux(otelgrpcgateway.ServeMuxOptions()...)
+//	handler := otelhttp.NewHandler(otelgrpcgateway.Middleware(mux), "gateway")
+package otelgrpcgateway // import "go.opentelemetry.io/contrib/instrumentation/github.com/grpc-ecosystem/grpc-gateway/v2/otelgrpcgateway"
@@ -0,0 +1,37 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelgrpcgateway_test
+
+import (
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+
+	"go.opentelemetry.io/contrib/instrumentation/github.com/grpc-ecosystem/grpc-gateway/v2/otelgrpcgateway"
+)
+
+func Example() {
+	opts := []otelgrpcgateway.Option{
+		otelgrpcgateway.WithBaggageHeaders("X-Tenant-Id"),
+	}
+	mux := runtime.NewServeMux(otelgrpcgateway.ServeMuxOptions(opts...)...)
+
+	// Register the generated gateway handlers with mux here, then wrap the
+	// gateway with an HTTP instrumentation (e.g. otelhttp) that starts the
+	// inbound span.
+	handler := otelgrpcgateway.Middleware(mux, opts...)
+
+	_ = http.ListenAndServe(":8080", handler)
+}
@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelgrpcgateway // import "go.opentelemetry.io/contrib/instrumentation/github.com/grpc-ecosystem/grpc-gateway/v2/otelgrpcgateway"
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// GRPCStatusCodeKey is convention for numeric status code of a gRPC request.
+const GRPCStatusCodeKey = attribute.Key("rpc.grpc.status_code")
+
+// ServeMuxOptions returns the runtime.ServeMuxOption values that connect the
+// span active in an inbound request context with the gRPC call made for it.
+//
+// The returned options:
+//   - inject the span context and baggage of the inbound request into the
+//     outgoing gRPC metadata so the gRPC spans join the same trace,
+//   - annotate the span with the gRPC method and HTTP route being served, and
+//   - record the gRPC status returned by the backend on the span.
+//
+// Pass them to runtime.NewServeMux along with any other options.
+func ServeMuxOptions(opts ...Option) []runtime.ServeMuxOption {
+	cfg := newConfig(opts)
+	return []runtime.ServeMuxOption{
+		runtime.WithMetadata(cfg.annotate),
+		runtime.WithForwardResponseOption(forwardResponse),
+		runtime.WithErrorHandler(cfg.handleError),
+	}
+}
+
+// annotate is a runtime metadata annotator. It is called for each request
+// after the gateway has resolved the gRPC method to call.
+func (c *config) annotate(ctx context.Context, _ *http.Request) metadata.MD {
+	span := trace.SpanFromContext(ctx)
+	if method, ok := runtime.RPCMethod(ctx); ok {
+		span.SetAttributes(rpcAttrs(method)...)
+	}
+	if pattern, ok := runtime.HTTPPathPattern(ctx); ok {
+		span.SetAttributes(semconv.HTTPRoute(pattern))
+	}
+
+	md := metadata.MD{}
+	c.Propagators.Inject(ctx, &metadataSupplier{metadata: &md})
+	return md
+}
+
+// forwardResponse records the successful completion of the gRPC call.
+func forwardResponse(ctx context.Context, _ http.ResponseWriter, _ proto.Message) error {
+	trace.SpanFromContext(ctx).SetAttributes(GRPCStatusCodeKey.Int(int(codes.OK)))
+	return nil
+}
+
+// handleError records the gRPC status of a failed call before delegating to
+// the configured error handler.
+func (c *config) handleError(ctx context.Context, mux *runtime.ServeMux, m runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	s := status.Convert(err)
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(GRPCStatusCodeKey.Int(int(s.Code())))
+	// Follow the server span status conventions of the HTTP status the
+	// gateway responds with: only server errors mark the span as failed.
+	if runtime.HTTPStatusFromCode(s.Code()) >= http.StatusInternalServerError {
+		span.SetStatus(otelcodes.Error, s.Message())
+	}
+	c.ErrorHandler(ctx, mux, m, w, r, err)
+}
+
+// rpcAttrs returns the RPC attributes of a full gRPC method name
+// ("/package.service/method").
+func rpcAttrs(fullMethod string) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{semconv.RPCSystemGRPC}
+	name := strings.TrimLeft(fullMethod, "/")
+	service, method, found := strings.Cut(name, "/")
+	if !found {
+		return attrs
+	}
+	if service != "" {
+		attrs = append(attrs, semconv.RPCService(service))
+	}
+	if method != "" {
+		attrs = append(attrs, semconv.RPCMethod(method))
+	}
+	return attrs
+}
+
+type metadataSupplier struct {
+	metadata *metadata.MD
+}
+
+func (s *metadataSupplier) Get(key string) string {
+	values := s.metadata.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func (s *metadataSupplier) Set(key string, value string) {
+	s.metadata.Set(key, value)
+}
+
+func (s *metadataSupplier) Keys() []string {
+	out := make([]string, 0, len(*s.metadata))
+	for key := range *s.metadata {
+		out = append(out, key)
+	}
+	return out
+}
@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelgrpcgateway // import "go.opentelemetry.io/contrib/instrumentation/github.com/grpc-ecosystem/grpc-gateway/v2/otelgrpcgateway"
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/baggage"
+)
+
+// Middleware returns an http.Handler that adds the incoming headers
+// configured with WithBaggageHeaders to the baggage of the request context
+// before calling h. The baggage is then propagated to the gRPC backend
+// together with the span context.
+//
+// Headers that cannot be represented as baggage members are skipped. Members
+// already present in the request baggage are not overwritten.
+func Middleware(h http.Handler, opts ...Option) http.Handler {
+	cfg := newConfig(opts)
+	if len(cfg.BaggageHeaders) == 0 {
+		return h
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		bag := baggage.FromContext(ctx)
+		changed := false
+		for _, header := range cfg.BaggageHeaders {
+			value := r.Header.Get(header)
+			if value == "" {
+				continue
+			}
+			key := strings.ToLower(header)
+			if bag.Member(key).Key() != "" {
+				continue
+			}
+			m, err := baggage.NewMember(key, url.PathEscape(value))
+			if err != nil {
+				otel.Handle(err)
+				continue
+			}
+			b, err := bag.SetMember(m)
+			if err != nil {
+				otel.Handle(err)
+				continue
+			}
+			bag, changed = b, true
+		}
+		if changed {
+			r = r.WithContext(baggage.ContextWithBaggage(ctx, bag))
+		}
+		h.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelgrpcgateway
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/baggage"
+)
+
+func TestMiddlewareBaggage(t *testing.T) {
+	var got baggage.Baggage
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = baggage.FromContext(r.Context())
+	})
+	h := Middleware(next, WithBaggageHeaders("x-tenant-id", "X-Request-Source", "x-missing"))
+
+	existing, err := baggage.NewMember("x-request-source", "upstream")
+	require.NoError(t, err)
+	bag, err := baggage.New(existing)
+	require.NoError(t, err)
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r = r.WithContext(baggage.ContextWithBaggage(r.Context(), bag))
+	r.Header.Set("X-Tenant-Id", "acme corp")
+	r.Header.Set("X-Request-Source", "mobile")
+	h.ServeHTTP(httptest.NewRecorder(), r)
+
+	assert.Equal(t, 2, got.Len())
+	assert.Equal(t, "acme corp", got.Member("x-tenant-id").Value())
+	assert.Equal(t, "upstream", got.Member("x-request-source").Value(), "existing member overwritten")
+}
+
+func TestMiddlewareNoHeaders(t *testing.T) {
+	var got baggage.Baggage
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = baggage.FromContext(r.Context())
+	})
+	h := Middleware(next)
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("X-Tenant-Id", "acme")
+	h.ServeHTTP(httptest.NewRecorder(), r)
+	assert.Equal(t, 0, got.Len())
+}
+
+func TestRPCAttrs(t *testing.T) {
+	assert.Len(t, rpcAttrs("/helloworld.Greeter/SayHello"), 3)
+	assert.Len(t, rpcAttrs("invalid"), 1)
+}
@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"go.opentelemetry.io/contrib/instrumentation/github.com/grpc-ecosystem/grpc-gateway/v2/otelgrpcgateway"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	rpcMethod   = "/helloworld.Greeter/SayHello"
+	pathPattern = "/v1/hello/{name}"
+)
+
+func setup(t *testing.T) (*tracetest.SpanRecorder, *runtime.ServeMux, context.Context, trace.Span) {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	mux := runtime.NewServeMux(otelgrpcgateway.ServeMuxOptions(
+		otelgrpcgateway.WithPropagators(propagation.TraceContext{}),
+	)...)
+	ctx, span := tp.Tracer("gateway").Start(context.Background(), "GET", trace.WithSpanKind(trace.SpanKindServer))
+	return sr, mux, ctx, span
+}
+
+func annotate(t *testing.T, ctx context.Context, mux *runtime.ServeMux) (context.Context, *http.Request) {
+	req := httptest.NewRequest(http.MethodGet, "/v1/hello/world", nil).WithContext(ctx)
+	ctx, err := runtime.AnnotateContext(ctx, mux, req, rpcMethod, runtime.WithHTTPPathPattern(pathPattern))
+	require.NoError(t, err)
+	return ctx, req
+}
+
+func TestSpanPropagatedToGRPC(t *testing.T) {
+	sr, mux, ctx, span := setup(t)
+	ctx, req := annotate(t, ctx, mux)
+	runtime.ForwardResponseMessage(ctx, mux, &runtime.JSONPb{}, httptest.NewRecorder(), req, wrapperspb.String("hello"), mux.GetForwardResponseOptions()...)
+	span.End()
+
+	md, ok := metadata.FromOutgoingContext(ctx)
+	require.True(t, ok)
+	carrier := propagation.HeaderCarrier{}
+	for _, v := range md.Get("traceparent") {
+		carrier.Set("traceparent", v)
+	}
+	got := trace.SpanContextFromContext(propagation.TraceContext{}.Extract(context.Background(), carrier))
+	assert.Equal(t, span.SpanContext().TraceID(), got.TraceID())
+	assert.Equal(t, span.SpanContext().SpanID(), got.SpanID())
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	attrs := spans[0].Attributes()
+	assert.Contains(t, attrs, semconv.RPCSystemGRPC)
+	assert.Contains(t, attrs, semconv.RPCService("helloworld.Greeter"))
+	assert.Contains(t, attrs, semconv.RPCMethod("SayHello"))
+	assert.Contains(t, attrs, semconv.HTTPRoute(pathPattern))
+	assert.Contains(t, attrs, otelgrpcgateway.GRPCStatusCodeKey.Int(int(codes.OK)))
+	assert.Equal(t, otelcodes.Unset, spans[0].Status().Code)
+}
+
+func TestGRPCStatusMapped(t *testing.T) {
+	tests := []struct {
+		code       codes.Code
+		httpStatus int
+		spanStatus otelcodes.Code
+	}{
+		{codes.NotFound, http.StatusNotFound, otelcodes.Unset},
+		{codes.InvalidArgument, http.StatusBadRequest, otelcodes.Unset},
+		{codes.Unavailable, http.StatusServiceUnavailable, otelcodes.Error},
+		{codes.Internal, http.StatusInternalServerError, otelcodes.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code.String(), func(t *testing.T) {
+			sr, mux, ctx, span := setup(t)
+			ctx, req := annotate(t, ctx, mux)
+			w := httptest.NewRecorder()
+			runtime.HTTPError(ctx, mux, &runtime.JSONPb{}, w, req, status.Error(tt.code, "failed"))
+			span.End()
+
+			assert.Equal(t, tt.httpStatus, w.Code)
+			spans := sr.Ended()
+			require.Len(t, spans, 1)
+			assert.Contains(t, spans[0].Attributes(), attribute.Int("rpc.grpc.status_code", int(tt.code)))
+			assert.Equal(t, tt.spanStatus, spans[0].Status().Code)
+		})
+	}
+}
+
+func TestWithErrorHandler(t *testing.T) {
+	var called bool
+	handler := func(ctx context.Context, mux *runtime.ServeMux, m runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+		called = true
+		w.WriteHeader(http.StatusTeapot)
+	}
+	mux := runtime.NewServeMux(otelgrpcgateway.ServeMuxOptions(otelgrpcgateway.WithErrorHandler(handler))...)
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+	runtime.HTTPError(req.Context(), mux, &runtime.JSONPb{}, w, req, status.Error(codes.Internal, "failed"))
+
+	assert.True(t, called)
+	assert.Equal(t, http.StatusTeapot, w.Code)
+}
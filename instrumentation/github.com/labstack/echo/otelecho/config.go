@@ -17,6 +17,7 @@ package otelecho // import "go.opentelemetry.io/contrib/instrumentation/github.c
 import (
 	"github.com/labstack/echo/v4/middleware"
 
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	oteltrace "go.opentelemetry.io/otel/trace"
 )
@@ -24,8 +25,10 @@ import (
 // config is used to configure the mux middleware.
 type config struct {
 	TracerProvider oteltrace.TracerProvider
+	MeterProvider  metric.MeterProvider
 	Propagators    propagation.TextMapPropagator
 	Skipper        middleware.Skipper
+	RecoverPanics  bool
 }
 
 // Option specifies instrumentation configuration options.
@@ -60,9 +63,31 @@ func WithTracerProvider(provider oteltrace.TracerProvider) Option {
 	})
 }
 
+// WithMeterProvider specifies a meter provider to use for creating a meter.
+// If none is specified, the global provider is used.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return optionFunc(func(cfg *config) {
+		if provider != nil {
+			cfg.MeterProvider = provider
+		}
+	})
+}
+
 // WithSkipper specifies a skipper for allowing requests to skip generating spans.
 func WithSkipper(skipper middleware.Skipper) Option {
 	return optionFunc(func(cfg *config) {
 		cfg.Skipper = skipper
 	})
 }
+
+// WithRecoverPanics specifies if panics of the handler are recovered. A panic
+// always sets the span status to Error, is recorded as an exception event,
+// and is measured as a request answered with status 500. If enabled is true,
+// the panic then stops at the middleware, which records its stack trace and
+// passes it as an error to the registered HTTP error handler. Otherwise the
+// panic is propagated to the caller of the middleware.
+func WithRecoverPanics(enabled bool) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.RecoverPanics = enabled
+	})
+}
@@ -16,5 +16,7 @@
 // (https://github.com/labstack/echo).
 //
 // Currently only the routing of a received message can be instrumented. To do
-// so, use the Middleware function.
+// so, use the Middleware function. The middleware traces the requests and
+// records the duration, request and response body sizes, and active requests
+// metrics, attributed with the matched echo route.
 package otelecho // import "go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
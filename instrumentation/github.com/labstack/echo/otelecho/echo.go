@@ -16,6 +16,8 @@ package otelecho // import "go.opentelemetry.io/contrib/instrumentation/github.c
 
 import (
 	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
@@ -24,6 +26,8 @@ import (
 
 	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho/internal/semconvutil"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
 	oteltrace "go.opentelemetry.io/otel/trace"
@@ -32,6 +36,19 @@ import (
 const (
 	tracerKey  = "otel-go-contrib-tracer-labstack-echo"
 	tracerName = "go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+
+	// serverDuration is the name of the histogram of the duration, in
+	// milliseconds, of the requests handled by the middleware.
+	serverDuration = "http.server.duration"
+	// serverRequestSize is the name of the histogram of the size of the
+	// request bodies.
+	serverRequestSize = "http.server.request.size"
+	// serverResponseSize is the name of the histogram of the size of the
+	// response bodies.
+	serverResponseSize = "http.server.response.size"
+	// serverActiveRequests is the name of the counter of the requests
+	// currently handled by the middleware.
+	serverActiveRequests = "http.server.active_requests"
 )
 
 // Middleware returns echo middleware which will trace incoming requests.
@@ -47,6 +64,45 @@ func Middleware(service string, opts ...Option) echo.MiddlewareFunc {
 		tracerName,
 		oteltrace.WithInstrumentationVersion(Version()),
 	)
+	if cfg.MeterProvider == nil {
+		cfg.MeterProvider = otel.GetMeterProvider()
+	}
+	meter := cfg.MeterProvider.Meter(
+		tracerName,
+		metric.WithInstrumentationVersion(Version()),
+	)
+	duration, err := meter.Float64Histogram(
+		serverDuration,
+		metric.WithUnit("ms"),
+		metric.WithDescription("Measures the duration of inbound HTTP requests."),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+	requestSize, err := meter.Int64Histogram(
+		serverRequestSize,
+		metric.WithUnit("By"),
+		metric.WithDescription("Measures the size of HTTP request bodies."),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+	responseSize, err := meter.Int64Histogram(
+		serverResponseSize,
+		metric.WithUnit("By"),
+		metric.WithDescription("Measures the size of HTTP response bodies."),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+	activeRequests, err := meter.Int64UpDownCounter(
+		serverActiveRequests,
+		metric.WithUnit("{request}"),
+		metric.WithDescription("Measures the number of concurrent HTTP requests that are currently in-flight."),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
 	if cfg.Propagators == nil {
 		cfg.Propagators = otel.GetTextMapPropagator()
 	}
@@ -56,11 +112,12 @@ func Middleware(service string, opts ...Option) echo.MiddlewareFunc {
 	}
 
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
-		return func(c echo.Context) error {
+		return func(c echo.Context) (returnErr error) {
 			if cfg.Skipper(c) {
 				return next(c)
 			}
 
+			start := time.Now()
 			c.Set(tracerKey, tracer)
 			request := c.Request()
 			savedCtx := request.Context()
@@ -73,9 +130,15 @@ func Middleware(service string, opts ...Option) echo.MiddlewareFunc {
 				oteltrace.WithAttributes(semconvutil.HTTPServerRequest(service, request)...),
 				oteltrace.WithSpanKind(oteltrace.SpanKindServer),
 			}
+			activeAttrs := metric.WithAttributes(semconvutil.HTTPServerRequestMetrics(service, request)...)
+			activeRequests.Add(ctx, 1, activeAttrs)
+			defer activeRequests.Add(ctx, -1, activeAttrs)
+
+			metricAttrs := semconvutil.HTTPServerRequestMetrics(service, request)
 			if path := c.Path(); path != "" {
 				rAttr := semconv.HTTPRoute(path)
 				opts = append(opts, oteltrace.WithAttributes(rAttr))
+				metricAttrs = append(metricAttrs, rAttr)
 			}
 			spanName := c.Path()
 			if spanName == "" {
@@ -88,21 +151,66 @@ func Middleware(service string, opts ...Option) echo.MiddlewareFunc {
 			// pass the span through the request context
 			c.SetRequest(request.WithContext(ctx))
 
+			end := func(status int, err error) {
+				code, desc := semconvutil.HTTPServerStatus(status)
+				if code == codes.Error && desc == "" && err != nil {
+					desc = err.Error()
+				}
+				span.SetStatus(code, desc)
+				if status > 0 {
+					span.SetAttributes(semconv.HTTPStatusCode(status))
+					metricAttrs = append(metricAttrs, semconv.HTTPStatusCode(status))
+				}
+
+				attrs := metric.WithAttributes(metricAttrs...)
+				// Use floating point division here for higher precision (instead of Millisecond method).
+				elapsed := float64(time.Since(start)) / float64(time.Millisecond)
+				duration.Record(ctx, elapsed, attrs)
+				var bodySize int64
+				if request.ContentLength > 0 {
+					bodySize = request.ContentLength
+				}
+				requestSize.Record(ctx, bodySize, attrs)
+				responseSize.Record(ctx, c.Response().Size, attrs)
+			}
+			defer func() {
+				rec := recover()
+				if rec == nil {
+					return
+				}
+				perr := panicError(rec)
+				if !cfg.RecoverPanics || rec == http.ErrAbortHandler {
+					// Ending the span while panicking records the exception.
+					end(http.StatusInternalServerError, perr)
+					panic(rec)
+				}
+				span.RecordError(perr, oteltrace.WithStackTrace(true))
+				span.SetAttributes(attribute.String("echo.error", perr.Error()))
+				// invokes the registered HTTP error handler
+				c.Error(perr)
+				end(c.Response().Status, perr)
+				returnErr = nil
+			}()
+
 			// serve the request to the next middleware
 			err := next(c)
 			if err != nil {
+				span.RecordError(err)
 				span.SetAttributes(attribute.String("echo.error", err.Error()))
 				// invokes the registered HTTP error handler
 				c.Error(err)
 			}
 
-			status := c.Response().Status
-			span.SetStatus(semconvutil.HTTPServerStatus(status))
-			if status > 0 {
-				span.SetAttributes(semconv.HTTPStatusCode(status))
-			}
-
+			end(c.Response().Status, err)
 			return err
 		}
 	}
 }
+
+// panicError returns the error of the value passed to panic.
+func panicError(rec interface{}) error {
+	if err, ok := rec.(error); ok {
+		return err
+	}
+	return fmt.Errorf("panic: %v", rec)
+}
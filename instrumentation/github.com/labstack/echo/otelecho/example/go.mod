@@ -4,6 +4,7 @@ go 1.20
 
 replace (
 	go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho => ../
+	go.opentelemetry.io/contrib/instrumentation/text/template/oteltemplate => ../../../../../text/template/oteltemplate
 	go.opentelemetry.io/contrib/propagators/b3 => ../../../../../../propagators/b3
 )
 
@@ -25,6 +26,7 @@ require (
 	github.com/mattn/go-isatty v0.0.19 // indirect
 	github.com/valyala/bytebufferpool v1.0.0 // indirect
 	github.com/valyala/fasttemplate v1.2.2 // indirect
+	go.opentelemetry.io/contrib/instrumentation/text/template/oteltemplate v0.45.0 // indirect
 	go.opentelemetry.io/otel/metric v1.19.0 // indirect
 	golang.org/x/crypto v0.14.0 // indirect
 	golang.org/x/net v0.17.0 // indirect
@@ -4,11 +4,15 @@ go 1.20
 
 replace go.opentelemetry.io/contrib/propagators/b3 => ../../../../../propagators/b3
 
+replace go.opentelemetry.io/contrib/instrumentation/text/template/oteltemplate => ../../../../text/template/oteltemplate
+
 require (
 	github.com/labstack/echo/v4 v4.11.2
 	github.com/stretchr/testify v1.8.4
+	go.opentelemetry.io/contrib/instrumentation/text/template/oteltemplate v0.45.0
 	go.opentelemetry.io/contrib/propagators/b3 v1.20.0
 	go.opentelemetry.io/otel v1.19.0
+	go.opentelemetry.io/otel/metric v1.19.0
 	go.opentelemetry.io/otel/trace v1.19.0
 )
 
@@ -23,7 +27,6 @@ require (
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/valyala/bytebufferpool v1.0.0 // indirect
 	github.com/valyala/fasttemplate v1.2.2 // indirect
-	go.opentelemetry.io/otel/metric v1.19.0 // indirect
 	golang.org/x/crypto v0.14.0 // indirect
 	golang.org/x/net v0.17.0 // indirect
 	golang.org/x/sys v0.13.0 // indirect
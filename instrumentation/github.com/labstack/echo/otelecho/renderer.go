@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelecho // import "go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+
+import (
+	"io"
+
+	"github.com/labstack/echo/v4"
+
+	"go.opentelemetry.io/contrib/instrumentation/text/template/oteltemplate"
+	"go.opentelemetry.io/otel"
+)
+
+type renderer struct {
+	renderer echo.Renderer
+	traced   *oteltemplate.Renderer
+}
+
+// NewRenderer returns an echo.Renderer tracing the template rendering of r
+// with the oteltemplate instrumentation. The span of each rendering is a
+// child of the span of the request started by Middleware, so slow views show
+// up in the request traces. Only the WithTracerProvider option is used.
+func NewRenderer(r echo.Renderer, opts ...Option) echo.Renderer {
+	cfg := config{}
+	for _, opt := range opts {
+		opt.apply(&cfg)
+	}
+	if cfg.TracerProvider == nil {
+		cfg.TracerProvider = otel.GetTracerProvider()
+	}
+	return &renderer{
+		renderer: r,
+		traced:   oteltemplate.NewRenderer(oteltemplate.WithTracerProvider(cfg.TracerProvider)),
+	}
+}
+
+// Render renders the template with the given name within a span.
+func (r *renderer) Render(w io.Writer, name string, data interface{}, c echo.Context) error {
+	return r.traced.Render(c.Request().Context(), name, func() error {
+		return r.renderer.Render(w, name, data, c)
+	})
+}
@@ -17,9 +17,14 @@
 package test
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"html/template"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/labstack/echo/v4"
@@ -30,6 +35,8 @@ import (
 	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	"go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 
@@ -136,6 +143,11 @@ func TestError(t *testing.T) {
 	assert.Contains(t, attrs, attribute.String("echo.error", "oh no"))
 	// server errors set the status
 	assert.Equal(t, codes.Error, span.Status().Code)
+	assert.Equal(t, "oh no", span.Status().Description)
+	// returned errors are recorded
+	require.Len(t, span.Events(), 1)
+	assert.Equal(t, "exception", span.Events()[0].Name)
+	assert.Contains(t, span.Events()[0].Attributes, attribute.String("exception.message", "oh no"))
 }
 
 func TestStatusError(t *testing.T) {
@@ -213,3 +225,169 @@ func TestErrorNotSwallowedByMiddleware(t *testing.T) {
 	err := h(c)
 	assert.Equal(t, assert.AnError, err)
 }
+
+func TestMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	router := echo.New()
+	router.Use(otelecho.Middleware("foobar", otelecho.WithMeterProvider(provider)))
+	router.POST("/user/:id", func(c echo.Context) error {
+		return c.String(http.StatusOK, "hello")
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/user/123", strings.NewReader("body")))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/user/456", strings.NewReader("body")))
+
+	rm := metricdata.ResourceMetrics{}
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	sm := rm.ScopeMetrics[0]
+	assert.Equal(t, "go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho", sm.Scope.Name)
+	assert.Equal(t, otelecho.Version(), sm.Scope.Version)
+
+	metrics := map[string]metricdata.Metrics{}
+	for _, m := range sm.Metrics {
+		metrics[m.Name] = m
+	}
+	require.Len(t, metrics, 4)
+
+	require.IsType(t, metricdata.Histogram[float64]{}, metrics["http.server.duration"].Data)
+	assert.Equal(t, "ms", metrics["http.server.duration"].Unit)
+	duration := metrics["http.server.duration"].Data.(metricdata.Histogram[float64])
+	require.Len(t, duration.DataPoints, 1)
+	dp := duration.DataPoints[0]
+	assert.Equal(t, uint64(2), dp.Count)
+	route, ok := dp.Attributes.Value("http.route")
+	assert.True(t, ok)
+	assert.Equal(t, "/user/:id", route.AsString())
+	status, ok := dp.Attributes.Value("http.status_code")
+	assert.True(t, ok)
+	assert.Equal(t, int64(http.StatusOK), status.AsInt64())
+	method, ok := dp.Attributes.Value("http.method")
+	assert.True(t, ok)
+	assert.Equal(t, "POST", method.AsString())
+
+	for name, size := range map[string]int64{
+		"http.server.request.size":  8,
+		"http.server.response.size": 10,
+	} {
+		require.IsType(t, metricdata.Histogram[int64]{}, metrics[name].Data, name)
+		hist := metrics[name].Data.(metricdata.Histogram[int64])
+		require.Len(t, hist.DataPoints, 1, name)
+		assert.Equal(t, "By", metrics[name].Unit, name)
+		assert.Equal(t, uint64(2), hist.DataPoints[0].Count, name)
+		assert.Equal(t, size, hist.DataPoints[0].Sum, name)
+	}
+
+	require.IsType(t, metricdata.Sum[int64]{}, metrics["http.server.active_requests"].Data)
+	active := metrics["http.server.active_requests"].Data.(metricdata.Sum[int64])
+	require.Len(t, active.DataPoints, 1)
+	assert.False(t, active.IsMonotonic)
+	assert.Equal(t, int64(0), active.DataPoints[0].Value)
+}
+
+func TestPanic(t *testing.T) {
+	for _, recoverPanics := range []bool{false, true} {
+		t.Run(fmt.Sprintf("recover=%v", recoverPanics), func(t *testing.T) {
+			sr := tracetest.NewSpanRecorder()
+			tp := trace.NewTracerProvider(trace.WithSpanProcessor(sr))
+			reader := sdkmetric.NewManualReader()
+			mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+			router := echo.New()
+			router.Use(otelecho.Middleware("foobar",
+				otelecho.WithTracerProvider(tp),
+				otelecho.WithMeterProvider(mp),
+				otelecho.WithRecoverPanics(recoverPanics),
+			))
+			router.GET("/user/:id", func(c echo.Context) error {
+				panic("boom")
+			})
+
+			w := httptest.NewRecorder()
+			serve := func() { router.ServeHTTP(w, httptest.NewRequest("GET", "/user/123", nil)) }
+			if recoverPanics {
+				assert.NotPanics(t, serve)
+				assert.Equal(t, http.StatusInternalServerError, w.Code)
+			} else {
+				assert.PanicsWithValue(t, "boom", serve)
+			}
+
+			spans := sr.Ended()
+			require.Len(t, spans, 1)
+			span := spans[0]
+			assert.Equal(t, codes.Error, span.Status().Code)
+			assert.Contains(t, span.Attributes(), attribute.Int("http.status_code", http.StatusInternalServerError))
+			require.Len(t, span.Events(), 1)
+			assert.Equal(t, "exception", span.Events()[0].Name)
+			attrs := attribute.NewSet(span.Events()[0].Attributes...)
+			msg, _ := attrs.Value("exception.message")
+			assert.Contains(t, msg.AsString(), "boom")
+			if recoverPanics {
+				assert.True(t, attrs.HasValue("exception.stacktrace"))
+			}
+
+			rm := metricdata.ResourceMetrics{}
+			require.NoError(t, reader.Collect(context.Background(), &rm))
+			require.Len(t, rm.ScopeMetrics, 1)
+			var found bool
+			for _, m := range rm.ScopeMetrics[0].Metrics {
+				if m.Name != "http.server.duration" {
+					continue
+				}
+				found = true
+				hist := m.Data.(metricdata.Histogram[float64])
+				require.Len(t, hist.DataPoints, 1)
+				status, ok := hist.DataPoints[0].Attributes.Value("http.status_code")
+				assert.True(t, ok)
+				assert.Equal(t, int64(http.StatusInternalServerError), status.AsInt64())
+			}
+			assert.True(t, found)
+		})
+	}
+}
+
+type templateRenderer struct {
+	templates *template.Template
+}
+
+func (r templateRenderer) Render(w io.Writer, name string, data interface{}, _ echo.Context) error {
+	return r.templates.ExecuteTemplate(w, name, data)
+}
+
+func TestRenderer(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := trace.NewTracerProvider(trace.WithSpanProcessor(sr))
+
+	router := echo.New()
+	router.Renderer = otelecho.NewRenderer(
+		templateRenderer{templates: template.Must(template.New("hello").Parse(`Hello {{.}}`))},
+		otelecho.WithTracerProvider(provider),
+	)
+	router.Use(otelecho.Middleware("foobar", otelecho.WithTracerProvider(provider)))
+	router.GET("/hello/:name", func(c echo.Context) error {
+		return c.Render(http.StatusOK, "hello", c.Param("name"))
+	})
+	router.GET("/missing", func(c echo.Context) error {
+		return c.Render(http.StatusOK, "missing", nil)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/hello/world", nil))
+	assert.Equal(t, "Hello world", w.Body.String())
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/missing", nil))
+
+	spans := sr.Ended()
+	require.Len(t, spans, 4)
+	render, request := spans[0], spans[1]
+	assert.Equal(t, "render hello", render.Name())
+	assert.Equal(t, oteltrace.SpanKindInternal, render.SpanKind())
+	assert.Contains(t, render.Attributes(), attribute.String("template.name", "hello"))
+	assert.Equal(t, request.SpanContext().SpanID(), render.Parent().SpanID())
+	assert.Equal(t, "go.opentelemetry.io/contrib/instrumentation/text/template/oteltemplate", render.InstrumentationScope().Name)
+
+	failed := spans[2]
+	assert.Equal(t, "render missing", failed.Name())
+	assert.Equal(t, codes.Error, failed.Status().Code)
+}
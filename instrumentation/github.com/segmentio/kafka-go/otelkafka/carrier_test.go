@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelkafka
+
+import (
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessageCarrier(t *testing.T) {
+	msg := kafka.Message{Headers: []kafka.Header{
+		{Key: "traceparent", Value: []byte("old")},
+		{Key: "foo", Value: []byte("bar")},
+	}}
+	c := NewMessageCarrier(&msg)
+
+	assert.Equal(t, "old", c.Get("traceparent"))
+	assert.Equal(t, "", c.Get("missing"))
+
+	c.Set("traceparent", "new")
+	c.Set("tracestate", "k=v")
+	assert.Equal(t, "new", c.Get("traceparent"))
+	assert.Equal(t, "k=v", c.Get("tracestate"))
+	assert.ElementsMatch(t, []string{"foo", "traceparent", "tracestate"}, c.Keys())
+}
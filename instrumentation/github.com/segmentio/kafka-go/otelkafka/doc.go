@@ -0,0 +1,26 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package otelkafka provides OpenTelemetry instrumentation for the
+// github.com/segmentio/kafka-go module.
+//
+// Writer records a producer span for each message written and injects the
+// span context into the message headers. Reader extracts that context from
+// the messages it reads and records a consumer span for each of them as a
+// child of the producer span, so a message can be followed from the
+// producer to its consumers in a single trace.
+//
+// Both types also record the number of messages published and received, and
+// Reader reports the consumer lag of the partitions it reads from.
+package otelkafka // import "go.opentelemetry.io/contrib/instrumentation/github.com/segmentio/kafka-go/otelkafka"
@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelkafka_test
+
+import (
+	"context"
+	"log"
+
+	"github.com/segmentio/kafka-go"
+
+	"go.opentelemetry.io/contrib/instrumentation/github.com/segmentio/kafka-go/otelkafka"
+)
+
+func ExampleNewWriter() {
+	w := otelkafka.NewWriter(&kafka.Writer{
+		Addr:  kafka.TCP("localhost:9092"),
+		Topic: "orders",
+	})
+	defer w.Close()
+
+	err := w.WriteMessages(context.Background(), kafka.Message{Value: []byte("order created")})
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func ExampleNewReader() {
+	r := otelkafka.NewReader(kafka.NewReader(kafka.ReaderConfig{
+		Brokers: []string{"localhost:9092"},
+		GroupID: "billing",
+		Topic:   "orders",
+	}))
+	defer r.Close()
+
+	for {
+		ctx, msg, err := r.ReadMessage(context.Background())
+		if err != nil {
+			break
+		}
+		process(ctx, msg)
+	}
+}
+
+func process(context.Context, kafka.Message) {}
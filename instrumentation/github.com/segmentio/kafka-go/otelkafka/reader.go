@@ -0,0 +1,175 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelkafka // import "go.opentelemetry.io/contrib/instrumentation/github.com/segmentio/kafka-go/otelkafka"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// partition identifies a partition of a topic.
+type partition struct {
+	topic string
+	id    int
+}
+
+// Reader is a kafka.Reader whose reads are traced.
+type Reader struct {
+	*kafka.Reader
+
+	cfg *config
+	reg metric.Registration
+
+	mu  sync.Mutex
+	lag map[partition]int64
+}
+
+// NewReader returns a Reader tracing the messages read with r. The consumer
+// lag of the partitions r reads from is reported until the returned Reader
+// is closed.
+func NewReader(r *kafka.Reader, opts ...Option) *Reader {
+	reader := &Reader{
+		Reader: r,
+		cfg:    newConfig(opts),
+		lag:    make(map[partition]int64),
+	}
+
+	lag, err := reader.cfg.meter.Int64ObservableGauge("messaging.kafka.consumer.lag",
+		metric.WithDescription("Measures the number of messages the consumer is behind the end of the partition."),
+		metric.WithUnit("{message}"))
+	if err != nil {
+		otel.Handle(err)
+		return reader
+	}
+	reader.reg, err = reader.cfg.meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		reader.mu.Lock()
+		defer reader.mu.Unlock()
+		for p, n := range reader.lag {
+			o.ObserveInt64(lag, n, metric.WithAttributes(reader.partitionAttrs(p)...))
+		}
+		return nil
+	}, lag)
+	if err != nil {
+		otel.Handle(err)
+	}
+	return reader
+}
+
+// FetchMessage fetches the next message with the wrapped kafka.Reader. See
+// kafka.Reader.FetchMessage for details.
+//
+// The returned context holds the consumer span recorded for the message.
+// That span is a child of the producer span whose context was propagated in
+// the message headers. Use the context to trace the processing of the
+// message.
+func (r *Reader) FetchMessage(ctx context.Context) (context.Context, kafka.Message, error) {
+	start := time.Now()
+	msg, err := r.Reader.FetchMessage(ctx)
+	if err != nil {
+		return ctx, msg, err
+	}
+	return r.received(ctx, start, msg), msg, nil
+}
+
+// ReadMessage reads the next message with the wrapped kafka.Reader. See
+// kafka.Reader.ReadMessage for details.
+//
+// The returned context holds the consumer span recorded for the message.
+// That span is a child of the producer span whose context was propagated in
+// the message headers. Use the context to trace the processing of the
+// message.
+func (r *Reader) ReadMessage(ctx context.Context) (context.Context, kafka.Message, error) {
+	start := time.Now()
+	msg, err := r.Reader.ReadMessage(ctx)
+	if err != nil {
+		return ctx, msg, err
+	}
+	return r.received(ctx, start, msg), msg, nil
+}
+
+// Close stops reporting the consumer lag and closes the wrapped
+// kafka.Reader.
+func (r *Reader) Close() error {
+	if r.reg != nil {
+		if err := r.reg.Unregister(); err != nil {
+			otel.Handle(err)
+		}
+	}
+	r.mu.Lock()
+	r.lag = make(map[partition]int64)
+	r.mu.Unlock()
+	return r.Reader.Close()
+}
+
+// received records the receipt of msg and returns the context holding the
+// consumer span.
+func (r *Reader) received(ctx context.Context, start time.Time, msg kafka.Message) context.Context {
+	p := partition{topic: msg.Topic, id: msg.Partition}
+	attrs := append(r.partitionAttrs(p),
+		semconv.MessagingOperationReceive,
+		semconv.MessagingKafkaMessageOffset(int(msg.Offset)),
+		semconv.MessagingMessagePayloadSizeBytes(len(msg.Value)),
+	)
+	if msg.Key != nil {
+		attrs = append(attrs, semconv.MessagingKafkaMessageKey(string(msg.Key)))
+	}
+	if msg.Value == nil {
+		attrs = append(attrs, semconv.MessagingKafkaMessageTombstone(true))
+	}
+
+	ctx = r.cfg.Propagators.Extract(ctx, NewMessageCarrier(&msg))
+	ctx, span := r.cfg.tracer.Start(ctx, msg.Topic+" receive",
+		trace.WithTimestamp(start),
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(attrs...),
+	)
+	span.End()
+
+	r.cfg.receiveMessages.Add(ctx, 1, metric.WithAttributes(r.destinationAttrs(msg.Topic)...))
+	if msg.HighWaterMark > 0 {
+		lag := msg.HighWaterMark - msg.Offset - 1
+		if lag < 0 {
+			lag = 0
+		}
+		r.mu.Lock()
+		r.lag[p] = lag
+		r.mu.Unlock()
+	}
+	return ctx
+}
+
+func (r *Reader) destinationAttrs(topic string) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		semconv.MessagingSystem("kafka"),
+		semconv.MessagingDestinationName(topic),
+	}
+	if group := r.Config().GroupID; group != "" {
+		attrs = append(attrs, semconv.MessagingKafkaConsumerGroup(group))
+	}
+	return attrs
+}
+
+func (r *Reader) partitionAttrs(p partition) []attribute.KeyValue {
+	return append(r.destinationAttrs(p.topic), semconv.MessagingKafkaDestinationPartition(p.id))
+}
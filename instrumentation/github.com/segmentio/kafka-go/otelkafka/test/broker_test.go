@@ -0,0 +1,170 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"bufio"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/protocol"
+	"github.com/segmentio/kafka-go/protocol/apiversions"
+	"github.com/segmentio/kafka-go/protocol/fetch"
+	"github.com/segmentio/kafka-go/protocol/listoffsets"
+	"github.com/segmentio/kafka-go/protocol/metadata"
+	"github.com/stretchr/testify/require"
+)
+
+// broker is a single node kafka broker serving the records of a single
+// partition of a topic. It implements just enough of the protocol for a
+// kafka.Reader to read the records.
+type broker struct {
+	topic   string
+	records []protocol.Record
+
+	ln   net.Listener
+	host string
+	port int32
+	wg   sync.WaitGroup
+}
+
+// newBroker starts a broker serving records as partition 0 of topic. The
+// records are assigned consecutive offsets starting at 0.
+func newBroker(t *testing.T, topic string, records ...protocol.Record) *broker {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	host, port, err := net.SplitHostPort(ln.Addr().String())
+	require.NoError(t, err)
+	p, err := strconv.ParseInt(port, 10, 32)
+	require.NoError(t, err)
+
+	b := &broker{topic: topic, records: records, ln: ln, host: host, port: int32(p)}
+	b.wg.Add(1)
+	go b.serve()
+	t.Cleanup(b.close)
+	return b
+}
+
+func (b *broker) addr() string {
+	return b.ln.Addr().String()
+}
+
+func (b *broker) close() {
+	_ = b.ln.Close()
+	b.wg.Wait()
+}
+
+func (b *broker) serve() {
+	defer b.wg.Done()
+
+	var conns sync.WaitGroup
+	defer conns.Wait()
+	for {
+		conn, err := b.ln.Accept()
+		if err != nil {
+			return
+		}
+		conns.Add(1)
+		go func() {
+			defer conns.Done()
+			defer conn.Close()
+			b.handle(conn)
+		}()
+	}
+}
+
+func (b *broker) handle(conn net.Conn) {
+	r := bufio.NewReader(conn)
+	for {
+		version, id, _, req, err := protocol.ReadRequest(r)
+		if err != nil {
+			return
+		}
+
+		var resp protocol.Message
+		switch req := req.(type) {
+		case *apiversions.Request:
+			resp = &apiversions.Response{ApiKeys: []apiversions.ApiKeyResponse{
+				{ApiKey: int16(protocol.ApiVersions), MinVersion: 0, MaxVersion: 0},
+				{ApiKey: int16(protocol.Metadata), MinVersion: 1, MaxVersion: 1},
+				{ApiKey: int16(protocol.ListOffsets), MinVersion: 1, MaxVersion: 1},
+				{ApiKey: int16(protocol.Fetch), MinVersion: 2, MaxVersion: 5},
+			}}
+		case *metadata.Request:
+			resp = &metadata.Response{
+				Brokers: []metadata.ResponseBroker{{NodeID: 1, Host: b.host, Port: b.port}},
+				Topics: []metadata.ResponseTopic{{
+					Name: b.topic,
+					Partitions: []metadata.ResponsePartition{{
+						LeaderID:     1,
+						ReplicaNodes: []int32{1},
+						IsrNodes:     []int32{1},
+					}},
+				}},
+			}
+		case *listoffsets.Request:
+			offset := b.hwm()
+			if req.Topics[0].Partitions[0].Timestamp == kafka.FirstOffset {
+				offset = 0
+			}
+			resp = &listoffsets.Response{Topics: []listoffsets.ResponseTopic{{
+				Topic:      b.topic,
+				Partitions: []listoffsets.ResponsePartition{{Timestamp: -1, Offset: offset}},
+			}}}
+		case *fetch.Request:
+			resp = b.fetch(req)
+		default:
+			return
+		}
+
+		if err := protocol.WriteResponse(conn, version, id, resp); err != nil {
+			return
+		}
+	}
+}
+
+func (b *broker) hwm() int64 {
+	return int64(len(b.records))
+}
+
+func (b *broker) fetch(req *fetch.Request) *fetch.Response {
+	offset := req.Topics[0].Partitions[0].FetchOffset
+	var records []protocol.Record
+	if offset < b.hwm() {
+		// Record batches start at offset 0, the reader skips the records
+		// before the fetched offset.
+		records = b.records
+	} else {
+		// Make the reader wait for records that will never be produced, but
+		// not longer than it takes for the test to close it.
+		time.Sleep(10 * time.Millisecond)
+	}
+	return &fetch.Response{Topics: []fetch.ResponseTopic{{
+		Topic: b.topic,
+		Partitions: []fetch.ResponsePartition{{
+			HighWatermark:    b.hwm(),
+			LastStableOffset: b.hwm(),
+			RecordSet: protocol.RecordSet{
+				Version: 2,
+				Records: protocol.NewRecordReader(records...),
+			},
+		}},
+	}}}
+}
@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/instrumentation/github.com/segmentio/kafka-go/otelkafka"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func collect(t *testing.T, reader sdkmetric.Reader) map[string]metricdata.Metrics {
+	t.Helper()
+	rm := metricdata.ResourceMetrics{}
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	metrics := map[string]metricdata.Metrics{}
+	if len(rm.ScopeMetrics) == 0 {
+		return metrics
+	}
+	require.Len(t, rm.ScopeMetrics, 1)
+	sm := rm.ScopeMetrics[0]
+	assert.Equal(t, "go.opentelemetry.io/contrib/instrumentation/github.com/segmentio/kafka-go/otelkafka", sm.Scope.Name)
+	assert.Equal(t, otelkafka.Version(), sm.Scope.Version)
+	for _, m := range sm.Metrics {
+		metrics[m.Name] = m
+	}
+	return metrics
+}
+
+func newReader(t *testing.T, b *broker, opts ...otelkafka.Option) *otelkafka.Reader {
+	t.Helper()
+	return otelkafka.NewReader(kafka.NewReader(kafka.ReaderConfig{
+		Brokers: []string{b.addr()},
+		Topic:   b.topic,
+		MaxWait: 10 * time.Millisecond,
+	}), opts...)
+}
+
+func TestReaderSpans(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	prop := propagation.TraceContext{}
+
+	// Propagate the context of a producer span in the headers of the first
+	// record, the way an otelkafka.Writer does.
+	ctx, producer := tp.Tracer("test").Start(context.Background(), "orders publish")
+	producer.End()
+	msg := kafka.Message{}
+	prop.Inject(ctx, otelkafka.NewMessageCarrier(&msg))
+	headers := make([]protocol.Header, len(msg.Headers))
+	for i, h := range msg.Headers {
+		headers[i] = protocol.Header{Key: h.Key, Value: h.Value}
+	}
+
+	b := newBroker(t, "orders",
+		protocol.Record{Key: protocol.NewBytes([]byte("k1")), Value: protocol.NewBytes([]byte("v1")), Headers: headers},
+		protocol.Record{Value: protocol.NewBytes([]byte("v2"))},
+		protocol.Record{Key: protocol.NewBytes([]byte("k3"))},
+	)
+	r := newReader(t, b, otelkafka.WithTracerProvider(tp), otelkafka.WithPropagators(prop))
+	defer r.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	for i := 0; i < 3; i++ {
+		msgCtx, _, err := r.ReadMessage(ctx)
+		require.NoError(t, err)
+		assert.True(t, trace.SpanContextFromContext(msgCtx).IsValid())
+	}
+
+	spans := sr.Ended()
+	require.Len(t, spans, 4)
+	for i, span := range spans[1:] {
+		assert.Equal(t, "orders receive", span.Name())
+		assert.Equal(t, trace.SpanKindConsumer, span.SpanKind())
+		assert.Contains(t, span.Attributes(), semconv.MessagingSystem("kafka"))
+		assert.Contains(t, span.Attributes(), semconv.MessagingOperationReceive)
+		assert.Contains(t, span.Attributes(), semconv.MessagingDestinationName("orders"))
+		assert.Contains(t, span.Attributes(), semconv.MessagingKafkaDestinationPartition(0))
+		assert.Contains(t, span.Attributes(), semconv.MessagingKafkaMessageOffset(i))
+	}
+
+	// The span of the first record is a child of the producer span.
+	assert.Equal(t, producer.SpanContext().TraceID(), spans[1].SpanContext().TraceID())
+	assert.Equal(t, producer.SpanContext().SpanID(), spans[1].Parent().SpanID())
+	assert.Contains(t, spans[1].Attributes(), semconv.MessagingKafkaMessageKey("k1"))
+	assert.False(t, spans[2].Parent().IsValid())
+	assert.NotContains(t, spans[2].Attributes(), semconv.MessagingKafkaMessageTombstone(true))
+	assert.Contains(t, spans[3].Attributes(), semconv.MessagingKafkaMessageTombstone(true))
+}
+
+func TestReaderConsumerLag(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	b := newBroker(t, "orders",
+		protocol.Record{Value: protocol.NewBytes([]byte("v1"))},
+		protocol.Record{Value: protocol.NewBytes([]byte("v2"))},
+		protocol.Record{Value: protocol.NewBytes([]byte("v3"))},
+	)
+	r := newReader(t, b, otelkafka.WithMeterProvider(mp))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, _, err := r.ReadMessage(ctx)
+	require.NoError(t, err)
+
+	metrics := collect(t, reader)
+	require.Contains(t, metrics, "messaging.kafka.consumer.lag")
+	m := metrics["messaging.kafka.consumer.lag"]
+	assert.Equal(t, "{message}", m.Unit)
+	require.IsType(t, metricdata.Gauge[int64]{}, m.Data)
+	points := m.Data.(metricdata.Gauge[int64]).DataPoints
+	require.Len(t, points, 1)
+	assert.Equal(t, int64(2), points[0].Value)
+	assert.Equal(t, attribute.NewSet(
+		semconv.MessagingSystem("kafka"),
+		semconv.MessagingDestinationName("orders"),
+		semconv.MessagingKafkaDestinationPartition(0),
+	), points[0].Attributes)
+
+	require.Contains(t, metrics, "messaging.receive.messages")
+	require.IsType(t, metricdata.Sum[int64]{}, metrics["messaging.receive.messages"].Data)
+	sum := metrics["messaging.receive.messages"].Data.(metricdata.Sum[int64])
+	require.Len(t, sum.DataPoints, 1)
+	assert.Equal(t, int64(1), sum.DataPoints[0].Value)
+
+	// The lag is no longer reported once the reader is closed.
+	require.NoError(t, r.Close())
+	assert.NotContains(t, collect(t, reader), "messaging.kafka.consumer.lag")
+}
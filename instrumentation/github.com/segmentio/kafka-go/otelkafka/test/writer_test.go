@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/instrumentation/github.com/segmentio/kafka-go/otelkafka"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestWriterSpans(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "parent")
+
+	// A writer without an address fails every write, which is enough to
+	// validate the spans without a broker.
+	w := otelkafka.NewWriter(&kafka.Writer{Topic: "orders"},
+		otelkafka.WithTracerProvider(tp),
+		otelkafka.WithPropagators(propagation.TraceContext{}),
+	)
+	msgs := []kafka.Message{
+		{Key: []byte("k1"), Value: []byte("v1")},
+		{Topic: "audit", Value: []byte("v2"), Headers: []kafka.Header{{Key: "foo", Value: []byte("bar")}}},
+	}
+	require.Error(t, w.WriteMessages(ctx, msgs...))
+	parent.End()
+
+	// The caller's messages are not modified.
+	assert.Nil(t, msgs[0].Headers)
+	assert.Len(t, msgs[1].Headers, 1)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 3)
+	for i, want := range []string{"orders publish", "audit publish"} {
+		span := spans[i]
+		assert.Equal(t, want, span.Name())
+		assert.Equal(t, trace.SpanKindProducer, span.SpanKind())
+		assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+		assert.Equal(t, codes.Error, span.Status().Code)
+		assert.Contains(t, span.Attributes(), semconv.MessagingSystem("kafka"))
+		assert.Contains(t, span.Attributes(), semconv.MessagingOperationPublish)
+	}
+	assert.Contains(t, spans[0].Attributes(), semconv.MessagingDestinationName("orders"))
+	assert.Contains(t, spans[0].Attributes(), semconv.MessagingKafkaMessageKey("k1"))
+	assert.Contains(t, spans[1].Attributes(), semconv.MessagingDestinationName("audit"))
+}
@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelkafka // import "go.opentelemetry.io/contrib/instrumentation/github.com/segmentio/kafka-go/otelkafka"
+
+import (
+	"context"
+	"errors"
+
+	"github.com/segmentio/kafka-go"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Writer is a kafka.Writer whose writes are traced.
+type Writer struct {
+	*kafka.Writer
+
+	cfg *config
+}
+
+// NewWriter returns a Writer tracing the messages written with w.
+func NewWriter(w *kafka.Writer, opts ...Option) *Writer {
+	return &Writer{
+		Writer: w,
+		cfg:    newConfig(opts),
+	}
+}
+
+// WriteMessages writes msgs with the wrapped kafka.Writer. A producer span,
+// child of the span in ctx, is recorded for each message and its context is
+// injected into the headers of the message written. The messages passed by
+// the caller are not modified.
+//
+// When the wrapped writer is asynchronous the spans end as soon as the
+// messages are queued.
+func (w *Writer) WriteMessages(ctx context.Context, msgs ...kafka.Message) error {
+	spans := make([]trace.Span, len(msgs))
+	topics := make([]string, len(msgs))
+	out := make([]kafka.Message, len(msgs))
+	for i, msg := range msgs {
+		topic := msg.Topic
+		if topic == "" {
+			topic = w.Topic
+		}
+		topics[i] = topic
+
+		attrs := []attribute.KeyValue{
+			semconv.MessagingSystem("kafka"),
+			semconv.MessagingDestinationName(topic),
+			semconv.MessagingOperationPublish,
+			semconv.MessagingMessagePayloadSizeBytes(len(msg.Value)),
+		}
+		if msg.Key != nil {
+			attrs = append(attrs, semconv.MessagingKafkaMessageKey(string(msg.Key)))
+		}
+		if msg.Value == nil {
+			attrs = append(attrs, semconv.MessagingKafkaMessageTombstone(true))
+		}
+
+		var spanCtx context.Context
+		spanCtx, spans[i] = w.cfg.tracer.Start(ctx, topic+" publish",
+			trace.WithSpanKind(trace.SpanKindProducer),
+			trace.WithAttributes(attrs...),
+		)
+
+		// Copy the headers so the caller's message is left untouched.
+		msg.Headers = append(make([]kafka.Header, 0, len(msg.Headers)+1), msg.Headers...)
+		w.cfg.Propagators.Inject(spanCtx, NewMessageCarrier(&msg))
+		out[i] = msg
+	}
+
+	err := w.Writer.WriteMessages(ctx, out...)
+
+	var writeErrs kafka.WriteErrors
+	perMessage := errors.As(err, &writeErrs) && len(writeErrs) == len(spans)
+	published := make(map[string]int64)
+	for i, span := range spans {
+		msgErr := err
+		if perMessage {
+			msgErr = writeErrs[i]
+		}
+		if msgErr != nil {
+			span.RecordError(msgErr)
+			span.SetStatus(codes.Error, msgErr.Error())
+		} else {
+			published[topics[i]]++
+		}
+		span.End()
+	}
+	for topic, n := range published {
+		w.cfg.publishMessages.Add(ctx, n, metric.WithAttributes(
+			semconv.MessagingSystem("kafka"),
+			semconv.MessagingDestinationName(topic),
+		))
+	}
+	return err
+}
@@ -16,6 +16,7 @@ package otelmongo // import "go.opentelemetry.io/contrib/instrumentation/go.mong
 
 import (
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -27,14 +28,27 @@ type config struct {
 
 	Tracer trace.Tracer
 
+	MeterProvider metric.MeterProvider
+
+	Meter metric.Meter
+
 	CommandAttributeDisabled bool
+
+	CommandSanitizer CommandSanitizer
+
+	MaxStatementLength int
+
+	CursorSpanLinks bool
 }
 
 // newConfig returns a config with all Options set.
 func newConfig(opts ...Option) config {
 	cfg := config{
 		TracerProvider:           otel.GetTracerProvider(),
+		MeterProvider:            otel.GetMeterProvider(),
 		CommandAttributeDisabled: true,
+		CommandSanitizer:         RedactCommand,
+		MaxStatementLength:       DefaultMaxStatementLength,
 	}
 	for _, opt := range opts {
 		opt.apply(&cfg)
@@ -44,6 +58,10 @@ func newConfig(opts ...Option) config {
 		defaultTracerName,
 		trace.WithInstrumentationVersion(Version()),
 	)
+	cfg.Meter = cfg.MeterProvider.Meter(
+		defaultTracerName,
+		metric.WithInstrumentationVersion(Version()),
+	)
 	return cfg
 }
 
@@ -68,6 +86,16 @@ func WithTracerProvider(provider trace.TracerProvider) Option {
 	})
 }
 
+// WithMeterProvider specifies a meter provider to use for creating a meter.
+// If none is specified, the global provider is used.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return optionFunc(func(cfg *config) {
+		if provider != nil {
+			cfg.MeterProvider = provider
+		}
+	})
+}
+
 // WithCommandAttributeDisabled specifies if the MongoDB command is added as an attribute to Spans or not.
 // This is disabled by default and the MongoDB command will not be added as an attribute
 // to Spans if this option is not provided.
@@ -76,3 +104,38 @@ func WithCommandAttributeDisabled(disabled bool) Option {
 		cfg.CommandAttributeDisabled = disabled
 	})
 }
+
+// WithCommandSanitizer specifies the CommandSanitizer returning the
+// db.statement attribute value of a MongoDB command when the attribute is
+// enabled with WithCommandAttributeDisabled(false).  If none is specified,
+// RedactCommand is used and the values of the command are not reported.  Use
+// MarshalCommand to report the command including its values.
+func WithCommandSanitizer(sanitizer CommandSanitizer) Option {
+	return optionFunc(func(cfg *config) {
+		if sanitizer != nil {
+			cfg.CommandSanitizer = sanitizer
+		}
+	})
+}
+
+// WithMaxStatementLength specifies the maximum length, in bytes, of the
+// db.statement attribute when it is enabled with
+// WithCommandAttributeDisabled(false).  Longer statements are truncated.  If none is
+// specified, DefaultMaxStatementLength is used.  A length lower than or
+// equal to 0 means no limit.
+func WithMaxStatementLength(length int) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.MaxStatementLength = length
+	})
+}
+
+// WithCursorSpanLinks specifies if the getMore and killCursors commands of a
+// cursor are traced as children of the span of their caller, linked to the
+// span of the command that opened the cursor, instead of as children of that
+// span. Links are better suited to long lived cursors, like change streams,
+// which would otherwise produce very large traces.
+func WithCursorSpanLinks(enabled bool) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.CursorSpanLinks = enabled
+	})
+}
@@ -0,0 +1,180 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelmongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	testConnectionID = "localhost:27017[-1]"
+	testCursorID     = int64(42)
+)
+
+func newCursorMonitor(opts ...Option) (*monitor, *tracetest.SpanRecorder, *float64HistogramRecorder) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	duration := &float64HistogramRecorder{}
+	m := &monitor{
+		spans:          make(map[spanKey]commandSpan),
+		cursors:        make(map[cursorKey]cursor),
+		cfg:            newConfig(append(opts, WithTracerProvider(provider))...),
+		cursorDuration: duration,
+	}
+	return m, sr, duration
+}
+
+func runCommand(t *testing.T, ctx context.Context, m *monitor, requestID int64, cmd, reply bson.D) {
+	t.Helper()
+	command, err := bson.Marshal(cmd)
+	require.NoError(t, err)
+	m.Started(ctx, &event.CommandStartedEvent{
+		Command:      command,
+		DatabaseName: "test-database",
+		CommandName:  cmd[0].Key,
+		RequestID:    requestID,
+		ConnectionID: testConnectionID,
+	})
+	finished := event.CommandFinishedEvent{
+		CommandName:  cmd[0].Key,
+		RequestID:    requestID,
+		ConnectionID: testConnectionID,
+	}
+	if reply == nil {
+		m.Failed(ctx, &event.CommandFailedEvent{CommandFinishedEvent: finished, Failure: "CursorNotFound"})
+		return
+	}
+	raw, err := bson.Marshal(reply)
+	require.NoError(t, err)
+	m.Succeeded(ctx, &event.CommandSucceededEvent{CommandFinishedEvent: finished, Reply: raw})
+}
+
+func cursorReply(id int64) bson.D {
+	return bson.D{{Key: "cursor", Value: bson.D{{Key: "id", Value: id}}}, {Key: "ok", Value: 1}}
+}
+
+func TestCursorSpans(t *testing.T) {
+	m, sr, duration := newCursorMonitor()
+	ctx := context.Background()
+
+	runCommand(t, ctx, m, 1, bson.D{{Key: "find", Value: "test-collection"}}, cursorReply(testCursorID))
+	runCommand(t, ctx, m, 2, bson.D{{Key: "getMore", Value: testCursorID}, {Key: "collection", Value: "test-collection"}}, cursorReply(testCursorID))
+	assert.Empty(t, duration.values, "cursor is still open")
+	runCommand(t, ctx, m, 3, bson.D{{Key: "getMore", Value: testCursorID}, {Key: "collection", Value: "test-collection"}}, cursorReply(0))
+
+	spans := sr.Ended()
+	require.Len(t, spans, 3)
+	find := spans[0]
+	assert.Equal(t, "test-collection.find", find.Name())
+	for _, s := range spans[1:] {
+		assert.Equal(t, "getMore", s.Name())
+		assert.Equal(t, find.SpanContext().SpanID(), s.Parent().SpanID())
+		assert.Equal(t, find.SpanContext().TraceID(), s.SpanContext().TraceID())
+		assert.Contains(t, s.Attributes(), cursorIDKey.Int64(testCursorID))
+	}
+	assert.Len(t, duration.values, 1)
+	assert.Empty(t, m.cursors)
+}
+
+func TestCursorSpanLinks(t *testing.T) {
+	m, sr, duration := newCursorMonitor(WithCursorSpanLinks(true))
+	ctx, parent := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "caller")
+	defer parent.End()
+
+	runCommand(t, context.Background(), m, 1, bson.D{{Key: "aggregate", Value: "test-collection"}}, cursorReply(testCursorID))
+	runCommand(t, ctx, m, 2, bson.D{{Key: "getMore", Value: testCursorID}, {Key: "collection", Value: "test-collection"}}, nil)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 2)
+	aggregate, getMore := spans[0], spans[1]
+	assert.Equal(t, parent.SpanContext().SpanID(), getMore.Parent().SpanID())
+	require.Len(t, getMore.Links(), 1)
+	assert.Equal(t, aggregate.SpanContext(), getMore.Links()[0].SpanContext)
+	assert.Len(t, duration.values, 1, "failed getMore closes the cursor")
+	assert.Empty(t, m.cursors)
+}
+
+func TestKillCursors(t *testing.T) {
+	m, sr, duration := newCursorMonitor()
+	ctx := context.Background()
+
+	runCommand(t, ctx, m, 1, bson.D{{Key: "find", Value: "test-collection"}}, cursorReply(testCursorID))
+	runCommand(t, ctx, m, 2, bson.D{{Key: "killCursors", Value: "test-collection"}, {Key: "cursors", Value: bson.A{testCursorID}}}, bson.D{{Key: "ok", Value: 1}})
+
+	spans := sr.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "test-collection.killCursors", spans[1].Name())
+	assert.Equal(t, spans[0].SpanContext().SpanID(), spans[1].Parent().SpanID())
+	assert.Len(t, duration.values, 1)
+	assert.Empty(t, m.cursors)
+}
+
+func TestExhaustedCursorNotTracked(t *testing.T) {
+	m, sr, duration := newCursorMonitor()
+	runCommand(t, context.Background(), m, 1, bson.D{{Key: "find", Value: "test-collection"}}, cursorReply(0))
+
+	require.Len(t, sr.Ended(), 1)
+	assert.Equal(t, trace.SpanKindClient, sr.Ended()[0].SpanKind())
+	assert.Empty(t, duration.values)
+	assert.Empty(t, m.cursors)
+}
+
+func TestIdleCursorsEvicted(t *testing.T) {
+	m, sr, duration := newCursorMonitor()
+	ctx := context.Background()
+	idle := cursorKey{Address: "localhost:27017", CursorID: testCursorID}
+	used := cursorKey{Address: "localhost:27017", CursorID: testCursorID + 1}
+
+	runCommand(t, ctx, m, 1, bson.D{{Key: "find", Value: "test-collection"}}, cursorReply(idle.CursorID))
+	runCommand(t, ctx, m, 2, bson.D{{Key: "find", Value: "test-collection"}}, cursorReply(used.CursorID))
+	require.Len(t, m.cursors, 2)
+
+	// Both cursors were opened more than cursorIdleTimeout ago, but only the
+	// idle one was not iterated since.
+	past := time.Now().Add(-2 * cursorIdleTimeout)
+	for key, c := range m.cursors {
+		c.lastUsed = past
+		m.cursors[key] = c
+	}
+	runCommand(t, ctx, m, 3, bson.D{{Key: "getMore", Value: used.CursorID}, {Key: "collection", Value: "test-collection"}}, cursorReply(used.CursorID))
+
+	// Evictions happen at most once per cursorIdleTimeout.
+	runCommand(t, ctx, m, 4, bson.D{{Key: "find", Value: "other-collection"}}, cursorReply(testCursorID+2))
+	assert.Contains(t, m.cursors, idle)
+
+	m.lastEviction = past
+	runCommand(t, ctx, m, 5, bson.D{{Key: "find", Value: "other-collection"}}, cursorReply(testCursorID+3))
+	assert.NotContains(t, m.cursors, idle)
+	assert.Contains(t, m.cursors, used)
+	assert.Len(t, m.cursors, 3)
+
+	// Commands on an evicted cursor are not parented to the command that
+	// opened it, and its lifetime is not recorded.
+	runCommand(t, ctx, m, 6, bson.D{{Key: "getMore", Value: idle.CursorID}, {Key: "collection", Value: "test-collection"}}, cursorReply(0))
+	spans := sr.Ended()
+	assert.False(t, spans[len(spans)-1].Parent().IsValid())
+	assert.Empty(t, duration.values)
+}
@@ -20,6 +20,9 @@
 // `NewMonitor` will return an event.CommandMonitor which is used to trace
 // requests.
 //
+// `NewPoolMonitor` will return an event.PoolMonitor which is used to record
+// metrics of the connection pools.
+//
 // This code was originally based on the following:
 // - https://github.com/DataDog/dd-trace-go/tree/02f0449efa3cb382d499fadc873957385dcb2192/contrib/go.mongodb.org/mongo-driver/mongo
 // - https://github.com/DataDog/dd-trace-go/tree/v1.23.3/ddtrace/ext
@@ -20,9 +20,12 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
 	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
 	"go.opentelemetry.io/otel/trace"
 
@@ -30,15 +33,58 @@ import (
 	"go.mongodb.org/mongo-driver/event"
 )
 
+// cursorDuration is the name of the histogram of the lifetime of cursors.
+const cursorDuration = "db.mongodb.cursor.duration" // Float64Histogram
+
+// cursorIDKey is the attribute key of the cursor iterated by a getMore command.
+const cursorIDKey = attribute.Key("db.mongodb.cursor_id")
+
+// cursorIdleTimeout is the time after which the server closes a cursor that
+// is not iterated, the default of the cursorTimeoutMillis server parameter.
+// Cursors idle for longer are no longer tracked.
+const cursorIdleTimeout = 10 * time.Minute
+
 type spanKey struct {
 	ConnectionID string
 	RequestID    int64
 }
 
+// cursorKey identifies a cursor opened on a server.
+type cursorKey struct {
+	Address  string
+	CursorID int64
+}
+
+// commandSpan is the span of a command in flight.
+type commandSpan struct {
+	span    trace.Span
+	start   time.Time
+	address string
+	// attrs are the attributes of the cursor metrics.
+	attrs []attribute.KeyValue
+	// cursors holds the cursors iterated or killed by the command.
+	cursors []int64
+}
+
+// cursor is a cursor opened by a find or aggregate command.
+type cursor struct {
+	// spanContext is the span context of the command that opened the cursor.
+	spanContext trace.SpanContext
+	start       time.Time
+	attrs       []attribute.KeyValue
+	// lastUsed is the time the cursor was opened or last iterated.
+	lastUsed time.Time
+}
+
 type monitor struct {
 	sync.Mutex
-	spans map[spanKey]trace.Span
-	cfg   config
+	spans   map[spanKey]commandSpan
+	cursors map[cursorKey]cursor
+	// lastEviction is the time idle cursors were last evicted.
+	lastEviction time.Time
+	cfg          config
+
+	cursorDuration metric.Float64Histogram
 }
 
 func (m *monitor) Started(ctx context.Context, evt *event.CommandStartedEvent) {
@@ -55,44 +101,74 @@ func (m *monitor) Started(ctx context.Context, evt *event.CommandStartedEvent) {
 		semconv.NetTransportTCP,
 	}
 	if !m.cfg.CommandAttributeDisabled {
-		attrs = append(attrs, semconv.DBStatement(sanitizeCommand(evt.Command)))
+		statement := m.cfg.CommandSanitizer(evt.Command)
+		attrs = append(attrs, semconv.DBStatement(truncateStatement(statement, m.cfg.MaxStatementLength)))
 	}
+	metricAttrs := []attribute.KeyValue{semconv.DBSystemMongoDB, semconv.DBName(evt.DatabaseName)}
 	if collection, err := extractCollection(evt); err == nil && collection != "" {
 		spanName = collection + "."
 		attrs = append(attrs, semconv.DBMongoDBCollection(collection))
+		metricAttrs = append(metricAttrs, semconv.DBMongoDBCollection(collection))
 	}
 	spanName += evt.CommandName
+	cursorIDs := commandCursors(evt)
+	if evt.CommandName == "getMore" && len(cursorIDs) == 1 {
+		attrs = append(attrs, cursorIDKey.Int64(cursorIDs[0]))
+	}
 	opts := []trace.SpanStartOption{
 		trace.WithSpanKind(trace.SpanKindClient),
 		trace.WithAttributes(attrs...),
 	}
-	_, span := m.cfg.Tracer.Start(ctx, spanName, opts...)
+	address := hostname + ":" + strconv.Itoa(port)
 	key := spanKey{
 		ConnectionID: evt.ConnectionID,
 		RequestID:    evt.RequestID,
 	}
+
 	m.Lock()
-	m.spans[key] = span
-	m.Unlock()
+	defer m.Unlock()
+	// Commands iterating or killing a cursor are traced as part of the
+	// command that opened it.
+	for _, id := range cursorIDs {
+		c, ok := m.cursors[cursorKey{Address: address, CursorID: id}]
+		if !ok {
+			continue
+		}
+		if m.cfg.CursorSpanLinks {
+			opts = append(opts, trace.WithLinks(trace.Link{SpanContext: c.spanContext}))
+		} else {
+			ctx = trace.ContextWithSpanContext(ctx, c.spanContext)
+			break
+		}
+	}
+	_, span := m.cfg.Tracer.Start(ctx, spanName, opts...)
+	m.spans[key] = commandSpan{
+		span:    span,
+		start:   time.Now(),
+		address: address,
+		attrs:   metricAttrs,
+		cursors: cursorIDs,
+	}
 }
 
 func (m *monitor) Succeeded(ctx context.Context, evt *event.CommandSucceededEvent) {
-	m.Finished(&evt.CommandFinishedEvent, nil)
+	m.Finished(&evt.CommandFinishedEvent, evt.Reply, nil)
 }
 
 func (m *monitor) Failed(ctx context.Context, evt *event.CommandFailedEvent) {
-	m.Finished(&evt.CommandFinishedEvent, fmt.Errorf("%s", evt.Failure))
+	m.Finished(&evt.CommandFinishedEvent, nil, fmt.Errorf("%s", evt.Failure))
 }
 
-func (m *monitor) Finished(evt *event.CommandFinishedEvent, err error) {
+func (m *monitor) Finished(evt *event.CommandFinishedEvent, reply bson.Raw, err error) {
 	key := spanKey{
 		ConnectionID: evt.ConnectionID,
 		RequestID:    evt.RequestID,
 	}
 	m.Lock()
-	span, ok := m.spans[key]
+	cs, ok := m.spans[key]
 	if ok {
 		delete(m.spans, key)
+		m.trackCursors(evt.CommandName, cs, reply, err)
 	}
 	m.Unlock()
 	if !ok {
@@ -100,17 +176,120 @@ func (m *monitor) Finished(evt *event.CommandFinishedEvent, err error) {
 	}
 
 	if err != nil {
-		span.SetStatus(codes.Error, err.Error())
+		cs.span.SetStatus(codes.Error, err.Error())
+	}
+
+	cs.span.End()
+}
+
+// trackCursors records the cursor opened by a find or aggregate command, and
+// the lifetime of the cursors exhausted or killed by the command. It must be
+// called with m locked.
+func (m *monitor) trackCursors(commandName string, cs commandSpan, reply bson.Raw, err error) {
+	switch commandName {
+	case "find", "aggregate":
+		if err != nil {
+			return
+		}
+		if id := replyCursor(reply); id != 0 {
+			m.evictIdleCursors()
+			m.cursors[cursorKey{Address: cs.address, CursorID: id}] = cursor{
+				spanContext: cs.span.SpanContext(),
+				start:       cs.start,
+				attrs:       cs.attrs,
+				lastUsed:    time.Now(),
+			}
+		}
+	case "getMore":
+		// A failed getMore closes the cursor on the server.
+		if err == nil && replyCursor(reply) != 0 {
+			for _, id := range cs.cursors {
+				key := cursorKey{Address: cs.address, CursorID: id}
+				if c, ok := m.cursors[key]; ok {
+					c.lastUsed = time.Now()
+					m.cursors[key] = c
+				}
+			}
+			return
+		}
+		m.closeCursors(cs)
+	case "killCursors":
+		m.closeCursors(cs)
+	}
+}
+
+// closeCursors records the lifetime of the cursors of cs and stops tracking
+// them. It must be called with m locked.
+func (m *monitor) closeCursors(cs commandSpan) {
+	for _, id := range cs.cursors {
+		key := cursorKey{Address: cs.address, CursorID: id}
+		c, ok := m.cursors[key]
+		if !ok {
+			continue
+		}
+		delete(m.cursors, key)
+		elapsed := time.Since(c.start).Seconds()
+		m.cursorDuration.Record(context.Background(), elapsed, metric.WithAttributes(c.attrs...))
+	}
+}
+
+// evictIdleCursors stops tracking the cursors that were not used for longer
+// than cursorIdleTimeout, they were closed by the server or abandoned. Their
+// lifetime is unknown and not recorded. Evictions are at most once per
+// cursorIdleTimeout. It must be called with m locked.
+func (m *monitor) evictIdleCursors() {
+	now := time.Now()
+	if now.Sub(m.lastEviction) < cursorIdleTimeout {
+		return
+	}
+	m.lastEviction = now
+	for key, c := range m.cursors {
+		if now.Sub(c.lastUsed) >= cursorIdleTimeout {
+			delete(m.cursors, key)
+		}
 	}
+}
 
-	span.End()
+// commandCursors returns the cursors iterated by a getMore command or killed
+// by a killCursors command.
+func commandCursors(evt *event.CommandStartedEvent) []int64 {
+	switch evt.CommandName {
+	case "getMore":
+		if id, ok := evt.Command.Lookup("getMore").AsInt64OK(); ok {
+			return []int64{id}
+		}
+	case "killCursors":
+		cursors, ok := evt.Command.Lookup("cursors").ArrayOK()
+		if !ok {
+			return nil
+		}
+		values, err := cursors.Values()
+		if err != nil {
+			return nil
+		}
+		var ids []int64
+		for _, v := range values {
+			if id, ok := v.AsInt64OK(); ok {
+				ids = append(ids, id)
+			}
+		}
+		return ids
+	}
+	return nil
 }
 
-// TODO sanitize values where possible, then reenable `db.statement` span attributes default.
-// TODO limit maximum size.
-func sanitizeCommand(command bson.Raw) string {
-	b, _ := bson.MarshalExtJSON(command, false, false)
-	return string(b)
+// replyCursor returns the id of the cursor of a find, aggregate or getMore
+// command reply. The id is 0 when the cursor is exhausted.
+func replyCursor(reply bson.Raw) int64 {
+	if reply == nil {
+		return 0
+	}
+	v, err := reply.LookupErr("cursor", "id")
+	if err != nil {
+		return 0
+	}
+	id, _ := v.AsInt64OK()
+	return id
 }
 
 // extractCollection extracts the collection for the given mongodb command event.
@@ -133,11 +312,29 @@ func extractCollection(evt *event.CommandStartedEvent) (string, error) {
 }
 
 // NewMonitor creates a new mongodb event CommandMonitor.
+//
+// The getMore and killCursors commands of a cursor opened by a find or
+// aggregate command, for example of a change stream, are traced as children
+// of the span of the command that opened the cursor, or linked to it if
+// WithCursorSpanLinks is used. The time cursors are open, until they are
+// exhausted or killed, is recorded by the db.mongodb.cursor.duration metric.
+// Cursors not iterated for 10 minutes, the default idle timeout of the
+// server, are no longer tracked.
 func NewMonitor(opts ...Option) *event.CommandMonitor {
 	cfg := newConfig(opts...)
 	m := &monitor{
-		spans: make(map[spanKey]trace.Span),
-		cfg:   cfg,
+		spans:   make(map[spanKey]commandSpan),
+		cursors: make(map[cursorKey]cursor),
+		cfg:     cfg,
+	}
+	var err error
+	m.cursorDuration, err = cfg.Meter.Float64Histogram(
+		cursorDuration,
+		metric.WithUnit("s"),
+		metric.WithDescription("The time a cursor was open, from the command opening it until it was exhausted or killed."),
+	)
+	if err != nil {
+		otel.Handle(err)
 	}
 	return &event.CommandMonitor{
 		Started:   m.Started,
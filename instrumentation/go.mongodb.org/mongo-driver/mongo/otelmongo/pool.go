@@ -0,0 +1,209 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelmongo // import "go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/event"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Pool metric names.
+const (
+	connectionsUsage    = "db.client.connections.usage"     // Int64UpDownCounter
+	connectionsMax      = "db.client.connections.max"       // Int64UpDownCounter
+	connectionsWaitTime = "db.client.connections.wait_time" // Float64Histogram
+	connectionsTimeouts = "db.client.connections.timeouts"  // Int64Counter
+)
+
+// Pool metric attribute keys and values.
+const (
+	poolNameKey = attribute.Key("pool.name")
+	stateKey    = attribute.Key("state")
+	stateIdle   = "idle"
+	stateUsed   = "used"
+)
+
+type connKey struct {
+	Address      string
+	ConnectionID uint64
+}
+
+type poolMonitor struct {
+	sync.Mutex
+	// checkedOut holds the open connections, and whether they are checked
+	// out of their pool.
+	checkedOut map[connKey]bool
+	// checkouts holds the start time of the pending checkouts of each pool.
+	checkouts map[string][]time.Time
+	// maxSize holds the maximum size of each pool.
+	maxSize map[string]int64
+
+	usage    metric.Int64UpDownCounter
+	max      metric.Int64UpDownCounter
+	waitTime metric.Float64Histogram
+	timeouts metric.Int64Counter
+
+	now func() time.Time
+}
+
+// NewPoolMonitor creates a new mongodb event PoolMonitor recording the
+// following metrics of the connection pools:
+//
+//   - db.client.connections.usage: the number of idle and used connections.
+//   - db.client.connections.max: the maximum number of open connections.
+//   - db.client.connections.wait_time: the time it took to check out a
+//     connection.
+//   - db.client.connections.timeouts: the number of checkouts that timed out.
+//
+// The driver does not identify the checkout a connection is checked out for,
+// the wait time is therefore measured from the start of the oldest pending
+// checkout of the pool, which is accurate as long as checkouts complete in the
+// order they were started.
+func NewPoolMonitor(opts ...Option) *event.PoolMonitor {
+	cfg := newConfig(opts...)
+	m := &poolMonitor{
+		checkedOut: make(map[connKey]bool),
+		checkouts:  make(map[string][]time.Time),
+		maxSize:    make(map[string]int64),
+		now:        time.Now,
+	}
+
+	var err error
+	m.usage, err = cfg.Meter.Int64UpDownCounter(
+		connectionsUsage,
+		metric.WithUnit("{connection}"),
+		metric.WithDescription("The number of connections that are currently in the state described by the state attribute."),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+	m.max, err = cfg.Meter.Int64UpDownCounter(
+		connectionsMax,
+		metric.WithUnit("{connection}"),
+		metric.WithDescription("The maximum number of open connections allowed."),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+	m.waitTime, err = cfg.Meter.Float64Histogram(
+		connectionsWaitTime,
+		metric.WithUnit("ms"),
+		metric.WithDescription("The time it took to obtain an open connection from the pool."),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+	m.timeouts, err = cfg.Meter.Int64Counter(
+		connectionsTimeouts,
+		metric.WithUnit("{timeout}"),
+		metric.WithDescription("The number of connection timeouts that have occurred trying to obtain a connection from the pool."),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return &event.PoolMonitor{
+		Event: m.Event,
+	}
+}
+
+// Event records the metrics of the pool event evt.
+func (m *poolMonitor) Event(evt *event.PoolEvent) {
+	ctx := context.Background()
+	pool := poolNameKey.String(evt.Address)
+	key := connKey{Address: evt.Address, ConnectionID: evt.ConnectionID}
+
+	m.Lock()
+	defer m.Unlock()
+
+	switch evt.Type {
+	case event.PoolCreated:
+		if evt.PoolOptions != nil && evt.PoolOptions.MaxPoolSize > 0 {
+			size := int64(evt.PoolOptions.MaxPoolSize)
+			m.maxSize[evt.Address] = size
+			m.max.Add(ctx, size, metric.WithAttributes(pool))
+		}
+	case event.PoolClosedEvent:
+		if size, ok := m.maxSize[evt.Address]; ok {
+			delete(m.maxSize, evt.Address)
+			m.max.Add(ctx, -size, metric.WithAttributes(pool))
+		}
+		delete(m.checkouts, evt.Address)
+	case event.ConnectionCreated:
+		m.checkedOut[key] = false
+		m.usage.Add(ctx, 1, metric.WithAttributes(pool, connectionState(false)))
+	case event.ConnectionClosed:
+		used, ok := m.checkedOut[key]
+		if !ok {
+			return
+		}
+		delete(m.checkedOut, key)
+		m.usage.Add(ctx, -1, metric.WithAttributes(pool, connectionState(used)))
+	case event.GetStarted:
+		m.checkouts[evt.Address] = append(m.checkouts[evt.Address], m.now())
+	case event.GetSucceeded:
+		if start, ok := m.popCheckout(evt.Address); ok {
+			elapsed := float64(m.now().Sub(start)) / float64(time.Millisecond)
+			m.waitTime.Record(ctx, elapsed, metric.WithAttributes(pool))
+		}
+		if used, ok := m.checkedOut[key]; ok && !used {
+			m.checkedOut[key] = true
+			m.usage.Add(ctx, -1, metric.WithAttributes(pool, connectionState(false)))
+			m.usage.Add(ctx, 1, metric.WithAttributes(pool, connectionState(true)))
+		}
+	case event.GetFailed:
+		m.popCheckout(evt.Address)
+		if evt.Reason == event.ReasonTimedOut {
+			m.timeouts.Add(ctx, 1, metric.WithAttributes(pool))
+		}
+	case event.ConnectionReturned:
+		if used, ok := m.checkedOut[key]; ok && used {
+			m.checkedOut[key] = false
+			m.usage.Add(ctx, -1, metric.WithAttributes(pool, connectionState(true)))
+			m.usage.Add(ctx, 1, metric.WithAttributes(pool, connectionState(false)))
+		}
+	}
+}
+
+// popCheckout removes and returns the start time of the oldest pending
+// checkout of the pool at address.
+func (m *poolMonitor) popCheckout(address string) (time.Time, bool) {
+	pending := m.checkouts[address]
+	if len(pending) == 0 {
+		return time.Time{}, false
+	}
+	start := pending[0]
+	if len(pending) == 1 {
+		delete(m.checkouts, address)
+	} else {
+		m.checkouts[address] = pending[1:]
+	}
+	return start, true
+}
+
+// connectionState returns the state attribute of a connection.
+func connectionState(used bool) attribute.KeyValue {
+	if used {
+		return stateKey.String(stateUsed)
+	}
+	return stateKey.String(stateIdle)
+}
@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelmongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/event"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/embedded"
+)
+
+// int64Recorder sums the values added to an Int64UpDownCounter or
+// Int64Counter by attribute set.
+type int64Recorder struct {
+	embedded.Int64UpDownCounter
+	embedded.Int64Counter
+
+	sums map[attribute.Set]int64
+}
+
+func newInt64Recorder() *int64Recorder {
+	return &int64Recorder{sums: make(map[attribute.Set]int64)}
+}
+
+func (r *int64Recorder) Add(_ context.Context, v int64, opts ...metric.AddOption) {
+	r.sums[metric.NewAddConfig(opts).Attributes()] += v
+}
+
+func (r *int64Recorder) sum(attrs ...attribute.KeyValue) int64 {
+	return r.sums[attribute.NewSet(attrs...)]
+}
+
+type float64HistogramRecorder struct {
+	embedded.Float64Histogram
+
+	values []float64
+}
+
+func (r *float64HistogramRecorder) Record(_ context.Context, v float64, _ ...metric.RecordOption) {
+	r.values = append(r.values, v)
+}
+
+func TestPoolMonitor(t *testing.T) {
+	const addr = "localhost:27017"
+	usage, max, timeouts := newInt64Recorder(), newInt64Recorder(), newInt64Recorder()
+	waitTime := &float64HistogramRecorder{}
+	now := time.Unix(0, 0)
+	m := &poolMonitor{
+		checkedOut: make(map[connKey]bool),
+		checkouts:  make(map[string][]time.Time),
+		maxSize:    make(map[string]int64),
+		usage:      usage,
+		max:        max,
+		waitTime:   waitTime,
+		timeouts:   timeouts,
+		now:        func() time.Time { return now },
+	}
+	pool := poolNameKey.String(addr)
+	idle, used := connectionState(false), connectionState(true)
+
+	m.Event(&event.PoolEvent{
+		Type:        event.PoolCreated,
+		Address:     addr,
+		PoolOptions: &event.MonitorPoolOptions{MaxPoolSize: 10},
+	})
+	if got := max.sum(pool); got != 10 {
+		t.Errorf("max = %d, want 10", got)
+	}
+
+	m.Event(&event.PoolEvent{Type: event.GetStarted, Address: addr})
+	now = now.Add(5 * time.Millisecond)
+	m.Event(&event.PoolEvent{Type: event.ConnectionCreated, Address: addr, ConnectionID: 1})
+	m.Event(&event.PoolEvent{Type: event.GetSucceeded, Address: addr, ConnectionID: 1})
+	if got := usage.sum(pool, used); got != 1 {
+		t.Errorf("used = %d, want 1", got)
+	}
+	if got := usage.sum(pool, idle); got != 0 {
+		t.Errorf("idle = %d, want 0", got)
+	}
+	if len(waitTime.values) != 1 || waitTime.values[0] != 5 {
+		t.Errorf("wait time = %v, want [5]", waitTime.values)
+	}
+
+	m.Event(&event.PoolEvent{Type: event.GetStarted, Address: addr})
+	m.Event(&event.PoolEvent{Type: event.GetFailed, Address: addr, Reason: event.ReasonTimedOut})
+	if got := timeouts.sum(pool); got != 1 {
+		t.Errorf("timeouts = %d, want 1", got)
+	}
+	if len(m.checkouts) != 0 {
+		t.Errorf("pending checkouts = %v, want none", m.checkouts)
+	}
+
+	m.Event(&event.PoolEvent{Type: event.ConnectionReturned, Address: addr, ConnectionID: 1})
+	if got := usage.sum(pool, used); got != 0 {
+		t.Errorf("used = %d, want 0", got)
+	}
+	if got := usage.sum(pool, idle); got != 1 {
+		t.Errorf("idle = %d, want 1", got)
+	}
+
+	// A connection closed while checked out is not returned as idle.
+	m.Event(&event.PoolEvent{Type: event.GetStarted, Address: addr})
+	m.Event(&event.PoolEvent{Type: event.GetSucceeded, Address: addr, ConnectionID: 1})
+	m.Event(&event.PoolEvent{Type: event.ConnectionClosed, Address: addr, ConnectionID: 1})
+	m.Event(&event.PoolEvent{Type: event.ConnectionReturned, Address: addr, ConnectionID: 1})
+	if got := usage.sum(pool, used); got != 0 {
+		t.Errorf("used = %d, want 0", got)
+	}
+	if got := usage.sum(pool, idle); got != 0 {
+		t.Errorf("idle = %d, want 0", got)
+	}
+
+	m.Event(&event.PoolEvent{Type: event.PoolClosedEvent, Address: addr})
+	if got := max.sum(pool); got != 0 {
+		t.Errorf("max = %d, want 0", got)
+	}
+}
+
+func TestNewPoolMonitor(t *testing.T) {
+	m := NewPoolMonitor()
+	if m == nil || m.Event == nil {
+		t.Fatal("NewPoolMonitor returned no event handler")
+	}
+	m.Event(&event.PoolEvent{Type: event.GetStarted, Address: "localhost:27017"})
+}
@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelmongo // import "go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo"
+
+import (
+	"unicode/utf8"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+// DefaultMaxStatementLength is the default maximum length, in bytes, of the
+// db.statement attribute.
+const DefaultMaxStatementLength = 4096
+
+// redactedValue replaces the values of a command redacted by RedactCommand.
+const redactedValue = "?"
+
+// CommandSanitizer returns the db.statement attribute value of a MongoDB
+// command.
+type CommandSanitizer func(command bson.Raw) string
+
+// RedactCommand is a CommandSanitizer returning the command as Extended JSON
+// with every value replaced by "?", except for the name of the collection the
+// command applies to.  The shape of the command, i.e. its
+// field names and the nesting of its documents and arrays, is kept.
+func RedactCommand(command bson.Raw) string {
+	elems, err := command.Elements()
+	if err != nil {
+		return ""
+	}
+
+	doc := make(bson.D, 0, len(elems))
+	for i, e := range elems {
+		v := e.Value()
+		// The first element holds the command name and, for CRUD
+		// operations, the name of the collection.
+		if i == 0 && v.Type == bsontype.String {
+			doc = append(doc, bson.E{Key: e.Key(), Value: v.StringValue()})
+			continue
+		}
+		doc = append(doc, bson.E{Key: e.Key(), Value: redactValue(v)})
+	}
+
+	b, err := bson.MarshalExtJSON(doc, false, false)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// MarshalCommand is a CommandSanitizer returning the command as Extended JSON,
+// including its values.  Values may hold sensitive data; only use it with
+// WithCommandSanitizer when they can be recorded.
+func MarshalCommand(command bson.Raw) string {
+	b, _ := bson.MarshalExtJSON(command, false, false)
+	return string(b)
+}
+
+// redactValue returns v with every scalar value replaced by redactedValue.
+func redactValue(v bson.RawValue) interface{} {
+	switch v.Type {
+	case bsontype.EmbeddedDocument:
+		elems, err := v.Document().Elements()
+		if err != nil {
+			return redactedValue
+		}
+		doc := make(bson.D, len(elems))
+		for i, e := range elems {
+			doc[i] = bson.E{Key: e.Key(), Value: redactValue(e.Value())}
+		}
+		return doc
+	case bsontype.Array:
+		values, err := v.Array().Values()
+		if err != nil {
+			return redactedValue
+		}
+		arr := make(bson.A, len(values))
+		for i, value := range values {
+			arr[i] = redactValue(value)
+		}
+		return arr
+	}
+	return redactedValue
+}
+
+// truncateStatement returns statement truncated to at most max bytes without
+// splitting a UTF-8 encoded character.  A max lower than or equal to 0 means
+// no limit.
+func truncateStatement(statement string, max int) string {
+	if max <= 0 || len(statement) <= max {
+		return statement
+	}
+	for max > 0 && !utf8.RuneStart(statement[max]) {
+		max--
+	}
+	return statement[:max]
+}
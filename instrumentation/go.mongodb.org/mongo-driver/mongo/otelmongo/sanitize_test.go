@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelmongo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+)
+
+func TestRedactCommand(t *testing.T) {
+	command, err := bson.Marshal(bson.D{
+		{Key: "insert", Value: "inventory"},
+		{Key: "ordered", Value: true},
+		{Key: "documents", Value: bson.A{
+			bson.D{
+				{Key: "item", Value: "canvas"},
+				{Key: "qty", Value: 100},
+				{Key: "tags", Value: bson.A{"cotton", "blue"}},
+				{Key: "size", Value: bson.D{{Key: "h", Value: 28}}},
+			},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"insert":"inventory","ordered":"?","documents":[{"item":"?","qty":"?","tags":["?","?"],"size":{"h":"?"}}]}`
+	if got := RedactCommand(command); got != want {
+		t.Errorf("RedactCommand() = %s, want %s", got, want)
+	}
+}
+
+func TestMarshalCommand(t *testing.T) {
+	command, err := bson.Marshal(bson.D{
+		{Key: "insert", Value: "inventory"},
+		{Key: "documents", Value: bson.A{bson.D{{Key: "item", Value: "canvas"}}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"insert":"inventory","documents":[{"item":"canvas"}]}`
+	if got := MarshalCommand(command); got != want {
+		t.Errorf("MarshalCommand() = %s, want %s", got, want)
+	}
+}
+
+func TestCommandAttributeDisabledByDefault(t *testing.T) {
+	if !newConfig().CommandAttributeDisabled {
+		t.Error("db.statement attribute enabled by default")
+	}
+	if newConfig(WithCommandAttributeDisabled(false)).CommandAttributeDisabled {
+		t.Error("db.statement attribute not enabled")
+	}
+}
+
+func TestRedactCommandInvalid(t *testing.T) {
+	if got := RedactCommand(bson.Raw{0x01}); got != "" {
+		t.Errorf("RedactCommand() = %q, want empty", got)
+	}
+}
+
+func TestTruncateStatement(t *testing.T) {
+	testCases := []struct {
+		statement string
+		max       int
+		want      string
+	}{
+		{"find", 0, "find"},
+		{"find", -1, "find"},
+		{"find", 10, "find"},
+		{"find", 2, "fi"},
+		// "é" is encoded on 2 bytes and is not split.
+		{"café", 4, "caf"},
+		{"café", 5, "café"},
+	}
+	for _, tc := range testCases {
+		if got := truncateStatement(tc.statement, tc.max); got != tc.want {
+			t.Errorf("truncateStatement(%q, %d) = %q, want %q", tc.statement, tc.max, got, tc.want)
+		}
+	}
+}
+
+func TestDefaultCommandSanitizerRedacts(t *testing.T) {
+	m, sr, _ := newCursorMonitor(WithCommandAttributeDisabled(false))
+	runCommand(t, context.Background(), m, 1, bson.D{
+		{Key: "insert", Value: "inventory"},
+		{Key: "documents", Value: bson.A{bson.D{{Key: "item", Value: "canvas"}}}},
+	}, bson.D{{Key: "ok", Value: 1}})
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	want := `{"insert":"inventory","documents":[{"item":"?"}]}`
+	assert.Contains(t, spans[0].Attributes(), semconv.DBStatement(want))
+}
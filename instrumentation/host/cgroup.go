@@ -0,0 +1,199 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package host // import "go.opentelemetry.io/contrib/instrumentation/host"
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/metric"
+)
+
+// cgroupRoot is where the cgroup filesystem is mounted.  Inside a container,
+// it holds the cgroup of the container.
+const cgroupRoot = "/sys/fs/cgroup"
+
+// cgroupV1UnlimitedMemory is the lowest memory limit cgroup v1 reports when
+// the memory is not limited.  The exact value depends on the page size.
+const cgroupV1UnlimitedMemory = 1 << 62
+
+// errNoCgroup is returned when no cgroup is found.
+var errNoCgroup = errors.New("no cgroup found")
+
+// cgroupStats are the CPU and memory limits and usage of a cgroup.  A zero
+// limit means the resource is not limited.
+type cgroupStats struct {
+	CPULimit    float64
+	MemoryLimit uint64
+	MemoryUsage uint64
+}
+
+// readCgroup reads the stats of the cgroup mounted at root, supporting both
+// the cgroup v1 and v2 hierarchies.
+func readCgroup(root string) (cgroupStats, error) {
+	if _, err := os.Stat(filepath.Join(root, "cgroup.controllers")); err == nil {
+		return readCgroupV2(root)
+	}
+	if _, err := os.Stat(filepath.Join(root, "memory", "memory.usage_in_bytes")); err == nil {
+		return readCgroupV1(root)
+	}
+	return cgroupStats{}, errNoCgroup
+}
+
+func readCgroupV2(root string) (cgroupStats, error) {
+	var (
+		stats cgroupStats
+		err   error
+	)
+
+	// cpu.max holds "$MAX $PERIOD", where $MAX is "max" if unlimited.
+	if fields, err := readFields(filepath.Join(root, "cpu.max")); err == nil && len(fields) == 2 && fields[0] != "max" {
+		stats.CPULimit = cpuLimit(fields[0], fields[1])
+	}
+
+	if fields, err := readFields(filepath.Join(root, "memory.max")); err == nil && len(fields) == 1 && fields[0] != "max" {
+		stats.MemoryLimit, _ = strconv.ParseUint(fields[0], 10, 64)
+	}
+
+	stats.MemoryUsage, err = readUint(filepath.Join(root, "memory.current"))
+	return stats, err
+}
+
+func readCgroupV1(root string) (cgroupStats, error) {
+	var (
+		stats cgroupStats
+		err   error
+	)
+
+	// A quota of -1 means the CPU time is not limited.
+	quota, qErr := readFields(filepath.Join(root, "cpu", "cpu.cfs_quota_us"))
+	period, pErr := readFields(filepath.Join(root, "cpu", "cpu.cfs_period_us"))
+	if qErr == nil && pErr == nil && len(quota) == 1 && len(period) == 1 && quota[0] != "-1" {
+		stats.CPULimit = cpuLimit(quota[0], period[0])
+	}
+
+	if limit, err := readUint(filepath.Join(root, "memory", "memory.limit_in_bytes")); err == nil && limit < cgroupV1UnlimitedMemory {
+		stats.MemoryLimit = limit
+	}
+
+	stats.MemoryUsage, err = readUint(filepath.Join(root, "memory", "memory.usage_in_bytes"))
+	return stats, err
+}
+
+// cpuLimit returns the number of CPUs the quota of CPU time per period
+// amounts to.
+func cpuLimit(quota, period string) float64 {
+	q, err := strconv.ParseFloat(quota, 64)
+	if err != nil {
+		return 0
+	}
+	p, err := strconv.ParseFloat(period, 64)
+	if err != nil || p <= 0 {
+		return 0
+	}
+	return q / p
+}
+
+func readFields(path string) ([]string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(string(b)), nil
+}
+
+func readUint(path string) (uint64, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(strings.TrimSpace(string(b)), 10, 64)
+}
+
+// registerCgroup registers the container CPU and memory metrics if this
+// process runs in a cgroup, e.g. inside a container.
+func (h *host) registerCgroup() error {
+	if _, err := readCgroup(cgroupRoot); err != nil {
+		return nil
+	}
+
+	cpuLimit, err := h.meter.Float64ObservableUpDownCounter(
+		"container.cpu.limit",
+		metric.WithUnit("{cpu}"),
+		metric.WithDescription("Number of CPUs the container is limited to"),
+	)
+	if err != nil {
+		return err
+	}
+
+	memoryLimit, err := h.meter.Int64ObservableUpDownCounter(
+		"container.memory.limit",
+		metric.WithUnit("By"),
+		metric.WithDescription("Memory limit of the container"),
+	)
+	if err != nil {
+		return err
+	}
+
+	memoryUsage, err := h.meter.Int64ObservableUpDownCounter(
+		"container.memory.usage",
+		metric.WithUnit("By"),
+		metric.WithDescription("Memory usage of the container"),
+	)
+	if err != nil {
+		return err
+	}
+
+	memoryUtilization, err := h.meter.Float64ObservableGauge(
+		"container.memory.utilization",
+		metric.WithUnit("1"),
+		metric.WithDescription("Memory usage of the container relative to its limit"),
+	)
+	if err != nil {
+		return err
+	}
+
+	cgroupCollector := newCollector(h, ContainerMetrics, func(context.Context) (cgroupStats, error) {
+		return readCgroup(cgroupRoot)
+	})
+
+	_, err = h.meter.RegisterCallback(
+		func(ctx context.Context, o metric.Observer) error {
+			stats, err := cgroupCollector.get(ctx)
+			if err != nil {
+				return err
+			}
+
+			o.ObserveInt64(memoryUsage, int64(stats.MemoryUsage))
+			if stats.CPULimit > 0 {
+				o.ObserveFloat64(cpuLimit, stats.CPULimit)
+			}
+			if stats.MemoryLimit > 0 {
+				o.ObserveInt64(memoryLimit, int64(stats.MemoryLimit))
+				o.ObserveFloat64(memoryUtilization, float64(stats.MemoryUsage)/float64(stats.MemoryLimit))
+			}
+			return nil
+		},
+		cpuLimit,
+		memoryLimit,
+		memoryUsage,
+		memoryUtilization,
+	)
+	return err
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package host
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestReadCgroup(t *testing.T) {
+	testCases := []struct {
+		name  string
+		files map[string]string
+		want  cgroupStats
+	}{
+		{
+			name: "v2 limited",
+			files: map[string]string{
+				"cgroup.controllers": "cpu memory\n",
+				"cpu.max":            "150000 100000\n",
+				"memory.max":         "536870912\n",
+				"memory.current":     "134217728\n",
+			},
+			want: cgroupStats{CPULimit: 1.5, MemoryLimit: 536870912, MemoryUsage: 134217728},
+		},
+		{
+			name: "v2 unlimited",
+			files: map[string]string{
+				"cgroup.controllers": "cpu memory\n",
+				"cpu.max":            "max 100000\n",
+				"memory.max":         "max\n",
+				"memory.current":     "134217728\n",
+			},
+			want: cgroupStats{MemoryUsage: 134217728},
+		},
+		{
+			name: "v1 limited",
+			files: map[string]string{
+				"cpu/cpu.cfs_quota_us":         "50000\n",
+				"cpu/cpu.cfs_period_us":        "100000\n",
+				"memory/memory.limit_in_bytes": "268435456\n",
+				"memory/memory.usage_in_bytes": "67108864\n",
+			},
+			want: cgroupStats{CPULimit: 0.5, MemoryLimit: 268435456, MemoryUsage: 67108864},
+		},
+		{
+			name: "v1 unlimited",
+			files: map[string]string{
+				"cpu/cpu.cfs_quota_us":         "-1\n",
+				"cpu/cpu.cfs_period_us":        "100000\n",
+				"memory/memory.limit_in_bytes": "9223372036854771712\n",
+				"memory/memory.usage_in_bytes": "67108864\n",
+			},
+			want: cgroupStats{MemoryUsage: 67108864},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := readCgroup(writeFiles(t, tc.files))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Errorf("readCgroup() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReadCgroupNotFound(t *testing.T) {
+	if _, err := readCgroup(t.TempDir()); !errors.Is(err, errNoCgroup) {
+		t.Errorf("readCgroup() error = %v, want %v", err, errNoCgroup)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package host // import "go.opentelemetry.io/contrib/instrumentation/host"
+
+import (
+	"fmt"
+	"time"
+)
+
+// ConfigOptions returns the Options set by the "host" node of the "go"
+// instrumentation section of an OpenTelemetry configuration file, as
+// returned by the GoInstrumentation method of the
+// go.opentelemetry.io/contrib/config package.  For example:
+//
+//	instrumentation:
+//	  go:
+//	    host:
+//	      per_cpu: true
+//	      tcp_connections: false
+//	      excluded_filesystem_types: [tmpfs, overlay]
+//	      collection_intervals: # milliseconds
+//	        cpu: 10000
+//	        disk: 60000
+//
+// A nil node returns no Options, so the defaults apply.
+func ConfigOptions(node map[string]interface{}) ([]Option, error) {
+	var opts []Option
+	for key, v := range node {
+		switch key {
+		case "per_cpu":
+			if b, ok := v.(bool); !ok {
+				return nil, fmt.Errorf("host: %s: invalid value %v", key, v)
+			} else if b {
+				opts = append(opts, WithPerCPU())
+			}
+		case "tcp_connections":
+			if b, ok := v.(bool); !ok {
+				return nil, fmt.Errorf("host: %s: invalid value %v", key, v)
+			} else if b {
+				opts = append(opts, WithTCPConnections())
+			}
+		case "excluded_filesystem_types":
+			list, ok := v.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("host: %s: invalid value %v", key, v)
+			}
+			types := make([]string, len(list))
+			for i, item := range list {
+				types[i] = fmt.Sprint(item)
+			}
+			opts = append(opts, WithExcludedFilesystemTypes(types...))
+		case "collection_intervals":
+			intervals, ok := v.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("host: %s: invalid value %v", key, v)
+			}
+			for group, ms := range intervals {
+				d, err := configMilliseconds(key, ms)
+				if err != nil {
+					return nil, err
+				}
+				opts = append(opts, WithMinimumCollectionInterval(MetricGroup(group), d))
+			}
+		default:
+			return nil, fmt.Errorf("host: unknown setting %q", key)
+		}
+	}
+	return opts, nil
+}
+
+// configMilliseconds returns v, a number of milliseconds, as a duration.
+// Numbers are decoded as int from YAML and as float64 from JSON.
+func configMilliseconds(key string, v interface{}) (time.Duration, error) {
+	switch n := v.(type) {
+	case int:
+		return time.Duration(n) * time.Millisecond, nil
+	case int64:
+		return time.Duration(n) * time.Millisecond, nil
+	case float64:
+		if n == float64(int64(n)) {
+			return time.Duration(n) * time.Millisecond, nil
+		}
+	}
+	return 0, fmt.Errorf("host: %s: invalid value %v", key, v)
+}
@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package host
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestConfigOptions(t *testing.T) {
+	opts, err := ConfigOptions(map[string]interface{}{
+		"per_cpu":                   true,
+		"tcp_connections":           true,
+		"excluded_filesystem_types": []interface{}{"tmpfs"},
+		"collection_intervals":      map[string]interface{}{"disk": 60000, "cpu": float64(10000)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := newConfig(opts...)
+	if !c.PerCPU || !c.TCPConnections {
+		t.Error("boolean settings not applied")
+	}
+	if want := []string{"tmpfs"}; !reflect.DeepEqual(c.ExcludedFilesystemTypes, want) {
+		t.Errorf("ExcludedFilesystemTypes = %v, want %v", c.ExcludedFilesystemTypes, want)
+	}
+
+	wantIntervals := map[MetricGroup]time.Duration{DiskMetrics: time.Minute, CPUMetrics: 10 * time.Second}
+	if !reflect.DeepEqual(c.CollectionIntervals, wantIntervals) {
+		t.Errorf("CollectionIntervals = %v, want %v", c.CollectionIntervals, wantIntervals)
+	}
+
+	if opts, err := ConfigOptions(nil); err != nil || len(opts) != 0 {
+		t.Errorf("ConfigOptions(nil) = %v, %v", opts, err)
+	}
+}
+
+func TestConfigOptionsErrors(t *testing.T) {
+	for _, node := range []map[string]interface{}{
+		{"unknown": true},
+		{"per_cpu": "yes"},
+		{"excluded_filesystem_types": "tmpfs"},
+		{"collection_intervals": map[string]interface{}{"disk": "1m"}},
+	} {
+		if _, err := ConfigOptions(node); err == nil {
+			t.Errorf("ConfigOptions(%v) returned no error", node)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package host // import "go.opentelemetry.io/contrib/instrumentation/host"
+
+import (
+	"context"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/disk"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Attribute values used for disk measurements.
+var (
+	attributeDirectionRead  = attribute.String("direction", "read")
+	attributeDirectionWrite = attribute.String("direction", "write")
+)
+
+// registerDisk registers the disk I/O metrics, reported per device.
+func (h *host) registerDisk() error {
+	diskIO, err := h.meter.Int64ObservableCounter(
+		"system.disk.io",
+		metric.WithUnit("By"),
+		metric.WithDescription(
+			"Bytes transferred attributed by device and direction (Read, Write)",
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	diskOperations, err := h.meter.Int64ObservableCounter(
+		"system.disk.operations",
+		metric.WithUnit("{operation}"),
+		metric.WithDescription(
+			"Completed disk operations attributed by device and direction (Read, Write)",
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	diskIOTime, err := h.meter.Float64ObservableCounter(
+		"system.disk.io_time",
+		metric.WithUnit("s"),
+		metric.WithDescription(
+			"Time the disk spent activated attributed by device",
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	diskCollector := newCollector(h, DiskMetrics, func(ctx context.Context) (map[string]disk.IOCountersStat, error) {
+		return disk.IOCountersWithContext(ctx)
+	})
+
+	_, err = h.meter.RegisterCallback(
+		func(ctx context.Context, o metric.Observer) error {
+			counters, err := diskCollector.get(ctx)
+			if err != nil {
+				return err
+			}
+
+			for device, c := range counters {
+				dev := attribute.String("device", device)
+
+				opt := metric.WithAttributes(dev, attributeDirectionRead)
+				o.ObserveInt64(diskIO, int64(c.ReadBytes), opt)
+				o.ObserveInt64(diskOperations, int64(c.ReadCount), opt)
+
+				opt = metric.WithAttributes(dev, attributeDirectionWrite)
+				o.ObserveInt64(diskIO, int64(c.WriteBytes), opt)
+				o.ObserveInt64(diskOperations, int64(c.WriteCount), opt)
+
+				// IoTime is reported in milliseconds.
+				ioTime := time.Duration(c.IoTime) * time.Millisecond
+				o.ObserveFloat64(diskIOTime, ioTime.Seconds(), metric.WithAttributes(dev))
+			}
+			return nil
+		},
+		diskIO,
+		diskOperations,
+		diskIOTime,
+	)
+	return err
+}
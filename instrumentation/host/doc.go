@@ -25,11 +25,32 @@
 //
 // ----------------------------------------------------------------------
 //
-//	process.cpu.time           state=user|system
-//	system.cpu.time            state=user|system|other|idle
-//	system.memory.usage        state=used|available
-//	system.memory.utilization  state=used|available
-//	system.network.io          direction=transmit|receive
+//	process.cpu.time              state=user|system
+//	system.cpu.time               state=user|system|other|idle, cpu (with WithPerCPU)
+//	system.cpu.load_average.1m
+//	system.cpu.load_average.5m
+//	system.cpu.load_average.15m
+//	system.memory.usage           state=used|available
+//	system.memory.utilization     state=used|available
+//	system.network.io             direction=transmit|receive
+//	system.network.errors         device, direction=transmit|receive
+//	system.network.dropped        device, direction=transmit|receive
+//	system.network.connections    protocol=tcp, state (with WithTCPConnections)
+//	system.disk.io                device, direction=read|write
+//	system.disk.operations        device, direction=read|write
+//	system.disk.io_time           device
+//	system.filesystem.usage       device, mountpoint, type, state=used|free
+//	system.filesystem.utilization device, mountpoint, type
+//
+// When the process runs in a cgroup, e.g. inside a container, the CPU and
+// memory limits and the memory usage of the cgroup are additionally reported
+// by the container.cpu.limit, container.memory.limit, container.memory.usage
+// and container.memory.utilization metrics, for both the cgroup v1 and v2
+// hierarchies.
+//
+// The metrics are collected in groups, e.g. DiskMetrics or CPUMetrics, each
+// of which can be collected at its own pace with the
+// WithMinimumCollectionInterval option.
 //
 // See https://github.com/open-telemetry/oteps/blob/main/text/0119-standard-system-metrics.md
 // for the definition of these metric instruments.
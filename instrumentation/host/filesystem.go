@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package host // import "go.opentelemetry.io/contrib/instrumentation/host"
+
+import (
+	"context"
+
+	"github.com/shirou/gopsutil/v3/disk"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// defaultExcludedFilesystemTypes are the types of the virtual filesystems
+// that are not reported by default.
+var defaultExcludedFilesystemTypes = []string{
+	"autofs", "binfmt_misc", "bpf", "cgroup", "cgroup2", "configfs",
+	"debugfs", "devpts", "devtmpfs", "fusectl", "hugetlbfs", "mqueue",
+	"nsfs", "overlay", "proc", "pstore", "securityfs", "squashfs",
+	"sysfs", "tmpfs", "tracefs",
+}
+
+// DefaultExcludedFilesystemTypes returns the types of the virtual filesystems
+// that are not reported by default.  Use the
+// WithExcludedFilesystemTypes() option to modify this setting in Start().
+func DefaultExcludedFilesystemTypes() []string {
+	return append([]string(nil), defaultExcludedFilesystemTypes...)
+}
+
+// Attribute values used for filesystem measurements.
+var (
+	attributeFilesystemUsed = attribute.String("state", "used")
+	attributeFilesystemFree = attribute.String("state", "free")
+)
+
+// registerFilesystem registers the filesystem usage metrics, reported per
+// mount point.
+func (h *host) registerFilesystem() error {
+	fsUsage, err := h.meter.Int64ObservableUpDownCounter(
+		"system.filesystem.usage",
+		metric.WithUnit("By"),
+		metric.WithDescription(
+			"Filesystem bytes attributed by device, mount point, type and state (Used, Free)",
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	fsUtilization, err := h.meter.Float64ObservableGauge(
+		"system.filesystem.utilization",
+		metric.WithUnit("1"),
+		metric.WithDescription(
+			"Filesystem utilization attributed by device, mount point and type",
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	filter := newFilesystemFilter(h.config.ExcludedFilesystemTypes)
+
+	type filesystemUsage struct {
+		partition disk.PartitionStat
+		usage     *disk.UsageStat
+	}
+	fsCollector := newCollector(h, FilesystemMetrics, func(ctx context.Context) ([]filesystemUsage, error) {
+		partitions, err := disk.PartitionsWithContext(ctx, false)
+		if err != nil {
+			return nil, err
+		}
+
+		var usages []filesystemUsage
+		for _, p := range filter.partitions(partitions) {
+			usage, err := disk.UsageWithContext(ctx, p.Mountpoint)
+			if err != nil {
+				// The mount point may have been unmounted or
+				// be inaccessible to this process.
+				continue
+			}
+			usages = append(usages, filesystemUsage{partition: p, usage: usage})
+		}
+		return usages, nil
+	})
+
+	_, err = h.meter.RegisterCallback(
+		func(ctx context.Context, o metric.Observer) error {
+			usages, err := fsCollector.get(ctx)
+			if err != nil {
+				return err
+			}
+
+			for _, u := range usages {
+				o.ObserveInt64(fsUsage, int64(u.usage.Used), metric.WithAttributes(filesystemAttributes(u.partition, attributeFilesystemUsed)...))
+				o.ObserveInt64(fsUsage, int64(u.usage.Free), metric.WithAttributes(filesystemAttributes(u.partition, attributeFilesystemFree)...))
+				if u.usage.Total > 0 {
+					o.ObserveFloat64(fsUtilization, float64(u.usage.Used)/float64(u.usage.Total), metric.WithAttributes(filesystemAttributes(u.partition)...))
+				}
+			}
+			return nil
+		},
+		fsUsage,
+		fsUtilization,
+	)
+	return err
+}
+
+// filesystemFilter holds the types of the filesystems that are not reported.
+type filesystemFilter map[string]bool
+
+func newFilesystemFilter(excluded []string) filesystemFilter {
+	f := make(filesystemFilter, len(excluded))
+	for _, t := range excluded {
+		f[t] = true
+	}
+	return f
+}
+
+// partitions returns the partitions whose filesystem type is not excluded.
+func (f filesystemFilter) partitions(partitions []disk.PartitionStat) []disk.PartitionStat {
+	var included []disk.PartitionStat
+	for _, p := range partitions {
+		if !f[p.Fstype] {
+			included = append(included, p)
+		}
+	}
+	return included
+}
+
+// filesystemAttributes returns the attributes identifying the partition p
+// followed by extra.
+func filesystemAttributes(p disk.PartitionStat, extra ...attribute.KeyValue) []attribute.KeyValue {
+	return append([]attribute.KeyValue{
+		attribute.String("device", p.Device),
+		attribute.String("mountpoint", p.Mountpoint),
+		attribute.String("type", p.Fstype),
+	}, extra...)
+}
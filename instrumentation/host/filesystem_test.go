@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package host
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/shirou/gopsutil/v3/disk"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestDefaultExcludedFilesystemTypes(t *testing.T) {
+	types := DefaultExcludedFilesystemTypes()
+	if len(types) == 0 {
+		t.Fatal("no filesystem type excluded by default")
+	}
+	types[0] = "ext4"
+	if got := DefaultExcludedFilesystemTypes()[0]; got == "ext4" {
+		t.Error("default excluded filesystem types modified through the returned slice")
+	}
+
+	if c := newConfig(); !reflect.DeepEqual(c.ExcludedFilesystemTypes, DefaultExcludedFilesystemTypes()) {
+		t.Errorf("ExcludedFilesystemTypes = %v, want the default", c.ExcludedFilesystemTypes)
+	}
+}
+
+func TestFilesystemFilter(t *testing.T) {
+	partitions := []disk.PartitionStat{
+		{Device: "/dev/sda1", Mountpoint: "/", Fstype: "ext4"},
+		{Device: "tmpfs", Mountpoint: "/tmp", Fstype: "tmpfs"},
+		{Device: "proc", Mountpoint: "/proc", Fstype: "proc"},
+		{Device: "/dev/sdb1", Mountpoint: "/data", Fstype: "xfs"},
+	}
+
+	testCases := []struct {
+		name     string
+		excluded []string
+		want     []string
+	}{
+		{"default", DefaultExcludedFilesystemTypes(), []string{"/", "/data"}},
+		{"custom", []string{"xfs"}, []string{"/", "/tmp", "/proc"}},
+		{"none", nil, []string{"/", "/tmp", "/proc", "/data"}},
+	}
+	for _, tc := range testCases {
+		var got []string
+		for _, p := range newFilesystemFilter(tc.excluded).partitions(partitions) {
+			got = append(got, p.Mountpoint)
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: reported mount points %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestFilesystemAttributes(t *testing.T) {
+	p := disk.PartitionStat{Device: "/dev/sda1", Mountpoint: "/", Fstype: "ext4"}
+	want := []attribute.KeyValue{
+		attribute.String("device", "/dev/sda1"),
+		attribute.String("mountpoint", "/"),
+		attribute.String("type", "ext4"),
+	}
+	if got := filesystemAttributes(p); !reflect.DeepEqual(got, want) {
+		t.Errorf("filesystemAttributes = %v, want %v", got, want)
+	}
+
+	used := filesystemAttributes(p, attributeFilesystemUsed)
+	free := filesystemAttributes(p, attributeFilesystemFree)
+	if want := append(want, attributeFilesystemUsed); !reflect.DeepEqual(used, want) {
+		t.Errorf("filesystemAttributes with state = %v, want %v", used, want)
+	}
+	if state := free[len(free)-1]; state != attributeFilesystemFree {
+		t.Errorf("state attribute = %v, want %v", state, attributeFilesystemFree)
+	}
+	if state := used[len(used)-1]; state != attributeFilesystemUsed {
+		t.Errorf("state attribute overwritten with %v", state)
+	}
+}
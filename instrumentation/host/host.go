@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/shirou/gopsutil/v3/cpu"
 	"github.com/shirou/gopsutil/v3/mem"
@@ -41,6 +42,20 @@ type config struct {
 	// MeterProvider sets the metric.MeterProvider.  If nil, the global
 	// Provider will be used.
 	MeterProvider metric.MeterProvider
+
+	// PerCPU enables the report of the CPU time per CPU.
+	PerCPU bool
+
+	// TCPConnections enables the report of the TCP connection counts.
+	TCPConnections bool
+
+	// CollectionIntervals are the minimum intervals between two
+	// collections of the metric groups.
+	CollectionIntervals map[MetricGroup]time.Duration
+
+	// ExcludedFilesystemTypes are the types of the filesystems that are
+	// not reported.
+	ExcludedFilesystemTypes []string
 }
 
 // Option supports configuring optional settings for host metrics.
@@ -63,6 +78,46 @@ func (o metricProviderOption) apply(c *config) {
 	}
 }
 
+// WithPerCPU enables the report of system.cpu.time per logical CPU, with a
+// "cpu" attribute identifying it, instead of aggregated over all CPUs.  This
+// multiplies the cardinality of the metric by the number of CPUs of the host.
+func WithPerCPU() Option {
+	return perCPUOption{}
+}
+
+type perCPUOption struct{}
+
+func (perCPUOption) apply(c *config) {
+	c.PerCPU = true
+}
+
+// WithTCPConnections enables the report of the number of TCP connections of
+// the host by state.  Counting connections requires listing all the sockets
+// of the host on every collection, which is costly on hosts with many
+// connections.
+func WithTCPConnections() Option {
+	return tcpConnectionsOption{}
+}
+
+type tcpConnectionsOption struct{}
+
+func (tcpConnectionsOption) apply(c *config) {
+	c.TCPConnections = true
+}
+
+// WithExcludedFilesystemTypes sets the types of the filesystems, e.g.
+// "tmpfs", whose usage is not reported.  If this option is not used,
+// DefaultExcludedFilesystemTypes() are excluded.
+func WithExcludedFilesystemTypes(types ...string) Option {
+	return excludedFilesystemTypesOption(types)
+}
+
+type excludedFilesystemTypesOption []string
+
+func (o excludedFilesystemTypesOption) apply(c *config) {
+	c.ExcludedFilesystemTypes = o
+}
+
 // Attribute sets.
 var (
 	// Attribute sets for CPU time measurements.
@@ -86,7 +141,8 @@ var (
 // newConfig computes a config from a list of Options.
 func newConfig(opts ...Option) config {
 	c := config{
-		MeterProvider: otel.GetMeterProvider(),
+		MeterProvider:           otel.GetMeterProvider(),
+		ExcludedFilesystemTypes: DefaultExcludedFilesystemTypes(),
 	}
 	for _, opt := range opts {
 		opt.apply(&c)
@@ -131,6 +187,44 @@ func (h *host) register() error {
 		return fmt.Errorf("could not find this process: %w", err)
 	}
 
+	type cpuTimes struct {
+		process *cpu.TimesStat
+		host    []cpu.TimesStat
+	}
+	cpuCollector := newCollector(h, CPUMetrics, func(ctx context.Context) (cpuTimes, error) {
+		// This follows the OpenTelemetry Collector's "hostmetrics"
+		// receiver/hostmetricsreceiver/internal/scraper/processscraper
+		// measures User and System IOwait time.
+		// TODO: the Collector has per-OS compilation modules to support
+		// specific metrics that are not universal.
+		processTimes, err := proc.TimesWithContext(ctx)
+		if err != nil {
+			return cpuTimes{}, err
+		}
+
+		hostTimeSlice, err := cpu.TimesWithContext(ctx, h.config.PerCPU)
+		if err != nil {
+			return cpuTimes{}, err
+		}
+		if !h.config.PerCPU && len(hostTimeSlice) != 1 {
+			return cpuTimes{}, fmt.Errorf("host CPU usage: incorrect summary count")
+		}
+		return cpuTimes{process: processTimes, host: hostTimeSlice}, nil
+	})
+
+	memCollector := newCollector(h, MemoryMetrics, mem.VirtualMemoryWithContext)
+
+	netCollector := newCollector(h, NetworkMetrics, func(ctx context.Context) (net.IOCountersStat, error) {
+		ioStats, err := net.IOCountersWithContext(ctx, false)
+		if err != nil {
+			return net.IOCountersStat{}, err
+		}
+		if len(ioStats) != 1 {
+			return net.IOCountersStat{}, fmt.Errorf("host network usage: incorrect summary count")
+		}
+		return ioStats[0], nil
+	})
+
 	lock.Lock()
 	defer lock.Unlock()
 
@@ -192,65 +286,47 @@ func (h *host) register() error {
 			lock.Lock()
 			defer lock.Unlock()
 
-			// This follows the OpenTelemetry Collector's "hostmetrics"
-			// receiver/hostmetricsreceiver/internal/scraper/processscraper
-			// measures User and System IOwait time.
-			// TODO: the Collector has per-OS compilation modules to support
-			// specific metrics that are not universal.
-			processTimes, err := proc.TimesWithContext(ctx)
+			times, err := cpuCollector.get(ctx)
 			if err != nil {
 				return err
 			}
+			processTimes, hostTimeSlice := times.process, times.host
 
-			hostTimeSlice, err := cpu.TimesWithContext(ctx, false)
+			vmStats, err := memCollector.get(ctx)
 			if err != nil {
 				return err
 			}
-			if len(hostTimeSlice) != 1 {
-				return fmt.Errorf("host CPU usage: incorrect summary count")
-			}
 
-			vmStats, err := mem.VirtualMemoryWithContext(ctx)
+			ioStats, err := netCollector.get(ctx)
 			if err != nil {
 				return err
 			}
 
-			ioStats, err := net.IOCountersWithContext(ctx, false)
-			if err != nil {
-				return err
-			}
-			if len(ioStats) != 1 {
-				return fmt.Errorf("host network usage: incorrect summary count")
-			}
-
-			hostTime := hostTimeSlice[0]
 			opt := metric.WithAttributeSet(AttributeCPUTimeUser)
 			o.ObserveFloat64(processCPUTime, processTimes.User, opt)
-			o.ObserveFloat64(hostCPUTime, hostTime.User, opt)
-
 			opt = metric.WithAttributeSet(AttributeCPUTimeSystem)
 			o.ObserveFloat64(processCPUTime, processTimes.System, opt)
-			o.ObserveFloat64(hostCPUTime, hostTime.System, opt)
-
-			// TODO(#244): "other" is a placeholder for actually dealing
-			// with these states.  Do users actually want this
-			// (unconditionally)?  How should we handle "iowait"
-			// if not all systems expose it?  Should we break
-			// these down by CPU?  If so, are users going to want
-			// to aggregate in-process?  See:
-			// https://github.com/open-telemetry/opentelemetry-go-contrib/issues/244
-			other := hostTime.Nice +
-				hostTime.Iowait +
-				hostTime.Irq +
-				hostTime.Softirq +
-				hostTime.Steal +
-				hostTime.Guest +
-				hostTime.GuestNice
-
-			opt = metric.WithAttributeSet(AttributeCPUTimeOther)
-			o.ObserveFloat64(hostCPUTime, other, opt)
-			opt = metric.WithAttributeSet(AttributeCPUTimeIdle)
-			o.ObserveFloat64(hostCPUTime, hostTime.Idle, opt)
+
+			for _, hostTime := range hostTimeSlice {
+				o.ObserveFloat64(hostCPUTime, hostTime.User, h.cpuTimeOption(AttributeCPUTimeUser, hostTime.CPU))
+				o.ObserveFloat64(hostCPUTime, hostTime.System, h.cpuTimeOption(AttributeCPUTimeSystem, hostTime.CPU))
+
+				// TODO(#244): "other" is a placeholder for actually dealing
+				// with these states.  Do users actually want this
+				// (unconditionally)?  How should we handle "iowait"
+				// if not all systems expose it?  See:
+				// https://github.com/open-telemetry/opentelemetry-go-contrib/issues/244
+				other := hostTime.Nice +
+					hostTime.Iowait +
+					hostTime.Irq +
+					hostTime.Softirq +
+					hostTime.Steal +
+					hostTime.Guest +
+					hostTime.GuestNice
+
+				o.ObserveFloat64(hostCPUTime, other, h.cpuTimeOption(AttributeCPUTimeOther, hostTime.CPU))
+				o.ObserveFloat64(hostCPUTime, hostTime.Idle, h.cpuTimeOption(AttributeCPUTimeIdle, hostTime.CPU))
+			}
 
 			// Host memory usage
 			opt = metric.WithAttributeSet(AttributeMemoryUsed)
@@ -270,9 +346,9 @@ func (h *host) register() error {
 			// interface, with similar questions to those posed
 			// about per-CPU measurements above.
 			opt = metric.WithAttributeSet(AttributeNetworkTransmit)
-			o.ObserveInt64(networkIOUsage, int64(ioStats[0].BytesSent), opt)
+			o.ObserveInt64(networkIOUsage, int64(ioStats.BytesSent), opt)
 			opt = metric.WithAttributeSet(AttributeNetworkReceive)
-			o.ObserveInt64(networkIOUsage, int64(ioStats[0].BytesRecv), opt)
+			o.ObserveInt64(networkIOUsage, int64(ioStats.BytesRecv), opt)
 
 			return nil
 		},
@@ -287,5 +363,31 @@ func (h *host) register() error {
 		return err
 	}
 
-	return nil
+	if err := h.registerNetwork(); err != nil {
+		return err
+	}
+
+	if err := h.registerDisk(); err != nil {
+		return err
+	}
+
+	if err := h.registerFilesystem(); err != nil {
+		return err
+	}
+
+	if err := h.registerLoad(); err != nil {
+		return err
+	}
+
+	return h.registerCgroup()
+}
+
+// cpuTimeOption returns the option observing the CPU time in state of the
+// CPU identified by cpu, which is only recorded when time is reported per
+// CPU.
+func (h *host) cpuTimeOption(state attribute.Set, cpu string) metric.ObserveOption {
+	if !h.config.PerCPU {
+		return metric.WithAttributeSet(state)
+	}
+	return metric.WithAttributes(append(state.ToSlice(), attribute.String("cpu", cpu))...)
 }
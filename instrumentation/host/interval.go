@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package host // import "go.opentelemetry.io/contrib/instrumentation/host"
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MetricGroup identifies a group of host metrics collected together.
+type MetricGroup string
+
+const (
+	// CPUMetrics are the process.cpu.time and system.cpu.time metrics.
+	CPUMetrics MetricGroup = "cpu"
+	// MemoryMetrics are the system.memory.* metrics.
+	MemoryMetrics MetricGroup = "memory"
+	// NetworkMetrics are the system.network.* metrics.
+	NetworkMetrics MetricGroup = "network"
+	// DiskMetrics are the system.disk.* metrics.
+	DiskMetrics MetricGroup = "disk"
+	// FilesystemMetrics are the system.filesystem.* metrics.
+	FilesystemMetrics MetricGroup = "filesystem"
+	// LoadMetrics are the system.cpu.load_average.* metrics.
+	LoadMetrics MetricGroup = "load"
+	// ContainerMetrics are the container.* metrics.
+	ContainerMetrics MetricGroup = "container"
+)
+
+// WithMinimumCollectionInterval sets a minimum interval between two
+// collections of the metrics of group g.  When metrics are read more often,
+// the values of the last collection of the group are reported again.  This
+// lets expensive collections, e.g. the DiskMetrics or FilesystemMetrics, run
+// less often than cheap ones.  This setting is ignored when `d` is negative.
+//
+// By default, all groups are collected every time metrics are read.
+func WithMinimumCollectionInterval(g MetricGroup, d time.Duration) Option {
+	return minimumCollectionIntervalOption{group: g, interval: d}
+}
+
+type minimumCollectionIntervalOption struct {
+	group    MetricGroup
+	interval time.Duration
+}
+
+func (o minimumCollectionIntervalOption) apply(c *config) {
+	if o.interval < 0 {
+		return
+	}
+	if c.CollectionIntervals == nil {
+		c.CollectionIntervals = make(map[MetricGroup]time.Duration)
+	}
+	c.CollectionIntervals[o.group] = o.interval
+}
+
+// collector collects a value at most once per interval.
+type collector[T any] struct {
+	interval time.Duration
+	collect  func(context.Context) (T, error)
+	now      func() time.Time
+
+	mu    sync.Mutex
+	last  time.Time
+	value T
+}
+
+// newCollector returns a collector of the metric group g using collect.
+func newCollector[T any](h *host, g MetricGroup, collect func(context.Context) (T, error)) *collector[T] {
+	return &collector[T]{
+		interval: h.config.CollectionIntervals[g],
+		collect:  collect,
+		now:      time.Now,
+	}
+}
+
+// get returns the value of the last collection if it happened less than the
+// interval ago, otherwise the value is collected again.
+func (c *collector[T]) get(ctx context.Context) (T, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if !c.last.IsZero() && now.Sub(c.last) < c.interval {
+		return c.value, nil
+	}
+
+	v, err := c.collect(ctx)
+	if err != nil {
+		return v, err
+	}
+	c.value, c.last = v, now
+	return v, nil
+}
@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package host
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCollector(t *testing.T) {
+	h := &host{config: newConfig(
+		WithMinimumCollectionInterval(DiskMetrics, time.Minute),
+		WithMinimumCollectionInterval(CPUMetrics, -time.Second),
+	)}
+	if _, ok := h.config.CollectionIntervals[CPUMetrics]; ok {
+		t.Error("negative interval applied")
+	}
+
+	var (
+		calls int
+		err   error
+	)
+	c := newCollector(h, DiskMetrics, func(context.Context) (int, error) {
+		calls++
+		return calls, err
+	})
+	now := time.Unix(0, 0)
+	c.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	for i, step := range []struct {
+		advance time.Duration
+		err     error
+		want    int
+		wantErr bool
+	}{
+		{want: 1},
+		{advance: 30 * time.Second, want: 1},
+		{advance: 30 * time.Second, want: 2},
+		{advance: time.Minute, err: errors.New("failed"), wantErr: true},
+		// A failed collection is retried on the next read.
+		{want: 4},
+	} {
+		now = now.Add(step.advance)
+		err = step.err
+		got, gotErr := c.get(ctx)
+		if (gotErr != nil) != step.wantErr {
+			t.Fatalf("step %d: unexpected error %v", i, gotErr)
+		}
+		if !step.wantErr && got != step.want {
+			t.Errorf("step %d: got %d, want %d", i, got, step.want)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package host // import "go.opentelemetry.io/contrib/instrumentation/host"
+
+import (
+	"context"
+
+	"github.com/shirou/gopsutil/v3/load"
+
+	"go.opentelemetry.io/otel/metric"
+)
+
+// registerLoad registers the load average metrics.
+func (h *host) registerLoad() error {
+	load1, err := h.meter.Float64ObservableGauge(
+		"system.cpu.load_average.1m",
+		metric.WithUnit("{thread}"),
+		metric.WithDescription("Average CPU load over the last minute"),
+	)
+	if err != nil {
+		return err
+	}
+
+	load5, err := h.meter.Float64ObservableGauge(
+		"system.cpu.load_average.5m",
+		metric.WithUnit("{thread}"),
+		metric.WithDescription("Average CPU load over the last 5 minutes"),
+	)
+	if err != nil {
+		return err
+	}
+
+	load15, err := h.meter.Float64ObservableGauge(
+		"system.cpu.load_average.15m",
+		metric.WithUnit("{thread}"),
+		metric.WithDescription("Average CPU load over the last 15 minutes"),
+	)
+	if err != nil {
+		return err
+	}
+
+	loadCollector := newCollector(h, LoadMetrics, load.AvgWithContext)
+
+	_, err = h.meter.RegisterCallback(
+		func(ctx context.Context, o metric.Observer) error {
+			avg, err := loadCollector.get(ctx)
+			if err != nil {
+				return err
+			}
+
+			o.ObserveFloat64(load1, avg.Load1)
+			o.ObserveFloat64(load5, avg.Load5)
+			o.ObserveFloat64(load15, avg.Load15)
+			return nil
+		},
+		load1,
+		load5,
+		load15,
+	)
+	return err
+}
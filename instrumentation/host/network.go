@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package host // import "go.opentelemetry.io/contrib/instrumentation/host"
+
+import (
+	"context"
+
+	"github.com/shirou/gopsutil/v3/net"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Attribute values used for network measurements.
+var (
+	attributeDirectionTransmit = attribute.String("direction", "transmit")
+	attributeDirectionReceive  = attribute.String("direction", "receive")
+	attributeProtocolTCP       = attribute.String("protocol", "tcp")
+)
+
+// registerNetwork registers the network error and drop metrics, reported
+// per interface, and the TCP connection metric if enabled.
+func (h *host) registerNetwork() error {
+	networkErrors, err := h.meter.Int64ObservableCounter(
+		"system.network.errors",
+		metric.WithUnit("{error}"),
+		metric.WithDescription(
+			"Network errors attributed by device and direction (Transmit, Receive)",
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	networkDropped, err := h.meter.Int64ObservableCounter(
+		"system.network.dropped",
+		metric.WithUnit("{packet}"),
+		metric.WithDescription(
+			"Dropped packets attributed by device and direction (Transmit, Receive)",
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	instruments := []metric.Observable{networkErrors, networkDropped}
+
+	var networkConnections metric.Int64ObservableUpDownCounter
+	if h.config.TCPConnections {
+		if networkConnections, err = h.meter.Int64ObservableUpDownCounter(
+			"system.network.connections",
+			metric.WithUnit("{connection}"),
+			metric.WithDescription(
+				"TCP connections attributed by protocol and state (ESTABLISHED, TIME_WAIT, ...)",
+			),
+		); err != nil {
+			return err
+		}
+		instruments = append(instruments, networkConnections)
+	}
+
+	ioCollector := newCollector(h, NetworkMetrics, func(ctx context.Context) ([]net.IOCountersStat, error) {
+		return net.IOCountersWithContext(ctx, true)
+	})
+
+	// Connections are counted when collected so the list of connections
+	// is not retained between collections.
+	connCollector := newCollector(h, NetworkMetrics, func(ctx context.Context) (map[string]int64, error) {
+		conns, err := net.ConnectionsWithContext(ctx, "tcp")
+		if err != nil {
+			return nil, err
+		}
+		states := make(map[string]int64)
+		for _, c := range conns {
+			states[c.Status]++
+		}
+		return states, nil
+	})
+
+	_, err = h.meter.RegisterCallback(
+		func(ctx context.Context, o metric.Observer) error {
+			ioStats, err := ioCollector.get(ctx)
+			if err != nil {
+				return err
+			}
+
+			for _, s := range ioStats {
+				dev := attribute.String("device", s.Name)
+
+				opt := metric.WithAttributes(dev, attributeDirectionTransmit)
+				o.ObserveInt64(networkErrors, int64(s.Errout), opt)
+				o.ObserveInt64(networkDropped, int64(s.Dropout), opt)
+
+				opt = metric.WithAttributes(dev, attributeDirectionReceive)
+				o.ObserveInt64(networkErrors, int64(s.Errin), opt)
+				o.ObserveInt64(networkDropped, int64(s.Dropin), opt)
+			}
+
+			if networkConnections == nil {
+				return nil
+			}
+
+			states, err := connCollector.get(ctx)
+			if err != nil {
+				return err
+			}
+			for state, n := range states {
+				o.ObserveInt64(networkConnections, n, metric.WithAttributes(
+					attributeProtocolTCP,
+					attribute.String("state", state),
+				))
+			}
+			return nil
+		},
+		instruments...,
+	)
+	return err
+}
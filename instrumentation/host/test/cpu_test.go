@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"testing"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/load"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/instrumentation/host"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// cpuStates are the values of the state attribute of system.cpu.time.
+var cpuStates = []string{"user", "system", "other", "idle"}
+
+func TestCPUTimeAggregated(t *testing.T) {
+	reader := start(t)
+	metrics := collect(t, reader)
+
+	require.Contains(t, metrics, "system.cpu.time")
+	m := metrics["system.cpu.time"]
+	assert.Equal(t, "s", m.Unit)
+	for _, set := range attributeSets(t, m) {
+		assert.Equal(t, 1, set.Len(), "attributes other than state: %v", set.ToSlice())
+	}
+	states := values(t, m, "state")
+	for _, state := range cpuStates {
+		assert.Equalf(t, 1, states[state], "data points of state %s", state)
+	}
+}
+
+func TestCPUTimePerCPU(t *testing.T) {
+	times, err := cpu.Times(true)
+	if err != nil || len(times) == 0 {
+		t.Skip("per-CPU times not available")
+	}
+
+	reader := start(t, host.WithPerCPU())
+	metrics := collect(t, reader)
+
+	require.Contains(t, metrics, "system.cpu.time")
+	m := metrics["system.cpu.time"]
+	for _, set := range attributeSets(t, m) {
+		assert.Equal(t, 2, set.Len(), set.ToSlice())
+	}
+
+	// Every state is reported for every CPU.
+	cpus := values(t, m, "cpu")
+	assert.Len(t, cpus, len(times))
+	for _, c := range times {
+		assert.Equalf(t, len(cpuStates), cpus[c.CPU], "data points of %s", c.CPU)
+	}
+	states := values(t, m, "state")
+	for _, state := range cpuStates {
+		assert.Equalf(t, len(times), states[state], "data points of state %s", state)
+	}
+}
+
+func TestLoadAverage(t *testing.T) {
+	if _, err := load.Avg(); err != nil {
+		t.Skip("load average not available")
+	}
+
+	reader := start(t, host.WithMinimumCollectionInterval(host.LoadMetrics, 0))
+	metrics := collect(t, reader)
+
+	for _, name := range []string{
+		"system.cpu.load_average.1m",
+		"system.cpu.load_average.5m",
+		"system.cpu.load_average.15m",
+	} {
+		require.Contains(t, metrics, name)
+		m := metrics[name]
+		assert.Equal(t, "{thread}", m.Unit, name)
+		require.IsType(t, metricdata.Gauge[float64]{}, m.Data, name)
+		dps := m.Data.(metricdata.Gauge[float64]).DataPoints
+		require.Len(t, dps, 1, name)
+		assert.Equal(t, 0, dps[0].Attributes.Len(), name)
+		assert.GreaterOrEqual(t, dps[0].Value, 0.0, name)
+	}
+}
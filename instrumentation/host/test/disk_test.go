@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"testing"
+
+	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/instrumentation/host"
+)
+
+func TestDiskMetrics(t *testing.T) {
+	counters, err := disk.IOCounters()
+	if err != nil || len(counters) == 0 {
+		t.Skip("disk I/O counters not available")
+	}
+
+	reader := start(t, host.WithMinimumCollectionInterval(host.DiskMetrics, 0))
+	metrics := collect(t, reader)
+
+	for name, unit := range map[string]string{
+		"system.disk.io":         "By",
+		"system.disk.operations": "{operation}",
+		"system.disk.io_time":    "s",
+	} {
+		require.Contains(t, metrics, name)
+		assert.Equal(t, unit, metrics[name].Unit, name)
+	}
+
+	// Read and write are reported for every device.
+	for _, name := range []string{"system.disk.io", "system.disk.operations"} {
+		devices := values(t, metrics[name], "device")
+		assert.Len(t, devices, len(counters), name)
+		for device := range counters {
+			assert.Equalf(t, 2, devices[device], "%s data points of device %s", name, device)
+		}
+		directions := values(t, metrics[name], "direction")
+		assert.Equal(t, map[string]int{"read": len(counters), "write": len(counters)}, directions, name)
+	}
+
+	devices := values(t, metrics["system.disk.io_time"], "device")
+	for device := range counters {
+		assert.Equalf(t, 1, devices[device], "system.disk.io_time data points of device %s", device)
+	}
+	for _, set := range attributeSets(t, metrics["system.disk.io_time"]) {
+		assert.Equal(t, 1, set.Len(), "system.disk.io_time attributes")
+	}
+}
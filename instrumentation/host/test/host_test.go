@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/instrumentation/host"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func start(t *testing.T, opts ...host.Option) sdkmetric.Reader {
+	t.Helper()
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	t.Cleanup(func() { assert.NoError(t, provider.Shutdown(context.Background())) })
+	opts = append(opts, host.WithMeterProvider(provider))
+	require.NoError(t, host.Start(opts...))
+	return reader
+}
+
+func collect(t *testing.T, reader sdkmetric.Reader) map[string]metricdata.Metrics {
+	t.Helper()
+	rm := metricdata.ResourceMetrics{}
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	sm := rm.ScopeMetrics[0]
+	assert.Equal(t, "go.opentelemetry.io/contrib/instrumentation/host", sm.Scope.Name)
+	assert.Equal(t, host.Version(), sm.Scope.Version)
+	metrics := map[string]metricdata.Metrics{}
+	for _, m := range sm.Metrics {
+		metrics[m.Name] = m
+	}
+	return metrics
+}
+
+// attributeSets returns the attribute sets of the data points of m.
+func attributeSets(t *testing.T, m metricdata.Metrics) []attribute.Set {
+	t.Helper()
+	var sets []attribute.Set
+	switch data := m.Data.(type) {
+	case metricdata.Sum[int64]:
+		for _, dp := range data.DataPoints {
+			sets = append(sets, dp.Attributes)
+		}
+	case metricdata.Sum[float64]:
+		for _, dp := range data.DataPoints {
+			sets = append(sets, dp.Attributes)
+		}
+	case metricdata.Gauge[int64]:
+		for _, dp := range data.DataPoints {
+			sets = append(sets, dp.Attributes)
+		}
+	case metricdata.Gauge[float64]:
+		for _, dp := range data.DataPoints {
+			sets = append(sets, dp.Attributes)
+		}
+	default:
+		t.Fatalf("unexpected data type %T of %s", m.Data, m.Name)
+	}
+	return sets
+}
+
+// values returns the values of the key attribute of the data points of m,
+// each of which is expected to have the key attribute.
+func values(t *testing.T, m metricdata.Metrics, key attribute.Key) map[string]int {
+	t.Helper()
+	vals := make(map[string]int)
+	for _, set := range attributeSets(t, m) {
+		v, ok := set.Value(key)
+		if assert.Truef(t, ok, "%s data point without %s: %v", m.Name, key, set.ToSlice()) {
+			vals[v.Emit()]++
+		}
+	}
+	return vals
+}
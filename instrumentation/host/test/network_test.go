@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	stdnet "net"
+	"testing"
+
+	"github.com/shirou/gopsutil/v3/net"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/instrumentation/host"
+)
+
+func TestNetworkMetrics(t *testing.T) {
+	counters, err := net.IOCounters(true)
+	if err != nil || len(counters) == 0 {
+		t.Skip("network I/O counters not available")
+	}
+
+	reader := start(t, host.WithMinimumCollectionInterval(host.NetworkMetrics, 0))
+	metrics := collect(t, reader)
+
+	for name, unit := range map[string]string{
+		"system.network.errors":  "{error}",
+		"system.network.dropped": "{packet}",
+	} {
+		require.Contains(t, metrics, name)
+		assert.Equal(t, unit, metrics[name].Unit, name)
+
+		// Transmit and receive are reported for every interface.
+		devices := values(t, metrics[name], "device")
+		assert.Len(t, devices, len(counters), name)
+		for _, c := range counters {
+			assert.Equalf(t, 2, devices[c.Name], "%s data points of interface %s", name, c.Name)
+		}
+		directions := values(t, metrics[name], "direction")
+		assert.Equal(t, map[string]int{"transmit": len(counters), "receive": len(counters)}, directions, name)
+	}
+
+	assert.NotContains(t, metrics, "system.network.connections", "reported without WithTCPConnections")
+}
+
+func TestNetworkConnections(t *testing.T) {
+	if _, err := net.Connections("tcp"); err != nil {
+		t.Skip("TCP connections not available")
+	}
+
+	// Ensure at least one connection is in the LISTEN state.
+	ln, err := stdnet.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	reader := start(t,
+		host.WithTCPConnections(),
+		host.WithMinimumCollectionInterval(host.NetworkMetrics, 0),
+	)
+	metrics := collect(t, reader)
+
+	require.Contains(t, metrics, "system.network.connections")
+	m := metrics["system.network.connections"]
+	assert.Equal(t, "{connection}", m.Unit)
+	for _, set := range attributeSets(t, m) {
+		assert.Equal(t, 2, set.Len(), set.ToSlice())
+	}
+	assert.Equal(t, map[string]int{"tcp": len(attributeSets(t, m))}, values(t, m, "protocol"))
+	assert.Contains(t, values(t, m, "state"), "LISTEN")
+}
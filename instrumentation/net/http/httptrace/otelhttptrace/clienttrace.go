@@ -21,10 +21,12 @@ import (
 	"net/textproto"
 	"strings"
 	"sync"
+	"time"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
 	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -42,6 +44,7 @@ var (
 	HTTPConnectionDoneNetwork  = attribute.Key("http.conn.done.network")
 	HTTPConnectionDoneAddr     = attribute.Key("http.conn.done.addr")
 	HTTPDNSAddrs               = attribute.Key("http.dns.addrs")
+	HTTPPhaseDuration          = attribute.Key("http.phase.duration")
 )
 
 var hookMap = map[string]string{
@@ -78,6 +81,20 @@ func WithoutSubSpans() ClientTraceOption {
 	})
 }
 
+// WithParentSpanEvents will modify the httptrace.ClientTrace to record a
+// single event on the span found in the context for each completed stage of a
+// request (http.getconn, http.dns, http.connect, http.tls, http.send and
+// http.receive), with its duration in seconds as the http.phase.duration
+// attribute.  The attributes of the connection used by the request are set on
+// the span.  Compared to WithoutSubSpans, which records an event when each
+// stage starts and completes, it halves the number of events recorded.
+func WithParentSpanEvents() ClientTraceOption {
+	return clientTraceOptionFunc(func(ct *clientTracer) {
+		ct.useSpans = false
+		ct.parentEvents = true
+	})
+}
+
 // WithRedactedHeaders will be replaced by fixed '****' values for the header
 // names provided.  These are in addition to the sensitive headers already
 // redacted by default: Authorization, WWW-Authenticate, Proxy-Authenticate
@@ -127,12 +144,24 @@ type clientTracer struct {
 
 	tr trace.Tracer
 
+	meterProvider metric.MeterProvider
+
+	metrics phaseMetrics
+	// phaseStarts holds the start time of the connection phases in progress.
+	phaseStarts map[string]time.Time
+	// host is the host the request is sent to.
+	host string
+
 	activeHooks     map[string]context.Context
 	root            trace.Span
 	mtx             sync.Mutex
 	redactedHeaders map[string]struct{}
 	addHeaders      bool
 	useSpans        bool
+	parentEvents    bool
+	// hookStarts holds the start time of the stages in progress when
+	// parentEvents is set.
+	hookStarts map[string]time.Time
 }
 
 // NewClientTrace returns an httptrace.ClientTrace implementation that will
@@ -141,11 +170,16 @@ type clientTracer struct {
 // (dns, connection, tls, etc). Also by default, all HTTP headers will be
 // added as attributes to spans, although several headers will be automatically
 // redacted: Authorization, WWW-Authenticate, Proxy-Authenticate,
-// Proxy-Authorization, Cookie, and Set-Cookie.
+// Proxy-Authorization, Cookie, and Set-Cookie. The duration of the DNS lookup,
+// connection establishment, TLS handshake and the time to first byte of the
+// request are recorded as histograms.
 func NewClientTrace(ctx context.Context, opts ...ClientTraceOption) *httptrace.ClientTrace {
 	ct := &clientTracer{
-		Context:     ctx,
-		activeHooks: make(map[string]context.Context),
+		Context:       ctx,
+		meterProvider: otel.GetMeterProvider(),
+		phaseStarts:   make(map[string]time.Time),
+		hookStarts:    make(map[string]time.Time),
+		activeHooks:   make(map[string]context.Context),
 		redactedHeaders: map[string]struct{}{
 			"authorization":       {},
 			"www-authenticate":    {},
@@ -167,11 +201,15 @@ func NewClientTrace(ctx context.Context, opts ...ClientTraceOption) *httptrace.C
 	for _, opt := range opts {
 		opt.apply(ct)
 	}
+	if !ct.useSpans {
+		ct.root = trace.SpanFromContext(ctx)
+	}
 
 	ct.tr = ct.tracerProvider.Tracer(
 		"go.opentelemetry.io/otel/instrumentation/httptrace",
 		trace.WithInstrumentationVersion(Version()),
 	)
+	ct.metrics = getPhaseMetrics(ct.meterProvider)
 
 	return &httptrace.ClientTrace{
 		GetConn:              ct.getConn,
@@ -195,8 +233,11 @@ func NewClientTrace(ctx context.Context, opts ...ClientTraceOption) *httptrace.C
 
 func (ct *clientTracer) start(hook, spanName string, attrs ...attribute.KeyValue) {
 	if !ct.useSpans {
-		if ct.root == nil {
-			ct.root = trace.SpanFromContext(ct.Context)
+		if ct.parentEvents {
+			ct.mtx.Lock()
+			ct.hookStarts[hook] = time.Now()
+			ct.mtx.Unlock()
+			return
 		}
 		ct.root.AddEvent(hook+".start", trace.WithAttributes(attrs...))
 		return
@@ -226,6 +267,17 @@ func (ct *clientTracer) end(hook string, err error, attrs ...attribute.KeyValue)
 		if err != nil {
 			attrs = append(attrs, attribute.String(hook+".error", err.Error()))
 		}
+		if ct.parentEvents {
+			ct.mtx.Lock()
+			start, ok := ct.hookStarts[hook]
+			delete(ct.hookStarts, hook)
+			ct.mtx.Unlock()
+			if ok {
+				attrs = append(attrs, HTTPPhaseDuration.Float64(time.Since(start).Seconds()))
+			}
+			ct.root.AddEvent(hookEventName(hook), trace.WithAttributes(attrs...))
+			return
+		}
 		ct.root.AddEvent(hook+".done", trace.WithAttributes(attrs...))
 		return
 	}
@@ -252,6 +304,15 @@ func (ct *clientTracer) end(hook string, err error, attrs ...attribute.KeyValue)
 	}
 }
 
+// hookEventName returns the name of the event recorded for hook when
+// parentEvents is set.
+func hookEventName(hook string) string {
+	if strings.HasPrefix(hook, "http.connect") {
+		return "http.connect"
+	}
+	return hook
+}
+
 func (ct *clientTracer) getParentContext(hook string) context.Context {
 	ctx, ok := ct.activeHooks[parentHook(hook)]
 	if !ok {
@@ -270,6 +331,8 @@ func (ct *clientTracer) span(hook string) trace.Span {
 }
 
 func (ct *clientTracer) getConn(host string) {
+	ct.setHost(host)
+	ct.startPhase(requestPhase)
 	ct.start("http.getconn", "http.getconn", semconv.NetHostName(host))
 }
 
@@ -283,6 +346,10 @@ func (ct *clientTracer) gotConn(info httptrace.GotConnInfo) {
 	if info.WasIdle {
 		attrs = append(attrs, HTTPConnectionIdleTime.String(info.IdleTime.String()))
 	}
+	if ct.parentEvents {
+		ct.root.SetAttributes(attrs...)
+		attrs = nil
+	}
 	ct.end("http.getconn", nil, attrs...)
 }
 
@@ -291,10 +358,12 @@ func (ct *clientTracer) putIdleConn(err error) {
 }
 
 func (ct *clientTracer) gotFirstResponseByte() {
+	ct.endPhase(ct.metrics.ttfb, requestPhase, nil)
 	ct.start("http.receive", "http.receive")
 }
 
 func (ct *clientTracer) dnsStart(info httptrace.DNSStartInfo) {
+	ct.startPhase("http.dns")
 	ct.start("http.dns", "http.dns", semconv.NetHostName(info.Host))
 }
 
@@ -303,10 +372,12 @@ func (ct *clientTracer) dnsDone(info httptrace.DNSDoneInfo) {
 	for _, netAddr := range info.Addrs {
 		addrs = append(addrs, netAddr.String())
 	}
+	ct.endPhase(ct.metrics.dns, "http.dns", info.Err)
 	ct.end("http.dns", info.Err, HTTPDNSAddrs.String(sliceToString(addrs)))
 }
 
 func (ct *clientTracer) connectStart(network, addr string) {
+	ct.startPhase("http.connect." + addr)
 	ct.start("http.connect."+addr, "http.connect",
 		HTTPRemoteAddr.String(addr),
 		HTTPConnectionStartNetwork.String(network),
@@ -314,6 +385,7 @@ func (ct *clientTracer) connectStart(network, addr string) {
 }
 
 func (ct *clientTracer) connectDone(network, addr string, err error) {
+	ct.endPhase(ct.metrics.connect, "http.connect."+addr, err)
 	ct.end("http.connect."+addr, err,
 		HTTPConnectionDoneAddr.String(addr),
 		HTTPConnectionDoneNetwork.String(network),
@@ -321,10 +393,12 @@ func (ct *clientTracer) connectDone(network, addr string, err error) {
 }
 
 func (ct *clientTracer) tlsHandshakeStart() {
+	ct.startPhase("http.tls")
 	ct.start("http.tls", "http.tls")
 }
 
 func (ct *clientTracer) tlsHandshakeDone(_ tls.ConnectionState, err error) {
+	ct.endPhase(ct.metrics.tls, "http.tls", err)
 	ct.end("http.tls", err)
 }
 
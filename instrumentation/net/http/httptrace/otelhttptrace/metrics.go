@@ -0,0 +1,167 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelhttptrace // import "go.opentelemetry.io/contrib/instrumentation/net/http/httptrace/otelhttptrace"
+
+import (
+	"net"
+	"reflect"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+)
+
+const meterName = "go.opentelemetry.io/contrib/instrumentation/net/http/httptrace/otelhttptrace"
+
+// Connection phase metric names.
+const (
+	dnsDuration     = "http.client.dns.duration"       // Float64Histogram
+	connectDuration = "http.client.connect.duration"   // Float64Histogram
+	tlsDuration     = "http.client.tls.duration"       // Float64Histogram
+	timeToFirstByte = "http.client.time_to_first_byte" // Float64Histogram
+)
+
+// requestPhase identifies the phase of the whole request, from the GetConn
+// hook, for the time to first byte.
+const requestPhase = "http.request"
+
+// errorKey is set on the connection phase metrics of the phases that failed.
+var errorKey = attribute.Key("error")
+
+// WithMeterProvider specifies a meter provider for creating a meter.
+// The global provider is used if none is specified.
+func WithMeterProvider(provider metric.MeterProvider) ClientTraceOption {
+	return clientTraceOptionFunc(func(ct *clientTracer) {
+		if provider != nil {
+			ct.meterProvider = provider
+		}
+	})
+}
+
+// phaseMetrics holds the histograms of the duration of the connection phases
+// of a request.
+type phaseMetrics struct {
+	dns     metric.Float64Histogram
+	connect metric.Float64Histogram
+	tls     metric.Float64Histogram
+	ttfb    metric.Float64Histogram
+}
+
+// phaseMetricsCache holds the phaseMetrics of each metric.MeterProvider, so
+// their instruments are created once rather than for each request.
+var phaseMetricsCache sync.Map // metric.MeterProvider -> phaseMetrics
+
+// getPhaseMetrics returns the phaseMetrics of provider, creating them the
+// first time provider is used.
+func getPhaseMetrics(provider metric.MeterProvider) phaseMetrics {
+	if !reflect.TypeOf(provider).Comparable() {
+		// provider cannot be a map key.
+		return newPhaseMetrics(provider)
+	}
+	if m, ok := phaseMetricsCache.Load(provider); ok {
+		return m.(phaseMetrics)
+	}
+	m, _ := phaseMetricsCache.LoadOrStore(provider, newPhaseMetrics(provider))
+	return m.(phaseMetrics)
+}
+
+func newPhaseMetrics(provider metric.MeterProvider) phaseMetrics {
+	meter := provider.Meter(
+		meterName,
+		metric.WithInstrumentationVersion(Version()),
+	)
+
+	var (
+		m   phaseMetrics
+		err error
+	)
+	m.dns, err = meter.Float64Histogram(
+		dnsDuration,
+		metric.WithUnit("s"),
+		metric.WithDescription("Measures the duration of the DNS lookups of HTTP client requests."),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+	m.connect, err = meter.Float64Histogram(
+		connectDuration,
+		metric.WithUnit("s"),
+		metric.WithDescription("Measures the duration of the establishment of the connections of HTTP client requests."),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+	m.tls, err = meter.Float64Histogram(
+		tlsDuration,
+		metric.WithUnit("s"),
+		metric.WithDescription("Measures the duration of the TLS handshakes of HTTP client requests."),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+	m.ttfb, err = meter.Float64Histogram(
+		timeToFirstByte,
+		metric.WithUnit("s"),
+		metric.WithDescription("Measures the duration from the start of HTTP client requests to the first byte of their response."),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+	return m
+}
+
+// startPhase records the start time of the phase identified by key.
+func (ct *clientTracer) startPhase(key string) {
+	ct.mtx.Lock()
+	defer ct.mtx.Unlock()
+	ct.phaseStarts[key] = time.Now()
+}
+
+// endPhase records the duration of the phase identified by key on h, if its
+// start was recorded.
+func (ct *clientTracer) endPhase(h metric.Float64Histogram, key string, err error) {
+	ct.mtx.Lock()
+	start, ok := ct.phaseStarts[key]
+	delete(ct.phaseStarts, key)
+	host := ct.host
+	ct.mtx.Unlock()
+	if !ok {
+		return
+	}
+
+	attrs := make([]attribute.KeyValue, 0, 2)
+	if host != "" {
+		attrs = append(attrs, semconv.NetPeerName(host))
+	}
+	if err != nil {
+		attrs = append(attrs, errorKey.Bool(true))
+	}
+	h.Record(ct.Context, time.Since(start).Seconds(), metric.WithAttributes(attrs...))
+}
+
+// setHost records the host of the request from the host:port passed to the
+// GetConn hook.
+func (ct *clientTracer) setHost(hostPort string) {
+	host, _, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		host = hostPort
+	}
+	ct.mtx.Lock()
+	ct.host = host
+	ct.mtx.Unlock()
+}
@@ -29,6 +29,9 @@ import (
 	"go.opentelemetry.io/contrib/instrumentation/net/http/httptrace/otelhttptrace"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	"go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
@@ -543,3 +546,108 @@ func TestHTTPRequestWithExpect100Continue(t *testing.T) {
 	}
 	require.True(t, found)
 }
+
+func TestConnectionPhaseMetrics(t *testing.T) {
+	fixture := prepareClientTraceTest(t)
+	reader := metric.NewManualReader()
+	mp := metric.NewMeterProvider(metric.WithReader(reader))
+
+	ctx := context.Background()
+	ctx = httptrace.WithClientTrace(ctx,
+		otelhttptrace.NewClientTrace(ctx, otelhttptrace.WithMeterProvider(mp)),
+	)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fixture.URL, nil)
+	require.NoError(t, err)
+	resp, err := fixture.Client.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	rm := metricdata.ResourceMetrics{}
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	sm := rm.ScopeMetrics[0]
+	assert.Equal(t, "go.opentelemetry.io/contrib/instrumentation/net/http/httptrace/otelhttptrace", sm.Scope.Name)
+
+	counts := make(map[string]uint64)
+	for _, m := range sm.Metrics {
+		hist, ok := m.Data.(metricdata.Histogram[float64])
+		require.True(t, ok, "%s is not a histogram", m.Name)
+		assert.Equal(t, "s", m.Unit)
+		for _, dp := range hist.DataPoints {
+			counts[m.Name] += dp.Count
+			assert.Contains(t, dp.Attributes.ToSlice(), attribute.String("net.peer.name", "127.0.0.1"))
+		}
+	}
+	// The test server is addressed by IP, there is neither DNS lookup nor TLS
+	// handshake.
+	assert.Equal(t, map[string]uint64{
+		"http.client.connect.duration":   1,
+		"http.client.time_to_first_byte": 1,
+	}, counts)
+}
+
+// meterCounter counts the meters created with its MeterProvider.
+type meterCounter struct {
+	otelmetric.MeterProvider
+	meters int
+}
+
+func (mc *meterCounter) Meter(name string, opts ...otelmetric.MeterOption) otelmetric.Meter {
+	mc.meters++
+	return mc.MeterProvider.Meter(name, opts...)
+}
+
+func TestConnectionPhaseMetricsCreatedOnce(t *testing.T) {
+	mp := &meterCounter{MeterProvider: metric.NewMeterProvider()}
+	for i := 0; i < 3; i++ {
+		otelhttptrace.NewClientTrace(context.Background(), otelhttptrace.WithMeterProvider(mp))
+	}
+	assert.Equal(t, 1, mp.meters)
+}
+
+func TestWithParentSpanEvents(t *testing.T) {
+	fixture := prepareClientTraceTest(t)
+
+	ctx, span := otel.Tracer("oteltest").Start(context.Background(), "root")
+	ctx = httptrace.WithClientTrace(ctx,
+		otelhttptrace.NewClientTrace(ctx,
+			otelhttptrace.WithParentSpanEvents(),
+			otelhttptrace.WithoutHeaders(),
+		),
+	)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fixture.URL, nil)
+	require.NoError(t, err)
+	resp, err := fixture.Client.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	span.End()
+
+	require.Len(t, fixture.SpanRecorder.Ended(), 1)
+	recSpan := fixture.SpanRecorder.Ended()[0]
+
+	attrs := attribute.NewSet(recSpan.Attributes()...)
+	remote, ok := attrs.Value(otelhttptrace.HTTPRemoteAddr)
+	assert.True(t, ok)
+	assert.Equal(t, fixture.Address, remote.AsString())
+	reused, ok := attrs.Value(otelhttptrace.HTTPConnectionReused)
+	assert.True(t, ok)
+	assert.False(t, reused.AsBool())
+
+	var names []string
+	for _, e := range recSpan.Events() {
+		names = append(names, e.Name)
+		eventAttrs := attribute.NewSet(e.Attributes...)
+		d, ok := eventAttrs.Value(otelhttptrace.HTTPPhaseDuration)
+		assert.True(t, ok, "event %q has no duration", e.Name)
+		assert.GreaterOrEqual(t, d.AsFloat64(), 0.0)
+	}
+	assert.Equal(t, []string{"http.connect", "http.getconn", "http.send", "http.receive"}, names)
+}
+
+func TestWithoutSubSpansEndBeforeStart(t *testing.T) {
+	ct := otelhttptrace.NewClientTrace(context.Background(), otelhttptrace.WithoutSubSpans())
+	assert.NotPanics(t, func() {
+		ct.DNSDone(httptrace.DNSDoneInfo{})
+		ct.PutIdleConn(nil)
+	})
+}
@@ -0,0 +1,31 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package otelnet provides metrics instrumentation for net.Listener and
+// net.Conn.
+//
+// It is meant for servers and clients of custom protocols that have no
+// higher-level instrumentation. NewListener wraps a listener so that the
+// connections it accepts are measured, and NewConn wraps a single, typically
+// dialed, connection. The following metrics are recorded:
+//
+//   - net.connection.bytes_received: the number of bytes read from connections.
+//   - net.connection.bytes_sent: the number of bytes written to connections.
+//   - net.connections.open: the number of connections currently open.
+//   - net.connection.duration: the time connections were open.
+//
+// The metrics are attributed with the transport and the address of the
+// server side of the connections, that is the listener address for accepted
+// connections and the remote address for dialed ones.
+package otelnet // import "go.opentelemetry.io/contrib/instrumentation/net/otelnet"
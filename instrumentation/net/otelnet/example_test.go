@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelnet_test
+
+import (
+	"bufio"
+	"log"
+	"net"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/otelnet"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func ExampleNewListener() {
+	ln, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		log.Fatal(err)
+	}
+	ln = otelnet.NewListener(ln, otelnet.WithAttributes(attribute.String("protocol", "echo")))
+	defer ln.Close()
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		go func(conn net.Conn) {
+			defer conn.Close()
+			line, err := bufio.NewReader(conn).ReadString('\n')
+			if err != nil {
+				return
+			}
+			_, _ = conn.Write([]byte(line))
+		}(conn)
+	}
+}
+
+func ExampleNewConn() {
+	conn, err := net.Dial("tcp", "localhost:7")
+	if err != nil {
+		log.Fatal(err)
+	}
+	conn = otelnet.NewConn(conn)
+	defer conn.Close()
+
+	_, _ = conn.Write([]byte("hello\n"))
+}
@@ -0,0 +1,201 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelnet // import "go.opentelemetry.io/contrib/instrumentation/net/otelnet"
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+// Metric names.
+const (
+	bytesReceived   = "net.connection.bytes_received" // Int64Counter
+	bytesSent       = "net.connection.bytes_sent"     // Int64Counter
+	openConnections = "net.connections.open"          // Int64UpDownCounter
+	connDuration    = "net.connection.duration"       // Float64Histogram
+)
+
+// instruments records the metrics of the connections of a listener or of a
+// single connection.
+type instruments struct {
+	received metric.Int64Counter
+	sent     metric.Int64Counter
+	open     metric.Int64UpDownCounter
+	duration metric.Float64Histogram
+
+	attrs metric.MeasurementOption
+}
+
+func newInstruments(cfg *config, addr net.Addr) *instruments {
+	meter := cfg.MeterProvider.Meter(
+		instrumentationName,
+		metric.WithInstrumentationVersion(Version()),
+	)
+	inst := &instruments{
+		attrs: metric.WithAttributes(append(addrAttributes(addr), cfg.Attributes...)...),
+	}
+
+	var err error
+	inst.received, err = meter.Int64Counter(
+		bytesReceived,
+		metric.WithUnit("By"),
+		metric.WithDescription("The number of bytes read from connections."),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+	inst.sent, err = meter.Int64Counter(
+		bytesSent,
+		metric.WithUnit("By"),
+		metric.WithDescription("The number of bytes written to connections."),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+	inst.open, err = meter.Int64UpDownCounter(
+		openConnections,
+		metric.WithUnit("{connection}"),
+		metric.WithDescription("The number of connections that are currently open."),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+	inst.duration, err = meter.Float64Histogram(
+		connDuration,
+		metric.WithUnit("s"),
+		metric.WithDescription("The time connections were open."),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+	return inst
+}
+
+// addrAttributes returns the transport and server address attributes of addr.
+func addrAttributes(addr net.Addr) []attribute.KeyValue {
+	if addr == nil {
+		return nil
+	}
+	attrs := []attribute.KeyValue{transport(addr.Network())}
+	host, port, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		// Unix sockets have no port.
+		if s := addr.String(); s != "" {
+			attrs = append(attrs, semconv.ServerAddress(s))
+		}
+		return attrs
+	}
+	attrs = append(attrs, semconv.ServerAddress(host))
+	if p, err := strconv.Atoi(port); err == nil {
+		attrs = append(attrs, semconv.ServerPort(p))
+	}
+	return attrs
+}
+
+func transport(network string) attribute.KeyValue {
+	switch {
+	case strings.HasPrefix(network, "tcp"):
+		return semconv.NetworkTransportTCP
+	case strings.HasPrefix(network, "udp"):
+		return semconv.NetworkTransportUDP
+	case strings.HasPrefix(network, "unix"):
+		return semconv.NetworkTransportUnix
+	default:
+		return semconv.NetworkTransportKey.String(network)
+	}
+}
+
+// wrap returns c recording its metrics with inst.
+func (inst *instruments) wrap(c net.Conn) net.Conn {
+	inst.open.Add(context.Background(), 1, inst.attrs)
+	return &conn{Conn: c, inst: inst, start: time.Now()}
+}
+
+// NewListener returns a net.Listener recording the metrics of the connections
+// accepted by ln.
+func NewListener(ln net.Listener, opts ...Option) net.Listener {
+	return &listener{
+		Listener: ln,
+		inst:     newInstruments(newConfig(opts), ln.Addr()),
+	}
+}
+
+type listener struct {
+	net.Listener
+	inst *instruments
+}
+
+// Accept waits for and returns the next connection to the listener. The
+// returned connection records its metrics until it is closed.
+func (l *listener) Accept() (net.Conn, error) {
+	c, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return l.inst.wrap(c), nil
+}
+
+// NewConn returns a net.Conn recording the metrics of c, typically a dialed
+// connection, until it is closed.
+func NewConn(c net.Conn, opts ...Option) net.Conn {
+	return newInstruments(newConfig(opts), c.RemoteAddr()).wrap(c)
+}
+
+type conn struct {
+	net.Conn
+	inst  *instruments
+	start time.Time
+
+	closeOnce sync.Once
+}
+
+// Read reads data from the connection and records the number of bytes read.
+func (c *conn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	if n > 0 {
+		c.inst.received.Add(context.Background(), int64(n), c.inst.attrs)
+	}
+	return n, err
+}
+
+// Write writes data to the connection and records the number of bytes
+// written.
+func (c *conn) Write(b []byte) (int, error) {
+	n, err := c.Conn.Write(b)
+	if n > 0 {
+		c.inst.sent.Add(context.Background(), int64(n), c.inst.attrs)
+	}
+	return n, err
+}
+
+// Close closes the connection and records the time it was open.
+func (c *conn) Close() error {
+	err := c.Conn.Close()
+	c.closeOnce.Do(func() {
+		ctx := context.Background()
+		c.inst.open.Add(ctx, -1, c.inst.attrs)
+		c.inst.duration.Record(ctx, time.Since(c.start).Seconds(), c.inst.attrs)
+	})
+	return err
+}
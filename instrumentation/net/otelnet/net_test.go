@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelnet
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+func TestAddrAttributes(t *testing.T) {
+	testcases := []struct {
+		name string
+		addr net.Addr
+		want []attribute.KeyValue
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name: "tcp",
+			addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 8080},
+			want: []attribute.KeyValue{semconv.NetworkTransportTCP, semconv.ServerAddress("127.0.0.1"), semconv.ServerPort(8080)},
+		},
+		{
+			name: "udp6",
+			addr: &net.UDPAddr{IP: net.IPv6loopback, Port: 53},
+			want: []attribute.KeyValue{semconv.NetworkTransportUDP, semconv.ServerAddress("::1"), semconv.ServerPort(53)},
+		},
+		{
+			name: "unix",
+			addr: &net.UnixAddr{Name: "/tmp/app.sock", Net: "unix"},
+			want: []attribute.KeyValue{semconv.NetworkTransportUnix, semconv.ServerAddress("/tmp/app.sock")},
+		},
+		{
+			name: "unnamed unix",
+			addr: &net.UnixAddr{Net: "unixpacket"},
+			want: []attribute.KeyValue{semconv.NetworkTransportUnix},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, addrAttributes(tc.addr))
+		})
+	}
+}
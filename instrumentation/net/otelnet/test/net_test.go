@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/otelnet"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func collect(t *testing.T, reader sdkmetric.Reader) map[string]metricdata.Metrics {
+	t.Helper()
+	rm := metricdata.ResourceMetrics{}
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	sm := rm.ScopeMetrics[0]
+	assert.Equal(t, "go.opentelemetry.io/contrib/instrumentation/net/otelnet", sm.Scope.Name)
+	assert.Equal(t, otelnet.Version(), sm.Scope.Version)
+	metrics := map[string]metricdata.Metrics{}
+	for _, m := range sm.Metrics {
+		metrics[m.Name] = m
+	}
+	return metrics
+}
+
+func sum(t *testing.T, m metricdata.Metrics) int64 {
+	t.Helper()
+	require.IsType(t, metricdata.Sum[int64]{}, m.Data, m.Name)
+	data := m.Data.(metricdata.Sum[int64])
+	require.Len(t, data.DataPoints, 1, m.Name)
+	return data.DataPoints[0].Value
+}
+
+func TestListener(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	ln = otelnet.NewListener(ln,
+		otelnet.WithMeterProvider(provider),
+		otelnet.WithAttributes(attribute.String("protocol", "echo")),
+	)
+	defer ln.Close()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		conn, err := ln.Accept()
+		if !assert.NoError(t, err) {
+			return
+		}
+		buf := make([]byte, 5)
+		_, err = io.ReadFull(conn, buf)
+		assert.NoError(t, err)
+		_, err = conn.Write(append(buf, buf...))
+		assert.NoError(t, err)
+		assert.NoError(t, conn.Close())
+	}()
+
+	client, err := net.Dial("tcp", ln.Addr().String())
+	require.NoError(t, err)
+	defer client.Close()
+	_, err = client.Write([]byte("hello"))
+	require.NoError(t, err)
+	_, err = io.ReadAll(client)
+	require.NoError(t, err)
+	<-done
+
+	metrics := collect(t, reader)
+	require.Len(t, metrics, 4)
+	assert.Equal(t, int64(5), sum(t, metrics["net.connection.bytes_received"]))
+	assert.Equal(t, int64(10), sum(t, metrics["net.connection.bytes_sent"]))
+	assert.Equal(t, int64(0), sum(t, metrics["net.connections.open"]))
+
+	require.IsType(t, metricdata.Histogram[float64]{}, metrics["net.connection.duration"].Data)
+	duration := metrics["net.connection.duration"].Data.(metricdata.Histogram[float64])
+	require.Len(t, duration.DataPoints, 1)
+	dp := duration.DataPoints[0]
+	assert.Equal(t, uint64(1), dp.Count)
+	for _, kv := range []attribute.KeyValue{
+		attribute.String("network.transport", "tcp"),
+		attribute.String("server.address", "127.0.0.1"),
+		attribute.String("protocol", "echo"),
+	} {
+		v, ok := dp.Attributes.Value(kv.Key)
+		assert.True(t, ok, kv.Key)
+		assert.Equal(t, kv.Value, v, kv.Key)
+	}
+}
+
+func TestConn(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	server, client := net.Pipe()
+	conn := otelnet.NewConn(client, otelnet.WithMeterProvider(provider))
+	go func() {
+		_, _ = io.Copy(server, server)
+	}()
+
+	_, err := conn.Write([]byte("ping"))
+	require.NoError(t, err)
+	_, err = io.ReadFull(conn, make([]byte, 4))
+	require.NoError(t, err)
+
+	metrics := collect(t, reader)
+	assert.Equal(t, int64(1), sum(t, metrics["net.connections.open"]))
+
+	require.NoError(t, conn.Close())
+	// Closing twice only records the connection once.
+	_ = conn.Close()
+	require.NoError(t, server.Close())
+
+	metrics = collect(t, reader)
+	assert.Equal(t, int64(4), sum(t, metrics["net.connection.bytes_received"]))
+	assert.Equal(t, int64(4), sum(t, metrics["net.connection.bytes_sent"]))
+	assert.Equal(t, int64(0), sum(t, metrics["net.connections.open"]))
+	duration := metrics["net.connection.duration"].Data.(metricdata.Histogram[float64])
+	require.Len(t, duration.DataPoints, 1)
+	assert.Equal(t, uint64(1), duration.DataPoints[0].Count)
+}
@@ -0,0 +1,205 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelexec // import "go.opentelemetry.io/contrib/instrumentation/os/exec/otelexec"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// exitCodeKey is the attribute Key recording the exit code of the child
+// process.
+const exitCodeKey = attribute.Key("process.exit_code")
+
+// Cmd is an exec.Cmd whose execution is traced.
+//
+// Use the methods of Cmd, not those of the embedded exec.Cmd, to start and
+// wait for the command. Otherwise the execution is not traced.
+type Cmd struct {
+	*exec.Cmd
+
+	ctx  context.Context
+	cfg  *config
+	span trace.Span
+}
+
+// Wrap returns a Cmd tracing the execution of cmd. The span recorded for the
+// execution is a child of the span in ctx.
+//
+// ctx is only used for tracing: use exec.CommandContext to create cmd if
+// the process needs to be killed when the context is done.
+func Wrap(ctx context.Context, cmd *exec.Cmd, opts ...Option) *Cmd {
+	return &Cmd{
+		Cmd: cmd,
+		ctx: ctx,
+		cfg: newConfig(opts),
+	}
+}
+
+// Start starts the command and the span recording its execution. The span
+// context is injected into the environment of the child process.
+//
+// The Wait method must be called to end the span.
+func (c *Cmd) Start() error {
+	if c.Process != nil || c.span != nil {
+		// Let exec.Cmd report the misuse.
+		return c.Cmd.Start()
+	}
+
+	args := c.Args
+	if c.cfg.ArgsRedactor != nil {
+		args = c.cfg.ArgsRedactor(args)
+	}
+	command := c.Path
+	if len(c.Args) > 0 {
+		command = c.Args[0]
+	}
+	var ctx context.Context
+	ctx, c.span = c.cfg.tracer.Start(c.ctx, "exec "+filepath.Base(c.Path),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(
+			semconv.ProcessCommand(command),
+			semconv.ProcessCommandArgs(args...),
+			semconv.ProcessExecutablePath(c.Path),
+		),
+	)
+	c.injectEnv(ctx)
+
+	if err := c.Cmd.Start(); err != nil {
+		c.end(err)
+		return err
+	}
+	c.span.SetAttributes(semconv.ProcessPID(c.Process.Pid))
+	return nil
+}
+
+// Wait waits for the command to exit and ends the span recording its
+// execution. See exec.Cmd.Wait for details.
+func (c *Cmd) Wait() error {
+	err := c.Cmd.Wait()
+	c.end(err)
+	return err
+}
+
+// Run starts the command and waits for it to complete. See exec.Cmd.Run for
+// details.
+func (c *Cmd) Run() error {
+	if err := c.Start(); err != nil {
+		return err
+	}
+	return c.Wait()
+}
+
+// Output runs the command and returns its standard output. See
+// exec.Cmd.Output for details.
+func (c *Cmd) Output() ([]byte, error) {
+	if c.Stdout != nil {
+		return nil, errors.New("exec: Stdout already set")
+	}
+	var stdout, stderr bytes.Buffer
+	c.Stdout = &stdout
+
+	captureErr := c.Stderr == nil
+	if captureErr {
+		c.Stderr = &stderr
+	}
+
+	err := c.Run()
+	var ee *exec.ExitError
+	if err != nil && captureErr && errors.As(err, &ee) {
+		ee.Stderr = stderr.Bytes()
+	}
+	return stdout.Bytes(), err
+}
+
+// CombinedOutput runs the command and returns its combined standard output
+// and standard error. See exec.Cmd.CombinedOutput for details.
+func (c *Cmd) CombinedOutput() ([]byte, error) {
+	if c.Stdout != nil {
+		return nil, errors.New("exec: Stdout already set")
+	}
+	if c.Stderr != nil {
+		return nil, errors.New("exec: Stderr already set")
+	}
+	var b bytes.Buffer
+	c.Stdout = &b
+	c.Stderr = &b
+	err := c.Run()
+	return b.Bytes(), err
+}
+
+// end records the outcome of the execution and ends the span.
+func (c *Cmd) end(err error) {
+	if c.span == nil {
+		return
+	}
+	if c.ProcessState != nil {
+		c.span.SetAttributes(exitCodeKey.Int(c.ProcessState.ExitCode()))
+	}
+	if err != nil {
+		c.span.RecordError(err)
+		c.span.SetStatus(codes.Error, err.Error())
+	}
+	c.span.End()
+}
+
+// injectEnv adds the propagation fields of ctx to the environment of the
+// child process. Each field is set as an upper-cased environment variable,
+// replacing any value inherited from the parent.
+func (c *Cmd) injectEnv(ctx context.Context) {
+	carrier := propagation.MapCarrier{}
+	c.cfg.Propagators.Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return
+	}
+
+	env := c.Env
+	if env == nil {
+		env = os.Environ()
+	}
+	vars := make(map[string]string, len(carrier))
+	for k, v := range carrier {
+		vars[envName(k)] = v
+	}
+
+	out := make([]string, 0, len(env)+len(vars))
+	for _, kv := range env {
+		name, _, _ := strings.Cut(kv, "=")
+		if _, ok := vars[name]; ok {
+			continue
+		}
+		out = append(out, kv)
+	}
+	for name, v := range vars {
+		out = append(out, name+"="+v)
+	}
+	c.Env = out
+}
+
+// envName returns the environment variable name of a propagation field.
+func envName(field string) string {
+	return strings.ToUpper(strings.ReplaceAll(field, "-", "_"))
+}
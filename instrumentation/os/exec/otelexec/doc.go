@@ -0,0 +1,25 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package otelexec provides tracing instrumentation for the os/exec package.
+//
+// A Cmd wraps an exec.Cmd and records a span covering the execution of the
+// child process. The span describes the executed command, its arguments
+// (with secrets redacted), the process ID, and the exit code of the process.
+//
+// The span context and baggage are also passed to the child process through
+// its environment, using the upper-cased propagation field names as variable
+// names (e.g. TRACEPARENT), so a child process instrumented with
+// OpenTelemetry can continue the trace.
+package otelexec // import "go.opentelemetry.io/contrib/instrumentation/os/exec/otelexec"
@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelexec_test
+
+import (
+	"context"
+	"log"
+	"os/exec"
+
+	"go.opentelemetry.io/contrib/instrumentation/os/exec/otelexec"
+)
+
+func ExampleWrap() {
+	ctx := context.Background()
+
+	// The child process receives the span context in its TRACEPARENT
+	// environment variable.
+	cmd := otelexec.Wrap(ctx, exec.CommandContext(ctx, "make", "build"))
+	if err := cmd.Run(); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelexec // import "go.opentelemetry.io/contrib/instrumentation/os/exec/otelexec"
+
+import "strings"
+
+// Redacted replaces the redacted values of command arguments.
+const Redacted = "REDACTED"
+
+// ArgsRedactor returns the command arguments as they will be recorded. It
+// must not modify args.
+type ArgsRedactor func(args []string) []string
+
+// sensitiveFlags are the flag name fragments whose values RedactArgs
+// redacts.
+var sensitiveFlags = []string{
+	"password",
+	"passwd",
+	"secret",
+	"token",
+	"apikey",
+	"api-key",
+	"api_key",
+	"credential",
+	"authorization",
+}
+
+// RedactArgs is the default ArgsRedactor. It redacts the value of any flag
+// whose name contains a sensitive term (e.g. password, secret, token),
+// whether the value is passed as "--flag=value" or as the following
+// argument ("--flag value").
+func RedactArgs(args []string) []string {
+	out := make([]string, len(args))
+	redactNext := false
+	for i, arg := range args {
+		if redactNext {
+			out[i] = Redacted
+			redactNext = false
+			continue
+		}
+		out[i] = arg
+		if !strings.HasPrefix(arg, "-") {
+			continue
+		}
+		name, _, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !isSensitive(name) {
+			continue
+		}
+		if hasValue {
+			out[i] = arg[:strings.Index(arg, "=")+1] + Redacted
+		} else {
+			redactNext = true
+		}
+	}
+	return out
+}
+
+func isSensitive(flag string) bool {
+	flag = strings.ToLower(flag)
+	for _, s := range sensitiveFlags {
+		if strings.Contains(flag, s) {
+			return true
+		}
+	}
+	return false
+}
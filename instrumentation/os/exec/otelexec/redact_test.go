@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelexec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{
+			name: "no flags",
+			args: []string{"ls", "-la", "/tmp"},
+			want: []string{"ls", "-la", "/tmp"},
+		},
+		{
+			name: "inline value",
+			args: []string{"deploy", "--token=abc123", "--env=prod"},
+			want: []string{"deploy", "--token=REDACTED", "--env=prod"},
+		},
+		{
+			name: "separate value",
+			args: []string{"mysql", "--Password", "hunter2", "-u", "root"},
+			want: []string{"mysql", "--Password", "REDACTED", "-u", "root"},
+		},
+		{
+			name: "single dash",
+			args: []string{"curl", "-api-key", "k", "https://example.com"},
+			want: []string{"curl", "-api-key", "REDACTED", "https://example.com"},
+		},
+		{
+			name: "trailing flag",
+			args: []string{"login", "--secret"},
+			want: []string{"login", "--secret"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orig := append([]string(nil), tt.args...)
+			assert.Equal(t, tt.want, RedactArgs(tt.args))
+			assert.Equal(t, orig, tt.args, "input modified")
+		})
+	}
+}
+
+func TestEnvName(t *testing.T) {
+	assert.Equal(t, "TRACEPARENT", envName("traceparent"))
+	assert.Equal(t, "X_B3_TRACEID", envName("x-b3-traceid"))
+}
@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/instrumentation/os/exec/otelexec"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const helperEnv = "OTELEXEC_TEST_HELPER"
+
+// TestMain lets the test binary act as the child process: when helperEnv is
+// set it prints the TRACEPARENT it received and exits with the requested
+// code.
+func TestMain(m *testing.M) {
+	if code := os.Getenv(helperEnv); code != "" {
+		fmt.Print(os.Getenv("TRACEPARENT"))
+		c, _ := strconv.Atoi(code)
+		os.Exit(c)
+	}
+	os.Exit(m.Run())
+}
+
+func helper(exitCode int, args ...string) *exec.Cmd {
+	cmd := exec.Command(os.Args[0], args...)
+	cmd.Env = append(os.Environ(), helperEnv+"="+strconv.Itoa(exitCode), "TRACEPARENT=stale")
+	return cmd
+}
+
+func newProvider() (*tracetest.SpanRecorder, *sdktrace.TracerProvider) {
+	sr := tracetest.NewSpanRecorder()
+	return sr, sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+}
+
+func TestOutput(t *testing.T) {
+	sr, tp := newProvider()
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "parent")
+
+	cmd := otelexec.Wrap(ctx, helper(0, "-test.run=none", "--password=hunter2"),
+		otelexec.WithTracerProvider(tp),
+		otelexec.WithPropagators(propagation.TraceContext{}),
+	)
+	out, err := cmd.Output()
+	require.NoError(t, err)
+	parent.End()
+
+	spans := sr.Ended()
+	require.Len(t, spans, 2)
+	span := spans[0]
+	assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+	assert.Equal(t, trace.SpanKindInternal, span.SpanKind())
+
+	// The child received the context of the exec span, not the stale one.
+	carrier := propagation.MapCarrier{"traceparent": string(out)}
+	got := trace.SpanContextFromContext(propagation.TraceContext{}.Extract(context.Background(), carrier))
+	assert.Equal(t, span.SpanContext().SpanID(), got.SpanID())
+
+	attrs := span.Attributes()
+	assert.Contains(t, attrs, semconv.ProcessCommandArgs(os.Args[0], "-test.run=none", "--password=REDACTED"))
+	assert.Contains(t, attrs, semconv.ProcessPID(cmd.Process.Pid))
+	assert.Contains(t, attrs, attribute.Int("process.exit_code", 0))
+	assert.Equal(t, codes.Unset, span.Status().Code)
+}
+
+func TestRunExitError(t *testing.T) {
+	sr, tp := newProvider()
+
+	cmd := otelexec.Wrap(context.Background(), helper(3), otelexec.WithTracerProvider(tp))
+	err := cmd.Run()
+	var ee *exec.ExitError
+	require.True(t, errors.As(err, &ee))
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	assert.Contains(t, spans[0].Attributes(), attribute.Int("process.exit_code", 3))
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+}
+
+func TestStartError(t *testing.T) {
+	sr, tp := newProvider()
+
+	cmd := otelexec.Wrap(context.Background(), exec.Command("otelexec-does-not-exist"), otelexec.WithTracerProvider(tp))
+	require.Error(t, cmd.Run())
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+}
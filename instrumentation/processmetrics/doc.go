@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package processmetrics provides the conventional process metric
+// instruments specified by OpenTelemetry for the current process.  It
+// complements the runtime instrumentation, which reports metrics specific to
+// the Go runtime, and the host instrumentation, which reports metrics of the
+// whole machine.
+//
+// The metric events produced are listed here with attribute dimensions.
+//
+//	Name                          Attribute
+//
+// ----------------------------------------------------------------------
+//
+//	process.cpu.time              state=user|system
+//	process.memory.usage
+//	process.memory.virtual
+//	process.open_file_descriptors
+//	process.threads
+//	process.uptime
+//
+// See https://github.com/open-telemetry/semantic-conventions/blob/main/docs/system/process-metrics.md
+// for the definition of these metric instruments.
+package processmetrics // import "go.opentelemetry.io/contrib/instrumentation/processmetrics"
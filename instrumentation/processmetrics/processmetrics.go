@@ -0,0 +1,217 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processmetrics // import "go.opentelemetry.io/contrib/instrumentation/processmetrics"
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/process"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// processMetrics reports the conventional process metrics specified by
+// OpenTelemetry.
+type processMetrics struct {
+	config config
+	meter  metric.Meter
+	proc   *process.Process
+}
+
+// config contains optional settings for reporting process metrics.
+type config struct {
+	// MeterProvider sets the metric.MeterProvider.  If nil, the global
+	// Provider will be used.
+	MeterProvider metric.MeterProvider
+}
+
+// Option supports configuring optional settings for process metrics.
+type Option interface {
+	apply(*config)
+}
+
+// WithMeterProvider sets the Metric implementation to use for
+// reporting.  If this option is not used, the global metric.MeterProvider
+// will be used.  `provider` must be non-nil.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return metricProviderOption{provider}
+}
+
+type metricProviderOption struct{ metric.MeterProvider }
+
+func (o metricProviderOption) apply(c *config) {
+	if o.MeterProvider != nil {
+		c.MeterProvider = o.MeterProvider
+	}
+}
+
+// Attribute sets.
+var (
+	// Attribute sets for CPU time measurements.
+
+	AttributeCPUTimeUser   = attribute.NewSet(attribute.String("state", "user"))
+	AttributeCPUTimeSystem = attribute.NewSet(attribute.String("state", "system"))
+)
+
+// newConfig computes a config from a list of Options.
+func newConfig(opts ...Option) config {
+	c := config{
+		MeterProvider: otel.GetMeterProvider(),
+	}
+	for _, opt := range opts {
+		opt.apply(&c)
+	}
+	return c
+}
+
+// Start initializes reporting of process metrics using the supplied config.
+func Start(opts ...Option) error {
+	c := newConfig(opts...)
+	proc, err := process.NewProcess(int32(os.Getpid()))
+	if err != nil {
+		return fmt.Errorf("could not find this process: %w", err)
+	}
+	p := &processMetrics{
+		meter: c.MeterProvider.Meter(
+			"go.opentelemetry.io/contrib/instrumentation/processmetrics",
+			metric.WithInstrumentationVersion(Version()),
+		),
+		config: c,
+		proc:   proc,
+	}
+	return p.register()
+}
+
+func (p *processMetrics) register() error {
+	var (
+		err error
+
+		cpuTime       metric.Float64ObservableCounter
+		memoryUsage   metric.Int64ObservableUpDownCounter
+		memoryVirtual metric.Int64ObservableUpDownCounter
+		openFDs       metric.Int64ObservableUpDownCounter
+		threads       metric.Int64ObservableUpDownCounter
+		uptime        metric.Float64ObservableCounter
+
+		// lock prevents a race between batch observer and instrument registration.
+		lock sync.Mutex
+	)
+
+	createTime, err := p.proc.CreateTime()
+	if err != nil {
+		return fmt.Errorf("could not read the process start time: %w", err)
+	}
+	start := time.UnixMilli(createTime)
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	if cpuTime, err = p.meter.Float64ObservableCounter(
+		"process.cpu.time",
+		metric.WithUnit("s"),
+		metric.WithDescription("Total CPU seconds attributed by state (User, System)"),
+	); err != nil {
+		return err
+	}
+
+	if memoryUsage, err = p.meter.Int64ObservableUpDownCounter(
+		"process.memory.usage",
+		metric.WithUnit("By"),
+		metric.WithDescription("Amount of physical memory in use"),
+	); err != nil {
+		return err
+	}
+
+	if memoryVirtual, err = p.meter.Int64ObservableUpDownCounter(
+		"process.memory.virtual",
+		metric.WithUnit("By"),
+		metric.WithDescription("Amount of committed virtual memory"),
+	); err != nil {
+		return err
+	}
+
+	if openFDs, err = p.meter.Int64ObservableUpDownCounter(
+		"process.open_file_descriptors",
+		metric.WithUnit("{count}"),
+		metric.WithDescription("Number of file descriptors in use by the process"),
+	); err != nil {
+		return err
+	}
+
+	if threads, err = p.meter.Int64ObservableUpDownCounter(
+		"process.threads",
+		metric.WithUnit("{thread}"),
+		metric.WithDescription("Process threads count"),
+	); err != nil {
+		return err
+	}
+
+	if uptime, err = p.meter.Float64ObservableCounter(
+		"process.uptime",
+		metric.WithUnit("s"),
+		metric.WithDescription("The time the process has been running"),
+	); err != nil {
+		return err
+	}
+
+	_, err = p.meter.RegisterCallback(
+		func(ctx context.Context, o metric.Observer) error {
+			lock.Lock()
+			defer lock.Unlock()
+
+			times, err := p.proc.TimesWithContext(ctx)
+			if err != nil {
+				return err
+			}
+			o.ObserveFloat64(cpuTime, times.User, metric.WithAttributeSet(AttributeCPUTimeUser))
+			o.ObserveFloat64(cpuTime, times.System, metric.WithAttributeSet(AttributeCPUTimeSystem))
+
+			mem, err := p.proc.MemoryInfoWithContext(ctx)
+			if err != nil {
+				return err
+			}
+			o.ObserveInt64(memoryUsage, int64(mem.RSS))
+			o.ObserveInt64(memoryVirtual, int64(mem.VMS))
+
+			// The number of file descriptors is not available on
+			// every platform, e.g. Windows.
+			if n, err := p.proc.NumFDsWithContext(ctx); err == nil {
+				o.ObserveInt64(openFDs, int64(n))
+			}
+
+			n, err := p.proc.NumThreadsWithContext(ctx)
+			if err != nil {
+				return err
+			}
+			o.ObserveInt64(threads, int64(n))
+
+			o.ObserveFloat64(uptime, time.Since(start).Seconds())
+			return nil
+		},
+		cpuTime,
+		memoryUsage,
+		memoryVirtual,
+		openFDs,
+		threads,
+		uptime,
+	)
+	return err
+}
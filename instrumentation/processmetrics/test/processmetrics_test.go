@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	goruntime "runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/instrumentation/processmetrics"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func collect(t *testing.T, reader sdkmetric.Reader) map[string]metricdata.Metrics {
+	t.Helper()
+	rm := metricdata.ResourceMetrics{}
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	sm := rm.ScopeMetrics[0]
+	assert.Equal(t, "go.opentelemetry.io/contrib/instrumentation/processmetrics", sm.Scope.Name)
+	assert.Equal(t, processmetrics.Version(), sm.Scope.Version)
+	metrics := map[string]metricdata.Metrics{}
+	for _, m := range sm.Metrics {
+		metrics[m.Name] = m
+	}
+	return metrics
+}
+
+// sum returns the data points of the sum m, which is expected to be of unit
+// and to be monotonic or not.
+func sum[N int64 | float64](t *testing.T, m metricdata.Metrics, unit string, monotonic bool) []metricdata.DataPoint[N] {
+	t.Helper()
+	assert.Equal(t, unit, m.Unit, m.Name)
+	require.IsType(t, metricdata.Sum[N]{}, m.Data, m.Name)
+	data := m.Data.(metricdata.Sum[N])
+	assert.Equal(t, monotonic, data.IsMonotonic, m.Name)
+	assert.Equal(t, metricdata.CumulativeTemporality, data.Temporality, m.Name)
+	return data.DataPoints
+}
+
+// value returns the value of the single data point, without attribute, of
+// the sum m.
+func value[N int64 | float64](t *testing.T, m metricdata.Metrics, unit string, monotonic bool) N {
+	t.Helper()
+	dps := sum[N](t, m, unit, monotonic)
+	require.Len(t, dps, 1, m.Name)
+	assert.Equal(t, 0, dps[0].Attributes.Len(), m.Name)
+	return dps[0].Value
+}
+
+func TestProcessMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	defer func() { assert.NoError(t, provider.Shutdown(context.Background())) }()
+	require.NoError(t, processmetrics.Start(processmetrics.WithMeterProvider(provider)))
+
+	metrics := collect(t, reader)
+	for _, name := range []string{
+		"process.cpu.time",
+		"process.memory.usage",
+		"process.memory.virtual",
+		"process.threads",
+		"process.uptime",
+	} {
+		require.Contains(t, metrics, name)
+	}
+
+	cpuTime := make(map[attribute.Set]float64)
+	for _, dp := range sum[float64](t, metrics["process.cpu.time"], "s", true) {
+		cpuTime[dp.Attributes] = dp.Value
+	}
+	assert.Len(t, cpuTime, 2)
+	for _, state := range []attribute.Set{processmetrics.AttributeCPUTimeUser, processmetrics.AttributeCPUTimeSystem} {
+		v, ok := cpuTime[state]
+		assert.Truef(t, ok, "process.cpu.time state %v not reported", state.ToSlice())
+		assert.GreaterOrEqual(t, v, 0.0)
+	}
+
+	rss := value[int64](t, metrics["process.memory.usage"], "By", false)
+	assert.Positive(t, rss)
+	assert.GreaterOrEqual(t, value[int64](t, metrics["process.memory.virtual"], "By", false), rss)
+	assert.Positive(t, value[int64](t, metrics["process.threads"], "{thread}", false))
+
+	if goruntime.GOOS == "linux" {
+		require.Contains(t, metrics, "process.open_file_descriptors")
+		assert.Positive(t, value[int64](t, metrics["process.open_file_descriptors"], "{count}", false))
+	}
+
+	uptime := value[float64](t, metrics["process.uptime"], "s", true)
+	assert.Positive(t, uptime)
+	time.Sleep(10 * time.Millisecond)
+	metrics = collect(t, reader)
+	assert.Greater(t, value[float64](t, metrics["process.uptime"], "s", true), uptime)
+}
@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMetricGroups(t *testing.T) {
+	groups := []MetricGroup{UptimeMetrics, MemoryMetrics, GCMetrics, SchedulerMetrics}
+
+	testCases := []struct {
+		name string
+		opts []Option
+		want []MetricGroup
+	}{
+		{
+			name: "default",
+			want: groups,
+		},
+		{
+			name: "with metrics",
+			opts: []Option{WithMetrics(MemoryMetrics), WithMetrics(GCMetrics)},
+			want: []MetricGroup{MemoryMetrics, GCMetrics},
+		},
+		{
+			name: "without metrics",
+			opts: []Option{WithoutMetrics(MemoryMetrics, GCMetrics)},
+			want: []MetricGroup{UptimeMetrics, SchedulerMetrics},
+		},
+		{
+			name: "without takes precedence",
+			opts: []Option{WithMetrics(SchedulerMetrics, GCMetrics), WithoutMetrics(GCMetrics)},
+			want: []MetricGroup{SchedulerMetrics},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newConfig(tc.opts...)
+			var got []MetricGroup
+			for _, g := range groups {
+				if c.enabled(g) {
+					got = append(got, g)
+				}
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("enabled groups %v, want %v", got, tc.want)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Fatalf("enabled groups %v, want %v", got, tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestConfigOptions(t *testing.T) {
+	opts, err := ConfigOptions(map[string]interface{}{
+		"min_read_mem_stats_interval": 5000,
+		"metrics":                     []interface{}{"memory", "gc"},
+		"without_metrics":             []interface{}{"gc"},
+		"goroutine_high_water_mark":   true,
+		"full_runtime_metrics":        true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := newConfig(opts...)
+	if c.MinimumReadMemStatsInterval != 5*time.Second {
+		t.Errorf("MinimumReadMemStatsInterval = %v, want 5s", c.MinimumReadMemStatsInterval)
+	}
+	if !c.enabled(MemoryMetrics) || c.enabled(GCMetrics) || c.enabled(SchedulerMetrics) {
+		t.Errorf("unexpected metric groups %v without %v", c.Metrics, c.DisabledMetrics)
+	}
+	if !c.GoroutineHighWaterMark || !c.FullRuntimeMetrics {
+		t.Error("boolean settings not applied")
+	}
+
+	// JSON decodes numbers as float64.
+	opts, err = ConfigOptions(map[string]interface{}{"min_read_mem_stats_interval": float64(1000)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := newConfig(opts...); c.MinimumReadMemStatsInterval != time.Second {
+		t.Errorf("MinimumReadMemStatsInterval = %v, want 1s", c.MinimumReadMemStatsInterval)
+	}
+
+	if opts, err := ConfigOptions(nil); err != nil || len(opts) != 0 {
+		t.Errorf("ConfigOptions(nil) = %v, %v", opts, err)
+	}
+}
+
+func TestConfigOptionsErrors(t *testing.T) {
+	for _, node := range []map[string]interface{}{
+		{"unknown": true},
+		{"metrics": []interface{}{"network"}},
+		{"metrics": "memory"},
+		{"min_read_mem_stats_interval": "5s"},
+		{"full_runtime_metrics": "yes"},
+	} {
+		if _, err := ConfigOptions(node); err == nil {
+			t.Errorf("ConfigOptions(%v) returned no error", node)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime // import "go.opentelemetry.io/contrib/instrumentation/runtime"
+
+import (
+	"fmt"
+	"time"
+)
+
+// ConfigOptions returns the Options set by the "runtime" node of the "go"
+// instrumentation section of an OpenTelemetry configuration file, as
+// returned by the GoInstrumentation method of the
+// go.opentelemetry.io/contrib/config package.  For example:
+//
+//	instrumentation:
+//	  go:
+//	    runtime:
+//	      min_read_mem_stats_interval: 5000 # milliseconds
+//	      metrics: [memory, gc]
+//	      without_metrics: [uptime]
+//	      goroutine_high_water_mark: true
+//	      full_runtime_metrics: false
+//
+// A nil node returns no Options, so the defaults apply.
+func ConfigOptions(node map[string]interface{}) ([]Option, error) {
+	var opts []Option
+	for key, v := range node {
+		switch key {
+		case "min_read_mem_stats_interval":
+			ms, err := configInt(key, v)
+			if err != nil {
+				return nil, err
+			}
+			opts = append(opts, WithMinimumReadMemStatsInterval(time.Duration(ms)*time.Millisecond))
+		case "metrics", "without_metrics":
+			groups, err := configMetricGroups(key, v)
+			if err != nil {
+				return nil, err
+			}
+			if key == "metrics" {
+				opts = append(opts, WithMetrics(groups...))
+			} else {
+				opts = append(opts, WithoutMetrics(groups...))
+			}
+		case "goroutine_high_water_mark":
+			if b, ok := v.(bool); !ok {
+				return nil, fmt.Errorf("runtime: %s: invalid value %v", key, v)
+			} else if b {
+				opts = append(opts, WithGoroutineHighWaterMark())
+			}
+		case "full_runtime_metrics":
+			if b, ok := v.(bool); !ok {
+				return nil, fmt.Errorf("runtime: %s: invalid value %v", key, v)
+			} else if b {
+				opts = append(opts, WithFullRuntimeMetrics())
+			}
+		default:
+			return nil, fmt.Errorf("runtime: unknown setting %q", key)
+		}
+	}
+	return opts, nil
+}
+
+// configInt returns v as an int.  Numbers are decoded as int from YAML and
+// as float64 from JSON.
+func configInt(key string, v interface{}) (int64, error) {
+	switch n := v.(type) {
+	case int:
+		return int64(n), nil
+	case int64:
+		return n, nil
+	case float64:
+		if n == float64(int64(n)) {
+			return int64(n), nil
+		}
+	}
+	return 0, fmt.Errorf("runtime: %s: invalid value %v", key, v)
+}
+
+func configMetricGroups(key string, v interface{}) ([]MetricGroup, error) {
+	list, ok := v.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("runtime: %s: invalid value %v", key, v)
+	}
+	groups := make([]MetricGroup, 0, len(list))
+	for _, item := range list {
+		switch g := MetricGroup(fmt.Sprint(item)); g {
+		case UptimeMetrics, MemoryMetrics, GCMetrics, SchedulerMetrics:
+			groups = append(groups, g)
+		default:
+			return nil, fmt.Errorf("runtime: %s: unknown metric group %q", key, g)
+		}
+	}
+	return groups, nil
+}
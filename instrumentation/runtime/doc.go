@@ -16,18 +16,42 @@
 //
 // The metric events produced are:
 //
-//	runtime.go.cgo.calls         -          Number of cgo calls made by the current process
-//	runtime.go.gc.count          -          Number of completed garbage collection cycles
-//	runtime.go.gc.pause_ns       (ns)       Amount of nanoseconds in GC stop-the-world pauses
-//	runtime.go.gc.pause_total_ns (ns)       Cumulative nanoseconds in GC stop-the-world pauses since the program started
-//	runtime.go.goroutines        -          Number of goroutines that currently exist
-//	runtime.go.lookups           -          Number of pointer lookups performed by the runtime
-//	runtime.go.mem.heap_alloc    (bytes)    Bytes of allocated heap objects
-//	runtime.go.mem.heap_idle     (bytes)    Bytes in idle (unused) spans
-//	runtime.go.mem.heap_inuse    (bytes)    Bytes in in-use spans
-//	runtime.go.mem.heap_objects  -          Number of allocated heap objects
-//	runtime.go.mem.heap_released (bytes)    Bytes of idle spans whose physical memory has been returned to the OS
-//	runtime.go.mem.heap_sys      (bytes)    Bytes of heap memory obtained from the OS
-//	runtime.go.mem.live_objects  -          Number of live objects is the number of cumulative Mallocs - Frees
-//	runtime.uptime               (ms)       Milliseconds since application was initialized
+//	runtime.go.cgo.calls          -           Number of cgo calls made by the current process
+//	runtime.go.gc.count           -           Number of completed garbage collection cycles
+//	runtime.go.gc.pause.duration  {pause}     Number of GC stop-the-world pauses by upper bound of their duration in seconds
+//	runtime.go.gc.pause_ns        (ns)        Amount of nanoseconds in GC stop-the-world pauses
+//	runtime.go.gc.pause_total_ns  (ns)        Cumulative nanoseconds in GC stop-the-world pauses since the program started
+//	runtime.go.goroutines         -           Number of goroutines that currently exist
+//	runtime.go.goroutines.created {goroutine} Cumulative number of goroutines created since the program started
+//	runtime.go.goroutines.max     {goroutine} Highest number of goroutines observed since the instrumentation started
+//	runtime.go.lookups            -           Number of pointer lookups performed by the runtime
+//	runtime.go.mem.heap_alloc     (bytes)     Bytes of allocated heap objects
+//	runtime.go.mem.heap_idle      (bytes)     Bytes in idle (unused) spans
+//	runtime.go.mem.heap_inuse     (bytes)     Bytes in in-use spans
+//	runtime.go.mem.heap_objects   -           Number of allocated heap objects
+//	runtime.go.mem.heap_released  (bytes)     Bytes of idle spans whose physical memory has been returned to the OS
+//	runtime.go.mem.heap_sys       (bytes)     Bytes of heap memory obtained from the OS
+//	runtime.go.mem.live_objects   -           Number of live objects is the number of cumulative Mallocs - Frees
+//	runtime.go.threads            {thread}    Number of live OS threads owned by the Go runtime
+//	runtime.uptime                (ms)        Milliseconds since application was initialized
+//
+// The runtime.go.gc.pause.duration metric is a counter, not a histogram: it
+// reports the number of pauses whose duration falls in each bucket of the
+// runtime/metrics histogram, with the exclusive upper bound of the bucket as
+// "upper_bound" attribute. The runtime only provides the cumulative count of
+// each bucket, not the individual pauses a histogram instrument would
+// record, and the metric API has no asynchronous histogram instrument to
+// report such counts.
+//
+// The runtime.go.goroutines.created and runtime.go.threads metrics are only
+// reported by Go versions whose runtime/metrics package supports them, and
+// runtime.go.goroutines.max is only reported when the
+// WithGoroutineHighWaterMark option is used.
+//
+// The WithMetrics and WithoutMetrics options select the groups of the metrics
+// above that are reported, e.g. only the MemoryMetrics or the
+// SchedulerMetrics.
+//
+// The WithFullRuntimeMetrics option additionally reports every metric
+// supported by the runtime/metrics package of the running Go version.
 package runtime // import "go.opentelemetry.io/contrib/instrumentation/runtime"
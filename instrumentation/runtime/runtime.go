@@ -17,6 +17,7 @@ package runtime // import "go.opentelemetry.io/contrib/instrumentation/runtime"
 import (
 	"context"
 	goruntime "runtime"
+	"runtime/metrics"
 	"sync"
 	"time"
 
@@ -40,6 +41,21 @@ type config struct {
 	// MeterProvider sets the metric.MeterProvider.  If nil, the global
 	// Provider will be used.
 	MeterProvider metric.MeterProvider
+
+	// Metrics are the metric groups that are reported.  If empty, all
+	// groups are reported.
+	Metrics []MetricGroup
+
+	// DisabledMetrics are the metric groups that are not reported.
+	DisabledMetrics []MetricGroup
+
+	// GoroutineHighWaterMark enables the report of the highest number
+	// of goroutines observed.
+	GoroutineHighWaterMark bool
+
+	// FullRuntimeMetrics enables the export of every metric supported
+	// by the runtime/metrics package.
+	FullRuntimeMetrics bool
 }
 
 // Option supports configuring optional settings for runtime metrics.
@@ -83,6 +99,101 @@ func (o metricProviderOption) apply(c *config) {
 	}
 }
 
+// WithGoroutineHighWaterMark enables the report of the highest number of
+// goroutines observed since the instrumentation started, which helps to
+// spot goroutine leaks.  The number of goroutines is only observed when
+// metrics are collected, so short-lived peaks between two collections are
+// not accounted for.
+func WithGoroutineHighWaterMark() Option {
+	return goroutineHighWaterMarkOption{}
+}
+
+type goroutineHighWaterMarkOption struct{}
+
+func (goroutineHighWaterMarkOption) apply(c *config) {
+	c.GoroutineHighWaterMark = true
+}
+
+// WithFullRuntimeMetrics enables the export of the complete set of metrics
+// supported by the runtime/metrics package of the running Go version, e.g.
+// scheduling latencies, garbage collection cycles by cause and mutex wait
+// time, in addition to the metrics reported by default.  These metrics are
+// named after the runtime/metrics name with a "process.runtime.go" prefix,
+// e.g. "/sched/latencies:seconds" is reported as
+// "process.runtime.go.sched.latencies" in seconds.
+//
+// Histograms of the runtime/metrics package are reported as counters of the
+// number of observations of each bucket, with the upper bound of the bucket,
+// in the unit of the runtime/metrics metric, as "upper_bound" attribute.
+func WithFullRuntimeMetrics() Option {
+	return fullRuntimeMetricsOption{}
+}
+
+type fullRuntimeMetricsOption struct{}
+
+func (fullRuntimeMetricsOption) apply(c *config) {
+	c.FullRuntimeMetrics = true
+}
+
+// MetricGroup identifies a group of the runtime metrics reported by default.
+type MetricGroup string
+
+const (
+	// UptimeMetrics is the runtime.uptime metric.
+	UptimeMetrics MetricGroup = "uptime"
+	// MemoryMetrics are the process.runtime.go.mem.* metrics.
+	MemoryMetrics MetricGroup = "memory"
+	// GCMetrics are the process.runtime.go.gc.* metrics.
+	GCMetrics MetricGroup = "gc"
+	// SchedulerMetrics are the goroutine, thread and cgo call metrics.
+	SchedulerMetrics MetricGroup = "scheduler"
+)
+
+// WithMetrics restricts the metrics reported by default to the passed
+// groups.  Groups passed by multiple WithMetrics options are all reported.
+// If this option is not used, all groups are reported.
+func WithMetrics(groups ...MetricGroup) Option {
+	return metricsOption(groups)
+}
+
+type metricsOption []MetricGroup
+
+func (o metricsOption) apply(c *config) {
+	c.Metrics = append(c.Metrics, o...)
+}
+
+// WithoutMetrics disables the report of the metrics of the passed groups,
+// e.g. to skip the cost of runtime.ReadMemStats() when neither the
+// MemoryMetrics nor the GCMetrics groups are needed.  It takes precedence
+// over WithMetrics.
+func WithoutMetrics(groups ...MetricGroup) Option {
+	return withoutMetricsOption(groups)
+}
+
+type withoutMetricsOption []MetricGroup
+
+func (o withoutMetricsOption) apply(c *config) {
+	c.DisabledMetrics = append(c.DisabledMetrics, o...)
+}
+
+// enabled reports whether the metrics of group g are reported.
+func (c config) enabled(g MetricGroup) bool {
+	for _, d := range c.DisabledMetrics {
+		if d == g {
+			return false
+		}
+	}
+	if len(c.Metrics) == 0 {
+		return true
+	}
+	for _, e := range c.Metrics {
+		if e == g {
+			return true
+		}
+	}
+	return false
+}
+
 // newConfig computes a config from the supplied Options.
 func newConfig(opts ...Option) config {
 	c := config{
@@ -115,6 +226,31 @@ func Start(opts ...Option) error {
 }
 
 func (r *runtime) register() error {
+	if r.config.enabled(UptimeMetrics) {
+		if err := r.registerUptime(); err != nil {
+			return err
+		}
+	}
+
+	if r.config.enabled(SchedulerMetrics) {
+		if err := r.registerScheduler(); err != nil {
+			return err
+		}
+	}
+
+	if r.config.enabled(MemoryMetrics) || r.config.enabled(GCMetrics) {
+		if err := r.registerMemStats(); err != nil {
+			return err
+		}
+	}
+
+	if r.config.FullRuntimeMetrics {
+		return r.registerRuntimeMetrics()
+	}
+	return nil
+}
+
+func (r *runtime) registerUptime() error {
 	startTime := time.Now()
 	uptime, err := r.meter.Int64ObservableCounter(
 		"runtime.uptime",
@@ -125,6 +261,17 @@ func (r *runtime) register() error {
 		return err
 	}
 
+	_, err = r.meter.RegisterCallback(
+		func(ctx context.Context, o metric.Observer) error {
+			o.ObserveInt64(uptime, time.Since(startTime).Milliseconds())
+			return nil
+		},
+		uptime,
+	)
+	return err
+}
+
+func (r *runtime) registerScheduler() error {
 	goroutines, err := r.meter.Int64ObservableUpDownCounter(
 		"process.runtime.go.goroutines",
 		metric.WithDescription("Number of goroutines that currently exist"),
@@ -133,6 +280,53 @@ func (r *runtime) register() error {
 		return err
 	}
 
+	instruments := []metric.Observable{goroutines}
+
+	var goroutinesMax metric.Int64ObservableGauge
+	if r.config.GoroutineHighWaterMark {
+		goroutinesMax, err = r.meter.Int64ObservableGauge(
+			"process.runtime.go.goroutines.max",
+			metric.WithUnit("{goroutine}"),
+			metric.WithDescription("Highest number of goroutines observed since the instrumentation started"),
+		)
+		if err != nil {
+			return err
+		}
+		instruments = append(instruments, goroutinesMax)
+	}
+
+	// The following metrics are only reported when the runtime/metrics
+	// package of the running Go version supports them.
+	var (
+		schedSamples      []metrics.Sample
+		goroutinesCreated metric.Int64ObservableCounter
+		threads           metric.Int64ObservableUpDownCounter
+	)
+	if name, ok := supportedMetric(goroutinesCreatedMetric); ok {
+		goroutinesCreated, err = r.meter.Int64ObservableCounter(
+			"process.runtime.go.goroutines.created",
+			metric.WithUnit("{goroutine}"),
+			metric.WithDescription("Cumulative number of goroutines created since the program started"),
+		)
+		if err != nil {
+			return err
+		}
+		schedSamples = append(schedSamples, metrics.Sample{Name: name})
+		instruments = append(instruments, goroutinesCreated)
+	}
+	if name, ok := supportedMetric(threadsMetric); ok {
+		threads, err = r.meter.Int64ObservableUpDownCounter(
+			"process.runtime.go.threads",
+			metric.WithUnit("{thread}"),
+			metric.WithDescription("Number of live OS threads owned by the Go runtime"),
+		)
+		if err != nil {
+			return err
+		}
+		schedSamples = append(schedSamples, metrics.Sample{Name: name})
+		instruments = append(instruments, threads)
+	}
+
 	cgoCalls, err := r.meter.Int64ObservableUpDownCounter(
 		"process.runtime.go.cgo.calls",
 		metric.WithDescription("Number of cgo calls made by the current process"),
@@ -140,23 +334,51 @@ func (r *runtime) register() error {
 	if err != nil {
 		return err
 	}
+	instruments = append(instruments, cgoCalls)
+
+	var (
+		maxGoroutines int64
+
+		// lock prevents a race between batch observer and instrument registration.
+		lock sync.Mutex
+	)
+
+	lock.Lock()
+	defer lock.Unlock()
 
 	_, err = r.meter.RegisterCallback(
 		func(ctx context.Context, o metric.Observer) error {
-			o.ObserveInt64(uptime, time.Since(startTime).Milliseconds())
-			o.ObserveInt64(goroutines, int64(goruntime.NumGoroutine()))
+			lock.Lock()
+			defer lock.Unlock()
+
+			n := int64(goruntime.NumGoroutine())
+			o.ObserveInt64(goroutines, n)
 			o.ObserveInt64(cgoCalls, goruntime.NumCgoCall())
+
+			if goroutinesMax != nil {
+				if n > maxGoroutines {
+					maxGoroutines = n
+				}
+				o.ObserveInt64(goroutinesMax, maxGoroutines)
+			}
+
+			metrics.Read(schedSamples)
+			for _, sample := range schedSamples {
+				if sample.Value.Kind() != metrics.KindUint64 {
+					continue
+				}
+				switch sample.Name {
+				case goroutinesCreatedMetric:
+					o.ObserveInt64(goroutinesCreated, int64(sample.Value.Uint64()))
+				case threadsMetric:
+					o.ObserveInt64(threads, int64(sample.Value.Uint64()))
+				}
+			}
 			return nil
 		},
-		uptime,
-		goroutines,
-		cgoCalls,
+		instruments...,
 	)
-	if err != nil {
-		return err
-	}
-
-	return r.registerMemStats()
+	return err
 }
 
 func (r *runtime) registerMemStats() error {
@@ -179,6 +401,11 @@ func (r *runtime) registerMemStats() error {
 		pauseTotalNs metric.Int64ObservableCounter
 		gcPauseNs    metric.Int64Histogram
 
+		gcPauses      *runtimeHistogram
+		gcPauseMetric string
+
+		instruments []metric.Observable
+
 		lastNumGC    uint32
 		lastMemStats time.Time
 		memStats     goruntime.MemStats
@@ -190,93 +417,135 @@ func (r *runtime) registerMemStats() error {
 	lock.Lock()
 	defer lock.Unlock()
 
-	if heapAlloc, err = r.meter.Int64ObservableUpDownCounter(
-		"process.runtime.go.mem.heap_alloc",
-		metric.WithUnit("By"),
-		metric.WithDescription("Bytes of allocated heap objects"),
-	); err != nil {
-		return err
-	}
-
-	if heapIdle, err = r.meter.Int64ObservableUpDownCounter(
-		"process.runtime.go.mem.heap_idle",
-		metric.WithUnit("By"),
-		metric.WithDescription("Bytes in idle (unused) spans"),
-	); err != nil {
-		return err
-	}
-
-	if heapInuse, err = r.meter.Int64ObservableUpDownCounter(
-		"process.runtime.go.mem.heap_inuse",
-		metric.WithUnit("By"),
-		metric.WithDescription("Bytes in in-use spans"),
-	); err != nil {
-		return err
-	}
-
-	if heapObjects, err = r.meter.Int64ObservableUpDownCounter(
-		"process.runtime.go.mem.heap_objects",
-		metric.WithDescription("Number of allocated heap objects"),
-	); err != nil {
-		return err
-	}
-
-	// FYI see https://github.com/golang/go/issues/32284 to help
-	// understand the meaning of this value.
-	if heapReleased, err = r.meter.Int64ObservableUpDownCounter(
-		"process.runtime.go.mem.heap_released",
-		metric.WithUnit("By"),
-		metric.WithDescription("Bytes of idle spans whose physical memory has been returned to the OS"),
-	); err != nil {
-		return err
+	memory := r.config.enabled(MemoryMetrics)
+	if memory {
+		if heapAlloc, err = r.meter.Int64ObservableUpDownCounter(
+			"process.runtime.go.mem.heap_alloc",
+			metric.WithUnit("By"),
+			metric.WithDescription("Bytes of allocated heap objects"),
+		); err != nil {
+			return err
+		}
+
+		if heapIdle, err = r.meter.Int64ObservableUpDownCounter(
+			"process.runtime.go.mem.heap_idle",
+			metric.WithUnit("By"),
+			metric.WithDescription("Bytes in idle (unused) spans"),
+		); err != nil {
+			return err
+		}
+
+		if heapInuse, err = r.meter.Int64ObservableUpDownCounter(
+			"process.runtime.go.mem.heap_inuse",
+			metric.WithUnit("By"),
+			metric.WithDescription("Bytes in in-use spans"),
+		); err != nil {
+			return err
+		}
+
+		if heapObjects, err = r.meter.Int64ObservableUpDownCounter(
+			"process.runtime.go.mem.heap_objects",
+			metric.WithDescription("Number of allocated heap objects"),
+		); err != nil {
+			return err
+		}
+
+		// FYI see https://github.com/golang/go/issues/32284 to help
+		// understand the meaning of this value.
+		if heapReleased, err = r.meter.Int64ObservableUpDownCounter(
+			"process.runtime.go.mem.heap_released",
+			metric.WithUnit("By"),
+			metric.WithDescription("Bytes of idle spans whose physical memory has been returned to the OS"),
+		); err != nil {
+			return err
+		}
+
+		if heapSys, err = r.meter.Int64ObservableUpDownCounter(
+			"process.runtime.go.mem.heap_sys",
+			metric.WithUnit("By"),
+			metric.WithDescription("Bytes of heap memory obtained from the OS"),
+		); err != nil {
+			return err
+		}
+
+		if ptrLookups, err = r.meter.Int64ObservableCounter(
+			"process.runtime.go.mem.lookups",
+			metric.WithDescription("Number of pointer lookups performed by the runtime"),
+		); err != nil {
+			return err
+		}
+
+		if liveObjects, err = r.meter.Int64ObservableUpDownCounter(
+			"process.runtime.go.mem.live_objects",
+			metric.WithDescription("Number of live objects is the number of cumulative Mallocs - Frees"),
+		); err != nil {
+			return err
+		}
+
+		instruments = append(instruments,
+			heapAlloc,
+			heapIdle,
+			heapInuse,
+			heapObjects,
+			heapReleased,
+			heapSys,
+			liveObjects,
+
+			ptrLookups,
+		)
 	}
 
-	if heapSys, err = r.meter.Int64ObservableUpDownCounter(
-		"process.runtime.go.mem.heap_sys",
-		metric.WithUnit("By"),
-		metric.WithDescription("Bytes of heap memory obtained from the OS"),
-	); err != nil {
-		return err
-	}
-
-	if ptrLookups, err = r.meter.Int64ObservableCounter(
-		"process.runtime.go.mem.lookups",
-		metric.WithDescription("Number of pointer lookups performed by the runtime"),
-	); err != nil {
-		return err
-	}
-
-	if liveObjects, err = r.meter.Int64ObservableUpDownCounter(
-		"process.runtime.go.mem.live_objects",
-		metric.WithDescription("Number of live objects is the number of cumulative Mallocs - Frees"),
-	); err != nil {
-		return err
-	}
-
-	if gcCount, err = r.meter.Int64ObservableCounter(
-		"process.runtime.go.gc.count",
-		metric.WithDescription("Number of completed garbage collection cycles"),
-	); err != nil {
-		return err
-	}
-
-	// Note that the following could be derived as a sum of
-	// individual pauses, but we may lose individual pauses if the
-	// observation interval is too slow.
-	if pauseTotalNs, err = r.meter.Int64ObservableCounter(
-		"process.runtime.go.gc.pause_total_ns",
-		// TODO: nanoseconds units
-		metric.WithDescription("Cumulative nanoseconds in GC stop-the-world pauses since the program started"),
-	); err != nil {
-		return err
-	}
-
-	if gcPauseNs, err = r.meter.Int64Histogram(
-		"process.runtime.go.gc.pause_ns",
-		// TODO: nanoseconds units
-		metric.WithDescription("Amount of nanoseconds in GC stop-the-world pauses"),
-	); err != nil {
-		return err
+	gc := r.config.enabled(GCMetrics)
+	if gc {
+		if gcCount, err = r.meter.Int64ObservableCounter(
+			"process.runtime.go.gc.count",
+			metric.WithDescription("Number of completed garbage collection cycles"),
+		); err != nil {
+			return err
+		}
+
+		// Note that the following could be derived as a sum of
+		// individual pauses, but we may lose individual pauses if the
+		// observation interval is too slow.
+		if pauseTotalNs, err = r.meter.Int64ObservableCounter(
+			"process.runtime.go.gc.pause_total_ns",
+			// TODO: nanoseconds units
+			metric.WithDescription("Cumulative nanoseconds in GC stop-the-world pauses since the program started"),
+		); err != nil {
+			return err
+		}
+
+		if gcPauseNs, err = r.meter.Int64Histogram(
+			"process.runtime.go.gc.pause_ns",
+			// TODO: nanoseconds units
+			metric.WithDescription("Amount of nanoseconds in GC stop-the-world pauses"),
+		); err != nil {
+			return err
+		}
+
+		// The pause durations are read from runtime/metrics, which, unlike
+		// runtime.ReadMemStats, does not bound the number of reported pauses.
+		// The name differs from the one of the runtime/metrics histogram
+		// reported by WithFullRuntimeMetrics, process.runtime.go.gc.pauses,
+		// so both can be enabled.
+		var ok bool
+		if gcPauseMetric, ok = supportedMetric(gcPauseMetrics...); ok {
+			pauses, err := r.meter.Int64ObservableCounter(
+				"process.runtime.go.gc.pause.duration",
+				metric.WithUnit("{pause}"),
+				metric.WithDescription("Number of GC stop-the-world pauses by upper bound of their duration in seconds"),
+			)
+			if err != nil {
+				return err
+			}
+			gcPauses = &runtimeHistogram{counter: pauses}
+			instruments = append(instruments, pauses)
+		}
+
+		instruments = append(instruments,
+			gcCount,
+			pauseTotalNs,
+		)
 	}
 
 	_, err = r.meter.RegisterCallback(
@@ -290,35 +559,35 @@ func (r *runtime) registerMemStats() error {
 				lastMemStats = now
 			}
 
-			o.ObserveInt64(heapAlloc, int64(memStats.HeapAlloc))
-			o.ObserveInt64(heapIdle, int64(memStats.HeapIdle))
-			o.ObserveInt64(heapInuse, int64(memStats.HeapInuse))
-			o.ObserveInt64(heapObjects, int64(memStats.HeapObjects))
-			o.ObserveInt64(heapReleased, int64(memStats.HeapReleased))
-			o.ObserveInt64(heapSys, int64(memStats.HeapSys))
-			o.ObserveInt64(liveObjects, int64(memStats.Mallocs-memStats.Frees))
-			o.ObserveInt64(ptrLookups, int64(memStats.Lookups))
-			o.ObserveInt64(gcCount, int64(memStats.NumGC))
-			o.ObserveInt64(pauseTotalNs, int64(memStats.PauseTotalNs))
+			if memory {
+				o.ObserveInt64(heapAlloc, int64(memStats.HeapAlloc))
+				o.ObserveInt64(heapIdle, int64(memStats.HeapIdle))
+				o.ObserveInt64(heapInuse, int64(memStats.HeapInuse))
+				o.ObserveInt64(heapObjects, int64(memStats.HeapObjects))
+				o.ObserveInt64(heapReleased, int64(memStats.HeapReleased))
+				o.ObserveInt64(heapSys, int64(memStats.HeapSys))
+				o.ObserveInt64(liveObjects, int64(memStats.Mallocs-memStats.Frees))
+				o.ObserveInt64(ptrLookups, int64(memStats.Lookups))
+			}
 
-			computeGCPauses(ctx, gcPauseNs, memStats.PauseNs[:], lastNumGC, memStats.NumGC)
+			if gc {
+				o.ObserveInt64(gcCount, int64(memStats.NumGC))
+				o.ObserveInt64(pauseTotalNs, int64(memStats.PauseTotalNs))
+
+				computeGCPauses(ctx, gcPauseNs, memStats.PauseNs[:], lastNumGC, memStats.NumGC)
+
+				if gcPauses != nil {
+					samples := []metrics.Sample{{Name: gcPauseMetric}}
+					metrics.Read(samples)
+					gcPauses.observe(o, samples[0].Value.Float64Histogram())
+				}
+			}
 
 			lastNumGC = memStats.NumGC
 
 			return nil
 		},
-		heapAlloc,
-		heapIdle,
-		heapInuse,
-		heapObjects,
-		heapReleased,
-		heapSys,
-		liveObjects,
-
-		ptrLookups,
-
-		gcCount,
-		pauseTotalNs,
+		instruments...,
 	)
 	if err != nil {
 		return err
@@ -0,0 +1,216 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime // import "go.opentelemetry.io/contrib/instrumentation/runtime"
+
+import (
+	"context"
+	"runtime/metrics"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// runtimeMetricsPrefix is the prefix of the names of the instruments
+// reporting runtime/metrics metrics.
+const runtimeMetricsPrefix = "process.runtime.go"
+
+// gcPauseMetrics are the runtime/metrics histograms of the GC stop-the-world
+// pause durations, in order of preference. "/gc/pauses:seconds" is deprecated
+// since Go 1.22.
+var gcPauseMetrics = []string{"/sched/pauses/total/gc:seconds", "/gc/pauses:seconds"}
+
+// runtime/metrics metrics of the goroutine and thread counts, which are not
+// supported by every Go version.
+const (
+	goroutinesCreatedMetric = "/sched/goroutines-created:goroutines"
+	threadsMetric           = "/sched/threads/total:threads"
+)
+
+// supportedMetric returns the first of names that is supported by the
+// runtime/metrics package of the running Go version.
+func supportedMetric(names ...string) (string, bool) {
+	supported := make(map[string]bool)
+	for _, d := range metrics.All() {
+		supported[d.Name] = true
+	}
+	for _, name := range names {
+		if supported[name] {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// registerRuntimeMetrics registers an instrument for every metric supported
+// by the runtime/metrics package.
+func (r *runtime) registerRuntimeMetrics() error {
+	var (
+		descs   = metrics.All()
+		samples = make([]metrics.Sample, len(descs))
+
+		int64Observables   = make(map[int]metric.Int64Observable)
+		float64Observables = make(map[int]metric.Float64Observable)
+		histograms         = make(map[int]*runtimeHistogram)
+		observables        []metric.Observable
+
+		// lock prevents a race between batch observer and instrument registration.
+		lock sync.Mutex
+	)
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	// Metrics that only differ by their unit, e.g. "/gc/heap/allocs:bytes"
+	// and "/gc/heap/allocs:objects", are suffixed with their unit.
+	paths := make(map[string]int, len(descs))
+	for _, d := range descs {
+		path, _, _ := strings.Cut(d.Name, ":")
+		paths[path]++
+	}
+
+	for i, d := range descs {
+		samples[i].Name = d.Name
+
+		path, unit, _ := strings.Cut(d.Name, ":")
+		name := runtimeMetricName(path, unit, paths[path] > 1)
+		u := metric.WithUnit(runtimeMetricUnit(unit))
+		desc := metric.WithDescription(d.Description)
+
+		switch d.Kind {
+		case metrics.KindUint64:
+			var (
+				inst metric.Int64Observable
+				err  error
+			)
+			if d.Cumulative {
+				inst, err = r.meter.Int64ObservableCounter(name, u, desc)
+			} else {
+				inst, err = r.meter.Int64ObservableUpDownCounter(name, u, desc)
+			}
+			if err != nil {
+				return err
+			}
+			int64Observables[i] = inst
+			observables = append(observables, inst)
+		case metrics.KindFloat64:
+			var (
+				inst metric.Float64Observable
+				err  error
+			)
+			if d.Cumulative {
+				inst, err = r.meter.Float64ObservableCounter(name, u, desc)
+			} else {
+				inst, err = r.meter.Float64ObservableGauge(name, u, desc)
+			}
+			if err != nil {
+				return err
+			}
+			float64Observables[i] = inst
+			observables = append(observables, inst)
+		case metrics.KindFloat64Histogram:
+			inst, err := r.meter.Int64ObservableCounter(name, metric.WithUnit("{count}"), desc)
+			if err != nil {
+				return err
+			}
+			histograms[i] = &runtimeHistogram{counter: inst}
+			observables = append(observables, inst)
+		}
+	}
+
+	_, err := r.meter.RegisterCallback(
+		func(ctx context.Context, o metric.Observer) error {
+			lock.Lock()
+			defer lock.Unlock()
+
+			metrics.Read(samples)
+			for i, inst := range int64Observables {
+				if samples[i].Value.Kind() == metrics.KindUint64 {
+					o.ObserveInt64(inst, int64(samples[i].Value.Uint64()))
+				}
+			}
+			for i, inst := range float64Observables {
+				if samples[i].Value.Kind() == metrics.KindFloat64 {
+					o.ObserveFloat64(inst, samples[i].Value.Float64())
+				}
+			}
+			for i, h := range histograms {
+				if samples[i].Value.Kind() == metrics.KindFloat64Histogram {
+					h.observe(o, samples[i].Value.Float64Histogram())
+				}
+			}
+			return nil
+		},
+		observables...,
+	)
+	return err
+}
+
+// runtimeMetricName returns the name of the instrument reporting the
+// runtime/metrics metric with the passed path and unit.
+func runtimeMetricName(path, unit string, withUnit bool) string {
+	name := runtimeMetricsPrefix + strings.ReplaceAll(path, "/", ".")
+	if withUnit {
+		name += "." + unit
+	}
+	return strings.ReplaceAll(name, "-", "_")
+}
+
+// runtimeMetricUnit converts a runtime/metrics unit to UCUM.
+func runtimeMetricUnit(unit string) string {
+	switch unit {
+	case "bytes":
+		return "By"
+	case "seconds", "cpu-seconds":
+		return "s"
+	case "percent":
+		return "%"
+	}
+	return "{" + unit + "}"
+}
+
+// bucketUpperBoundKey is the attribute key of the exclusive upper bound of
+// the runtime/metrics histogram bucket an observation count is reported for.
+const bucketUpperBoundKey = attribute.Key("upper_bound")
+
+// runtimeHistogram reports a cumulative runtime/metrics histogram. The
+// metric API provides no asynchronous histogram instrument, the number of
+// observations of each bucket is therefore reported by a counter with the
+// upper bound of the bucket as attribute. Empty buckets are not reported.
+type runtimeHistogram struct {
+	counter metric.Int64ObservableCounter
+
+	// bounds holds the attributes of each bucket. The buckets of a
+	// runtime/metrics histogram do not change for the lifetime of the
+	// process.
+	bounds []metric.ObserveOption
+}
+
+func (h *runtimeHistogram) observe(o metric.Observer, hist *metrics.Float64Histogram) {
+	if len(h.bounds) != len(hist.Counts) {
+		h.bounds = make([]metric.ObserveOption, len(hist.Counts))
+		for i := range h.bounds {
+			upper := strconv.FormatFloat(hist.Buckets[i+1], 'g', -1, 64)
+			h.bounds[i] = metric.WithAttributes(bucketUpperBoundKey.String(upper))
+		}
+	}
+	for i, count := range hist.Counts {
+		if count > 0 {
+			o.ObserveInt64(h.counter, int64(count), h.bounds[i])
+		}
+	}
+}
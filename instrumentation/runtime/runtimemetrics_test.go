@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"math"
+	"reflect"
+	"runtime/metrics"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/embedded"
+)
+
+func TestRuntimeMetricName(t *testing.T) {
+	testCases := []struct {
+		path, unit string
+		withUnit   bool
+		want       string
+	}{
+		{"/sched/latencies", "seconds", false, "process.runtime.go.sched.latencies"},
+		{"/memory/classes/os-stacks", "bytes", false, "process.runtime.go.memory.classes.os_stacks"},
+		{"/gc/heap/allocs", "objects", true, "process.runtime.go.gc.heap.allocs.objects"},
+	}
+	for _, tc := range testCases {
+		if got := runtimeMetricName(tc.path, tc.unit, tc.withUnit); got != tc.want {
+			t.Errorf("runtimeMetricName(%q, %q, %t) = %q, want %q", tc.path, tc.unit, tc.withUnit, got, tc.want)
+		}
+	}
+}
+
+func TestRuntimeMetricNamesUnique(t *testing.T) {
+	descs := metrics.All()
+	paths := make(map[string]int, len(descs))
+	for _, d := range descs {
+		path, _, _ := strings.Cut(d.Name, ":")
+		paths[path]++
+	}
+
+	names := make(map[string]string, len(descs))
+	for _, d := range descs {
+		path, unit, _ := strings.Cut(d.Name, ":")
+		name := runtimeMetricName(path, unit, paths[path] > 1)
+		if other, ok := names[name]; ok {
+			t.Errorf("%q and %q are both reported as %q", other, d.Name, name)
+		}
+		names[name] = d.Name
+	}
+}
+
+func TestRuntimeMetricUnit(t *testing.T) {
+	for unit, want := range map[string]string{
+		"bytes":       "By",
+		"seconds":     "s",
+		"cpu-seconds": "s",
+		"percent":     "%",
+		"gc-cycles":   "{gc-cycles}",
+	} {
+		if got := runtimeMetricUnit(unit); got != want {
+			t.Errorf("runtimeMetricUnit(%q) = %q, want %q", unit, got, want)
+		}
+	}
+}
+
+// bucketObserver records the last value observed for each bucket upper bound.
+type bucketObserver struct {
+	embedded.Observer
+
+	counts map[string]int64
+}
+
+func (o *bucketObserver) ObserveFloat64(metric.Float64Observable, float64, ...metric.ObserveOption) {}
+
+func (o *bucketObserver) ObserveInt64(_ metric.Int64Observable, v int64, opts ...metric.ObserveOption) {
+	attrs := metric.NewObserveConfig(opts).Attributes()
+	upper, _ := attrs.Value(bucketUpperBoundKey)
+	o.counts[upper.AsString()] = v
+}
+
+func TestRuntimeHistogramObserve(t *testing.T) {
+	h := &runtimeHistogram{}
+	hist := &metrics.Float64Histogram{
+		Counts:  []uint64{1, 0, 2},
+		Buckets: []float64{math.Inf(-1), 0.5, 3, math.Inf(1)},
+	}
+
+	o := &bucketObserver{counts: make(map[string]int64)}
+	h.observe(o, hist)
+	want := map[string]int64{"0.5": 1, "+Inf": 2}
+	if !reflect.DeepEqual(o.counts, want) {
+		t.Errorf("observed %v, want %v", o.counts, want)
+	}
+
+	// The cumulative counts of the buckets are observed.
+	o = &bucketObserver{counts: make(map[string]int64)}
+	hist.Counts = []uint64{1, 1000, 2}
+	h.observe(o, hist)
+	want = map[string]int64{"0.5": 1, "3": 1000, "+Inf": 2}
+	if !reflect.DeepEqual(o.counts, want) {
+		t.Errorf("observed %v, want %v", o.counts, want)
+	}
+}
+
+func TestSupportedMetric(t *testing.T) {
+	if _, ok := supportedMetric("/unknown:seconds"); ok {
+		t.Error("unknown metric reported as supported")
+	}
+	if name, ok := supportedMetric(append([]string{"/unknown:seconds"}, gcPauseMetrics...)...); !ok || name == "/unknown:seconds" {
+		t.Errorf("supportedMetric returned %q, %t", name, ok)
+	}
+}
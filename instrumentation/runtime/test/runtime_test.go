@@ -0,0 +1,183 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	goruntime "runtime"
+	"runtime/metrics"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/instrumentation/runtime"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func collect(t *testing.T, reader sdkmetric.Reader) map[string]metricdata.Metrics {
+	t.Helper()
+	rm := metricdata.ResourceMetrics{}
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	sm := rm.ScopeMetrics[0]
+	assert.Equal(t, "go.opentelemetry.io/contrib/instrumentation/runtime", sm.Scope.Name)
+	assert.Equal(t, runtime.Version(), sm.Scope.Version)
+	metrics := map[string]metricdata.Metrics{}
+	for _, m := range sm.Metrics {
+		metrics[m.Name] = m
+	}
+	return metrics
+}
+
+func start(t *testing.T, opts ...runtime.Option) sdkmetric.Reader {
+	t.Helper()
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	t.Cleanup(func() { assert.NoError(t, provider.Shutdown(context.Background())) })
+	opts = append(opts, runtime.WithMeterProvider(provider))
+	require.NoError(t, runtime.Start(opts...))
+	return reader
+}
+
+// supported returns if the runtime/metrics package supports the named metric.
+func supported(name string) bool {
+	for _, d := range metrics.All() {
+		if d.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// value returns the value of the single data point of m, which is expected
+// to be of type T.
+func value[T metricdata.Sum[int64] | metricdata.Gauge[int64]](t *testing.T, m metricdata.Metrics, unit string) int64 {
+	t.Helper()
+	assert.Equal(t, unit, m.Unit, m.Name)
+	require.IsType(t, *new(T), m.Data, m.Name)
+	var dps []metricdata.DataPoint[int64]
+	switch data := any(m.Data).(type) {
+	case metricdata.Sum[int64]:
+		dps = data.DataPoints
+	case metricdata.Gauge[int64]:
+		dps = data.DataPoints
+	}
+	require.Len(t, dps, 1, m.Name)
+	assert.Equal(t, 0, dps[0].Attributes.Len(), m.Name)
+	return dps[0].Value
+}
+
+// gcPauses returns the total number of GC pauses reported by m.
+func gcPauses(t *testing.T, m metricdata.Metrics) int64 {
+	t.Helper()
+	assert.Equal(t, "{pause}", m.Unit)
+	require.IsType(t, metricdata.Sum[int64]{}, m.Data)
+	data := m.Data.(metricdata.Sum[int64])
+	assert.True(t, data.IsMonotonic)
+	assert.Equal(t, metricdata.CumulativeTemporality, data.Temporality)
+
+	var total int64
+	for _, dp := range data.DataPoints {
+		assert.Equal(t, 1, dp.Attributes.Len())
+		_, ok := dp.Attributes.Value("upper_bound")
+		assert.True(t, ok, "upper_bound attribute missing")
+		assert.Positive(t, dp.Value)
+		total += dp.Value
+	}
+	return total
+}
+
+func TestGCPauses(t *testing.T) {
+	reader := start(t, runtime.WithMetrics(runtime.GCMetrics), runtime.WithMinimumReadMemStatsInterval(0))
+
+	goruntime.GC()
+	metrics := collect(t, reader)
+	require.Contains(t, metrics, "process.runtime.go.gc.pause.duration")
+	before := gcPauses(t, metrics["process.runtime.go.gc.pause.duration"])
+	assert.Positive(t, before)
+
+	goruntime.GC()
+	metrics = collect(t, reader)
+	after := gcPauses(t, metrics["process.runtime.go.gc.pause.duration"])
+	assert.Greater(t, after, before, "pauses of the last GC not reported")
+}
+
+func TestUniqueMetricNames(t *testing.T) {
+	reader := start(t, runtime.WithFullRuntimeMetrics(), runtime.WithGoroutineHighWaterMark())
+
+	// Empty histograms are not reported, make sure the pause ones are not.
+	goruntime.GC()
+	rm := metricdata.ResourceMetrics{}
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	names := make(map[string]bool)
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		assert.False(t, names[m.Name], "%s is reported twice", m.Name)
+		names[m.Name] = true
+	}
+	if supported("/gc/pauses:seconds") {
+		assert.Contains(t, names, "process.runtime.go.gc.pauses")
+	}
+	assert.Contains(t, names, "process.runtime.go.gc.pause.duration")
+}
+
+func TestGoroutinesAndThreads(t *testing.T) {
+	reader := start(t, runtime.WithMetrics(runtime.SchedulerMetrics), runtime.WithGoroutineHighWaterMark())
+
+	created := supported("/sched/goroutines-created:goroutines")
+	threads := supported("/sched/threads/total:threads")
+
+	metrics := collect(t, reader)
+	before := value[metricdata.Sum[int64]](t, metrics["process.runtime.go.goroutines"], "")
+	var createdBefore int64
+	if created {
+		createdBefore = value[metricdata.Sum[int64]](t, metrics["process.runtime.go.goroutines.created"], "{goroutine}")
+	}
+
+	const n = 10
+	release := make(chan struct{})
+	started := make(chan struct{}, n)
+	for i := 0; i < n; i++ {
+		go func() {
+			started <- struct{}{}
+			<-release
+		}()
+	}
+	for i := 0; i < n; i++ {
+		<-started
+	}
+
+	metrics = collect(t, reader)
+	peak := value[metricdata.Sum[int64]](t, metrics["process.runtime.go.goroutines"], "")
+	assert.GreaterOrEqual(t, peak, before+n)
+	assert.GreaterOrEqual(t, value[metricdata.Gauge[int64]](t, metrics["process.runtime.go.goroutines.max"], "{goroutine}"), peak)
+	if created {
+		createdAfter := value[metricdata.Sum[int64]](t, metrics["process.runtime.go.goroutines.created"], "{goroutine}")
+		assert.GreaterOrEqual(t, createdAfter, createdBefore+n)
+	} else {
+		assert.NotContains(t, metrics, "process.runtime.go.goroutines.created")
+	}
+	if threads {
+		assert.Positive(t, value[metricdata.Sum[int64]](t, metrics["process.runtime.go.threads"], "{thread}"))
+	} else {
+		assert.NotContains(t, metrics, "process.runtime.go.threads")
+	}
+
+	close(release)
+	metrics = collect(t, reader)
+	assert.GreaterOrEqual(t, value[metricdata.Gauge[int64]](t, metrics["process.runtime.go.goroutines.max"], "{goroutine}"), peak, "high-water mark decreased")
+}
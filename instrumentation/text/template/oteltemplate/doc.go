@@ -0,0 +1,25 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package oteltemplate provides tracing instrumentation for the rendering of
+// text/template and html/template templates.
+//
+// Rendering is not traced unless templates are wrapped with New. The
+// returned Template executes the wrapped template within a span covering the
+// rendering, named after the executed template, so slow views show up in the
+// traces of the request that rendered them. Servers instrumented with
+// otelmux or otelhttp should pass the request context to Execute. Renderers
+// of web frameworks executing the templates themselves can trace them with a
+// Renderer, as the otelecho.NewRenderer does.
+package oteltemplate // import "go.opentelemetry.io/contrib/instrumentation/text/template/oteltemplate"
@@ -0,0 +1,33 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oteltemplate_test
+
+import (
+	"html/template"
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/text/template/oteltemplate"
+)
+
+func ExampleNew() {
+	page := oteltemplate.New(template.Must(template.New("page").Parse(`<h1>Hello {{.}}</h1>`)))
+
+	http.HandleFunc("/hello", func(w http.ResponseWriter, r *http.Request) {
+		// The rendering span is a child of the request span.
+		if err := page.Execute(r.Context(), w, r.URL.Query().Get("name")); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
@@ -0,0 +1,14 @@
+module go.opentelemetry.io/contrib/instrumentation/text/template/oteltemplate
+
+go 1.20
+
+require (
+	go.opentelemetry.io/otel v1.19.0
+	go.opentelemetry.io/otel/trace v1.19.0
+)
+
+require (
+	github.com/go-logr/logr v1.2.4 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	go.opentelemetry.io/otel/metric v1.19.0 // indirect
+)
@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oteltemplate // import "go.opentelemetry.io/contrib/instrumentation/text/template/oteltemplate"
+
+import (
+	"context"
+	"io"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TemplateNameKey is the attribute key of the name of the rendered template.
+const TemplateNameKey = attribute.Key("template.name")
+
+// Executor executes templates. It is implemented by *text/template.Template
+// and *html/template.Template.
+type Executor interface {
+	Name() string
+	Execute(w io.Writer, data interface{}) error
+	ExecuteTemplate(w io.Writer, name string, data interface{}) error
+}
+
+// Template traces the rendering of the template it wraps.
+type Template struct {
+	tmpl     Executor
+	renderer *Renderer
+}
+
+// New returns a Template tracing the rendering of tmpl.
+func New(tmpl Executor, opts ...Option) *Template {
+	return &Template{
+		tmpl:     tmpl,
+		renderer: NewRenderer(opts...),
+	}
+}
+
+// Execute applies the template to data, writing the output to w, within a
+// span that is a child of the span in ctx.
+func (t *Template) Execute(ctx context.Context, w io.Writer, data interface{}) error {
+	return t.renderer.Render(ctx, t.tmpl.Name(), func() error {
+		return t.tmpl.Execute(w, data)
+	})
+}
+
+// ExecuteTemplate applies the template associated with t that has the given
+// name to data, writing the output to w, within a span that is a child of the
+// span in ctx.
+func (t *Template) ExecuteTemplate(ctx context.Context, w io.Writer, name string, data interface{}) error {
+	return t.renderer.Render(ctx, name, func() error {
+		return t.tmpl.ExecuteTemplate(w, name, data)
+	})
+}
+
+// Renderer traces the rendering of templates executed by others, like the
+// renderers of web frameworks.
+type Renderer struct {
+	tracer trace.Tracer
+}
+
+// NewRenderer returns a Renderer.
+func NewRenderer(opts ...Option) *Renderer {
+	cfg := newConfig(opts)
+	return &Renderer{
+		tracer: cfg.TracerProvider.Tracer(
+			instrumentationName,
+			trace.WithInstrumentationVersion(Version()),
+		),
+	}
+}
+
+// Render calls fn, rendering the template with the given name, within a span
+// that is a child of the span in ctx. The error returned by fn is recorded
+// and returned.
+func (r *Renderer) Render(ctx context.Context, name string, fn func() error) error {
+	_, span := r.tracer.Start(ctx, "render "+name,
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(TemplateNameKey.String(name)),
+	)
+	defer span.End()
+
+	err := fn()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
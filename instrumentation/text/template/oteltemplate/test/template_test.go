@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"bytes"
+	"context"
+	htmltemplate "html/template"
+	"testing"
+	texttemplate "text/template"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/instrumentation/text/template/oteltemplate"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestExecute(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	tmpl := oteltemplate.New(
+		htmltemplate.Must(htmltemplate.New("page").Parse(`<p>{{.}}</p>`)),
+		oteltemplate.WithTracerProvider(provider),
+	)
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "request")
+	var buf bytes.Buffer
+	require.NoError(t, tmpl.Execute(ctx, &buf, "<b>"))
+	parent.End()
+	assert.Equal(t, "<p>&lt;b&gt;</p>", buf.String())
+
+	spans := sr.Ended()
+	require.Len(t, spans, 2)
+	span := spans[0]
+	assert.Equal(t, "render page", span.Name())
+	assert.Equal(t, trace.SpanKindInternal, span.SpanKind())
+	assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+	assert.Contains(t, span.Attributes(), attribute.String("template.name", "page"))
+	assert.Equal(t, codes.Unset, span.Status().Code)
+	assert.Equal(t, "go.opentelemetry.io/contrib/instrumentation/text/template/oteltemplate", span.InstrumentationScope().Name)
+	assert.Equal(t, oteltemplate.Version(), span.InstrumentationScope().Version)
+}
+
+func TestExecuteTemplate(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	base := texttemplate.Must(texttemplate.New("base").Parse(`{{define "item"}}- {{.}}{{end}}`))
+	tmpl := oteltemplate.New(base, oteltemplate.WithTracerProvider(provider))
+
+	var buf bytes.Buffer
+	require.NoError(t, tmpl.ExecuteTemplate(context.Background(), &buf, "item", "one"))
+	assert.Equal(t, "- one", buf.String())
+
+	err := tmpl.ExecuteTemplate(context.Background(), &buf, "missing", nil)
+	require.Error(t, err)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "render item", spans[0].Name())
+	assert.Contains(t, spans[0].Attributes(), attribute.String("template.name", "item"))
+	assert.Equal(t, "render missing", spans[1].Name())
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.Equal(t, err.Error(), spans[1].Status().Description)
+	require.Len(t, spans[1].Events(), 1)
+	assert.Equal(t, "exception", spans[1].Events()[0].Name)
+}
+
+func TestRenderer(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	r := oteltemplate.NewRenderer(oteltemplate.WithTracerProvider(provider))
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "request")
+	require.NoError(t, r.Render(ctx, "page", func() error { return nil }))
+	parent.End()
+
+	spans := sr.Ended()
+	require.Len(t, spans, 2)
+	span := spans[0]
+	assert.Equal(t, "render page", span.Name())
+	assert.Equal(t, trace.SpanKindInternal, span.SpanKind())
+	assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+	assert.Contains(t, span.Attributes(), oteltemplate.TemplateNameKey.String("page"))
+	assert.Equal(t, "go.opentelemetry.io/contrib/instrumentation/text/template/oteltemplate", span.InstrumentationScope().Name)
+}
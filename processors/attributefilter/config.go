@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attributefilter // import "go.opentelemetry.io/contrib/processors/attributefilter"
+
+type config struct {
+	allowed []string
+	denied  []string
+}
+
+// Option applies configuration settings to a span processor.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (fn optionFunc) apply(c *config) {
+	fn(c)
+}
+
+// newConfig returns a config with opts applied.
+func newConfig(opts ...Option) config {
+	var c config
+	for _, opt := range opts {
+		opt.apply(&c)
+	}
+	return c
+}
+
+// WithAllowedKeys adds the patterns of the keys of the attributes kept by
+// the span processor, e.g. WithAllowedKeys("http.*", "rpc.*"). The
+// attributes whose keys match none of the patterns are removed. All the
+// attributes are allowed by default.
+//
+// The patterns use the syntax of path.Match. Invalid patterns are reported
+// to the global error handler and ignored.
+func WithAllowedKeys(patterns ...string) Option {
+	patterns = append([]string(nil), patterns...)
+	return optionFunc(func(c *config) {
+		c.allowed = append(c.allowed, patterns...)
+	})
+}
+
+// WithDeniedKeys adds the patterns of the keys of the attributes removed by
+// the span processor, e.g. WithDeniedKeys("http.user_agent", "*.id"). The
+// denied keys take precedence over the allowed ones.
+//
+// The patterns use the syntax of path.Match. Invalid patterns are reported
+// to the global error handler and ignored.
+func WithDeniedKeys(patterns ...string) Option {
+	patterns = append([]string(nil), patterns...)
+	return optionFunc(func(c *config) {
+		c.denied = append(c.denied, patterns...)
+	})
+}
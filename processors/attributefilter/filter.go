@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attributefilter // import "go.opentelemetry.io/contrib/processors/attributefilter"
+
+import (
+	"fmt"
+	"path"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// filter filters attributes by their keys.
+type filter struct {
+	// allowed is nil if all the keys are allowed.
+	allowed []string
+	denied  []string
+}
+
+func newFilter(c config) *filter {
+	f := &filter{
+		allowed: validPatterns(c.allowed),
+		denied:  validPatterns(c.denied),
+	}
+	if len(c.allowed) > 0 && f.allowed == nil {
+		// Only invalid patterns were allowed: allow none of the keys.
+		f.allowed = []string{}
+	}
+	return f
+}
+
+// validPatterns returns the valid patterns, reporting the invalid ones to
+// the global error handler.
+func validPatterns(patterns []string) []string {
+	var valid []string
+	for _, p := range patterns {
+		if _, err := path.Match(p, ""); err != nil {
+			otel.Handle(fmt.Errorf("attributefilter: invalid key pattern %q: %w", p, err))
+			continue
+		}
+		valid = append(valid, p)
+	}
+	return valid
+}
+
+// keep returns whether the attribute with key is kept.
+func (f *filter) keep(key attribute.Key) bool {
+	if f.allowed != nil && !match(f.allowed, string(key)) {
+		return false
+	}
+	return !match(f.denied, string(key))
+}
+
+// match returns whether key matches any of the patterns.
+func match(patterns []string, key string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, key); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// attributes returns the attributes of attrs which are kept, and whether any
+// attribute was removed. attrs is not modified.
+func (f *filter) attributes(attrs []attribute.KeyValue) ([]attribute.KeyValue, bool) {
+	var filtered []attribute.KeyValue
+	for i, kv := range attrs {
+		if f.keep(kv.Key) {
+			if filtered != nil {
+				filtered = append(filtered, kv)
+			}
+			continue
+		}
+		if filtered == nil {
+			filtered = make([]attribute.KeyValue, i, len(attrs))
+			copy(filtered, attrs[:i])
+		}
+	}
+	if filtered == nil {
+		return attrs, false
+	}
+	return filtered, true
+}
@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package attributefilter provides a span processor removing attributes of
+// the spans before they are exported, to reduce the size and the
+// cardinality of the exported telemetry.
+//
+// The attributes are kept or removed by their keys: only the attributes
+// whose keys match the patterns passed with WithAllowedKeys are kept, if
+// any, and the attributes whose keys match the patterns passed with
+// WithDeniedKeys are removed. The attributes of the spans, of their events
+// and of their links are filtered.
+//
+// The span processor wraps the span processor exporting the spans, e.g. a
+// batch span processor:
+//
+//	bsp := trace.NewBatchSpanProcessor(exporter)
+//	tp := trace.NewTracerProvider(trace.WithSpanProcessor(
+//		attributefilter.NewSpanProcessor(bsp,
+//			attributefilter.WithDeniedKeys("http.user_agent", "net.sock.*"),
+//		),
+//	))
+package attributefilter // import "go.opentelemetry.io/contrib/processors/attributefilter"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// spanProcessor filters the attributes of the ended spans before passing
+// them to the next span processor.
+type spanProcessor struct {
+	next   sdktrace.SpanProcessor
+	filter *filter
+}
+
+// compile time assertion that spanProcessor implements the
+// sdktrace.SpanProcessor interface.
+var _ sdktrace.SpanProcessor = (*spanProcessor)(nil)
+
+// NewSpanProcessor returns a span processor filtering the attributes of the
+// ended spans, configured with opts, before passing them to next.
+//
+// The spans passed to the OnStart method of next are not filtered.
+func NewSpanProcessor(next sdktrace.SpanProcessor, opts ...Option) sdktrace.SpanProcessor {
+	return &spanProcessor{next: next, filter: newFilter(newConfig(opts...))}
+}
+
+// OnStart passes s to the next span processor.
+func (p *spanProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	p.next.OnStart(parent, s)
+}
+
+// OnEnd passes s, with its attributes filtered, to the next span processor.
+func (p *spanProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	p.next.OnEnd(p.filterSpan(s))
+}
+
+// Shutdown shuts the next span processor down.
+func (p *spanProcessor) Shutdown(ctx context.Context) error {
+	return p.next.Shutdown(ctx)
+}
+
+// ForceFlush flushes the next span processor.
+func (p *spanProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
+
+// filterSpan returns s with its attributes filtered. s is returned unchanged
+// if none of its attributes is removed.
+func (p *spanProcessor) filterSpan(s sdktrace.ReadOnlySpan) sdktrace.ReadOnlySpan {
+	attrs, attrsFiltered := p.filter.attributes(s.Attributes())
+	events, eventsFiltered := p.events(s.Events())
+	links, linksFiltered := p.links(s.Links())
+	if !attrsFiltered && !eventsFiltered && !linksFiltered {
+		return s
+	}
+	return filteredSpan{ReadOnlySpan: s, attrs: attrs, events: events, links: links}
+}
+
+// events returns events with their attributes filtered, and whether any
+// attribute was removed. events is not modified.
+func (p *spanProcessor) events(events []sdktrace.Event) ([]sdktrace.Event, bool) {
+	var filtered []sdktrace.Event
+	for i, e := range events {
+		attrs, ok := p.filter.attributes(e.Attributes)
+		if !ok {
+			continue
+		}
+		if filtered == nil {
+			filtered = append([]sdktrace.Event(nil), events...)
+		}
+		filtered[i].Attributes = attrs
+	}
+	if filtered == nil {
+		return events, false
+	}
+	return filtered, true
+}
+
+// links returns links with their attributes filtered, and whether any
+// attribute was removed. links is not modified.
+func (p *spanProcessor) links(links []sdktrace.Link) ([]sdktrace.Link, bool) {
+	var filtered []sdktrace.Link
+	for i, l := range links {
+		attrs, ok := p.filter.attributes(l.Attributes)
+		if !ok {
+			continue
+		}
+		if filtered == nil {
+			filtered = append([]sdktrace.Link(nil), links...)
+		}
+		filtered[i].Attributes = attrs
+	}
+	if filtered == nil {
+		return links, false
+	}
+	return filtered, true
+}
+
+// filteredSpan is an ended span whose attributes are filtered.
+type filteredSpan struct {
+	sdktrace.ReadOnlySpan
+
+	attrs  []attribute.KeyValue
+	events []sdktrace.Event
+	links  []sdktrace.Link
+}
+
+// Attributes returns the filtered attributes of the span.
+func (s filteredSpan) Attributes() []attribute.KeyValue { return s.attrs }
+
+// Events returns the events of the span with their attributes filtered.
+func (s filteredSpan) Events() []sdktrace.Event { return s.events }
+
+// Links returns the links of the span with their attributes filtered.
+func (s filteredSpan) Links() []sdktrace.Link { return s.links }
@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attributefilter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var attrs = []attribute.KeyValue{
+	attribute.String("http.method", "GET"),
+	attribute.String("http.user_agent", "curl/8.0"),
+	attribute.Int("http.status_code", 200),
+	attribute.String("net.sock.peer.addr", "10.0.0.1"),
+	attribute.String("user.id", "42"),
+}
+
+// record returns the span recorded by the span processor with opts.
+func record(t *testing.T, opts ...Option) sdktrace.ReadOnlySpan {
+	t.Helper()
+
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(NewSpanProcessor(sr, opts...)))
+	_, span := tp.Tracer("test").Start(context.Background(), "span")
+	span.SetAttributes(attrs...)
+	span.End()
+
+	ended := sr.Ended()
+	require.Len(t, ended, 1)
+	return ended[0]
+}
+
+func TestFilter(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		want []attribute.KeyValue
+	}{
+		{
+			name: "Default",
+			want: attrs,
+		},
+		{
+			name: "Allowed",
+			opts: []Option{WithAllowedKeys("http.*")},
+			want: attrs[:3],
+		},
+		{
+			name: "Denied",
+			opts: []Option{WithDeniedKeys("http.user_agent", "net.sock.*")},
+			want: []attribute.KeyValue{attrs[0], attrs[2], attrs[4]},
+		},
+		{
+			name: "AllowedAndDenied",
+			opts: []Option{WithAllowedKeys("http.*", "user.id"), WithDeniedKeys("http.user_agent")},
+			want: []attribute.KeyValue{attrs[0], attrs[2], attrs[4]},
+		},
+		{
+			name: "NoneAllowed",
+			opts: []Option{WithAllowedKeys("db.*")},
+			want: []attribute.KeyValue{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, record(t, tt.opts...).Attributes())
+		})
+	}
+}
+
+func TestFilterEventsAndLinks(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(
+		NewSpanProcessor(sr, WithDeniedKeys("*.stacktrace", "link.*")),
+	))
+	tracer := tp.Tracer("test")
+
+	_, linked := tracer.Start(context.Background(), "linked")
+	linked.End()
+	_, span := tracer.Start(context.Background(), "span", trace.WithLinks(trace.Link{
+		SpanContext: linked.SpanContext(),
+		Attributes:  []attribute.KeyValue{attribute.String("link.reason", "retry")},
+	}))
+	span.AddEvent("exception", trace.WithAttributes(
+		attribute.String("exception.message", "boom"),
+		attribute.String("exception.stacktrace", "main.main()"),
+	))
+	span.End()
+
+	ended := sr.Ended()
+	require.Len(t, ended, 2)
+	s := ended[1]
+
+	events := s.Events()
+	require.Len(t, events, 1)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("exception.message", "boom")}, events[0].Attributes)
+	links := s.Links()
+	require.Len(t, links, 1)
+	assert.Equal(t, linked.SpanContext(), links[0].SpanContext)
+	assert.Empty(t, links[0].Attributes)
+}
+
+func TestFilterUnchanged(t *testing.T) {
+	p := NewSpanProcessor(tracetest.NewSpanRecorder(), WithDeniedKeys("db.*")).(*spanProcessor)
+
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	_, span := tp.Tracer("test").Start(context.Background(), "span")
+	span.SetAttributes(attrs...)
+	span.End()
+
+	s := sr.Ended()[0]
+	assert.Same(t, s, p.filterSpan(s))
+}
+
+func TestInvalidKeyPattern(t *testing.T) {
+	var errs []error
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) { errs = append(errs, err) }))
+	t.Cleanup(func() { otel.SetErrorHandler(otel.ErrorHandlerFunc(func(error) {})) })
+
+	s := record(t, WithAllowedKeys("["), WithDeniedKeys("[", "user.*"))
+	assert.Len(t, errs, 2)
+	assert.Empty(t, s.Attributes(), "only invalid allowed patterns allow none of the keys")
+}
@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errorexport // import "go.opentelemetry.io/contrib/processors/errorexport"
+
+import (
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+type config struct {
+	filter func(sdktrace.ReadOnlySpan) bool
+}
+
+// Option applies configuration settings to a span processor.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (fn optionFunc) apply(c *config) {
+	fn(c)
+}
+
+// newConfig returns a config with opts applied.
+func newConfig(opts ...Option) config {
+	c := config{filter: isError}
+	for _, opt := range opts {
+		opt.apply(&c)
+	}
+	return c
+}
+
+// WithFilter sets the function selecting the ended spans of the unsampled
+// traces exported along their ancestors. The spans whose status is an error
+// are selected by default.
+func WithFilter(filter func(sdktrace.ReadOnlySpan) bool) Option {
+	return optionFunc(func(c *config) {
+		if filter != nil {
+			c.filter = filter
+		}
+	})
+}
+
+// isError returns whether the status of s is an error.
+func isError(s sdktrace.ReadOnlySpan) bool {
+	return s.Status().Code == codes.Error
+}
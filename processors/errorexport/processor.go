@@ -0,0 +1,165 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package errorexport provides a span processor exporting the failing spans
+// of the traces not sampled, and their local ancestors, so the failures are
+// not lost to probability sampling.
+//
+// The spans of the traces not sampled must be recorded for the span
+// processor to receive them: wrap the sampler with RecordingSampler, and the
+// span processor exporting the spans with the span processor of this
+// package:
+//
+//	bsp := trace.NewBatchSpanProcessor(exporter)
+//	tp := trace.NewTracerProvider(
+//		trace.WithSampler(errorexport.RecordingSampler(
+//			trace.ParentBased(trace.TraceIDRatioBased(0.01)),
+//		)),
+//		trace.WithSpanProcessor(errorexport.NewSpanProcessor(bsp)),
+//	)
+//
+// The sampled spans are passed to the wrapped span processor unchanged. The
+// spans of the traces not sampled whose status is an error, or which are
+// selected with WithFilter, are passed to it as sampled, along with their
+// ancestors started in the process and still running when they end. The
+// other spans of the traces not sampled are dropped.
+//
+// The exported traces are partial: the spans of other processes, and the
+// spans of the process ended before the failing spans, are missing.
+package errorexport // import "go.opentelemetry.io/contrib/processors/errorexport"
+
+import (
+	"context"
+	"sync"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// spanKey identifies a span.
+type spanKey struct {
+	traceID trace.TraceID
+	spanID  trace.SpanID
+}
+
+// spanProcessor exports the failing spans of the traces not sampled, and
+// their running ancestors.
+type spanProcessor struct {
+	next   sdktrace.SpanProcessor
+	filter func(sdktrace.ReadOnlySpan) bool
+
+	mu sync.Mutex
+	// ancestors are the local ancestors of the running spans not sampled,
+	// from their parents.
+	ancestors map[spanKey][]trace.SpanID
+	// exported are the running spans not sampled which are exported once
+	// ended, as ancestors of exported spans.
+	exported map[spanKey]struct{}
+}
+
+// compile time assertion that spanProcessor implements the
+// sdktrace.SpanProcessor interface.
+var _ sdktrace.SpanProcessor = (*spanProcessor)(nil)
+
+// NewSpanProcessor returns a span processor passing the sampled spans, and
+// the failing spans of the traces not sampled with their running local
+// ancestors, to next, configured with opts.
+func NewSpanProcessor(next sdktrace.SpanProcessor, opts ...Option) sdktrace.SpanProcessor {
+	c := newConfig(opts...)
+	return &spanProcessor{
+		next:      next,
+		filter:    c.filter,
+		ancestors: make(map[spanKey][]trace.SpanID),
+		exported:  make(map[spanKey]struct{}),
+	}
+}
+
+// OnStart records the local ancestors of s if it is not sampled, and passes
+// s to the next span processor.
+func (p *spanProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	if sc := s.SpanContext(); !sc.IsSampled() {
+		p.mu.Lock()
+		var ancestors []trace.SpanID
+		if psc := s.Parent(); psc.IsValid() && !psc.IsRemote() {
+			// The ancestors are recorded at start as the parent may end
+			// before s.
+			grandparents := p.ancestors[spanKey{sc.TraceID(), psc.SpanID()}]
+			ancestors = make([]trace.SpanID, 0, 1+len(grandparents))
+			ancestors = append(append(ancestors, psc.SpanID()), grandparents...)
+		}
+		p.ancestors[spanKey{sc.TraceID(), sc.SpanID()}] = ancestors
+		p.mu.Unlock()
+	}
+	p.next.OnStart(parent, s)
+}
+
+// OnEnd passes s to the next span processor if it is sampled, selected by
+// the filter, or an ancestor of an exported span.
+func (p *spanProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	sc := s.SpanContext()
+	if sc.IsSampled() {
+		p.next.OnEnd(s)
+		return
+	}
+
+	selected := p.filter(s)
+	key := spanKey{sc.TraceID(), sc.SpanID()}
+
+	p.mu.Lock()
+	_, ancestor := p.exported[key]
+	if selected {
+		// Export the running ancestors of s once they end.
+		for _, id := range p.ancestors[key] {
+			if k := (spanKey{key.traceID, id}); p.running(k) {
+				p.exported[k] = struct{}{}
+			}
+		}
+	}
+	delete(p.ancestors, key)
+	delete(p.exported, key)
+	p.mu.Unlock()
+
+	if selected || ancestor {
+		p.next.OnEnd(sampledSpan{ReadOnlySpan: s})
+	}
+}
+
+// running returns whether the span with key is running. The lock must be
+// held.
+func (p *spanProcessor) running(key spanKey) bool {
+	_, ok := p.ancestors[key]
+	return ok
+}
+
+// Shutdown shuts the next span processor down.
+func (p *spanProcessor) Shutdown(ctx context.Context) error {
+	return p.next.Shutdown(ctx)
+}
+
+// ForceFlush flushes the next span processor.
+func (p *spanProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
+
+// sampledSpan is an ended span not sampled, exported as sampled.
+type sampledSpan struct {
+	sdktrace.ReadOnlySpan
+}
+
+// SpanContext returns the span context of the span with the sampled flag
+// set.
+func (s sampledSpan) SpanContext() trace.SpanContext {
+	sc := s.ReadOnlySpan.SpanContext()
+	return sc.WithTraceFlags(sc.TraceFlags().WithSampled(true))
+}
@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errorexport
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func newTestTracer(sampler sdktrace.Sampler, opts ...Option) (trace.Tracer, *spanProcessor, *tracetest.InMemoryExporter) {
+	exporter := tracetest.NewInMemoryExporter()
+	p := NewSpanProcessor(sdktrace.NewSimpleSpanProcessor(exporter), opts...).(*spanProcessor)
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(RecordingSampler(sampler)),
+		sdktrace.WithSpanProcessor(p),
+	)
+	return tp.Tracer("test"), p, exporter
+}
+
+func names(spans tracetest.SpanStubs) []string {
+	var n []string
+	for _, s := range spans {
+		n = append(n, s.Name)
+	}
+	return n
+}
+
+func TestSpanProcessorExportsErrorsAndAncestors(t *testing.T) {
+	tracer, p, exporter := newTestTracer(sdktrace.NeverSample())
+
+	ctx, root := tracer.Start(context.Background(), "root")
+	_, sibling := tracer.Start(ctx, "sibling")
+	sibling.End()
+	ctx2, child := tracer.Start(ctx, "child")
+	_, failed := tracer.Start(ctx2, "failed")
+	failed.SetStatus(codes.Error, "boom")
+	failed.End()
+	child.End()
+	root.End()
+
+	spans := exporter.GetSpans()
+	assert.Equal(t, []string{"failed", "child", "root"}, names(spans))
+	for _, s := range spans {
+		assert.True(t, s.SpanContext.IsSampled(), "exported as sampled")
+	}
+	assert.Equal(t, spans[1].SpanContext.SpanID(), spans[0].Parent.SpanID())
+	assert.Empty(t, p.ancestors)
+	assert.Empty(t, p.exported)
+}
+
+func TestSpanProcessorDropsSuccessfulTraces(t *testing.T) {
+	tracer, p, exporter := newTestTracer(sdktrace.NeverSample())
+
+	ctx, root := tracer.Start(context.Background(), "root")
+	_, child := tracer.Start(ctx, "child")
+	child.End()
+	root.End()
+
+	assert.Empty(t, exporter.GetSpans())
+	assert.Empty(t, p.ancestors)
+}
+
+func TestSpanProcessorSampledTraces(t *testing.T) {
+	tracer, p, exporter := newTestTracer(sdktrace.AlwaysSample())
+
+	ctx, root := tracer.Start(context.Background(), "root")
+	_, child := tracer.Start(ctx, "child")
+	child.End()
+	root.End()
+
+	assert.Equal(t, []string{"child", "root"}, names(exporter.GetSpans()))
+	assert.Empty(t, p.ancestors)
+}
+
+func TestSpanProcessorEndedAncestors(t *testing.T) {
+	tracer, _, exporter := newTestTracer(sdktrace.NeverSample())
+
+	ctx, root := tracer.Start(context.Background(), "root")
+	ctx, parent := tracer.Start(ctx, "parent")
+	_, async := tracer.Start(ctx, "async")
+	parent.End()
+	async.SetStatus(codes.Error, "boom")
+	async.End()
+	root.End()
+
+	assert.Equal(t, []string{"async", "root"}, names(exporter.GetSpans()))
+}
+
+func TestSpanProcessorRemoteParent(t *testing.T) {
+	tracer, p, exporter := newTestTracer(sdktrace.ParentBased(sdktrace.AlwaysSample()))
+
+	remote := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{1},
+		SpanID:  trace.SpanID{1},
+		Remote:  true,
+	})
+	ctx := trace.ContextWithRemoteSpanContext(context.Background(), remote)
+	ctx, server := tracer.Start(ctx, "server")
+	_, failed := tracer.Start(ctx, "failed")
+	failed.SetStatus(codes.Error, "boom")
+	failed.End()
+	server.End()
+
+	assert.Equal(t, []string{"failed", "server"}, names(exporter.GetSpans()))
+	assert.Empty(t, p.exported)
+}
+
+func TestSpanProcessorFilter(t *testing.T) {
+	tracer, _, exporter := newTestTracer(sdktrace.NeverSample(), WithFilter(func(s sdktrace.ReadOnlySpan) bool {
+		return s.Name() == "slow"
+	}))
+
+	ctx, root := tracer.Start(context.Background(), "root")
+	_, failed := tracer.Start(ctx, "failed")
+	failed.SetStatus(codes.Error, "boom")
+	failed.End()
+	_, slow := tracer.Start(ctx, "slow")
+	slow.End()
+	root.End()
+
+	assert.Equal(t, []string{"slow", "root"}, names(exporter.GetSpans()))
+}
+
+func TestRecordingSampler(t *testing.T) {
+	s := RecordingSampler(sdktrace.NeverSample())
+	assert.Equal(t, "RecordingSampler{AlwaysOffSampler}", s.Description())
+	assert.Equal(t, sdktrace.RecordOnly, s.ShouldSample(sdktrace.SamplingParameters{}).Decision)
+
+	s = RecordingSampler(sdktrace.AlwaysSample())
+	assert.Equal(t, sdktrace.RecordAndSample, s.ShouldSample(sdktrace.SamplingParameters{}).Decision)
+}
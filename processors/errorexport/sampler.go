@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errorexport // import "go.opentelemetry.io/contrib/processors/errorexport"
+
+import (
+	"fmt"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// recordingSampler records the spans of the traces not sampled by its
+// delegate, so they reach the span processor until one of them fails.
+type recordingSampler struct {
+	delegate sdktrace.Sampler
+}
+
+// compile time assertion that recordingSampler implements the
+// sdktrace.Sampler interface.
+var _ sdktrace.Sampler = recordingSampler{}
+
+// RecordingSampler returns a sampler recording, without sampling, the spans
+// delegate drops. The span processor of this package tracks the running
+// recorded spans, and exports a recorded span as sampled if it fails, along
+// with its running local ancestors. The spans sampled by delegate are
+// unchanged.
+//
+// The parent-based samplers of the SDK drop the children of recorded but not
+// sampled parents, which are then recorded as well: wrap the whole sampler,
+// e.g. trace.ParentBased(trace.TraceIDRatioBased(0.01)), rather than its root
+// sampler.
+func RecordingSampler(delegate sdktrace.Sampler) sdktrace.Sampler {
+	return recordingSampler{delegate: delegate}
+}
+
+// ShouldSample returns the decision of the delegate, recording the spans it
+// drops.
+func (s recordingSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	res := s.delegate.ShouldSample(p)
+	if res.Decision == sdktrace.Drop {
+		res.Decision = sdktrace.RecordOnly
+	}
+	return res
+}
+
+// Description returns the description of the sampler.
+func (s recordingSampler) Description() string {
+	return fmt.Sprintf("RecordingSampler{%s}", s.delegate.Description())
+}
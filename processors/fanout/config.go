@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fanout // import "go.opentelemetry.io/contrib/processors/fanout"
+
+import (
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Filter returns whether an ended span is passed to a destination.
+type Filter func(sdktrace.ReadOnlySpan) bool
+
+// Errors is a Filter selecting the spans whose status is an error.
+func Errors(s sdktrace.ReadOnlySpan) bool {
+	return s.Status().Code == codes.Error
+}
+
+// route is a destination of the span processor.
+type route struct {
+	processor sdktrace.SpanProcessor
+	filter    Filter
+}
+
+type config struct {
+	routes []route
+}
+
+// Option applies configuration settings to a span processor.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (fn optionFunc) apply(c *config) {
+	fn(c)
+}
+
+// newConfig returns a config with opts applied.
+func newConfig(opts ...Option) config {
+	var c config
+	for _, opt := range opts {
+		opt.apply(&c)
+	}
+	return c
+}
+
+// WithDestination adds a destination passing the ended spans selected by
+// filter to processor, e.g. a batch span processor exporting them. All the
+// spans are passed to processor if filter is nil.
+func WithDestination(processor sdktrace.SpanProcessor, filter Filter) Option {
+	return optionFunc(func(c *config) {
+		if processor != nil {
+			c.routes = append(c.routes, route{processor: processor, filter: filter})
+		}
+	})
+}
@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fanout_test
+
+import (
+	"go.opentelemetry.io/contrib/processors/fanout"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func ExampleNewSpanProcessor() {
+	vendor := tracetest.NewInMemoryExporter()
+	local := tracetest.NewInMemoryExporter()
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(
+		fanout.NewSpanProcessor(
+			// Only the failing spans are exported to the vendor, all the
+			// spans are exported locally.
+			fanout.WithDestination(sdktrace.NewBatchSpanProcessor(vendor), fanout.Errors),
+			fanout.WithDestination(sdktrace.NewBatchSpanProcessor(local), nil),
+		),
+	))
+	_ = tp
+}
@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fanout provides a span processor passing the ended spans to
+// several destinations, each selecting the spans it receives with a
+// Filter, e.g. the failing spans to a vendor and all the spans to a local
+// file:
+//
+//	tp := trace.NewTracerProvider(trace.WithSpanProcessor(
+//		fanout.NewSpanProcessor(
+//			fanout.WithDestination(trace.NewBatchSpanProcessor(vendorExporter), fanout.Errors),
+//			fanout.WithDestination(trace.NewBatchSpanProcessor(fileExporter), nil),
+//		),
+//	))
+//
+// The destinations are span processors, usually exporting the spans. The
+// started spans are passed to all of them: the filters only select the
+// ended spans.
+//
+// The spans of a trace are routed independently: a Filter only sees the span
+// it selects, so a destination selecting the failing spans receives them
+// without their successful parents.
+package fanout // import "go.opentelemetry.io/contrib/processors/fanout"
+
+import (
+	"context"
+	"errors"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// spanProcessor passes the spans to its routes.
+type spanProcessor struct {
+	routes []route
+}
+
+// compile time assertion that spanProcessor implements the
+// sdktrace.SpanProcessor interface.
+var _ sdktrace.SpanProcessor = (*spanProcessor)(nil)
+
+// NewSpanProcessor returns a span processor passing the ended spans to the
+// destinations passed with WithDestination whose filters select them.
+func NewSpanProcessor(opts ...Option) sdktrace.SpanProcessor {
+	return &spanProcessor{routes: newConfig(opts...).routes}
+}
+
+// OnStart passes s to all the destinations.
+func (p *spanProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	for _, r := range p.routes {
+		r.processor.OnStart(parent, s)
+	}
+}
+
+// OnEnd passes s to the destinations whose filters select it.
+func (p *spanProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	for _, r := range p.routes {
+		if r.filter == nil || r.filter(s) {
+			r.processor.OnEnd(s)
+		}
+	}
+}
+
+// Shutdown shuts all the destinations down, returning their joined errors.
+func (p *spanProcessor) Shutdown(ctx context.Context) error {
+	var errs []error
+	for _, r := range p.routes {
+		if err := r.processor.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ForceFlush flushes all the destinations, returning their joined errors.
+func (p *spanProcessor) ForceFlush(ctx context.Context) error {
+	var errs []error
+	for _, r := range p.routes {
+		if err := r.processor.ForceFlush(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fanout
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func names(spans []sdktrace.ReadOnlySpan) []string {
+	var n []string
+	for _, s := range spans {
+		n = append(n, s.Name())
+	}
+	return n
+}
+
+func TestSpanProcessor(t *testing.T) {
+	errs, all, internal := tracetest.NewSpanRecorder(), tracetest.NewSpanRecorder(), tracetest.NewSpanRecorder()
+	p := NewSpanProcessor(
+		WithDestination(errs, Errors),
+		WithDestination(all, nil),
+		WithDestination(internal, func(s sdktrace.ReadOnlySpan) bool {
+			return s.Name() == "internal"
+		}),
+		WithDestination(nil, nil),
+	)
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(p))
+	tracer := tp.Tracer("test")
+
+	_, span := tracer.Start(context.Background(), "ok")
+	span.End()
+	_, span = tracer.Start(context.Background(), "failed")
+	span.SetStatus(codes.Error, "boom")
+	span.End()
+	_, span = tracer.Start(context.Background(), "internal")
+	span.End()
+
+	assert.Equal(t, []string{"failed"}, names(errs.Ended()))
+	assert.Equal(t, []string{"ok", "failed", "internal"}, names(all.Ended()))
+	assert.Equal(t, []string{"internal"}, names(internal.Ended()))
+	for _, sr := range []*tracetest.SpanRecorder{errs, all, internal} {
+		assert.Len(t, sr.Started(), 3, "started spans passed to all destinations")
+	}
+}
+
+type errorProcessor struct {
+	sdktrace.SpanProcessor
+	err error
+}
+
+func (p errorProcessor) Shutdown(context.Context) error   { return p.err }
+func (p errorProcessor) ForceFlush(context.Context) error { return p.err }
+
+func TestSpanProcessorErrors(t *testing.T) {
+	errA, errB := errors.New("a"), errors.New("b")
+	p := NewSpanProcessor(
+		WithDestination(errorProcessor{err: errA}, nil),
+		WithDestination(tracetest.NewSpanRecorder(), nil),
+		WithDestination(errorProcessor{err: errB}, nil),
+	)
+
+	for _, err := range []error{p.ForceFlush(context.Background()), p.Shutdown(context.Background())} {
+		assert.ErrorIs(t, err, errA)
+		assert.ErrorIs(t, err, errB)
+	}
+	assert.NoError(t, NewSpanProcessor().Shutdown(context.Background()))
+}
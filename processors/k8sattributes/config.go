@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sattributes // import "go.opentelemetry.io/contrib/processors/k8sattributes"
+
+import (
+	"net/http"
+	"os"
+	"time"
+)
+
+// defaultTimeout is the default timeout of the requests to the API server.
+const defaultTimeout = 10 * time.Second
+
+type config struct {
+	apiServer bool
+	timeout   time.Duration
+
+	getenv   func(string) string
+	readFile func(string) ([]byte, error)
+	// apiURL and httpClient replace the in-cluster API server client, for
+	// testing.
+	apiURL     string
+	httpClient *http.Client
+}
+
+// Option applies configuration settings to a span processor.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (fn optionFunc) apply(c *config) {
+	fn(c)
+}
+
+// newConfig returns a config with opts applied.
+func newConfig(opts ...Option) config {
+	c := config{
+		timeout:  defaultTimeout,
+		getenv:   os.Getenv,
+		readFile: os.ReadFile,
+	}
+	for _, opt := range opts {
+		opt.apply(&c)
+	}
+	return c
+}
+
+// WithAPIServer resolves the UID and the node of the pod, and the
+// workload controlling it, e.g. its deployment, from the Kubernetes API
+// server, authenticated with the service account of the pod.
+//
+// The service account must be allowed to get the pod and the replica sets
+// of its namespace.
+func WithAPIServer() Option {
+	return optionFunc(func(c *config) {
+		c.apiServer = true
+	})
+}
+
+// WithTimeout sets the timeout of the resolution of the attributes from the
+// API server. The default is 10 seconds. Non-positive durations are ignored.
+func WithTimeout(d time.Duration) Option {
+	return optionFunc(func(c *config) {
+		if d > 0 {
+			c.timeout = d
+		}
+	})
+}
+
+// withEnv sets the functions reading the environment variables and the
+// files of the span processor, for testing.
+func withEnv(getenv func(string) string, readFile func(string) ([]byte, error)) Option {
+	return optionFunc(func(c *config) {
+		c.getenv = getenv
+		c.readFile = readFile
+	})
+}
+
+// withAPIClient sets the URL of the API server and the client requesting
+// it, for testing.
+func withAPIClient(url string, client *http.Client) Option {
+	return optionFunc(func(c *config) {
+		c.apiURL = url
+		c.httpClient = client
+	})
+}
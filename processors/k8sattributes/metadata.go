@@ -0,0 +1,232 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sattributes // import "go.opentelemetry.io/contrib/processors/k8sattributes"
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+// Environment variables set with the downward API.
+const (
+	podNameEnv      = "K8S_POD_NAME"
+	podNamespaceEnv = "K8S_POD_NAMESPACE"
+	podUIDEnv       = "K8S_POD_UID"
+	nodeNameEnv     = "K8S_NODE_NAME"
+	hostnameEnv     = "HOSTNAME"
+)
+
+// In-cluster configuration of the service account of the pod.
+const (
+	serviceHostEnv = "KUBERNETES_SERVICE_HOST"
+	servicePortEnv = "KUBERNETES_SERVICE_PORT"
+
+	serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount/"
+	namespacePath     = serviceAccountDir + "namespace"
+	tokenPath         = serviceAccountDir + "token"
+	caPath            = serviceAccountDir + "ca.crt"
+)
+
+var errNotInCluster = errors.New("k8sattributes: not running in a Kubernetes cluster")
+
+// pod identifies the pod of the process.
+type pod struct {
+	name      string
+	namespace string
+}
+
+// downwardAPI returns the pod of the process and its attributes set with the
+// downward API environment variables, falling back to the hostname and the
+// namespace of the service account.
+func downwardAPI(c config) (pod, []attribute.KeyValue) {
+	p := pod{
+		name:      c.getenv(podNameEnv),
+		namespace: c.getenv(podNamespaceEnv),
+	}
+	if p.namespace == "" {
+		if ns, err := c.readFile(namespacePath); err == nil {
+			p.namespace = strings.TrimSpace(string(ns))
+		}
+	}
+	if p.namespace == "" {
+		// Not running in Kubernetes, or the downward API is not used.
+		return p, nil
+	}
+	if p.name == "" {
+		p.name = c.getenv(hostnameEnv)
+	}
+
+	attrs := []attribute.KeyValue{semconv.K8SNamespaceName(p.namespace)}
+	if p.name != "" {
+		attrs = append(attrs, semconv.K8SPodName(p.name))
+	}
+	if uid := c.getenv(podUIDEnv); uid != "" {
+		attrs = append(attrs, semconv.K8SPodUID(uid))
+	}
+	if node := c.getenv(nodeNameEnv); node != "" {
+		attrs = append(attrs, semconv.K8SNodeName(node))
+	}
+	return p, attrs
+}
+
+// apiClient requests the Kubernetes API server.
+type apiClient struct {
+	url      string
+	client   *http.Client
+	readFile func(string) ([]byte, error)
+}
+
+// newAPIClient returns a client of the API server of the cluster the process
+// runs in.
+func newAPIClient(c config) (*apiClient, error) {
+	if c.apiURL != "" {
+		return &apiClient{url: c.apiURL, client: c.httpClient, readFile: c.readFile}, nil
+	}
+
+	host, port := c.getenv(serviceHostEnv), c.getenv(servicePortEnv)
+	if host == "" || port == "" {
+		return nil, errNotInCluster
+	}
+	ca, err := c.readFile(caPath)
+	if err != nil {
+		return nil, fmt.Errorf("k8sattributes: reading the CA certificate: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, errors.New("k8sattributes: invalid CA certificate")
+	}
+	return &apiClient{
+		url: "https://" + net.JoinHostPort(host, port),
+		client: &http.Client{Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12},
+		}},
+		readFile: c.readFile,
+	}, nil
+}
+
+// objectMeta is the metadata of a Kubernetes object.
+type objectMeta struct {
+	Name            string           `json:"name"`
+	UID             string           `json:"uid"`
+	OwnerReferences []ownerReference `json:"ownerReferences"`
+}
+
+// controller returns the owner controlling the object, if any.
+func (m objectMeta) controller() (ownerReference, bool) {
+	for _, ref := range m.OwnerReferences {
+		if ref.Controller != nil && *ref.Controller {
+			return ref, true
+		}
+	}
+	return ownerReference{}, false
+}
+
+// ownerReference references the owner of a Kubernetes object.
+type ownerReference struct {
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	Controller *bool  `json:"controller"`
+}
+
+// podObject is a Kubernetes pod.
+type podObject struct {
+	Metadata objectMeta `json:"metadata"`
+	Spec     struct {
+		NodeName string `json:"nodeName"`
+	} `json:"spec"`
+}
+
+// replicaSetObject is a Kubernetes replica set.
+type replicaSetObject struct {
+	Metadata objectMeta `json:"metadata"`
+}
+
+// attributes returns the attributes of p resolved from the API server.
+func (c *apiClient) attributes(ctx context.Context, p pod) ([]attribute.KeyValue, error) {
+	var po podObject
+	if err := c.get(ctx, "/api/v1/namespaces/"+url.PathEscape(p.namespace)+"/pods/"+url.PathEscape(p.name), &po); err != nil {
+		return nil, err
+	}
+
+	attrs := []attribute.KeyValue{semconv.K8SPodName(po.Metadata.Name)}
+	if po.Metadata.UID != "" {
+		attrs = append(attrs, semconv.K8SPodUID(po.Metadata.UID))
+	}
+	if po.Spec.NodeName != "" {
+		attrs = append(attrs, semconv.K8SNodeName(po.Spec.NodeName))
+	}
+
+	owner, ok := po.Metadata.controller()
+	if !ok {
+		return attrs, nil
+	}
+	switch owner.Kind {
+	case "ReplicaSet":
+		attrs = append(attrs, semconv.K8SReplicaSetName(owner.Name))
+		var rs replicaSetObject
+		if err := c.get(ctx, "/apis/apps/v1/namespaces/"+url.PathEscape(p.namespace)+"/replicasets/"+url.PathEscape(owner.Name), &rs); err != nil {
+			return attrs, err
+		}
+		if owner, ok := rs.Metadata.controller(); ok && owner.Kind == "Deployment" {
+			attrs = append(attrs, semconv.K8SDeploymentName(owner.Name))
+		}
+	case "StatefulSet":
+		attrs = append(attrs, semconv.K8SStatefulSetName(owner.Name))
+	case "DaemonSet":
+		attrs = append(attrs, semconv.K8SDaemonSetName(owner.Name))
+	case "Job":
+		attrs = append(attrs, semconv.K8SJobName(owner.Name))
+	}
+	return attrs, nil
+}
+
+// get decodes the object at path of the API server into v.
+func (c *apiClient) get(ctx context.Context, path string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+path, nil)
+	if err != nil {
+		return err
+	}
+	// The token is read at each request as it is rotated by the kubelet.
+	token, err := c.readFile(tokenPath)
+	if err != nil {
+		return fmt.Errorf("k8sattributes: reading the service account token: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("k8sattributes: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("k8sattributes: GET %s: %s", path, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("k8sattributes: GET %s: %w", path, err)
+	}
+	return nil
+}
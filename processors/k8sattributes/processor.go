@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package k8sattributes provides a span processor adding the Kubernetes
+// attributes of the pod the process runs in, e.g. k8s.pod.name,
+// k8s.namespace.name, k8s.node.name or k8s.deployment.name, to the spans,
+// for the clusters where the k8sattributes processor of the collector does
+// not process the spans.
+//
+// The attributes are resolved from the environment variables set with the
+// downward API:
+//
+//	env:
+//	  - name: K8S_POD_NAME
+//	    valueFrom:
+//	      fieldRef:
+//	        fieldPath: metadata.name
+//	  - name: K8S_POD_NAMESPACE
+//	    valueFrom:
+//	      fieldRef:
+//	        fieldPath: metadata.namespace
+//	  - name: K8S_POD_UID
+//	    valueFrom:
+//	      fieldRef:
+//	        fieldPath: metadata.uid
+//	  - name: K8S_NODE_NAME
+//	    valueFrom:
+//	      fieldRef:
+//	        fieldPath: spec.nodeName
+//
+// falling back to the hostname for the pod name and to the namespace of the
+// service account. With WithAPIServer, the attributes are also resolved from
+// the Kubernetes API server, including the deployment, stateful set, daemon
+// set or job controlling the pod. The resolution is done once, in the
+// background: the spans started before it completes only have the
+// attributes of the downward API.
+package k8sattributes // import "go.opentelemetry.io/contrib/processors/k8sattributes"
+
+import (
+	"context"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// spanProcessor sets the Kubernetes attributes of the pod on the started
+// spans.
+type spanProcessor struct {
+	attrs  atomic.Pointer[[]attribute.KeyValue]
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// compile time assertion that spanProcessor implements the
+// sdktrace.SpanProcessor interface.
+var _ sdktrace.SpanProcessor = (*spanProcessor)(nil)
+
+// NewSpanProcessor returns a span processor setting the Kubernetes
+// attributes of the pod the process runs in, configured with opts, on the
+// started spans. No attribute is set if the process does not run in
+// Kubernetes.
+func NewSpanProcessor(opts ...Option) sdktrace.SpanProcessor {
+	c := newConfig(opts...)
+	p := &spanProcessor{done: make(chan struct{})}
+
+	pod, attrs := downwardAPI(c)
+	p.attrs.Store(&attrs)
+	if !c.apiServer || pod.name == "" || pod.namespace == "" {
+		p.cancel = func() {}
+		close(p.done)
+		return p
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	p.cancel = cancel
+	go func() {
+		defer close(p.done)
+		defer cancel()
+
+		client, err := newAPIClient(c)
+		if err != nil {
+			otel.Handle(err)
+			return
+		}
+		apiAttrs, err := client.attributes(ctx, pod)
+		if err != nil {
+			otel.Handle(err)
+		}
+		// The attributes of the API server take precedence.
+		set := attribute.NewSet(append(append([]attribute.KeyValue(nil), attrs...), apiAttrs...)...)
+		merged := set.ToSlice()
+		p.attrs.Store(&merged)
+	}()
+	return p
+}
+
+// OnStart sets the Kubernetes attributes on s.
+func (p *spanProcessor) OnStart(_ context.Context, s sdktrace.ReadWriteSpan) {
+	if attrs := *p.attrs.Load(); len(attrs) > 0 {
+		s.SetAttributes(attrs...)
+	}
+}
+
+// OnEnd does nothing.
+func (p *spanProcessor) OnEnd(sdktrace.ReadOnlySpan) {}
+
+// Shutdown cancels the resolution of the attributes from the API server.
+func (p *spanProcessor) Shutdown(ctx context.Context) error {
+	p.cancel()
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ForceFlush does nothing.
+func (p *spanProcessor) ForceFlush(context.Context) error { return nil }
@@ -0,0 +1,230 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sattributes
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+// env returns the options reading env and files instead of the environment
+// variables and the files of the process.
+func env(env map[string]string, files map[string]string) Option {
+	return withEnv(
+		func(k string) string { return env[k] },
+		func(path string) ([]byte, error) {
+			if f, ok := files[path]; ok {
+				return []byte(f), nil
+			}
+			return nil, os.ErrNotExist
+		},
+	)
+}
+
+// spanAttributes returns the attributes of a span started with the span
+// processor created with opts, once it resolved the attributes.
+func spanAttributes(t *testing.T, opts ...Option) []attribute.KeyValue {
+	t.Helper()
+
+	p := NewSpanProcessor(opts...)
+	<-p.(*spanProcessor).done
+
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(p), sdktrace.WithSpanProcessor(sr))
+	_, span := tp.Tracer("test").Start(context.Background(), "span")
+	span.End()
+	require.NoError(t, tp.Shutdown(context.Background()))
+
+	ended := sr.Ended()
+	require.Len(t, ended, 1)
+	return ended[0].Attributes()
+}
+
+func TestNotInKubernetes(t *testing.T) {
+	assert.Empty(t, spanAttributes(t, env(map[string]string{"HOSTNAME": "host"}, nil), WithAPIServer()))
+}
+
+func TestDownwardAPI(t *testing.T) {
+	attrs := spanAttributes(t, env(map[string]string{
+		"K8S_POD_NAME":      "api-7d9f8b6c5-x2x4z",
+		"K8S_POD_NAMESPACE": "shop",
+		"K8S_POD_UID":       "275ecb36-5aa8-4c2a-9c47-d8bb681b9aff",
+		"K8S_NODE_NAME":     "node-1",
+		"HOSTNAME":          "ignored",
+	}, nil))
+
+	assert.Equal(t, []attribute.KeyValue{
+		semconv.K8SNamespaceName("shop"),
+		semconv.K8SPodName("api-7d9f8b6c5-x2x4z"),
+		semconv.K8SPodUID("275ecb36-5aa8-4c2a-9c47-d8bb681b9aff"),
+		semconv.K8SNodeName("node-1"),
+	}, attrs)
+}
+
+func TestDownwardAPIFallbacks(t *testing.T) {
+	attrs := spanAttributes(t, env(
+		map[string]string{"HOSTNAME": "api-7d9f8b6c5-x2x4z"},
+		map[string]string{namespacePath: "shop\n"},
+	))
+
+	assert.Equal(t, []attribute.KeyValue{
+		semconv.K8SNamespaceName("shop"),
+		semconv.K8SPodName("api-7d9f8b6c5-x2x4z"),
+	}, attrs)
+}
+
+func newAPIServer(t *testing.T, objects map[string]interface{}) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		obj, ok := objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(obj)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func object(name, uid, ownerKind, ownerName string) map[string]interface{} {
+	meta := map[string]interface{}{"name": name, "uid": uid}
+	if ownerKind != "" {
+		meta["ownerReferences"] = []interface{}{
+			map[string]interface{}{"kind": "Other", "name": "other"},
+			map[string]interface{}{"kind": ownerKind, "name": ownerName, "controller": true},
+		}
+	}
+	return map[string]interface{}{
+		"metadata": meta,
+		"spec":     map[string]interface{}{"nodeName": "node-1"},
+	}
+}
+
+func TestAPIServer(t *testing.T) {
+	tests := []struct {
+		name    string
+		objects map[string]interface{}
+		want    []attribute.KeyValue
+	}{
+		{
+			name: "Deployment",
+			objects: map[string]interface{}{
+				"/api/v1/namespaces/shop/pods/api-7d9f8b6c5-x2x4z":        object("api-7d9f8b6c5-x2x4z", "uid", "ReplicaSet", "api-7d9f8b6c5"),
+				"/apis/apps/v1/namespaces/shop/replicasets/api-7d9f8b6c5": object("api-7d9f8b6c5", "rs-uid", "Deployment", "api"),
+			},
+			want: []attribute.KeyValue{
+				semconv.K8SDeploymentName("api"),
+				semconv.K8SNamespaceName("shop"),
+				semconv.K8SNodeName("node-1"),
+				semconv.K8SPodName("api-7d9f8b6c5-x2x4z"),
+				semconv.K8SPodUID("uid"),
+				semconv.K8SReplicaSetName("api-7d9f8b6c5"),
+			},
+		},
+		{
+			name: "StatefulSet",
+			objects: map[string]interface{}{
+				"/api/v1/namespaces/shop/pods/api-7d9f8b6c5-x2x4z": object("api-7d9f8b6c5-x2x4z", "uid", "StatefulSet", "db"),
+			},
+			want: []attribute.KeyValue{
+				semconv.K8SNamespaceName("shop"),
+				semconv.K8SNodeName("node-1"),
+				semconv.K8SPodName("api-7d9f8b6c5-x2x4z"),
+				semconv.K8SPodUID("uid"),
+				semconv.K8SStatefulSetName("db"),
+			},
+		},
+		{
+			name: "NoController",
+			objects: map[string]interface{}{
+				"/api/v1/namespaces/shop/pods/api-7d9f8b6c5-x2x4z": object("api-7d9f8b6c5-x2x4z", "uid", "", ""),
+			},
+			want: []attribute.KeyValue{
+				semconv.K8SNamespaceName("shop"),
+				semconv.K8SNodeName("node-1"),
+				semconv.K8SPodName("api-7d9f8b6c5-x2x4z"),
+				semconv.K8SPodUID("uid"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newAPIServer(t, tt.objects)
+			attrs := spanAttributes(t,
+				env(
+					map[string]string{"K8S_POD_NAME": "api-7d9f8b6c5-x2x4z", "K8S_POD_NAMESPACE": "shop"},
+					map[string]string{tokenPath: "token\n"},
+				),
+				WithAPIServer(),
+				withAPIClient(srv.URL, srv.Client()),
+			)
+			assert.Equal(t, tt.want, attrs)
+		})
+	}
+}
+
+func TestAPIServerError(t *testing.T) {
+	var errs []error
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) { errs = append(errs, err) }))
+	t.Cleanup(func() { otel.SetErrorHandler(otel.ErrorHandlerFunc(func(error) {})) })
+
+	srv := newAPIServer(t, nil)
+	attrs := spanAttributes(t,
+		env(
+			map[string]string{"K8S_POD_NAME": "api", "K8S_POD_NAMESPACE": "shop"},
+			map[string]string{tokenPath: "token"},
+		),
+		WithAPIServer(),
+		withAPIClient(srv.URL, srv.Client()),
+	)
+
+	require.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], "404 Not Found")
+	assert.Equal(t, []attribute.KeyValue{
+		semconv.K8SNamespaceName("shop"),
+		semconv.K8SPodName("api"),
+	}, attrs, "downward API attributes kept")
+}
+
+func TestNewAPIClientNotInCluster(t *testing.T) {
+	_, err := newAPIClient(newConfig(env(nil, nil)))
+	assert.ErrorIs(t, err, errNotInCluster)
+
+	_, err = newAPIClient(newConfig(env(
+		map[string]string{"KUBERNETES_SERVICE_HOST": "10.0.0.1", "KUBERNETES_SERVICE_PORT": "443"},
+		map[string]string{caPath: "invalid"},
+	)))
+	assert.EqualError(t, err, "k8sattributes: invalid CA certificate")
+}
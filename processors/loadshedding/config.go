@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadshedding // import "go.opentelemetry.io/contrib/processors/loadshedding"
+
+import (
+	"math"
+	"time"
+)
+
+// Strategy is the strategy choosing the spans dropped when the queue of the
+// span processor is full.
+type Strategy int
+
+const (
+	// DropNewest drops the spans ending while the queue is full.
+	DropNewest Strategy = iota
+	// DropOldest drops the oldest queued span to queue the span ending
+	// while the queue is full.
+	DropOldest
+)
+
+type config struct {
+	burst        float64
+	queueSize    int
+	strategy     Strategy
+	preferErrors bool
+	now          func() time.Time
+}
+
+// Option applies configuration settings to a span processor.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (fn optionFunc) apply(c *config) {
+	fn(c)
+}
+
+// newConfig returns the config of a span processor of spansPerSecond with
+// opts applied.
+func newConfig(spansPerSecond float64, opts ...Option) config {
+	c := config{now: time.Now}
+	for _, opt := range opts {
+		opt.apply(&c)
+	}
+	switch {
+	case spansPerSecond <= 0:
+		// No span is ever passed, not even a burst.
+		c.burst = 0
+	case c.burst < 1:
+		// Pass at least one span at once, or no span is ever passed.
+		c.burst = math.Max(1, spansPerSecond)
+	}
+	if c.queueSize <= 0 {
+		c.queueSize = int(math.Max(1, math.Min(spansPerSecond, math.MaxInt32)))
+	}
+	return c
+}
+
+// WithBurst sets the maximum number of spans passed at once, after a period
+// of low traffic, to n. It defaults to the number of spans per second of the
+// span processor, and at least 1. It is ignored if the number of spans per
+// second is not positive.
+func WithBurst(n float64) Option {
+	return optionFunc(func(c *config) {
+		c.burst = n
+	})
+}
+
+// WithQueueSize sets the maximum number of spans queued while the rate
+// limit is reached to n. It defaults to the number of spans per second of
+// the span processor, and at least 1.
+func WithQueueSize(n int) Option {
+	return optionFunc(func(c *config) {
+		c.queueSize = n
+	})
+}
+
+// WithStrategy sets the strategy choosing the spans dropped when the queue
+// is full. DropNewest is used by default.
+func WithStrategy(s Strategy) Option {
+	return optionFunc(func(c *config) {
+		c.strategy = s
+	})
+}
+
+// WithPreferErrors keeps the spans whose status is an error over the other
+// spans when the queue is full: the span dropped by the strategy is the
+// newest, or oldest, span without an error status, if any.
+func WithPreferErrors() Option {
+	return optionFunc(func(c *config) {
+		c.preferErrors = true
+	})
+}
+
+// withClock sets the clock of the span processor, for testing.
+func withClock(now func() time.Time) Option {
+	return optionFunc(func(c *config) {
+		c.now = now
+	})
+}
@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadshedding_test
+
+import (
+	"go.opentelemetry.io/contrib/processors/loadshedding"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func ExampleNewSpanProcessor() {
+	exporter := tracetest.NewInMemoryExporter()
+	bsp := sdktrace.NewBatchSpanProcessor(exporter)
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(
+		// Export at most 1000 spans per second, keeping the latest spans,
+		// and the failed ones, during traffic spikes.
+		loadshedding.NewSpanProcessor(bsp, 1000,
+			loadshedding.WithStrategy(loadshedding.DropOldest),
+			loadshedding.WithPreferErrors(),
+		),
+	))
+	_ = tp
+}
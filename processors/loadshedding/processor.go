@@ -0,0 +1,304 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package loadshedding provides a span processor limiting the rate of the
+// spans passed to the span processor exporting them, to protect the
+// exporters and the collectors from traffic spikes.
+//
+// The spans ending while the rate limit is reached are queued, and passed
+// once the rate allows. The spans ending while the queue is full are shed
+// according to the Strategy set with WithStrategy, optionally keeping the
+// spans whose status is an error with WithPreferErrors:
+//
+//	bsp := trace.NewBatchSpanProcessor(exporter)
+//	tp := trace.NewTracerProvider(trace.WithSpanProcessor(
+//		loadshedding.NewSpanProcessor(bsp, 1000,
+//			loadshedding.WithStrategy(loadshedding.DropOldest),
+//			loadshedding.WithPreferErrors(),
+//		),
+//	))
+//
+// Unlike the samplers, the span processor sheds the spans once they have
+// ended: the traces whose spans are shed are incomplete.
+package loadshedding // import "go.opentelemetry.io/contrib/processors/loadshedding"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// spanProcessor passes the ended spans to the next span processor with a
+// token bucket: each span passed takes a token, tokens are added at the rate
+// of the span processor up to its burst.
+type spanProcessor struct {
+	next         sdktrace.SpanProcessor
+	rate         float64
+	burst        float64
+	queueSize    int
+	strategy     Strategy
+	preferErrors bool
+	now          func() time.Time
+
+	mu      sync.Mutex
+	balance float64
+	last    time.Time
+	queue   []sdktrace.ReadOnlySpan
+
+	// signal is notified when a span is queued.
+	signal   chan struct{}
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// compile time assertion that spanProcessor implements the
+// sdktrace.SpanProcessor interface.
+var _ sdktrace.SpanProcessor = (*spanProcessor)(nil)
+
+// NewSpanProcessor returns a span processor passing at most spansPerSecond
+// ended spans per second on average to next, configured with opts. Up to
+// the burst of the span processor, set with WithBurst, spans are passed at
+// once after a period of low traffic. No span is passed if spansPerSecond is
+// not positive.
+func NewSpanProcessor(next sdktrace.SpanProcessor, spansPerSecond float64, opts ...Option) sdktrace.SpanProcessor {
+	p := newSpanProcessor(next, spansPerSecond, newConfig(spansPerSecond, opts...))
+	go p.run()
+	return p
+}
+
+func newSpanProcessor(next sdktrace.SpanProcessor, spansPerSecond float64, c config) *spanProcessor {
+	return &spanProcessor{
+		next:         next,
+		rate:         spansPerSecond,
+		burst:        c.burst,
+		queueSize:    c.queueSize,
+		strategy:     c.strategy,
+		preferErrors: c.preferErrors,
+		now:          c.now,
+		balance:      c.burst,
+		last:         c.now(),
+		signal:       make(chan struct{}, 1),
+		stop:         make(chan struct{}),
+		done:         make(chan struct{}),
+	}
+}
+
+// run passes the queued spans to the next span processor as the rate allows
+// until the span processor is shut down.
+func (p *spanProcessor) run() {
+	defer close(p.done)
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		p.mu.Lock()
+		spans := p.release()
+		wait, queued := p.wait()
+		p.mu.Unlock()
+
+		for _, s := range spans {
+			p.next.OnEnd(s)
+		}
+
+		if !queued {
+			select {
+			case <-p.signal:
+				continue
+			case <-p.stop:
+				return
+			}
+		}
+
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(wait)
+		select {
+		case <-timer.C:
+		case <-p.stop:
+			return
+		}
+	}
+}
+
+// OnStart passes s to the next span processor.
+func (p *spanProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	p.next.OnStart(parent, s)
+}
+
+// OnEnd passes s to the next span processor if the rate limit is not
+// reached, or queues it.
+func (p *spanProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	p.mu.Lock()
+	p.refill()
+	if len(p.queue) == 0 && p.balance >= 1 {
+		p.balance--
+		p.mu.Unlock()
+		p.next.OnEnd(s)
+		return
+	}
+	p.enqueue(s)
+	p.mu.Unlock()
+
+	select {
+	case p.signal <- struct{}{}:
+	default:
+	}
+}
+
+// refill adds the tokens accrued since the last refill. The lock must be
+// held.
+func (p *spanProcessor) refill() {
+	now := p.now()
+	if elapsed := now.Sub(p.last); elapsed > 0 && p.rate > 0 {
+		p.balance += elapsed.Seconds() * p.rate
+		if p.balance > p.burst {
+			p.balance = p.burst
+		}
+	}
+	p.last = now
+}
+
+// enqueue queues s, shedding a span if the queue is full. The lock must be
+// held.
+func (p *spanProcessor) enqueue(s sdktrace.ReadOnlySpan) {
+	if len(p.queue) < p.queueSize {
+		p.queue = append(p.queue, s)
+		return
+	}
+
+	switch p.strategy {
+	case DropOldest:
+		i := 0
+		if p.preferErrors {
+			if i = p.index(0, 1); i < 0 {
+				if !isError(s) {
+					// s is the only span without an error status.
+					return
+				}
+				i = 0
+			}
+		}
+		p.remove(i)
+	default:
+		if !p.preferErrors || !isError(s) {
+			return
+		}
+		i := p.index(len(p.queue)-1, -1)
+		if i < 0 {
+			return
+		}
+		p.remove(i)
+	}
+	p.queue = append(p.queue, s)
+}
+
+// index returns the index of the first queued span without an error status
+// from start, iterating in the direction of step, or -1 if there is none.
+// The lock must be held.
+func (p *spanProcessor) index(start, step int) int {
+	for i := start; i >= 0 && i < len(p.queue); i += step {
+		if !isError(p.queue[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// remove removes the queued span at index i. The lock must be held.
+func (p *spanProcessor) remove(i int) {
+	copy(p.queue[i:], p.queue[i+1:])
+	p.queue[len(p.queue)-1] = nil
+	p.queue = p.queue[:len(p.queue)-1]
+}
+
+// release removes and returns the queued spans the rate allows to pass. The
+// lock must be held.
+func (p *spanProcessor) release() []sdktrace.ReadOnlySpan {
+	p.refill()
+	n := len(p.queue)
+	if available := int(p.balance); available < n {
+		n = available
+	}
+	if n <= 0 {
+		return nil
+	}
+	p.balance -= float64(n)
+	return p.take(n)
+}
+
+// take removes and returns the n oldest queued spans. The lock must be held.
+func (p *spanProcessor) take(n int) []sdktrace.ReadOnlySpan {
+	spans := make([]sdktrace.ReadOnlySpan, n)
+	copy(spans, p.queue)
+	rest := copy(p.queue, p.queue[n:])
+	for i := rest; i < len(p.queue); i++ {
+		p.queue[i] = nil
+	}
+	p.queue = p.queue[:rest]
+	return spans
+}
+
+// wait returns the duration until the next queued span can be passed, and
+// whether a span is queued and will ever be passed. The lock must be held.
+func (p *spanProcessor) wait() (time.Duration, bool) {
+	if len(p.queue) == 0 || p.rate <= 0 {
+		return 0, false
+	}
+	return time.Duration((1 - p.balance) / p.rate * float64(time.Second)), true
+}
+
+// drain removes and returns all the queued spans.
+func (p *spanProcessor) drain() []sdktrace.ReadOnlySpan {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.take(len(p.queue))
+}
+
+// Shutdown passes the queued spans, regardless of the rate limit, to the
+// next span processor and shuts it down.
+func (p *spanProcessor) Shutdown(ctx context.Context) error {
+	p.stopOnce.Do(func() { close(p.stop) })
+	select {
+	case <-p.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	for _, s := range p.drain() {
+		p.next.OnEnd(s)
+	}
+	return p.next.Shutdown(ctx)
+}
+
+// ForceFlush passes the queued spans, regardless of the rate limit, to the
+// next span processor and flushes it.
+func (p *spanProcessor) ForceFlush(ctx context.Context) error {
+	for _, s := range p.drain() {
+		p.next.OnEnd(s)
+	}
+	return p.next.ForceFlush(ctx)
+}
+
+// isError returns whether the status of s is an error.
+func isError(s sdktrace.ReadOnlySpan) bool {
+	return s.Status().Code == codes.Error
+}
@@ -0,0 +1,209 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadshedding
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) Now() time.Time { return c.now }
+
+// newTestSpanProcessor returns a span processor, without its background
+// goroutine, passing the spans to the returned span recorder.
+func newTestSpanProcessor(spansPerSecond float64, opts ...Option) (*spanProcessor, *tracetest.SpanRecorder, *clock) {
+	c := &clock{now: time.Unix(1700000000, 0)}
+	sr := tracetest.NewSpanRecorder()
+	opts = append([]Option{withClock(c.Now)}, opts...)
+	p := newSpanProcessor(sr, spansPerSecond, newConfig(spansPerSecond, opts...))
+	close(p.done)
+	return p, sr, c
+}
+
+func span(name string) sdktrace.ReadOnlySpan {
+	return tracetest.SpanStub{Name: name}.Snapshot()
+}
+
+func errorSpan(name string) sdktrace.ReadOnlySpan {
+	return tracetest.SpanStub{Name: name, Status: sdktrace.Status{Code: codes.Error}}.Snapshot()
+}
+
+func names(spans []sdktrace.ReadOnlySpan) []string {
+	var n []string
+	for _, s := range spans {
+		n = append(n, s.Name())
+	}
+	return n
+}
+
+// release passes the spans released by p to the next span processor.
+func release(p *spanProcessor) {
+	p.mu.Lock()
+	spans := p.release()
+	p.mu.Unlock()
+	for _, s := range spans {
+		p.next.OnEnd(s)
+	}
+}
+
+func TestSpanProcessorRateLimit(t *testing.T) {
+	p, sr, c := newTestSpanProcessor(2)
+
+	for _, name := range []string{"a", "b", "c", "d"} {
+		p.OnEnd(span(name))
+	}
+	assert.Equal(t, []string{"a", "b"}, names(sr.Ended()), "burst passed at once")
+
+	wait, queued := p.wait()
+	assert.True(t, queued)
+	assert.Equal(t, 500*time.Millisecond, wait)
+
+	c.now = c.now.Add(500 * time.Millisecond)
+	release(p)
+	assert.Equal(t, []string{"a", "b", "c"}, names(sr.Ended()))
+
+	// Queued spans are passed before the new ones.
+	c.now = c.now.Add(time.Second)
+	p.OnEnd(span("e"))
+	assert.Equal(t, []string{"a", "b", "c"}, names(sr.Ended()))
+	release(p)
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, names(sr.Ended()))
+
+	_, queued = p.wait()
+	assert.False(t, queued)
+}
+
+func TestSpanProcessorStrategies(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  []Option
+		spans []sdktrace.ReadOnlySpan
+		want  []string
+	}{
+		{
+			name:  "DropNewest",
+			spans: []sdktrace.ReadOnlySpan{span("b"), span("c"), errorSpan("d")},
+			want:  []string{"b", "c"},
+		},
+		{
+			name:  "DropOldest",
+			opts:  []Option{WithStrategy(DropOldest)},
+			spans: []sdktrace.ReadOnlySpan{span("b"), span("c"), span("d")},
+			want:  []string{"c", "d"},
+		},
+		{
+			name:  "DropNewestPreferErrors",
+			opts:  []Option{WithPreferErrors()},
+			spans: []sdktrace.ReadOnlySpan{errorSpan("b"), span("c"), errorSpan("d"), span("e"), errorSpan("f")},
+			want:  []string{"b", "d"},
+		},
+		{
+			name:  "DropOldestPreferErrors",
+			opts:  []Option{WithStrategy(DropOldest), WithPreferErrors()},
+			spans: []sdktrace.ReadOnlySpan{span("b"), errorSpan("c"), span("d"), span("e")},
+			want:  []string{"c", "e"},
+		},
+		{
+			name:  "DropOldestPreferErrorsOnlyErrorsQueued",
+			opts:  []Option{WithStrategy(DropOldest), WithPreferErrors()},
+			spans: []sdktrace.ReadOnlySpan{errorSpan("b"), errorSpan("c"), span("d"), errorSpan("e")},
+			want:  []string{"c", "e"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, sr, c := newTestSpanProcessor(1, append([]Option{WithQueueSize(2)}, tt.opts...)...)
+			p.OnEnd(span("a"))
+			for _, s := range tt.spans {
+				p.OnEnd(s)
+			}
+
+			for i := 0; i < 2; i++ {
+				c.now = c.now.Add(time.Second)
+				release(p)
+			}
+			assert.Equal(t, append([]string{"a"}, tt.want...), names(sr.Ended()))
+		})
+	}
+}
+
+func TestSpanProcessorZeroRate(t *testing.T) {
+	for _, opts := range [][]Option{nil, {WithBurst(10)}} {
+		p, sr, c := newTestSpanProcessor(0, opts...)
+		p.OnEnd(span("a"))
+		p.OnEnd(span("b"))
+		assert.Empty(t, sr.Ended(), "no burst")
+
+		c.now = c.now.Add(time.Hour)
+		release(p)
+		assert.Empty(t, sr.Ended())
+		_, queued := p.wait()
+		assert.False(t, queued)
+	}
+}
+
+func TestSpanProcessorForceFlush(t *testing.T) {
+	p, sr, _ := newTestSpanProcessor(1, WithQueueSize(2))
+	for _, name := range []string{"a", "b", "c"} {
+		p.OnEnd(span(name))
+	}
+	assert.Equal(t, []string{"a"}, names(sr.Ended()))
+
+	require.NoError(t, p.ForceFlush(context.Background()))
+	assert.Equal(t, []string{"a", "b", "c"}, names(sr.Ended()))
+	assert.Empty(t, p.queue)
+}
+
+func TestSpanProcessorReleasesQueuedSpans(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	p := NewSpanProcessor(sr, 100, WithBurst(1))
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(p))
+	tracer := tp.Tracer("test")
+
+	for i := 0; i < 5; i++ {
+		_, s := tracer.Start(context.Background(), "span")
+		s.End()
+	}
+	assert.Eventually(t, func() bool {
+		return len(sr.Ended()) == 5
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, p.Shutdown(context.Background()))
+	require.NoError(t, p.Shutdown(context.Background()))
+}
+
+func TestSpanProcessorShutdown(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	p := NewSpanProcessor(sr, 0, WithQueueSize(2))
+	for _, name := range []string{"a", "b"} {
+		p.OnEnd(span(name))
+	}
+
+	require.NoError(t, p.Shutdown(context.Background()))
+	assert.Equal(t, []string{"a", "b"}, names(sr.Ended()), "queued spans passed")
+}
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redaction // import "go.opentelemetry.io/contrib/processors/redaction"
+
+import "regexp"
+
+// DefaultMask is the value replacing the redacted values by default.
+const DefaultMask = "****"
+
+var (
+	// CreditCardNumbers matches sequences of 13 to 19 digits, optionally
+	// grouped with spaces or dashes, e.g. "4111 1111 1111 1111".
+	CreditCardNumbers = regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`)
+
+	// EmailAddresses matches email addresses, e.g. "jane@example.com".
+	EmailAddresses = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+)
+
+type config struct {
+	keys    []string
+	values  []*regexp.Regexp
+	mask    string
+	hash    bool
+	hashKey []byte
+}
+
+// Option applies configuration settings to a span processor.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (fn optionFunc) apply(c *config) {
+	fn(c)
+}
+
+// newConfig returns a config with opts applied.
+func newConfig(opts ...Option) config {
+	c := config{mask: DefaultMask}
+	for _, opt := range opts {
+		opt.apply(&c)
+	}
+	return c
+}
+
+// WithKeys adds the patterns of the keys of the attributes whose values are
+// redacted entirely, e.g. WithKeys("*password*", "http.request.header.authorization").
+//
+// The patterns use the syntax of path.Match and are matched against the
+// keys case-insensitively. Invalid patterns are reported to the global error
+// handler and ignored.
+func WithKeys(patterns ...string) Option {
+	patterns = append([]string(nil), patterns...)
+	return optionFunc(func(c *config) {
+		c.keys = append(c.keys, patterns...)
+	})
+}
+
+// WithValues adds the regular expressions matching the sensitive parts of
+// the string values of the attributes, e.g. WithValues(CreditCardNumbers,
+// EmailAddresses). Only the matching parts of the values are redacted.
+func WithValues(res ...*regexp.Regexp) Option {
+	res = append([]*regexp.Regexp(nil), res...)
+	return optionFunc(func(c *config) {
+		c.values = append(c.values, res...)
+	})
+}
+
+// WithMask sets the value replacing the redacted values. DefaultMask is used
+// by default.
+func WithMask(mask string) Option {
+	return optionFunc(func(c *config) {
+		c.mask = mask
+		c.hash = false
+	})
+}
+
+// WithHash replaces the redacted values by their hex-encoded HMAC-SHA256
+// keyed with key, or by their SHA-256 if key is empty, instead of a mask.
+// Hashes allow correlating telemetry by the redacted values without
+// disclosing them; a secret key prevents recovering values with few possible
+// ones, e.g. email addresses, by brute force.
+func WithHash(key []byte) Option {
+	key = append([]byte(nil), key...)
+	return optionFunc(func(c *config) {
+		c.hash = true
+		c.hashKey = key
+	})
+}
@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package redaction provides a span processor redacting sensitive
+// attributes, e.g. credentials, credit card numbers or email addresses,
+// before the spans are exported.
+//
+// The values of the attributes whose keys match the patterns passed with
+// WithKeys are redacted entirely, while only the parts of the string values
+// matching the regular expressions passed with WithValues are redacted. The
+// redacted values are replaced with a mask, or with their hash when using
+// WithHash. The attributes of the spans, of their events and of their links
+// are redacted.
+//
+// The span processor wraps the span processor exporting the spans, e.g. a
+// batch span processor:
+//
+//	bsp := trace.NewBatchSpanProcessor(exporter)
+//	tp := trace.NewTracerProvider(trace.WithSpanProcessor(
+//		redaction.NewSpanProcessor(bsp,
+//			redaction.WithKeys("*password*", "*token*"),
+//			redaction.WithValues(redaction.CreditCardNumbers, redaction.EmailAddresses),
+//		),
+//	))
+//
+// The log records are not redacted by this package. The values that must not
+// leave the process, like the ones matched by CreditCardNumbers, have to be
+// kept out of the log records where they are emitted.
+package redaction // import "go.opentelemetry.io/contrib/processors/redaction"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// spanProcessor redacts the attributes of the ended spans before passing
+// them to the next span processor.
+type spanProcessor struct {
+	next     sdktrace.SpanProcessor
+	redactor *redactor
+}
+
+// compile time assertion that spanProcessor implements the
+// sdktrace.SpanProcessor interface.
+var _ sdktrace.SpanProcessor = (*spanProcessor)(nil)
+
+// NewSpanProcessor returns a span processor redacting the attributes of the
+// ended spans, configured with opts, before passing them to next.
+//
+// The spans passed to the OnStart method of next are not redacted: next must
+// not export them.
+func NewSpanProcessor(next sdktrace.SpanProcessor, opts ...Option) sdktrace.SpanProcessor {
+	return &spanProcessor{next: next, redactor: newRedactor(newConfig(opts...))}
+}
+
+// OnStart passes s to the next span processor.
+func (p *spanProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	p.next.OnStart(parent, s)
+}
+
+// OnEnd passes s, with its sensitive attributes redacted, to the next span
+// processor.
+func (p *spanProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	p.next.OnEnd(p.redact(s))
+}
+
+// Shutdown shuts the next span processor down.
+func (p *spanProcessor) Shutdown(ctx context.Context) error {
+	return p.next.Shutdown(ctx)
+}
+
+// ForceFlush flushes the next span processor.
+func (p *spanProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
+
+// redact returns s with its sensitive attributes redacted. s is returned
+// unchanged if none of its attributes is sensitive.
+func (p *spanProcessor) redact(s sdktrace.ReadOnlySpan) sdktrace.ReadOnlySpan {
+	attrs, attrsRedacted := p.redactor.attributes(s.Attributes())
+	events, eventsRedacted := p.events(s.Events())
+	links, linksRedacted := p.links(s.Links())
+	if !attrsRedacted && !eventsRedacted && !linksRedacted {
+		return s
+	}
+	return redactedSpan{ReadOnlySpan: s, attrs: attrs, events: events, links: links}
+}
+
+// events returns events with their sensitive attributes redacted, and
+// whether any attribute was redacted. events is not modified.
+func (p *spanProcessor) events(events []sdktrace.Event) ([]sdktrace.Event, bool) {
+	var redacted []sdktrace.Event
+	for i, e := range events {
+		attrs, ok := p.redactor.attributes(e.Attributes)
+		if !ok {
+			continue
+		}
+		if redacted == nil {
+			redacted = append([]sdktrace.Event(nil), events...)
+		}
+		redacted[i].Attributes = attrs
+	}
+	if redacted == nil {
+		return events, false
+	}
+	return redacted, true
+}
+
+// links returns links with their sensitive attributes redacted, and whether
+// any attribute was redacted. links is not modified.
+func (p *spanProcessor) links(links []sdktrace.Link) ([]sdktrace.Link, bool) {
+	var redacted []sdktrace.Link
+	for i, l := range links {
+		attrs, ok := p.redactor.attributes(l.Attributes)
+		if !ok {
+			continue
+		}
+		if redacted == nil {
+			redacted = append([]sdktrace.Link(nil), links...)
+		}
+		redacted[i].Attributes = attrs
+	}
+	if redacted == nil {
+		return links, false
+	}
+	return redacted, true
+}
+
+// redactedSpan is an ended span whose sensitive attributes are redacted.
+type redactedSpan struct {
+	sdktrace.ReadOnlySpan
+
+	attrs  []attribute.KeyValue
+	events []sdktrace.Event
+	links  []sdktrace.Link
+}
+
+// Attributes returns the redacted attributes of the span.
+func (s redactedSpan) Attributes() []attribute.KeyValue { return s.attrs }
+
+// Events returns the events of the span with their attributes redacted.
+func (s redactedSpan) Events() []sdktrace.Event { return s.events }
+
+// Links returns the links of the span with their attributes redacted.
+func (s redactedSpan) Links() []sdktrace.Link { return s.links }
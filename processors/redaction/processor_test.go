@@ -0,0 +1,196 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redaction
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// record returns the span recorded by the span processor with opts after
+// calling fn with the span.
+func record(t *testing.T, fn func(trace.Span), opts ...Option) sdktrace.ReadOnlySpan {
+	t.Helper()
+
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(NewSpanProcessor(sr, opts...)))
+	_, span := tp.Tracer("test").Start(context.Background(), "span")
+	fn(span)
+	span.End()
+
+	ended := sr.Ended()
+	require.Len(t, ended, 1)
+	return ended[0]
+}
+
+func TestRedactKeys(t *testing.T) {
+	s := record(t, func(span trace.Span) {
+		span.SetAttributes(
+			attribute.String("user.password", "hunter2"),
+			attribute.String("http.request.header.Authorization", "Bearer secret"),
+			attribute.Int("api.token.id", 42),
+			attribute.String("user.name", "jane"),
+		)
+	}, WithKeys("*password*", "http.request.header.authorization", "*token*"))
+
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("user.password", DefaultMask),
+		attribute.String("http.request.header.Authorization", DefaultMask),
+		attribute.String("api.token.id", DefaultMask),
+		attribute.String("user.name", "jane"),
+	}, s.Attributes())
+}
+
+func TestRedactValues(t *testing.T) {
+	s := record(t, func(span trace.Span) {
+		span.SetAttributes(
+			attribute.String("payment", "card 4111 1111 1111 1111 declined"),
+			attribute.StringSlice("recipients", []string{"jane@example.com", "ops"}),
+			attribute.String("order.id", "1234"),
+			attribute.Int64("amount", 4111111111111111),
+		)
+	}, WithValues(CreditCardNumbers, EmailAddresses), WithMask("[REDACTED]"))
+
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("payment", "card [REDACTED] declined"),
+		attribute.StringSlice("recipients", []string{"[REDACTED]", "ops"}),
+		attribute.String("order.id", "1234"),
+		attribute.Int64("amount", 4111111111111111),
+	}, s.Attributes())
+}
+
+func TestRedactHash(t *testing.T) {
+	sum := sha256.Sum256([]byte("jane@example.com"))
+	mac := hmac.New(sha256.New, []byte("secret"))
+	_, _ = mac.Write([]byte("hunter2"))
+
+	tests := []struct {
+		name string
+		opts []Option
+		want []attribute.KeyValue
+	}{
+		{
+			name: "SHA-256",
+			opts: []Option{WithValues(EmailAddresses), WithHash(nil)},
+			want: []attribute.KeyValue{
+				attribute.String("user.email", "email "+hex.EncodeToString(sum[:])),
+				attribute.String("user.password", "hunter2"),
+			},
+		},
+		{
+			name: "HMAC-SHA256",
+			opts: []Option{WithKeys("*password"), WithHash([]byte("secret"))},
+			want: []attribute.KeyValue{
+				attribute.String("user.email", "email jane@example.com"),
+				attribute.String("user.password", hex.EncodeToString(mac.Sum(nil))),
+			},
+		},
+		{
+			name: "Mask",
+			opts: []Option{WithKeys("*password"), WithHash(nil), WithMask("x")},
+			want: []attribute.KeyValue{
+				attribute.String("user.email", "email jane@example.com"),
+				attribute.String("user.password", "x"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := record(t, func(span trace.Span) {
+				span.SetAttributes(
+					attribute.String("user.email", "email jane@example.com"),
+					attribute.String("user.password", "hunter2"),
+				)
+			}, tt.opts...)
+			assert.Equal(t, tt.want, s.Attributes())
+		})
+	}
+}
+
+func TestRedactEventsAndLinks(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(
+		NewSpanProcessor(sr, WithValues(EmailAddresses)),
+	))
+	tracer := tp.Tracer("test")
+
+	_, linked := tracer.Start(context.Background(), "linked")
+	linked.End()
+	_, span := tracer.Start(context.Background(), "span", trace.WithLinks(trace.Link{
+		SpanContext: linked.SpanContext(),
+		Attributes:  []attribute.KeyValue{attribute.String("from", "jane@example.com")},
+	}))
+	span.AddEvent("sent", trace.WithAttributes(attribute.String("to", "john@example.com")))
+	span.AddEvent("ack", trace.WithAttributes(attribute.Int("status", 200)))
+	span.End()
+
+	ended := sr.Ended()
+	require.Len(t, ended, 2)
+	s := ended[1]
+
+	assert.Empty(t, s.Attributes())
+	events := s.Events()
+	require.Len(t, events, 2)
+	assert.Equal(t, "sent", events[0].Name)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("to", DefaultMask)}, events[0].Attributes)
+	assert.Equal(t, []attribute.KeyValue{attribute.Int("status", 200)}, events[1].Attributes)
+	links := s.Links()
+	require.Len(t, links, 1)
+	assert.Equal(t, linked.SpanContext(), links[0].SpanContext)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("from", DefaultMask)}, links[0].Attributes)
+}
+
+func TestRedactUnchanged(t *testing.T) {
+	p := NewSpanProcessor(tracetest.NewSpanRecorder(), WithKeys("*password*"), WithValues(EmailAddresses)).(*spanProcessor)
+
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	_, span := tp.Tracer("test").Start(context.Background(), "span")
+	span.SetAttributes(attribute.String("user.name", "jane"))
+	span.AddEvent("event")
+	span.End()
+
+	s := sr.Ended()[0]
+	assert.Same(t, s, p.redact(s))
+}
+
+func TestInvalidKeyPattern(t *testing.T) {
+	var errs []error
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) { errs = append(errs, err) }))
+	t.Cleanup(func() { otel.SetErrorHandler(otel.ErrorHandlerFunc(func(error) {})) })
+
+	s := record(t, func(span trace.Span) {
+		span.SetAttributes(attribute.String("password", "hunter2"), attribute.String("[", "value"))
+	}, WithKeys("[", "password"))
+
+	assert.Len(t, errs, 1)
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("password", DefaultMask),
+		attribute.String("[", "value"),
+	}, s.Attributes())
+}
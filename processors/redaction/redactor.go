@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redaction // import "go.opentelemetry.io/contrib/processors/redaction"
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"path"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// redactor redacts attributes.
+type redactor struct {
+	keys    []string
+	values  []*regexp.Regexp
+	mask    string
+	hash    bool
+	hashKey []byte
+}
+
+func newRedactor(c config) *redactor {
+	r := &redactor{
+		values:  c.values,
+		mask:    c.mask,
+		hash:    c.hash,
+		hashKey: c.hashKey,
+	}
+	for _, k := range c.keys {
+		k = strings.ToLower(k)
+		if _, err := path.Match(k, ""); err != nil {
+			otel.Handle(fmt.Errorf("redaction: invalid key pattern %q: %w", k, err))
+			continue
+		}
+		r.keys = append(r.keys, k)
+	}
+	return r
+}
+
+// attributes returns attrs with their sensitive values redacted, and whether
+// any value was redacted. attrs is not modified.
+func (r *redactor) attributes(attrs []attribute.KeyValue) ([]attribute.KeyValue, bool) {
+	var redacted []attribute.KeyValue
+	for i, kv := range attrs {
+		v, ok := r.value(kv)
+		if !ok {
+			if redacted != nil {
+				redacted = append(redacted, kv)
+			}
+			continue
+		}
+		if redacted == nil {
+			redacted = make([]attribute.KeyValue, i, len(attrs))
+			copy(redacted, attrs[:i])
+		}
+		redacted = append(redacted, attribute.KeyValue{Key: kv.Key, Value: v})
+	}
+	if redacted == nil {
+		return attrs, false
+	}
+	return redacted, true
+}
+
+// value returns the redacted value of kv, and whether it was redacted.
+func (r *redactor) value(kv attribute.KeyValue) (attribute.Value, bool) {
+	if r.matchKey(string(kv.Key)) {
+		return attribute.StringValue(r.replace(kv.Value.Emit())), true
+	}
+	if len(r.values) == 0 {
+		return kv.Value, false
+	}
+
+	switch kv.Value.Type() {
+	case attribute.STRING:
+		if s, ok := r.redactString(kv.Value.AsString()); ok {
+			return attribute.StringValue(s), true
+		}
+	case attribute.STRINGSLICE:
+		// AsStringSlice returns a copy which can be modified.
+		ss := kv.Value.AsStringSlice()
+		var redacted bool
+		for i, s := range ss {
+			if rs, ok := r.redactString(s); ok {
+				ss[i], redacted = rs, true
+			}
+		}
+		if redacted {
+			return attribute.StringSliceValue(ss), true
+		}
+	}
+	return kv.Value, false
+}
+
+// matchKey returns whether the value of the attribute with key is redacted
+// entirely.
+func (r *redactor) matchKey(key string) bool {
+	if len(r.keys) == 0 {
+		return false
+	}
+	key = strings.ToLower(key)
+	for _, pattern := range r.keys {
+		if ok, _ := path.Match(pattern, key); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// redactString returns s with the parts matching the value regular
+// expressions redacted, and whether any part was redacted.
+func (r *redactor) redactString(s string) (string, bool) {
+	var redacted bool
+	for _, re := range r.values {
+		if re.MatchString(s) {
+			s, redacted = re.ReplaceAllStringFunc(s, r.replace), true
+		}
+	}
+	return s, redacted
+}
+
+// replace returns the value replacing the sensitive value s.
+func (r *redactor) replace(s string) string {
+	if !r.hash {
+		return r.mask
+	}
+
+	var h hash.Hash
+	if len(r.hashKey) > 0 {
+		h = hmac.New(sha256.New, r.hashKey)
+	} else {
+		h = sha256.New()
+	}
+	_, _ = h.Write([]byte(s))
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanmetrics // import "go.opentelemetry.io/contrib/processors/spanmetrics"
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+type config struct {
+	meterProvider metric.MeterProvider
+	dimensions    []attribute.Key
+}
+
+// Option applies configuration settings to a span processor.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (fn optionFunc) apply(c *config) {
+	fn(c)
+}
+
+// newConfig returns a config with opts applied.
+func newConfig(opts ...Option) config {
+	c := config{meterProvider: otel.GetMeterProvider()}
+	for _, opt := range opts {
+		opt.apply(&c)
+	}
+	return c
+}
+
+// WithMeterProvider sets the meter provider recording the metrics. The
+// global meter provider is used by default.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return optionFunc(func(c *config) {
+		if mp != nil {
+			c.meterProvider = mp
+		}
+	})
+}
+
+// WithDimensions adds the keys of the span attributes recorded as
+// attributes of the metrics, in addition to the name, kind and status code
+// of the spans, e.g. WithDimensions(semconv.HTTPRouteKey). The attributes
+// missing from a span are not recorded.
+//
+// The attributes should have a low cardinality: each distinct combination
+// of their values is a new time series.
+func WithDimensions(keys ...attribute.Key) Option {
+	keys = append([]attribute.Key(nil), keys...)
+	return optionFunc(func(c *config) {
+		c.dimensions = append(c.dimensions, keys...)
+	})
+}
@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanmetrics_test
+
+import (
+	"go.opentelemetry.io/contrib/processors/spanmetrics"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+func ExampleNewSpanProcessor() {
+	mp := sdkmetric.NewMeterProvider()
+	exporter := tracetest.NewInMemoryExporter()
+
+	tp := sdktrace.NewTracerProvider(
+		// Record the metrics of all the spans while only exporting 1% of
+		// them.
+		sdktrace.WithSampler(spanmetrics.RecordingSampler(
+			sdktrace.ParentBased(sdktrace.TraceIDRatioBased(0.01)),
+		)),
+		sdktrace.WithSpanProcessor(spanmetrics.NewSpanProcessor(
+			spanmetrics.WithMeterProvider(mp),
+			spanmetrics.WithDimensions(semconv.HTTPRouteKey),
+		)),
+		sdktrace.WithBatcher(exporter),
+	)
+	_ = tp
+}
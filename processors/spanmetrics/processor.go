@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package spanmetrics provides a span processor recording the request
+// rate, error rate and duration, or RED, metrics of the ended spans.
+//
+// The metrics are recorded for each span name, span kind and status code:
+//
+//   - traces.span.metrics.calls counts the ended spans.
+//   - traces.span.metrics.duration is the histogram of the span durations,
+//     in seconds.
+//
+// The span processor only receives the recorded spans. For the metrics to
+// account for the spans dropped by the sampler, wrap the sampler with
+// RecordingSampler: the spans it does not sample are recorded, for the span
+// processor to measure them, but not exported.
+//
+//	tp := trace.NewTracerProvider(
+//		trace.WithSampler(spanmetrics.RecordingSampler(trace.TraceIDRatioBased(0.01))),
+//		trace.WithSpanProcessor(spanmetrics.NewSpanProcessor()),
+//		trace.WithBatcher(exporter),
+//	)
+package spanmetrics // import "go.opentelemetry.io/contrib/processors/spanmetrics"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	// ScopeName is the instrumentation scope name of the meter recording
+	// the metrics.
+	ScopeName = "go.opentelemetry.io/contrib/processors/spanmetrics"
+
+	// SpanNameKey is the attribute key of the span name.
+	SpanNameKey = attribute.Key("span.name")
+	// SpanKindKey is the attribute key of the span kind, e.g. "server".
+	SpanKindKey = attribute.Key("span.kind")
+	// StatusCodeKey is the attribute key of the span status code, e.g.
+	// "Error".
+	StatusCodeKey = attribute.Key("status.code")
+)
+
+// spanProcessor records the metrics of the ended spans.
+type spanProcessor struct {
+	dimensions []attribute.Key
+	calls      metric.Int64Counter
+	duration   metric.Float64Histogram
+}
+
+// compile time assertion that spanProcessor implements the
+// sdktrace.SpanProcessor interface.
+var _ sdktrace.SpanProcessor = (*spanProcessor)(nil)
+
+// NewSpanProcessor returns a span processor recording the metrics of the
+// ended spans, configured with opts.
+func NewSpanProcessor(opts ...Option) sdktrace.SpanProcessor {
+	c := newConfig(opts...)
+	meter := c.meterProvider.Meter(ScopeName, metric.WithInstrumentationVersion(Version()))
+
+	p := &spanProcessor{dimensions: c.dimensions}
+	var err error
+	if p.calls, err = meter.Int64Counter(
+		"traces.span.metrics.calls",
+		metric.WithUnit("{call}"),
+		metric.WithDescription("Number of ended spans"),
+	); err != nil {
+		otel.Handle(err)
+	}
+	if p.duration, err = meter.Float64Histogram(
+		"traces.span.metrics.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("Duration of the ended spans"),
+	); err != nil {
+		otel.Handle(err)
+	}
+	return p
+}
+
+// OnStart does nothing.
+func (p *spanProcessor) OnStart(context.Context, sdktrace.ReadWriteSpan) {}
+
+// OnEnd records the metrics of s.
+func (p *spanProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	attrs := make([]attribute.KeyValue, 0, 3+len(p.dimensions))
+	attrs = append(attrs,
+		SpanNameKey.String(s.Name()),
+		SpanKindKey.String(s.SpanKind().String()),
+		StatusCodeKey.String(s.Status().Code.String()),
+	)
+	if len(p.dimensions) > 0 {
+		for _, kv := range s.Attributes() {
+			for _, k := range p.dimensions {
+				if kv.Key == k {
+					attrs = append(attrs, kv)
+					break
+				}
+			}
+		}
+	}
+	opt := metric.WithAttributes(attrs...)
+
+	ctx := context.Background()
+	if p.calls != nil {
+		p.calls.Add(ctx, 1, opt)
+	}
+	if p.duration != nil {
+		p.duration.Record(ctx, s.EndTime().Sub(s.StartTime()).Seconds(), opt)
+	}
+}
+
+// Shutdown does nothing: the metrics are exported by the meter provider.
+func (p *spanProcessor) Shutdown(context.Context) error { return nil }
+
+// ForceFlush does nothing: the metrics are exported by the meter provider.
+func (p *spanProcessor) ForceFlush(context.Context) error { return nil }
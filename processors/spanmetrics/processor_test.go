@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanmetrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestSpanProcessor(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(
+		NewSpanProcessor(WithMeterProvider(mp), WithDimensions("http.route")),
+	))
+	tracer := tp.Tracer("test")
+
+	start := time.Unix(1700000000, 0)
+	for i, d := range []time.Duration{time.Second, 3 * time.Second} {
+		_, span := tracer.Start(context.Background(), "GET /users/{id}",
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithTimestamp(start),
+			trace.WithAttributes(
+				attribute.String("http.route", "/users/{id}"),
+				attribute.String("http.target", "/users/42"),
+			),
+		)
+		if i == 1 {
+			span.SetStatus(codes.Error, "boom")
+		}
+		span.End(trace.WithTimestamp(start.Add(d)))
+	}
+	_, span := tracer.Start(context.Background(), "query", trace.WithTimestamp(start))
+	span.End(trace.WithTimestamp(start.Add(time.Second / 2)))
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	sm := rm.ScopeMetrics[0]
+	assert.Equal(t, ScopeName, sm.Scope.Name)
+	assert.Equal(t, Version(), sm.Scope.Version)
+	require.Len(t, sm.Metrics, 2)
+
+	ok := attribute.NewSet(
+		SpanNameKey.String("GET /users/{id}"),
+		SpanKindKey.String("server"),
+		StatusCodeKey.String("Unset"),
+		attribute.String("http.route", "/users/{id}"),
+	)
+	failed := attribute.NewSet(
+		SpanNameKey.String("GET /users/{id}"),
+		SpanKindKey.String("server"),
+		StatusCodeKey.String("Error"),
+		attribute.String("http.route", "/users/{id}"),
+	)
+	query := attribute.NewSet(
+		SpanNameKey.String("query"),
+		SpanKindKey.String("internal"),
+		StatusCodeKey.String("Unset"),
+	)
+
+	metricdatatest.AssertEqual(t, metricdata.Metrics{
+		Name:        "traces.span.metrics.calls",
+		Description: "Number of ended spans",
+		Unit:        "{call}",
+		Data: metricdata.Sum[int64]{
+			Temporality: metricdata.CumulativeTemporality,
+			IsMonotonic: true,
+			DataPoints: []metricdata.DataPoint[int64]{
+				{Attributes: ok, Value: 1},
+				{Attributes: failed, Value: 1},
+				{Attributes: query, Value: 1},
+			},
+		},
+	}, sm.Metrics[0], metricdatatest.IgnoreTimestamp())
+
+	duration, isHistogram := sm.Metrics[1].Data.(metricdata.Histogram[float64])
+	require.True(t, isHistogram)
+	assert.Equal(t, "traces.span.metrics.duration", sm.Metrics[1].Name)
+	assert.Equal(t, "s", sm.Metrics[1].Unit)
+	sums := map[attribute.Distinct]float64{}
+	for _, dp := range duration.DataPoints {
+		sums[dp.Attributes.Equivalent()] = dp.Sum
+	}
+	assert.Equal(t, map[attribute.Distinct]float64{
+		ok.Equivalent():     1,
+		failed.Equivalent(): 3,
+		query.Equivalent():  0.5,
+	}, sums)
+}
+
+func TestRecordingSampler(t *testing.T) {
+	s := RecordingSampler(sdktrace.NeverSample())
+	assert.Equal(t, "RecordingSampler{AlwaysOffSampler}", s.Description())
+
+	res := s.ShouldSample(sdktrace.SamplingParameters{})
+	assert.Equal(t, sdktrace.RecordOnly, res.Decision)
+
+	res = RecordingSampler(sdktrace.AlwaysSample()).ShouldSample(sdktrace.SamplingParameters{})
+	assert.Equal(t, sdktrace.RecordAndSample, res.Decision)
+}
+
+func TestRecordingSamplerSpans(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(RecordingSampler(sdktrace.NeverSample())),
+		sdktrace.WithSpanProcessor(NewSpanProcessor(WithMeterProvider(mp))),
+	)
+
+	_, span := tp.Tracer("test").Start(context.Background(), "span")
+	assert.False(t, span.SpanContext().IsSampled())
+	span.End()
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	calls := rm.ScopeMetrics[0].Metrics[0].Data.(metricdata.Sum[int64])
+	require.Len(t, calls.DataPoints, 1)
+	assert.Equal(t, int64(1), calls.DataPoints[0].Value)
+}
@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanmetrics // import "go.opentelemetry.io/contrib/processors/spanmetrics"
+
+import (
+	"fmt"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// recordingSampler records the spans dropped by its delegate.
+type recordingSampler struct {
+	delegate sdktrace.Sampler
+}
+
+// compile time assertion that recordingSampler implements the
+// sdktrace.Sampler interface.
+var _ sdktrace.Sampler = recordingSampler{}
+
+// RecordingSampler returns a sampler recording, without sampling, the spans
+// dropped by delegate, for the span processor to measure them. The sampled
+// spans are unchanged.
+//
+// The spans recorded but not sampled are not exported by the span
+// processors of the SDK, but their overhead is the one of the sampled
+// spans until they end.
+func RecordingSampler(delegate sdktrace.Sampler) sdktrace.Sampler {
+	return recordingSampler{delegate: delegate}
+}
+
+// ShouldSample returns the decision of the delegate, turning Drop into
+// RecordOnly.
+func (s recordingSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	res := s.delegate.ShouldSample(p)
+	if res.Decision == sdktrace.Drop {
+		res.Decision = sdktrace.RecordOnly
+	}
+	return res
+}
+
+// Description returns the description of the sampler.
+func (s recordingSampler) Description() string {
+	return fmt.Sprintf("RecordingSampler{%s}", s.delegate.Description())
+}
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsampling // import "go.opentelemetry.io/contrib/processors/tailsampling"
+
+import "time"
+
+const (
+	// defaultDecisionWait is the default maximum duration a trace is
+	// buffered for.
+	defaultDecisionWait = 10 * time.Second
+	// defaultMaxTraces is the default maximum number of buffered traces.
+	defaultMaxTraces = 10000
+)
+
+type config struct {
+	policies     []Policy
+	decisionWait time.Duration
+	maxTraces    int
+	clock        func() time.Time
+}
+
+// Option applies configuration settings to a span processor.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (fn optionFunc) apply(c *config) {
+	fn(c)
+}
+
+// newConfig returns a config with opts applied.
+func newConfig(opts ...Option) config {
+	c := config{
+		decisionWait: defaultDecisionWait,
+		maxTraces:    defaultMaxTraces,
+		clock:        time.Now,
+	}
+	for _, opt := range opts {
+		opt.apply(&c)
+	}
+	return c
+}
+
+// WithPolicies adds the policies deciding whether the traces are kept. A
+// trace is kept if any of the policies, evaluated in order, samples it.
+func WithPolicies(policies ...Policy) Option {
+	policies = append([]Policy(nil), policies...)
+	return optionFunc(func(c *config) {
+		c.policies = append(c.policies, policies...)
+	})
+}
+
+// WithDecisionWait sets the maximum duration the spans of a trace are
+// buffered for, from the start of its first span, before the policies are
+// applied to the trace. The default is 10 seconds. Non-positive durations
+// are ignored.
+//
+// The policies are applied to a trace before the wait is over once its
+// local root span, and all the spans of the trace started meanwhile, have
+// ended.
+func WithDecisionWait(d time.Duration) Option {
+	return optionFunc(func(c *config) {
+		if d > 0 {
+			c.decisionWait = d
+		}
+	})
+}
+
+// WithMaxTraces sets the maximum number of traces buffered. The policies are
+// applied early to the oldest trace when a new trace would exceed it. The
+// default is 10000. Non-positive values are ignored.
+func WithMaxTraces(n int) Option {
+	return optionFunc(func(c *config) {
+		if n > 0 {
+			c.maxTraces = n
+		}
+	})
+}
+
+// withClock sets the clock of the span processor, for testing.
+func withClock(clock func() time.Time) Option {
+	return optionFunc(func(c *config) {
+		c.clock = clock
+	})
+}
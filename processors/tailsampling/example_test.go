@@ -0,0 +1,43 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsampling_test
+
+import (
+	"time"
+
+	"go.opentelemetry.io/contrib/processors/tailsampling"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func ExampleNewSpanProcessor() {
+	exporter := tracetest.NewInMemoryExporter()
+	bsp := sdktrace.NewBatchSpanProcessor(exporter)
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+		sdktrace.WithSpanProcessor(tailsampling.NewSpanProcessor(bsp,
+			// Keep all the failed and slow traces, and up to 10 other
+			// traces per second.
+			tailsampling.WithPolicies(
+				tailsampling.ErrorPolicy(),
+				tailsampling.LatencyPolicy(time.Second),
+				tailsampling.RateLimitingPolicy(10),
+			),
+			tailsampling.WithDecisionWait(30*time.Second),
+		)),
+	)
+	_ = tp
+}
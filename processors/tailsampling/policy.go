@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsampling // import "go.opentelemetry.io/contrib/processors/tailsampling"
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Policy decides whether a trace is kept.
+type Policy interface {
+	// ShouldSample returns whether the trace made of spans is kept. The
+	// spans are the ones of the trace ended in the process, in the order
+	// they ended.
+	ShouldSample(spans []sdktrace.ReadOnlySpan) bool
+}
+
+// PolicyFunc is a function implementing the Policy interface.
+type PolicyFunc func(spans []sdktrace.ReadOnlySpan) bool
+
+// ShouldSample returns fn(spans).
+func (fn PolicyFunc) ShouldSample(spans []sdktrace.ReadOnlySpan) bool {
+	return fn(spans)
+}
+
+// ErrorPolicy returns a policy keeping the traces with a span whose status
+// is an error.
+func ErrorPolicy() Policy {
+	return PolicyFunc(func(spans []sdktrace.ReadOnlySpan) bool {
+		for _, s := range spans {
+			if s.Status().Code == codes.Error {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// LatencyPolicy returns a policy keeping the traces lasting at least
+// threshold, from the start of their earliest span to the end of their
+// latest span.
+func LatencyPolicy(threshold time.Duration) Policy {
+	return PolicyFunc(func(spans []sdktrace.ReadOnlySpan) bool {
+		if len(spans) == 0 {
+			return false
+		}
+		start, end := spans[0].StartTime(), spans[0].EndTime()
+		for _, s := range spans[1:] {
+			if s.StartTime().Before(start) {
+				start = s.StartTime()
+			}
+			if s.EndTime().After(end) {
+				end = s.EndTime()
+			}
+		}
+		return end.Sub(start) >= threshold
+	})
+}
+
+// RateLimitingPolicy returns a policy keeping at most tracesPerSecond traces
+// per second, allowing bursts of up to max(tracesPerSecond, 1) traces.
+//
+// Once combined with other policies, only the traces not kept by the
+// policies preceding it are counted.
+func RateLimitingPolicy(tracesPerSecond float64) Policy {
+	return newRateLimitingPolicy(tracesPerSecond, time.Now)
+}
+
+// rateLimitingPolicy is a token bucket whose tokens are the traces kept.
+type rateLimitingPolicy struct {
+	rate  float64
+	burst float64
+	now   func() time.Time
+
+	mu      sync.Mutex
+	balance float64
+	last    time.Time
+}
+
+func newRateLimitingPolicy(tracesPerSecond float64, now func() time.Time) *rateLimitingPolicy {
+	if tracesPerSecond < 0 || math.IsNaN(tracesPerSecond) {
+		tracesPerSecond = 0
+	}
+	burst := math.Max(tracesPerSecond, 1)
+	if tracesPerSecond == 0 {
+		burst = 0
+	}
+	return &rateLimitingPolicy{
+		rate:    tracesPerSecond,
+		burst:   burst,
+		now:     now,
+		balance: burst,
+		last:    now(),
+	}
+}
+
+// ShouldSample returns whether a token is available, regardless of spans.
+func (p *rateLimitingPolicy) ShouldSample([]sdktrace.ReadOnlySpan) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	if elapsed := now.Sub(p.last).Seconds(); elapsed > 0 {
+		p.balance = math.Min(p.balance+elapsed*p.rate, p.burst)
+	}
+	p.last = now
+
+	if p.balance < 1 {
+		return false
+	}
+	p.balance--
+	return true
+}
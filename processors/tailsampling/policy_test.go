@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsampling
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestErrorPolicy(t *testing.T) {
+	ok := tracetest.SpanStub{Name: "ok"}.Snapshot()
+	failed := tracetest.SpanStub{Name: "failed", Status: sdktrace.Status{Code: codes.Error}}.Snapshot()
+
+	p := ErrorPolicy()
+	assert.False(t, p.ShouldSample(nil))
+	assert.False(t, p.ShouldSample([]sdktrace.ReadOnlySpan{ok}))
+	assert.True(t, p.ShouldSample([]sdktrace.ReadOnlySpan{ok, failed}))
+}
+
+func TestLatencyPolicy(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	span := func(startOffset, endOffset time.Duration) sdktrace.ReadOnlySpan {
+		return tracetest.SpanStub{
+			StartTime: start.Add(startOffset),
+			EndTime:   start.Add(endOffset),
+		}.Snapshot()
+	}
+
+	p := LatencyPolicy(time.Second)
+	assert.False(t, p.ShouldSample(nil))
+	assert.False(t, p.ShouldSample([]sdktrace.ReadOnlySpan{span(0, 999*time.Millisecond)}))
+	assert.True(t, p.ShouldSample([]sdktrace.ReadOnlySpan{span(0, time.Second)}))
+	assert.True(t, p.ShouldSample([]sdktrace.ReadOnlySpan{
+		span(500*time.Millisecond, 1200*time.Millisecond),
+		span(0, 600*time.Millisecond),
+	}), "duration from the earliest start to the latest end")
+}
+
+func TestRateLimitingPolicy(t *testing.T) {
+	c := &clock{now: time.Unix(1700000000, 0)}
+	p := newRateLimitingPolicy(2, c.Now)
+
+	assert.True(t, p.ShouldSample(nil))
+	assert.True(t, p.ShouldSample(nil))
+	assert.False(t, p.ShouldSample(nil), "burst exhausted")
+
+	c.now = c.now.Add(500 * time.Millisecond)
+	assert.True(t, p.ShouldSample(nil))
+	assert.False(t, p.ShouldSample(nil))
+
+	c.now = c.now.Add(time.Hour)
+	assert.True(t, p.ShouldSample(nil))
+	assert.True(t, p.ShouldSample(nil))
+	assert.False(t, p.ShouldSample(nil), "balance capped to the burst")
+}
+
+func TestRateLimitingPolicyFractional(t *testing.T) {
+	c := &clock{now: time.Unix(1700000000, 0)}
+	p := newRateLimitingPolicy(0.5, c.Now)
+
+	assert.True(t, p.ShouldSample(nil))
+	assert.False(t, p.ShouldSample(nil))
+	c.now = c.now.Add(time.Second)
+	assert.False(t, p.ShouldSample(nil))
+	c.now = c.now.Add(time.Second)
+	assert.True(t, p.ShouldSample(nil))
+}
+
+func TestRateLimitingPolicyZero(t *testing.T) {
+	c := &clock{now: time.Unix(1700000000, 0)}
+	for _, rate := range []float64{0, -1} {
+		p := newRateLimitingPolicy(rate, c.Now)
+		assert.False(t, p.ShouldSample(nil))
+		c.now = c.now.Add(time.Hour)
+		assert.False(t, p.ShouldSample(nil))
+	}
+}
@@ -0,0 +1,345 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tailsampling provides a span processor sampling the traces once
+// their spans have ended, e.g. to keep all the failing or slow requests,
+// rather than when they start as the samplers do.
+//
+// The span processor buffers the ended spans of each trace until the local
+// root span of the trace, and all the spans of the trace started meanwhile,
+// have ended, or until the decision wait set with WithDecisionWait is over.
+// The policies passed with WithPolicies, e.g. ErrorPolicy, LatencyPolicy or
+// RateLimitingPolicy, are then applied to the buffered spans: the spans of
+// the traces kept by any of the policies are passed to the wrapped span
+// processor, and the spans of the other traces are dropped. The spans of a
+// trace ending after its decision follow the decision.
+//
+// The spans must be sampled by the tracer provider, e.g. with the
+// AlwaysSample sampler, for the span processor to receive them:
+//
+//	bsp := trace.NewBatchSpanProcessor(exporter)
+//	tp := trace.NewTracerProvider(
+//		trace.WithSampler(trace.AlwaysSample()),
+//		trace.WithSpanProcessor(tailsampling.NewSpanProcessor(bsp,
+//			tailsampling.WithPolicies(
+//				tailsampling.ErrorPolicy(),
+//				tailsampling.LatencyPolicy(time.Second),
+//				tailsampling.RateLimitingPolicy(10),
+//			),
+//		)),
+//	)
+//
+// Only the spans ended in the process are considered: the spans of a trace
+// ended in other processes are sampled by their own decision.
+package tailsampling // import "go.opentelemetry.io/contrib/processors/tailsampling"
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// pendingTrace is a trace whose spans are buffered.
+type pendingTrace struct {
+	id       trace.TraceID
+	spans    []sdktrace.ReadOnlySpan
+	deadline time.Time
+	// running is the number of spans of the trace started and not ended.
+	running   int
+	rootEnded bool
+	elem      *list.Element
+	// decision is recorded when the trace is removed from the buffer.
+	decision *decision
+}
+
+// complete returns whether all the spans of the trace have ended.
+func (t *pendingTrace) complete() bool {
+	return t.rootEnded && t.running <= 0
+}
+
+// decision is the sampling decision of a trace removed from the buffer.
+type decision struct {
+	// decided is false while the policies are applied to the trace.
+	decided bool
+	sampled bool
+	// late are the spans of the trace ended while the policies are
+	// applied, passed along with the buffered ones if the trace is sampled.
+	late []sdktrace.ReadOnlySpan
+}
+
+// spanProcessor buffers the spans of each trace and passes the spans of the
+// traces sampled by its policies to the next span processor.
+type spanProcessor struct {
+	next         sdktrace.SpanProcessor
+	policies     []Policy
+	decisionWait time.Duration
+	maxTraces    int
+	clock        func() time.Time
+
+	mu     sync.Mutex
+	traces map[trace.TraceID]*pendingTrace
+	// pending lists the traces in the order of their deadlines.
+	pending *list.List
+	// decisions are the decisions of the latest traces removed from the
+	// buffer, evicted in the order of decisionsOrder.
+	decisions      map[trace.TraceID]*decision
+	decisionsOrder []trace.TraceID
+	decisionsNext  int
+
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// compile time assertion that spanProcessor implements the
+// sdktrace.SpanProcessor interface.
+var _ sdktrace.SpanProcessor = (*spanProcessor)(nil)
+
+// NewSpanProcessor returns a span processor sampling the traces, configured
+// with opts, and passing the spans of the sampled traces to next.
+//
+// The traces are dropped if no policy is passed with WithPolicies.
+func NewSpanProcessor(next sdktrace.SpanProcessor, opts ...Option) sdktrace.SpanProcessor {
+	c := newConfig(opts...)
+	p := newSpanProcessor(next, c)
+	go p.run(tickInterval(c.decisionWait))
+	return p
+}
+
+func newSpanProcessor(next sdktrace.SpanProcessor, c config) *spanProcessor {
+	return &spanProcessor{
+		next:           next,
+		policies:       c.policies,
+		decisionWait:   c.decisionWait,
+		maxTraces:      c.maxTraces,
+		clock:          c.clock,
+		traces:         make(map[trace.TraceID]*pendingTrace),
+		pending:        list.New(),
+		decisions:      make(map[trace.TraceID]*decision, c.maxTraces),
+		decisionsOrder: make([]trace.TraceID, c.maxTraces),
+		stop:           make(chan struct{}),
+		done:           make(chan struct{}),
+	}
+}
+
+// tickInterval returns the interval the expired traces are decided at.
+func tickInterval(decisionWait time.Duration) time.Duration {
+	const minInterval = 10 * time.Millisecond
+	if interval := decisionWait / 10; interval > minInterval {
+		return interval
+	}
+	return minInterval
+}
+
+// run decides the expired traces every interval until the span processor is
+// shut down.
+func (p *spanProcessor) run(interval time.Duration) {
+	defer close(p.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			p.decide(p.expired()...)
+		case <-p.stop:
+			return
+		}
+	}
+}
+
+// OnStart buffers the trace of s, if it is not decided yet, and passes s to
+// the next span processor.
+func (p *spanProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	id := s.SpanContext().TraceID()
+
+	p.mu.Lock()
+	var evicted *pendingTrace
+	if _, removed := p.decisions[id]; !removed {
+		var t *pendingTrace
+		t, evicted = p.trace(id)
+		t.running++
+	}
+	p.mu.Unlock()
+
+	p.decide(evicted)
+	p.next.OnStart(parent, s)
+}
+
+// OnEnd buffers s until its trace is decided, or passes it to the next span
+// processor if its trace is already sampled.
+func (p *spanProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	id := s.SpanContext().TraceID()
+
+	p.mu.Lock()
+	if d, removed := p.decisions[id]; removed {
+		if !d.decided {
+			d.late = append(d.late, s)
+			p.mu.Unlock()
+			return
+		}
+		p.mu.Unlock()
+		if d.sampled {
+			p.next.OnEnd(s)
+		}
+		return
+	}
+
+	t, evicted := p.trace(id)
+	t.spans = append(t.spans, s)
+	t.running--
+	if parent := s.Parent(); !parent.IsValid() || parent.IsRemote() {
+		t.rootEnded = true
+	}
+	var completed *pendingTrace
+	if t.complete() {
+		p.remove(t)
+		completed = t
+	}
+	p.mu.Unlock()
+
+	p.decide(evicted)
+	p.decide(completed)
+}
+
+// trace returns the buffered trace with id, buffering it if needed. The
+// oldest trace is evicted and returned if the buffer is full. The lock must
+// be held.
+func (p *spanProcessor) trace(id trace.TraceID) (t, evicted *pendingTrace) {
+	if t, ok := p.traces[id]; ok {
+		return t, nil
+	}
+
+	if len(p.traces) >= p.maxTraces {
+		evicted = p.pending.Front().Value.(*pendingTrace)
+		p.remove(evicted)
+	}
+	t = &pendingTrace{id: id, deadline: p.clock().Add(p.decisionWait)}
+	t.elem = p.pending.PushBack(t)
+	p.traces[id] = t
+	return t, evicted
+}
+
+// remove removes t from the buffer and records its pending decision,
+// evicting the oldest recorded decision if needed. The removed trace must be
+// passed to decide. The lock must be held.
+func (p *spanProcessor) remove(t *pendingTrace) {
+	p.pending.Remove(t.elem)
+	delete(p.traces, t.id)
+
+	if len(p.decisions) >= len(p.decisionsOrder) {
+		delete(p.decisions, p.decisionsOrder[p.decisionsNext])
+	}
+	p.decisionsOrder[p.decisionsNext] = t.id
+	p.decisionsNext = (p.decisionsNext + 1) % len(p.decisionsOrder)
+	t.decision = &decision{}
+	p.decisions[t.id] = t.decision
+}
+
+// expired removes and returns the buffered traces whose deadlines are over.
+func (p *spanProcessor) expired() []*pendingTrace {
+	now := p.clock()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var expired []*pendingTrace
+	for e := p.pending.Front(); e != nil; e = p.pending.Front() {
+		t := e.Value.(*pendingTrace)
+		if t.deadline.After(now) {
+			break
+		}
+		p.remove(t)
+		expired = append(expired, t)
+	}
+	return expired
+}
+
+// flush removes and returns all the buffered traces.
+func (p *spanProcessor) flush() []*pendingTrace {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	traces := make([]*pendingTrace, 0, p.pending.Len())
+	for e := p.pending.Front(); e != nil; e = p.pending.Front() {
+		t := e.Value.(*pendingTrace)
+		p.remove(t)
+		traces = append(traces, t)
+	}
+	return traces
+}
+
+// decide applies the policies to the traces removed from the buffer, and
+// passes the spans of the sampled ones to the next span processor.
+func (p *spanProcessor) decide(traces ...*pendingTrace) {
+	for _, t := range traces {
+		if t == nil {
+			continue
+		}
+
+		sampled := p.shouldSample(t.spans)
+
+		p.mu.Lock()
+		t.decision.decided = true
+		t.decision.sampled = sampled
+		late := t.decision.late
+		t.decision.late = nil
+		p.mu.Unlock()
+
+		if !sampled {
+			continue
+		}
+		for _, s := range t.spans {
+			p.next.OnEnd(s)
+		}
+		for _, s := range late {
+			p.next.OnEnd(s)
+		}
+	}
+}
+
+// shouldSample returns whether any policy samples the trace made of spans.
+func (p *spanProcessor) shouldSample(spans []sdktrace.ReadOnlySpan) bool {
+	for _, policy := range p.policies {
+		if policy.ShouldSample(spans) {
+			return true
+		}
+	}
+	return false
+}
+
+// Shutdown decides all the buffered traces and shuts the next span
+// processor down.
+func (p *spanProcessor) Shutdown(ctx context.Context) error {
+	p.stopOnce.Do(func() { close(p.stop) })
+	select {
+	case <-p.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	p.decide(p.flush()...)
+	return p.next.Shutdown(ctx)
+}
+
+// ForceFlush decides all the buffered traces, even if they are not
+// complete, and flushes the next span processor.
+func (p *spanProcessor) ForceFlush(ctx context.Context) error {
+	p.decide(p.flush()...)
+	return p.next.ForceFlush(ctx)
+}
@@ -0,0 +1,236 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsampling
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) Now() time.Time { return c.now }
+
+// newTestSpanProcessor returns a span processor, without its background
+// goroutine, passing the spans to the returned span recorder.
+func newTestSpanProcessor(opts ...Option) (*spanProcessor, *tracetest.SpanRecorder, *clock) {
+	c := &clock{now: time.Unix(1700000000, 0)}
+	sr := tracetest.NewSpanRecorder()
+	p := newSpanProcessor(sr, newConfig(append([]Option{withClock(c.Now)}, opts...)...))
+	close(p.done)
+	return p, sr, c
+}
+
+func newTracer(p sdktrace.SpanProcessor) trace.Tracer {
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(p))
+	return tp.Tracer("test")
+}
+
+func names(spans []sdktrace.ReadOnlySpan) []string {
+	var n []string
+	for _, s := range spans {
+		n = append(n, s.Name())
+	}
+	return n
+}
+
+func TestSpanProcessorCompleteTraces(t *testing.T) {
+	p, sr, _ := newTestSpanProcessor(WithPolicies(ErrorPolicy()))
+	tracer := newTracer(p)
+
+	ctx, root := tracer.Start(context.Background(), "ok")
+	_, child := tracer.Start(ctx, "ok.child")
+	child.End()
+	root.End()
+
+	ctx, root = tracer.Start(context.Background(), "failed")
+	_, child = tracer.Start(ctx, "failed.child")
+	child.SetStatus(codes.Error, "boom")
+	child.End()
+	assert.Empty(t, sr.Ended(), "spans buffered while the root span is running")
+	root.End()
+
+	assert.Equal(t, []string{"failed.child", "failed"}, names(sr.Ended()))
+	assert.Zero(t, p.pending.Len())
+}
+
+func TestSpanProcessorWaitsForRunningSpans(t *testing.T) {
+	p, sr, _ := newTestSpanProcessor(WithPolicies(ErrorPolicy()))
+	tracer := newTracer(p)
+
+	ctx, root := tracer.Start(context.Background(), "root")
+	_, async := tracer.Start(ctx, "async")
+	root.End()
+	assert.Empty(t, sr.Ended())
+
+	async.SetStatus(codes.Error, "boom")
+	async.End()
+	assert.Equal(t, []string{"root", "async"}, names(sr.Ended()))
+}
+
+func TestSpanProcessorDecisionWait(t *testing.T) {
+	p, sr, c := newTestSpanProcessor(
+		WithPolicies(PolicyFunc(func([]sdktrace.ReadOnlySpan) bool { return true })),
+		WithDecisionWait(time.Minute),
+	)
+	tracer := newTracer(p)
+
+	ctx, root := tracer.Start(context.Background(), "root")
+	_, child := tracer.Start(ctx, "child")
+	child.End()
+
+	c.now = c.now.Add(59 * time.Second)
+	p.decide(p.expired()...)
+	assert.Empty(t, sr.Ended())
+
+	c.now = c.now.Add(time.Second)
+	p.decide(p.expired()...)
+	assert.Equal(t, []string{"child"}, names(sr.Ended()))
+
+	// Spans ended after the decision follow it.
+	root.End()
+	assert.Equal(t, []string{"child", "root"}, names(sr.Ended()))
+	assert.Zero(t, p.pending.Len())
+}
+
+func TestSpanProcessorSpansEndedWhileDeciding(t *testing.T) {
+	var root trace.Span
+	p, sr, c := newTestSpanProcessor(
+		WithPolicies(PolicyFunc(func([]sdktrace.ReadOnlySpan) bool {
+			// The trace is no longer buffered but not decided yet.
+			root.End()
+			return true
+		})),
+		WithDecisionWait(time.Minute),
+	)
+	tracer := newTracer(p)
+
+	var ctx context.Context
+	ctx, root = tracer.Start(context.Background(), "root")
+	_, child := tracer.Start(ctx, "child")
+	child.End()
+
+	c.now = c.now.Add(time.Minute)
+	p.decide(p.expired()...)
+	assert.Equal(t, []string{"child", "root"}, names(sr.Ended()))
+	assert.Zero(t, p.pending.Len())
+}
+
+func TestSpanProcessorLateSpansDropped(t *testing.T) {
+	p, sr, c := newTestSpanProcessor(WithPolicies(ErrorPolicy()), WithDecisionWait(time.Minute))
+	tracer := newTracer(p)
+
+	ctx, root := tracer.Start(context.Background(), "root")
+	root.End()
+	_, late := tracer.Start(ctx, "late")
+	late.SetStatus(codes.Error, "boom")
+	late.End()
+	c.now = c.now.Add(time.Hour)
+	p.decide(p.expired()...)
+
+	assert.Empty(t, sr.Ended())
+	assert.Zero(t, p.pending.Len())
+}
+
+func TestSpanProcessorMaxTraces(t *testing.T) {
+	p, sr, _ := newTestSpanProcessor(
+		WithPolicies(PolicyFunc(func([]sdktrace.ReadOnlySpan) bool { return true })),
+		WithMaxTraces(2),
+	)
+	tracer := newTracer(p)
+
+	var spans []trace.Span
+	for _, name := range []string{"a", "b", "c"} {
+		ctx, root := tracer.Start(context.Background(), name)
+		_, child := tracer.Start(ctx, name+".child")
+		child.End()
+		spans = append(spans, root)
+	}
+	assert.Equal(t, []string{"a.child"}, names(sr.Ended()), "oldest trace evicted")
+	assert.Equal(t, 2, p.pending.Len())
+
+	for _, s := range spans {
+		s.End()
+	}
+	assert.Equal(t, []string{"a.child", "a", "b.child", "b", "c.child", "c"}, names(sr.Ended()))
+}
+
+func TestSpanProcessorNoPolicies(t *testing.T) {
+	p, sr, _ := newTestSpanProcessor()
+	_, span := newTracer(p).Start(context.Background(), "span")
+	span.SetStatus(codes.Error, "boom")
+	span.End()
+
+	assert.Empty(t, sr.Ended())
+}
+
+func TestSpanProcessorForceFlush(t *testing.T) {
+	p, sr, _ := newTestSpanProcessor(WithPolicies(ErrorPolicy()))
+	ctx, root := newTracer(p).Start(context.Background(), "root")
+	_, child := newTracer(p).Start(ctx, "child")
+	child.SetStatus(codes.Error, "boom")
+	child.End()
+
+	require.NoError(t, p.ForceFlush(context.Background()))
+	assert.Equal(t, []string{"child"}, names(sr.Ended()))
+	assert.Zero(t, p.pending.Len())
+	root.End()
+}
+
+func TestSpanProcessorShutdown(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	p := NewSpanProcessor(sr, WithPolicies(ErrorPolicy()))
+	ctx, root := newTracer(p).Start(context.Background(), "root")
+	_, child := newTracer(p).Start(ctx, "child")
+	child.SetStatus(codes.Error, "boom")
+	child.End()
+
+	require.NoError(t, p.Shutdown(context.Background()))
+	assert.Equal(t, []string{"child"}, names(sr.Ended()))
+	require.NoError(t, p.Shutdown(context.Background()))
+	root.End()
+}
+
+func TestSpanProcessorExpiresTraces(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	p := NewSpanProcessor(
+		sdktrace.NewSimpleSpanProcessor(exporter),
+		WithPolicies(ErrorPolicy()),
+		WithDecisionWait(time.Millisecond),
+	)
+	t.Cleanup(func() { _ = p.Shutdown(context.Background()) })
+
+	ctx, root := newTracer(p).Start(context.Background(), "root")
+	_, child := newTracer(p).Start(ctx, "child")
+	child.SetStatus(codes.Error, "boom")
+	child.End()
+
+	assert.Eventually(t, func() bool {
+		return len(exporter.GetSpans()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	root.End()
+	assert.Len(t, exporter.GetSpans(), 2)
+}
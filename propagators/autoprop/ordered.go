@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autoprop // import "go.opentelemetry.io/contrib/propagators/autoprop"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ExtractPolicy defines how the span context extracted by a propagator of an
+// ordered composite TextMapPropagator is handled when a previous propagator
+// of the composite already extracted one.
+type ExtractPolicy int
+
+const (
+	// Overwrite replaces the span context extracted by previous propagators.
+	// This is the behavior of the propagators composited with
+	// NewCompositeTextMapPropagator from the
+	// go.opentelemetry.io/otel/propagation package.
+	Overwrite ExtractPolicy = iota
+	// Preserve keeps the span context extracted by previous propagators.
+	// The other values the propagator extracts, e.g. Baggage, are kept.
+	Preserve
+)
+
+// Member is a TextMapPropagator of an ordered composite TextMapPropagator
+// with the ExtractPolicy applied to the span context it extracts.
+type Member struct {
+	Propagator propagation.TextMapPropagator
+	Policy     ExtractPolicy
+}
+
+type ordered []Member
+
+var _ propagation.TextMapPropagator = ordered{}
+
+// NewOrderedTextMapPropagator returns a TextMapPropagator composited by
+// members.
+//
+// Inject and Extract call the propagators of members in order. When a
+// propagator extracts a valid span context while a previous propagator
+// already did, its Policy determines which one is used. For example, when the
+// same traffic carries B3, X-Ray and W3C Trace Context headers,
+//
+//	NewOrderedTextMapPropagator(
+//		Member{Propagator: propagation.TraceContext{}},
+//		Member{Propagator: xray.Propagator{}, Policy: Preserve},
+//		Member{Propagator: b3.New(), Policy: Preserve},
+//	)
+//
+// uses the W3C Trace Context headers if valid, then the X-Ray and B3 headers
+// in that order of precedence.
+func NewOrderedTextMapPropagator(members ...Member) propagation.TextMapPropagator {
+	return ordered(members)
+}
+
+// Inject calls Inject of all the propagators in order.
+func (o ordered) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	for _, m := range o {
+		m.Propagator.Inject(ctx, carrier)
+	}
+}
+
+// Extract calls Extract of all the propagators in order, applying their
+// Policy to the span context they extract.
+func (o ordered) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	var extracted trace.SpanContext
+	for _, m := range o {
+		before := trace.SpanContextFromContext(ctx)
+		ctx = m.Propagator.Extract(ctx, carrier)
+		sc := trace.SpanContextFromContext(ctx)
+		if !sc.IsValid() || sc.Equal(before) {
+			// Nothing extracted.
+			continue
+		}
+		if extracted.IsValid() && m.Policy == Preserve {
+			ctx = trace.ContextWithRemoteSpanContext(ctx, extracted)
+			continue
+		}
+		extracted = sc
+	}
+	return ctx
+}
+
+// Fields returns the union of the fields of all the propagators.
+func (o ordered) Fields() []string {
+	unique := make(map[string]struct{})
+	var fields []string
+	for _, m := range o {
+		for _, f := range m.Propagator.Fields() {
+			if _, ok := unique[f]; ok {
+				continue
+			}
+			unique[f] = struct{}{}
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
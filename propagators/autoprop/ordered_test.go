@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autoprop
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/contrib/propagators/b3"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	b3Header    = "80f198ee56343ba864fe8b2a57d3eff7-e457b5a2e4d86bd1-1"
+)
+
+var (
+	traceContextID = trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36}
+	b3ID           = trace.TraceID{0x80, 0xf1, 0x98, 0xee, 0x56, 0x34, 0x3b, 0xa8, 0x64, 0xfe, 0x8b, 0x2a, 0x57, 0xd3, 0xef, 0xf7}
+)
+
+func TestOrderedExtract(t *testing.T) {
+	carrier := propagation.MapCarrier{
+		"traceparent": traceparent,
+		"b3":          b3Header,
+		"baggage":     "key=value",
+	}
+
+	tests := []struct {
+		name    string
+		members []Member
+		want    trace.TraceID
+	}{
+		{
+			name: "overwrite",
+			members: []Member{
+				{Propagator: propagation.TraceContext{}},
+				{Propagator: b3.New()},
+			},
+			want: b3ID,
+		},
+		{
+			name: "preserve",
+			members: []Member{
+				{Propagator: propagation.TraceContext{}},
+				{Propagator: b3.New(), Policy: Preserve},
+			},
+			want: traceContextID,
+		},
+		{
+			name: "preserve first",
+			members: []Member{
+				{Propagator: b3.New(), Policy: Preserve},
+				{Propagator: propagation.TraceContext{}, Policy: Preserve},
+			},
+			want: b3ID,
+		},
+		{
+			name: "preserve with nothing extracted",
+			members: []Member{
+				{Propagator: propagation.TraceContext{}},
+				{Propagator: propagation.Baggage{}, Policy: Preserve},
+				{Propagator: b3.New(), Policy: Preserve},
+			},
+			want: traceContextID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			members := append(tt.members, Member{Propagator: propagation.Baggage{}, Policy: Preserve})
+			p := NewOrderedTextMapPropagator(members...)
+
+			// The span context of ctx is not considered extracted.
+			local := trace.NewSpanContext(trace.SpanContextConfig{TraceID: trace.TraceID{1}, SpanID: trace.SpanID{1}})
+			ctx := trace.ContextWithSpanContext(context.Background(), local)
+
+			ctx = p.Extract(ctx, carrier)
+			sc := trace.SpanContextFromContext(ctx)
+			assert.Equal(t, tt.want, sc.TraceID())
+			assert.True(t, sc.IsRemote())
+			assert.Equal(t, "value", baggage.FromContext(ctx).Member("key").Value())
+		})
+	}
+}
+
+func TestOrderedInject(t *testing.T) {
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceContextID,
+		SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+
+	p := NewOrderedTextMapPropagator(
+		Member{Propagator: propagation.TraceContext{}},
+		Member{Propagator: b3.New(), Policy: Preserve},
+	)
+	carrier := propagation.MapCarrier{}
+	p.Inject(ctx, carrier)
+	assert.Equal(t, traceparent, carrier.Get("traceparent"))
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-1", carrier.Get("b3"))
+}
+
+func TestOrderedFields(t *testing.T) {
+	p := NewOrderedTextMapPropagator(
+		Member{Propagator: propagation.TraceContext{}},
+		Member{Propagator: propagation.TraceContext{}, Policy: Preserve},
+		Member{Propagator: propagation.Baggage{}},
+	)
+	assert.Equal(t, []string{"traceparent", "tracestate", "baggage"}, p.Fields())
+}
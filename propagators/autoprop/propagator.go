@@ -41,7 +41,10 @@ const otelPropagatorsEnvKey = "OTEL_PROPAGATORS"
 //
 // The supported environment variable propagators can be extended to include
 // custom 3rd-party TextMapPropagator. See the RegisterTextMapPropagator
-// function for more information.
+// function for more information. Propagator variants are selected with
+// arguments following their name, e.g. "b3:multi" or "jaeger:64bit", see the
+// TextMapPropagator and RegisterTextMapPropagatorFactory functions for more
+// information.
 //
 // If OTEL_PROPAGATORS is not defined and props is no provided, the returned
 // TextMapPropagator will be a composite of the TraceContext and Baggage
@@ -66,3 +66,9 @@ func TestNewTextMapPropagatorMultiEnvNone(t *testing.T) {
 	t.Setenv(otelPropagatorsEnvKey, "b3,none,tracecontext")
 	assert.Equal(t, noop, NewTextMapPropagator())
 }
+
+func TestNewTextMapPropagatorEnvArgs(t *testing.T) {
+	t.Setenv(otelPropagatorsEnvKey, "tracecontext,b3:multi")
+	expect := []string{"traceparent", "tracestate", "x-b3-traceid", "x-b3-spanid", "x-b3-sampled", "x-b3-flags"}
+	assert.ElementsMatch(t, expect, NewTextMapPropagator().Fields())
+}
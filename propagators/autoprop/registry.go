@@ -53,14 +53,68 @@ var propagators = &registry{
 		// No-op TextMapPropagator.
 		none: propagation.NewCompositeTextMapPropagator(),
 	},
+	factories: map[string]TextMapPropagatorFactory{
+		// B3 with the injected encodings, e.g. "b3:single:multi".
+		"b3": b3Factory,
+		// Jaeger, e.g. "jaeger:64bit".
+		"jaeger": jaegerFactory,
+		// AWS X-Ray, e.g. "xray:tracestate".
+		"xray": xrayFactory,
+	},
+}
+
+// errUnsupportedArg is returned by the default TextMapPropagatorFactory when
+// an argument they do not support is passed.
+var errUnsupportedArg = errors.New("unsupported propagator argument")
+
+func b3Factory(args []string) (propagation.TextMapPropagator, error) {
+	var encoding b3.Encoding
+	for _, arg := range args {
+		switch arg {
+		case "single":
+			encoding |= b3.B3SingleHeader
+		case "multi":
+			encoding |= b3.B3MultipleHeader
+		default:
+			return nil, fmt.Errorf("%w: %q", errUnsupportedArg, arg)
+		}
+	}
+	return b3.New(b3.WithInjectEncoding(encoding)), nil
+}
+
+func jaegerFactory(args []string) (propagation.TextMapPropagator, error) {
+	var opts []jaeger.Option
+	for _, arg := range args {
+		switch arg {
+		case "64bit":
+			opts = append(opts, jaeger.WithTraceID64Bit())
+		default:
+			return nil, fmt.Errorf("%w: %q", errUnsupportedArg, arg)
+		}
+	}
+	return jaeger.New(opts...), nil
+}
+
+func xrayFactory(args []string) (propagation.TextMapPropagator, error) {
+	var opts []xray.Option
+	for _, arg := range args {
+		switch arg {
+		case "tracestate":
+			opts = append(opts, xray.WithTraceState())
+		default:
+			return nil, fmt.Errorf("%w: %q", errUnsupportedArg, arg)
+		}
+	}
+	return xray.NewPropagator(opts...), nil
 }
 
 // registry maintains a map of propagator names to TextMapPropagator
 // implementations that is safe for concurrent use by multiple goroutines
 // without additional locking or coordination.
 type registry struct {
-	mu    sync.Mutex
-	names map[string]propagation.TextMapPropagator
+	mu        sync.Mutex
+	names     map[string]propagation.TextMapPropagator
+	factories map[string]TextMapPropagatorFactory
 }
 
 // load returns the value stored in the registry index for a key, or nil if no
@@ -73,6 +127,16 @@ func (r *registry) load(key string) (p propagation.TextMapPropagator, ok bool) {
 	return p, ok
 }
 
+// loadFactory returns the factory stored in the registry index for a key, or
+// nil if no factory is present. The ok result indicates whether factory was
+// found in the index.
+func (r *registry) loadFactory(key string) (f TextMapPropagatorFactory, ok bool) {
+	r.mu.Lock()
+	f, ok = r.factories[key]
+	r.mu.Unlock()
+	return f, ok
+}
+
 var errDupReg = errors.New("duplicate registration")
 
 // store sets the value for a key if is not already in the registry. errDupReg
@@ -80,14 +144,41 @@ var errDupReg = errors.New("duplicate registration")
 func (r *registry) store(key string, value propagation.TextMapPropagator) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	if err := r.checkDup(key); err != nil {
+		return err
+	}
 	if r.names == nil {
 		r.names = map[string]propagation.TextMapPropagator{key: value}
 		return nil
 	}
-	if _, ok := r.names[key]; ok {
+	r.names[key] = value
+	return nil
+}
+
+// storeFactory sets the factory for a key if is not already in the registry.
+// errDupReg is returned if the registry already contains key.
+func (r *registry) storeFactory(key string, f TextMapPropagatorFactory) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.checkDup(key); err != nil {
+		return err
+	}
+	if r.factories == nil {
+		r.factories = map[string]TextMapPropagatorFactory{key: f}
+		return nil
+	}
+	r.factories[key] = f
+	return nil
+}
+
+// checkDup returns errDupReg if key is already registered as a propagator or
+// a factory. The registry lock must be held.
+func (r *registry) checkDup(key string) error {
+	_, okName := r.names[key]
+	_, okFactory := r.factories[key]
+	if okName || okFactory {
 		return fmt.Errorf("%w: %q", errDupReg, key)
 	}
-	r.names[key] = value
 	return nil
 }
 
@@ -95,6 +186,7 @@ func (r *registry) store(key string, value propagation.TextMapPropagator) error
 func (r *registry) drop(key string) {
 	r.mu.Lock()
 	delete(r.names, key)
+	delete(r.factories, key)
 	r.mu.Unlock()
 }
 
@@ -117,22 +209,49 @@ func RegisterTextMapPropagator(name string, p propagation.TextMapPropagator) {
 	}
 }
 
+// TextMapPropagatorFactory returns a TextMapPropagator configured with args.
+// An error is returned if an argument is not supported.
+type TextMapPropagatorFactory func(args []string) (propagation.TextMapPropagator, error)
+
+// RegisterTextMapPropagatorFactory sets the TextMapPropagatorFactory f to be
+// used when the OTEL_PROPAGATORS environment variable contains the
+// propagator name, optionally followed by arguments separated by colons,
+// e.g. "name:arg1:arg2". The factory is called with the arguments, nil if
+// there are none. This will panic if name has already been registered or is
+// a default (tracecontext, baggage, b3, b3multi, jaeger, xray, or ottrace).
+func RegisterTextMapPropagatorFactory(name string, f TextMapPropagatorFactory) {
+	if err := propagators.storeFactory(name, f); err != nil {
+		// Panic for the same reasons as RegisterTextMapPropagator.
+		panic(err)
+	}
+}
+
 // TextMapPropagator returns a TextMapPropagator composed from the
 // passed names of registered TextMapPropagators. Each name must match an
 // already registered TextMapPropagator (see the RegisterTextMapPropagator
 // function for more information) or a default (tracecontext, baggage, b3,
 // b3multi, jaeger, xray, or ottrace).
 //
+// Names may be followed by arguments separated by colons, e.g. "b3:multi",
+// if they are registered with a TextMapPropagatorFactory (see the
+// RegisterTextMapPropagatorFactory function for more information). The
+// default factories support the following arguments:
+//
+//   - b3: "single" and "multi", the B3 encodings injected.
+//   - jaeger: "64bit", to inject 64-bit trace IDs.
+//   - xray: "tracestate", to propagate the tracestate header.
+//
 // If "none" is included in the arguments, or no names are provided, the
 // returned TextMapPropagator will be a no-operation implementation.
 //
-// An error is returned for any un-registered names. The remaining, known,
-// names will be used to compose a TextMapPropagator that is returned with the
-// error.
+// An error is returned for any un-registered names or unsupported arguments.
+// The remaining, known, names will be used to compose a TextMapPropagator
+// that is returned with the error.
 func TextMapPropagator(names ...string) (propagation.TextMapPropagator, error) {
 	var (
 		props   []propagation.TextMapPropagator
 		unknown []string
+		errs    []error
 	)
 
 	for _, name := range names {
@@ -143,7 +262,11 @@ func TextMapPropagator(names ...string) (propagation.TextMapPropagator, error) {
 			return propagation.NewCompositeTextMapPropagator(), nil
 		}
 
-		p, ok := propagators.load(name)
+		p, ok, err := load(name)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
 		if !ok {
 			unknown = append(unknown, name)
 			continue
@@ -151,11 +274,11 @@ func TextMapPropagator(names ...string) (propagation.TextMapPropagator, error) {
 		props = append(props, p)
 	}
 
-	var err error
 	if len(unknown) > 0 {
 		joined := strings.Join(unknown, ",")
-		err = fmt.Errorf("%w: %s", errUnknownPropagator, joined)
+		errs = append([]error{fmt.Errorf("%w: %s", errUnknownPropagator, joined)}, errs...)
 	}
+	err := errors.Join(errs...)
 
 	switch len(props) {
 	case 0:
@@ -167,3 +290,30 @@ func TextMapPropagator(names ...string) (propagation.TextMapPropagator, error) {
 		return propagation.NewCompositeTextMapPropagator(props...), err
 	}
 }
+
+// load returns the TextMapPropagator registered for name, or built by the
+// factory registered for it if name contains arguments or no propagator is
+// registered for it. The ok result indicates whether name is registered.
+func load(name string) (p propagation.TextMapPropagator, ok bool, err error) {
+	key, rawArgs, hasArgs := strings.Cut(name, ":")
+	if !hasArgs {
+		if p, ok = propagators.load(key); ok {
+			return p, true, nil
+		}
+	}
+
+	f, ok := propagators.loadFactory(key)
+	if !ok {
+		return nil, false, nil
+	}
+
+	var args []string
+	if hasArgs {
+		args = strings.Split(rawArgs, ":")
+	}
+	p, err = f(args)
+	if err != nil {
+		return nil, true, fmt.Errorf("%s: %w", name, err)
+	}
+	return p, true, nil
+}
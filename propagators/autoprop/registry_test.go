@@ -22,6 +22,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/contrib/propagators/aws/xray"
+	"go.opentelemetry.io/contrib/propagators/b3"
+	"go.opentelemetry.io/contrib/propagators/jaeger"
 	"go.opentelemetry.io/otel/propagation"
 )
 
@@ -94,3 +97,71 @@ func TestDuplicateRegisterTextMapPropagatorPanics(t *testing.T) {
 		RegisterTextMapPropagator(propName, noop)
 	})
 }
+
+func TestRegisterTextMapPropagatorFactory(t *testing.T) {
+	const propName = "custom"
+	var got []string
+	RegisterTextMapPropagatorFactory(propName, func(args []string) (propagation.TextMapPropagator, error) {
+		got = args
+		return noop, nil
+	})
+	t.Cleanup(func() { propagators.drop(propName) })
+
+	p, err := TextMapPropagator(propName)
+	require.NoError(t, err)
+	assert.Equal(t, noop, p)
+	assert.Nil(t, got)
+
+	p, err = TextMapPropagator(propName + ":a:b")
+	require.NoError(t, err)
+	assert.Equal(t, noop, p)
+	assert.Equal(t, []string{"a", "b"}, got)
+}
+
+func TestDuplicateRegisterTextMapPropagatorFactoryPanics(t *testing.T) {
+	const propName = "custom"
+	RegisterTextMapPropagator(propName, noop)
+	t.Cleanup(func() { propagators.drop(propName) })
+
+	errString := fmt.Sprintf("%s: %q", errDupReg, propName)
+	f := func([]string) (propagation.TextMapPropagator, error) { return noop, nil }
+	assert.PanicsWithError(t, errString, func() {
+		RegisterTextMapPropagatorFactory(propName, f)
+	})
+	errString = fmt.Sprintf("%s: %q", errDupReg, "jaeger")
+	assert.PanicsWithError(t, errString, func() {
+		RegisterTextMapPropagatorFactory("jaeger", f)
+	})
+}
+
+func TestTextMapPropagatorFactoryArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		want propagation.TextMapPropagator
+	}{
+		{"b3:single", b3.New(b3.WithInjectEncoding(b3.B3SingleHeader))},
+		{"b3:multi", b3.New(b3.WithInjectEncoding(b3.B3MultipleHeader))},
+		{"b3:single:multi", b3.New(b3.WithInjectEncoding(b3.B3SingleHeader | b3.B3MultipleHeader))},
+		{"jaeger:64bit", jaeger.New(jaeger.WithTraceID64Bit())},
+		{"xray:tracestate", xray.NewPropagator(xray.WithTraceState())},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := TextMapPropagator(tt.name)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, p)
+		})
+	}
+}
+
+func TestTextMapPropagatorFactoryErrors(t *testing.T) {
+	p, err := TextMapPropagator("tracecontext", "b3:invalid", "ottrace:single", "jaeger:64bit")
+	assert.ErrorIs(t, err, errUnsupportedArg)
+	assert.ErrorIs(t, err, errUnknownPropagator)
+	assert.ErrorContains(t, err, "ottrace:single")
+	assert.Equal(t, propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		jaeger.New(jaeger.WithTraceID64Bit()),
+	), p)
+}
@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xray // import "go.opentelemetry.io/contrib/propagators/aws/xray"
+
+type config struct {
+	traceState bool
+}
+
+// Option applies an option to the Propagator configuration.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (o optionFunc) apply(c *config) {
+	o(c)
+}
+
+// newConfig creates a new config struct and applies opts to it.
+func newConfig(opts ...Option) config {
+	var c config
+	for _, opt := range opts {
+		opt.apply(&c)
+	}
+	return c
+}
+
+// WithTraceState configures the Propagator to carry the W3C TraceState of
+// the span context in the tracestate header alongside the X-Amzn-Trace-Id
+// header, both when injecting and extracting.
+//
+// The X-Ray header has no field for the TraceState. Without this option
+// vendor TraceState entries are lost when the X-Ray propagator is the first
+// propagator of a composite that also contains the W3C TraceContext
+// propagator, as the extracted span context is then not replaced.
+func WithTraceState() Option {
+	return optionFunc(func(c *config) {
+		c.traceState = true
+	})
+}
@@ -17,19 +17,23 @@ package xray // import "go.opentelemetry.io/contrib/propagators/aws/xray"
 import (
 	"context"
 	"errors"
+	"strconv"
 	"strings"
 
+	"go.opentelemetry.io/otel/baggage"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 )
 
 const (
 	traceHeaderKey       = "X-Amzn-Trace-Id"
+	traceStateHeaderKey  = "tracestate"
 	traceHeaderDelimiter = ";"
 	kvDelimiter          = "="
 	traceIDKey           = "Root"
 	sampleFlagKey        = "Sampled"
 	parentIDKey          = "Parent"
+	lineageKey           = "Lineage"
 	traceIDVersion       = "1"
 	traceIDDelimiter     = "-"
 	isSampled            = "1"
@@ -42,6 +46,11 @@ const (
 	traceIDDelimitterIndex2 = 10
 	traceIDFirstPartLength  = 8
 	sampledFlagLength       = 1
+
+	lineageDelimiter   = ":"
+	lineageHashLength  = 8
+	lineageMaxCounter1 = 32767
+	lineageMaxCounter2 = 255
 )
 
 var (
@@ -58,11 +67,23 @@ var (
 // Example AWS X-Ray format:
 //
 // X-Amzn-Trace-Id: Root={traceId};Parent={parentId};Sampled={samplingFlag}.
-type Propagator struct{}
+//
+// The optional Lineage field of the header, i.e.
+// Lineage={counter1}:{hash}:{counter2}, is propagated as the "Lineage"
+// member of the Baggage of the context.
+type Propagator struct {
+	cfg config
+}
 
 // Asserts that the propagator implements the otel.TextMapPropagator interface at compile time.
 var _ propagation.TextMapPropagator = &Propagator{}
 
+// NewPropagator returns a Propagator configured with opts. The zero value
+// Propagator is equivalent to one created without any option.
+func NewPropagator(opts ...Option) Propagator {
+	return Propagator{cfg: newConfig(opts...)}
+}
+
 // Inject injects a context to the carrier following AWS X-Ray format.
 func (xray Propagator) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
 	sc := trace.SpanFromContext(ctx).SpanContext()
@@ -81,8 +102,17 @@ func (xray Propagator) Inject(ctx context.Context, carrier propagation.TextMapCa
 		traceIDKey, kvDelimiter, xrayTraceID, traceHeaderDelimiter, parentIDKey,
 		kvDelimiter, parentID.String(), traceHeaderDelimiter, sampleFlagKey, kvDelimiter, samplingFlag,
 	}
+	if lineage := baggage.FromContext(ctx).Member(lineageKey).Value(); isValidLineage(lineage) {
+		headers = append(headers, traceHeaderDelimiter, lineageKey, kvDelimiter, lineage)
+	}
 
 	carrier.Set(traceHeaderKey, strings.Join(headers, ""))
+
+	if xray.cfg.traceState {
+		if ts := sc.TraceState().String(); ts != "" {
+			carrier.Set(traceStateHeaderKey, ts)
+		}
+	}
 }
 
 // Extract gets a context from the carrier if it contains AWS X-Ray headers.
@@ -91,6 +121,14 @@ func (xray Propagator) Extract(ctx context.Context, carrier propagation.TextMapC
 	if header := carrier.Get(traceHeaderKey); header != "" {
 		sc, err := extract(header)
 		if err == nil && sc.IsValid() {
+			if xray.cfg.traceState {
+				// An invalid TraceState is ignored, as done by the W3C
+				// TraceContext propagator.
+				if ts, err := trace.ParseTraceState(carrier.Get(traceStateHeaderKey)); err == nil {
+					sc = sc.WithTraceState(ts)
+				}
+			}
+			ctx = withLineage(ctx, extractLineage(header))
 			return trace.ContextWithRemoteSpanContext(ctx, sc)
 		}
 	}
@@ -140,6 +178,58 @@ func extract(headerVal string) (trace.SpanContext, error) {
 	return trace.NewSpanContext(scc), nil
 }
 
+// extractLineage returns the valid Lineage field of headerVal, or an empty
+// string if there is none.
+func extractLineage(headerVal string) string {
+	for _, part := range strings.Split(headerVal, traceHeaderDelimiter) {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), kvDelimiter)
+		if ok && key == lineageKey && isValidLineage(value) {
+			return value
+		}
+	}
+	return ""
+}
+
+// isValidLineage returns if lineage is a valid Lineage field value, i.e.
+// {counter1}:{hash}:{counter2}.
+func isValidLineage(lineage string) bool {
+	parts := strings.Split(lineage, lineageDelimiter)
+	if len(parts) != 3 {
+		return false
+	}
+	counter1, err := strconv.ParseUint(parts[0], 10, 16)
+	if err != nil || counter1 > lineageMaxCounter1 {
+		return false
+	}
+	if len(parts[1]) != lineageHashLength {
+		return false
+	}
+	for _, c := range parts[1] {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+			return false
+		}
+	}
+	counter2, err := strconv.ParseUint(parts[2], 10, 8)
+	return err == nil && counter2 <= lineageMaxCounter2
+}
+
+// withLineage returns a copy of ctx with lineage stored in its Baggage. ctx
+// is returned unchanged if lineage is empty.
+func withLineage(ctx context.Context, lineage string) context.Context {
+	if lineage == "" {
+		return ctx
+	}
+	m, err := baggage.NewMember(lineageKey, lineage)
+	if err != nil {
+		return ctx
+	}
+	b, err := baggage.FromContext(ctx).SetMember(m)
+	if err != nil {
+		return ctx
+	}
+	return baggage.ContextWithBaggage(ctx, b)
+}
+
 // indexOf returns position of the first occurrence of a substr in str starting at pos index.
 func indexOf(str string, substr string, pos int) int {
 	index := strings.Index(str[pos:], substr)
@@ -180,5 +270,8 @@ func parseTraceFlag(xraySampledFlag string) trace.TraceFlags {
 
 // Fields returns list of fields used by HTTPTextFormat.
 func (xray Propagator) Fields() []string {
+	if xray.cfg.traceState {
+		return []string{traceHeaderKey, traceStateHeaderKey}
+	}
 	return []string{traceHeaderKey}
 }
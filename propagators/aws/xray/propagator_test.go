@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/baggage"
 	"go.opentelemetry.io/otel/propagation"
 
 	"github.com/stretchr/testify/assert"
@@ -104,6 +105,64 @@ func TestAwsXrayExtract(t *testing.T) {
 	}
 }
 
+func TestAwsXrayLineage(t *testing.T) {
+	testData := []struct {
+		name    string
+		header  string
+		lineage string
+	}{
+		{
+			name:    "valid",
+			header:  "Root=1-8a3c60f7-d188f8fa79d48a391a778fa6;Parent=53995c3f42cd8ad8;Sampled=1;Lineage=32767:e65a2c4c:255",
+			lineage: "32767:e65a2c4c:255",
+		},
+		{
+			name:    "valid before sampled",
+			header:  "Root=1-8a3c60f7-d188f8fa79d48a391a778fa6;Parent=53995c3f42cd8ad8;Lineage=1:E65A2C4C:0;Sampled=1",
+			lineage: "1:E65A2C4C:0",
+		},
+		{
+			name:   "missing",
+			header: "Root=1-8a3c60f7-d188f8fa79d48a391a778fa6;Parent=53995c3f42cd8ad8;Sampled=1",
+		},
+		{
+			name:   "counter1 out of range",
+			header: "Root=1-8a3c60f7-d188f8fa79d48a391a778fa6;Parent=53995c3f42cd8ad8;Sampled=1;Lineage=32768:e65a2c4c:255",
+		},
+		{
+			name:   "counter2 out of range",
+			header: "Root=1-8a3c60f7-d188f8fa79d48a391a778fa6;Parent=53995c3f42cd8ad8;Sampled=1;Lineage=1:e65a2c4c:256",
+		},
+		{
+			name:   "invalid hash",
+			header: "Root=1-8a3c60f7-d188f8fa79d48a391a778fa6;Parent=53995c3f42cd8ad8;Sampled=1;Lineage=1:e65a2c4g:1",
+		},
+		{
+			name:   "missing counter",
+			header: "Root=1-8a3c60f7-d188f8fa79d48a391a778fa6;Parent=53995c3f42cd8ad8;Sampled=1;Lineage=e65a2c4c:1",
+		},
+	}
+
+	propagator := Propagator{}
+	for _, test := range testData {
+		t.Run(test.name, func(t *testing.T) {
+			header := http.Header{}
+			header.Set(traceHeaderKey, test.header)
+			ctx := propagator.Extract(context.Background(), propagation.HeaderCarrier(header))
+			assert.True(t, trace.SpanContextFromContext(ctx).IsValid())
+			assert.Equal(t, test.lineage, baggage.FromContext(ctx).Member(lineageKey).Value())
+
+			got := http.Header{}
+			propagator.Inject(ctx, propagation.HeaderCarrier(got))
+			want := "Root=1-8a3c60f7-d188f8fa79d48a391a778fa6;Parent=53995c3f42cd8ad8;Sampled=1"
+			if test.lineage != "" {
+				want += ";Lineage=" + test.lineage
+			}
+			assert.Equal(t, want, got.Get(traceHeaderKey))
+		})
+	}
+}
+
 func BenchmarkPropagatorExtract(b *testing.B) {
 	propagator := Propagator{}
 
@@ -132,3 +191,35 @@ func BenchmarkPropagatorInject(b *testing.B) {
 		propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
 	}
 }
+
+func TestAwsXrayTraceState(t *testing.T) {
+	ts, err := trace.ParseTraceState("vendor=value,other=1")
+	assert.NoError(t, err)
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     parentSpanID,
+		TraceFlags: traceFlagSampled,
+		TraceState: ts,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+
+	header := http.Header{}
+	Propagator{}.Inject(ctx, propagation.HeaderCarrier(header))
+	assert.Empty(t, header.Get(traceStateHeaderKey))
+	got := Propagator{}.Extract(context.Background(), propagation.HeaderCarrier(header))
+	assert.Equal(t, 0, trace.SpanContextFromContext(got).TraceState().Len())
+
+	propagator := NewPropagator(WithTraceState())
+	assert.Equal(t, []string{traceHeaderKey, traceStateHeaderKey}, propagator.Fields())
+
+	header = http.Header{}
+	propagator.Inject(ctx, propagation.HeaderCarrier(header))
+	assert.Equal(t, "vendor=value,other=1", header.Get(traceStateHeaderKey))
+	got = propagator.Extract(context.Background(), propagation.HeaderCarrier(header))
+	assert.Equal(t, sc.WithRemote(true), trace.SpanContextFromContext(got))
+
+	// An invalid TraceState is dropped.
+	header.Set(traceStateHeaderKey, "invalid")
+	got = propagator.Extract(context.Background(), propagation.HeaderCarrier(header))
+	assert.Equal(t, sc.WithTraceState(trace.TraceState{}).WithRemote(true), trace.SpanContextFromContext(got))
+}
@@ -19,6 +19,20 @@ type config struct {
 	// information. If no encoding is specified (i.e. `B3Unspecified`)
 	// `B3SingleHeader` will be used as the default.
 	InjectEncoding Encoding
+
+	// ExtractEncoding are the B3 encodings accepted when extracting trace
+	// information. If no encoding is specified (i.e. `B3Unspecified`) both
+	// `B3SingleHeader` and `B3MultipleHeader` are accepted, with
+	// `B3SingleHeader` taking precedence.
+	ExtractEncoding Encoding
+
+	// DebugTraceState determines if the B3 debug flag is recorded in, and
+	// read from, the TraceState of the SpanContext.
+	DebugTraceState bool
+
+	// DenyPropagation determines if an extracted not-sampled decision is
+	// injected regardless of the local sampling decision.
+	DenyPropagation bool
 }
 
 // Option interface used for setting optional config properties.
@@ -77,3 +91,56 @@ func WithInjectEncoding(encoding Encoding) Option {
 		c.InjectEncoding = encoding
 	})
 }
+
+// WithExtractEncoding sets the encodings the propagator will accept when
+// extracting. The encoding is interpreted as a bitmask. Therefore
+//
+//	WithExtractEncoding(B3SingleHeader | B3MultipleHeader)
+//
+// means the propagator will extract either single or multi B3 headers, the
+// single header taking precedence when both are present. This is
+// independent from the encoding injected, e.g.
+//
+//	New(
+//		WithInjectEncoding(B3SingleHeader),
+//		WithExtractEncoding(B3SingleHeader|B3MultipleHeader),
+//	)
+//
+// accepts both header styles from peers while only emitting the single
+// header, which eases migrating between the two.
+func WithExtractEncoding(encoding Encoding) Option {
+	return optionFunc(func(c *config) {
+		c.ExtractEncoding = encoding
+	})
+}
+
+// WithDebugTraceState sets if the B3 debug flag is recorded in the TraceState
+// of extracted SpanContexts.
+//
+// By default the debug flag is only stored in the returned context.Context,
+// it is lost when the context is not passed along, e.g. when spans are
+// started from a context derived from another request. When enabled, an
+// extracted debug flag is also recorded as the "b3=d" TraceState member.
+// Child spans inherit the TraceState of their parent, and the W3C
+// TraceContext propagator carries it across processes, so the propagator
+// injects the debug flag whenever that member is present.
+func WithDebugTraceState(enabled bool) Option {
+	return optionFunc(func(c *config) {
+		c.DebugTraceState = enabled
+	})
+}
+
+// WithDenyPropagation sets if an explicit not-sampled decision extracted
+// from upstream (i.e. `b3: 0` or `x-b3-sampled: 0`) is preserved.
+//
+// By default the propagator injects the sampling decision of the current
+// span, which can be sampled by a local sampler even if the upstream service
+// denied sampling. When enabled, the upstream deny decision is recorded in
+// the context.Context returned by Extract and the propagator injects a
+// not-sampled decision for any span in that context, so services downstream
+// honor the upstream decision consistently.
+func WithDenyPropagation(enabled bool) Option {
+	return optionFunc(func(c *config) {
+		c.DenyPropagation = enabled
+	})
+}
@@ -31,3 +31,14 @@ func ExampleNew_injectEncoding() {
 	p := b3.New(b3.WithInjectEncoding(b3.B3MultipleHeader | b3.B3SingleHeader))
 	otel.SetTextMapPropagator(p)
 }
+
+func ExampleNew_extractEncoding() {
+	// Create a B3 propagator configured to accept context in both multiple
+	// and single header B3 HTTP encoding, but only inject the single header
+	// encoding.
+	p := b3.New(
+		b3.WithInjectEncoding(b3.B3SingleHeader),
+		b3.WithExtractEncoding(b3.B3MultipleHeader|b3.B3SingleHeader),
+	)
+	otel.SetTextMapPropagator(p)
+}
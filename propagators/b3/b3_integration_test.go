@@ -73,6 +73,97 @@ func TestExtractB3(t *testing.T) {
 	}
 }
 
+func TestExtractB3Encoding(t *testing.T) {
+	single := map[string]string{
+		b3Context: "4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-1",
+	}
+	multiple := map[string]string{
+		b3TraceID: traceIDStr,
+		b3SpanID:  spanIDStr,
+		b3Sampled: "1",
+	}
+	sampled := trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}
+
+	tests := []struct {
+		name     string
+		encoding b3.Encoding
+		headers  map[string]string
+		wantScc  trace.SpanContextConfig
+	}{
+		{
+			name:     "unspecified accepts single",
+			encoding: b3.B3Unspecified,
+			headers:  single,
+			wantScc:  sampled,
+		},
+		{
+			name:     "unspecified accepts multiple",
+			encoding: b3.B3Unspecified,
+			headers:  multiple,
+			wantScc:  sampled,
+		},
+		{
+			name:     "single accepts single",
+			encoding: b3.B3SingleHeader,
+			headers:  single,
+			wantScc:  sampled,
+		},
+		{
+			name:     "single ignores multiple",
+			encoding: b3.B3SingleHeader,
+			headers:  multiple,
+		},
+		{
+			name:     "multiple accepts multiple",
+			encoding: b3.B3MultipleHeader,
+			headers:  multiple,
+			wantScc:  sampled,
+		},
+		{
+			name:     "multiple ignores single",
+			encoding: b3.B3MultipleHeader,
+			headers:  single,
+		},
+		{
+			name:     "single and multiple accepts single",
+			encoding: b3.B3SingleHeader | b3.B3MultipleHeader,
+			headers:  single,
+			wantScc:  sampled,
+		},
+		{
+			name:     "single and multiple accepts multiple",
+			encoding: b3.B3SingleHeader | b3.B3MultipleHeader,
+			headers:  multiple,
+			wantScc:  sampled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			propagator := b3.New(
+				b3.WithInjectEncoding(b3.B3SingleHeader),
+				b3.WithExtractEncoding(tt.encoding),
+			)
+			header := make(http.Header, len(tt.headers))
+			for h, v := range tt.headers {
+				header.Set(h, v)
+			}
+
+			ctx := propagator.Extract(context.Background(), propagation.HeaderCarrier(header))
+			want := trace.NewSpanContext(tt.wantScc)
+			if want.IsValid() {
+				want = want.WithRemote(true)
+			}
+			assert.Equal(t, want, trace.SpanContextFromContext(ctx))
+			assert.False(t, b3.DeferredFromContext(ctx))
+		})
+	}
+}
+
 type testSpan struct {
 	trace.Span
 	sc trace.SpanContext
@@ -179,3 +270,155 @@ func TestB3Propagator_Fields(t *testing.T) {
 		}
 	}
 }
+
+func TestB3DebugTraceState(t *testing.T) {
+	tests := []struct {
+		name     string
+		encoding b3.Encoding
+		headers  map[string]string
+		want     map[string]string
+	}{
+		{
+			name:     "single header",
+			encoding: b3.B3SingleHeader,
+			headers: map[string]string{
+				b3Context: "4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-d",
+			},
+			want: map[string]string{
+				b3Context: "4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-d",
+			},
+		},
+		{
+			name:     "multiple header",
+			encoding: b3.B3MultipleHeader,
+			headers: map[string]string{
+				b3TraceID: traceIDStr,
+				b3SpanID:  spanIDStr,
+				b3Flags:   "1",
+			},
+			want: map[string]string{
+				b3TraceID: traceIDStr,
+				b3SpanID:  spanIDStr,
+				b3Flags:   "1",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := make(http.Header, len(tt.headers))
+			for h, v := range tt.headers {
+				header.Set(h, v)
+			}
+
+			disabled := b3.New(b3.WithInjectEncoding(tt.encoding))
+			ctx := disabled.Extract(context.Background(), propagation.HeaderCarrier(header))
+			assert.True(t, b3.DebugFromContext(ctx))
+			assert.Equal(t, 0, trace.SpanContextFromContext(ctx).TraceState().Len())
+
+			propagator := b3.New(b3.WithInjectEncoding(tt.encoding), b3.WithDebugTraceState(true))
+			ctx = propagator.Extract(context.Background(), propagation.HeaderCarrier(header))
+			assert.True(t, b3.DebugFromContext(ctx))
+			sc := trace.SpanContextFromContext(ctx)
+			assert.Equal(t, "b3=d", sc.TraceState().String())
+
+			// The debug flag is injected from the TraceState alone, e.g.
+			// for a child span started from a context that does not hold
+			// the extracted debug flag.
+			got := http.Header{}
+			ctx = trace.ContextWithSpanContext(context.Background(), sc)
+			propagator.Inject(ctx, propagation.HeaderCarrier(got))
+			for h, v := range tt.want {
+				assert.Equal(t, v, got.Get(h), h)
+			}
+			assert.Empty(t, got.Get(b3Sampled))
+
+			got = http.Header{}
+			disabled.Inject(ctx, propagation.HeaderCarrier(got))
+			assert.Empty(t, got.Get(b3Flags))
+			assert.NotContains(t, got.Get(b3Context), "-d")
+		})
+	}
+}
+
+func TestB3DenyPropagation(t *testing.T) {
+	tests := []struct {
+		name        string
+		headers     map[string]string
+		wantDenied  bool
+		wantSampled string
+	}{
+		{
+			name:        "single header denied",
+			headers:     map[string]string{b3Context: "0"},
+			wantDenied:  true,
+			wantSampled: "0",
+		},
+		{
+			name: "single header with IDs denied",
+			headers: map[string]string{
+				b3Context: "4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-0",
+			},
+			wantDenied:  true,
+			wantSampled: "0",
+		},
+		{
+			name: "multiple header denied",
+			headers: map[string]string{
+				b3TraceID: traceIDStr,
+				b3SpanID:  spanIDStr,
+				b3Sampled: "0",
+			},
+			wantDenied:  true,
+			wantSampled: "0",
+		},
+		{
+			name: "sampled",
+			headers: map[string]string{
+				b3TraceID: traceIDStr,
+				b3SpanID:  spanIDStr,
+				b3Sampled: "1",
+			},
+			wantSampled: "1",
+		},
+		{
+			name: "deferred",
+			headers: map[string]string{
+				b3TraceID: traceIDStr,
+				b3SpanID:  spanIDStr,
+			},
+		},
+	}
+
+	// The local span is sampled regardless of the upstream decision.
+	local := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     mustSpanIDFromHex("00f067aa0ba902b8"),
+		TraceFlags: trace.FlagsSampled,
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := make(http.Header, len(tt.headers))
+			for h, v := range tt.headers {
+				header.Set(h, v)
+			}
+
+			propagator := b3.New(b3.WithInjectEncoding(b3.B3MultipleHeader), b3.WithDenyPropagation(true))
+			ctx := propagator.Extract(context.Background(), propagation.HeaderCarrier(header))
+			assert.Equal(t, tt.wantDenied, b3.DeniedFromContext(ctx))
+
+			ctx = trace.ContextWithSpanContext(ctx, local)
+			got := http.Header{}
+			propagator.Inject(ctx, propagation.HeaderCarrier(got))
+			assert.Equal(t, tt.wantSampled, got.Get(b3Sampled))
+
+			// The local decision is injected if the option is not enabled.
+			if tt.wantDenied {
+				got = http.Header{}
+				b3.New(b3.WithInjectEncoding(b3.B3MultipleHeader)).Inject(ctx, propagation.HeaderCarrier(got))
+				assert.Equal(t, "1", got.Get(b3Sampled))
+			}
+		})
+	}
+}
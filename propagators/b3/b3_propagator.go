@@ -34,6 +34,10 @@ const (
 
 	b3TraceIDPadding = "0000000000000000"
 
+	// TraceState member recording the B3 debug flag.
+	b3TraceStateKey   = "b3"
+	b3TraceStateDebug = "d"
+
 	// B3 Single Header encoding widths.
 	separatorWidth      = 1       // Single "-" character.
 	samplingWidth       = 1       // Single hex character.
@@ -91,6 +95,8 @@ func New(opts ...Option) propagation.TextMapPropagator {
 // SpanContext.
 func (b3 propagator) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
 	sc := trace.SpanFromContext(ctx).SpanContext()
+	debug := b3.debug(ctx, sc)
+	denied := b3.cfg.DenyPropagation && deniedFromContext(ctx)
 
 	if b3.cfg.InjectEncoding.supports(B3SingleHeader) || b3.cfg.InjectEncoding == B3Unspecified {
 		header := []string{}
@@ -98,10 +104,10 @@ func (b3 propagator) Inject(ctx context.Context, carrier propagation.TextMapCarr
 			header = append(header, sc.TraceID().String(), sc.SpanID().String())
 		}
 
-		if debugFromContext(ctx) {
+		if debug {
 			header = append(header, "d")
 		} else if !(deferredFromContext(ctx)) {
-			if sc.IsSampled() {
+			if sc.IsSampled() && !denied {
 				header = append(header, "1")
 			} else {
 				header = append(header, "0")
@@ -117,11 +123,11 @@ func (b3 propagator) Inject(ctx context.Context, carrier propagation.TextMapCarr
 			carrier.Set(b3SpanIDHeader, sc.SpanID().String())
 		}
 
-		if debugFromContext(ctx) {
+		if debug {
 			// Since Debug implies deferred, don't also send "X-B3-Sampled".
 			carrier.Set(b3DebugFlagHeader, "1")
 		} else if !(deferredFromContext(ctx)) {
-			if sc.IsSampled() {
+			if sc.IsSampled() && !denied {
 				carrier.Set(b3SampledHeader, "1")
 			} else {
 				carrier.Set(b3SampledHeader, "0")
@@ -133,17 +139,31 @@ func (b3 propagator) Inject(ctx context.Context, carrier propagation.TextMapCarr
 // Extract extracts a context from the carrier if it contains B3 headers.
 func (b3 propagator) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
 	var (
-		sc  trace.SpanContext
-		err error
+		sc     trace.SpanContext
+		err    error
+		denied bool
 	)
 
+	encoding := b3.cfg.ExtractEncoding
+	if encoding == B3Unspecified {
+		encoding = B3SingleHeader | B3MultipleHeader
+	}
+
 	// Default to Single Header if a valid value exists.
-	if h := carrier.Get(b3ContextHeader); h != "" {
-		ctx, sc, err = extractSingle(ctx, h)
-		if err == nil && sc.IsValid() {
-			return trace.ContextWithRemoteSpanContext(ctx, sc)
+	if encoding.supports(B3SingleHeader) {
+		if h := carrier.Get(b3ContextHeader); h != "" {
+			ctx, sc, err = extractSingle(ctx, h)
+			if err == nil && sc.IsValid() {
+				return b3.contextWithRemoteSpanContext(ctx, sc)
+			}
+			// A sampling decision alone (i.e. `b3: 0`) is valid.
+			denied = err == nil && deniesSampling(ctx, sc)
+			// The Single Header value was invalid, fallback to Multiple Header.
 		}
-		// The Single Header value was invalid, fallback to Multiple Header.
+	}
+
+	if !encoding.supports(B3MultipleHeader) {
+		return b3.contextWithoutSpanContext(ctx, denied)
 	}
 
 	var (
@@ -155,10 +175,56 @@ func (b3 propagator) Extract(ctx context.Context, carrier propagation.TextMapCar
 	)
 	ctx, sc, err = extractMultiple(ctx, traceID, spanID, parentSpanID, sampled, debugFlag)
 	if err != nil || !sc.IsValid() {
-		// clear the deferred flag if we don't have a valid SpanContext
-		return withDeferred(ctx, false)
+		return b3.contextWithoutSpanContext(ctx, denied || (err == nil && deniesSampling(ctx, sc)))
+	}
+	return b3.contextWithRemoteSpanContext(ctx, sc)
+}
+
+// contextWithRemoteSpanContext returns a copy of ctx with the extracted sc
+// set as the remote span context along with the state the propagator is
+// configured to preserve.
+func (b3 propagator) contextWithRemoteSpanContext(ctx context.Context, sc trace.SpanContext) context.Context {
+	if b3.cfg.DenyPropagation {
+		ctx = withDenied(ctx, deniesSampling(ctx, sc))
+	}
+	return trace.ContextWithRemoteSpanContext(ctx, b3.withDebugTraceState(ctx, sc))
+}
+
+// contextWithoutSpanContext returns a copy of ctx for which no valid
+// SpanContext was extracted.
+func (b3 propagator) contextWithoutSpanContext(ctx context.Context, denied bool) context.Context {
+	if b3.cfg.DenyPropagation {
+		ctx = withDenied(ctx, denied)
+	}
+	// clear the deferred flag if we don't have a valid SpanContext
+	return withDeferred(ctx, false)
+}
+
+// deniesSampling returns if the extracted ctx and sc hold an explicit
+// not-sampled decision.
+func deniesSampling(ctx context.Context, sc trace.SpanContext) bool {
+	return !sc.IsSampled() && !deferredFromContext(ctx)
+}
+
+// debug returns if the debug flag needs to be propagated for sc.
+func (b3 propagator) debug(ctx context.Context, sc trace.SpanContext) bool {
+	if debugFromContext(ctx) {
+		return true
+	}
+	return b3.cfg.DebugTraceState && sc.TraceState().Get(b3TraceStateKey) == b3TraceStateDebug
+}
+
+// withDebugTraceState returns sc with the debug flag stored in ctx recorded
+// in its TraceState if the propagator is configured to do so.
+func (b3 propagator) withDebugTraceState(ctx context.Context, sc trace.SpanContext) trace.SpanContext {
+	if !b3.cfg.DebugTraceState || !debugFromContext(ctx) {
+		return sc
+	}
+	ts, err := sc.TraceState().Insert(b3TraceStateKey, b3TraceStateDebug)
+	if err != nil {
+		return sc
 	}
-	return trace.ContextWithRemoteSpanContext(ctx, sc)
+	return sc.WithTraceState(ts)
 }
 
 func (b3 propagator) Fields() []string {
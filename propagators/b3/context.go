@@ -21,6 +21,7 @@ type b3KeyType int
 const (
 	debugKey b3KeyType = iota
 	deferredKey
+	deniedKey
 )
 
 // withDebug returns a copy of parent with debug set as the debug flag value .
@@ -58,3 +59,21 @@ func deferredFromContext(ctx context.Context) bool {
 	}
 	return false
 }
+
+// withDenied returns a copy of parent with denied set as the denied flag value.
+func withDenied(parent context.Context, denied bool) context.Context {
+	return context.WithValue(parent, deniedKey, denied)
+}
+
+// deniedFromContext returns the denied value stored in ctx.
+//
+// If no denied value is stored in ctx false is returned.
+func deniedFromContext(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	if denied, ok := ctx.Value(deniedKey).(bool); ok {
+		return denied
+	}
+	return false
+}
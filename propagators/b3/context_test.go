@@ -20,4 +20,7 @@ var (
 
 	WithDeferred        = withDeferred
 	DeferredFromContext = deferredFromContext
+
+	WithDenied        = withDenied
+	DeniedFromContext = deniedFromContext
 )
@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package baggagelimit // import "go.opentelemetry.io/contrib/propagators/baggagelimit"
+
+type config struct {
+	// MaxMembers is the maximum number of baggage members propagated. There
+	// is no limit if it is not positive.
+	MaxMembers int
+
+	// MaxMemberBytes is the maximum length of the encoding of a single
+	// baggage member. There is no limit if it is not positive.
+	MaxMemberBytes int
+
+	// MaxBytes is the maximum length of the encoded baggage. There is no
+	// limit if it is not positive.
+	MaxBytes int
+
+	// AllowedKeys are the keys of the baggage members propagated. All keys
+	// are propagated if it is nil.
+	AllowedKeys map[string]struct{}
+
+	// DeniedKeys are the keys of the baggage members never propagated.
+	DeniedKeys map[string]struct{}
+}
+
+// Option interface used for setting optional config properties.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (o optionFunc) apply(c *config) {
+	o(c)
+}
+
+// newConfig creates a new config struct and applies opts to it.
+func newConfig(opts ...Option) config {
+	var c config
+	for _, opt := range opts {
+		opt.apply(&c)
+	}
+	return c
+}
+
+// WithMaxMembers limits the number of baggage members propagated to n. The
+// members exceeding the limit are dropped.
+func WithMaxMembers(n int) Option {
+	return optionFunc(func(c *config) {
+		c.MaxMembers = n
+	})
+}
+
+// WithMaxMemberBytes drops the baggage members whose encoding, including
+// their properties, is longer than n bytes.
+func WithMaxMemberBytes(n int) Option {
+	return optionFunc(func(c *config) {
+		c.MaxMemberBytes = n
+	})
+}
+
+// WithMaxBytes limits the length of the encoded baggage to n bytes. The
+// members exceeding the limit are dropped.
+func WithMaxBytes(n int) Option {
+	return optionFunc(func(c *config) {
+		c.MaxBytes = n
+	})
+}
+
+// WithAllowedKeys restricts the baggage members propagated to the ones with
+// the passed keys. Keys are case-sensitive.
+func WithAllowedKeys(keys ...string) Option {
+	return optionFunc(func(c *config) {
+		c.AllowedKeys = addKeys(c.AllowedKeys, keys)
+	})
+}
+
+// WithDeniedKeys prevents the baggage members with the passed keys from
+// being propagated. Keys are case-sensitive and denied keys take precedence
+// over allowed keys.
+func WithDeniedKeys(keys ...string) Option {
+	return optionFunc(func(c *config) {
+		c.DeniedKeys = addKeys(c.DeniedKeys, keys)
+	})
+}
+
+func addKeys(set map[string]struct{}, keys []string) map[string]struct{} {
+	if set == nil {
+		set = make(map[string]struct{}, len(keys))
+	}
+	for _, k := range keys {
+		set[k] = struct{}{}
+	}
+	return set
+}
@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package baggagelimit_test
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/contrib/propagators/baggagelimit"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+func ExampleNew() {
+	p := baggagelimit.New(
+		baggagelimit.WithMaxMembers(8),
+		baggagelimit.WithMaxBytes(1024),
+		baggagelimit.WithDeniedKeys("user.email"),
+	)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, p))
+
+	carrier := propagation.MapCarrier{"baggage": "user.id=42,user.email=a%40example.com"}
+	ctx := otel.GetTextMapPropagator().Extract(context.Background(), carrier)
+	fmt.Println(baggage.FromContext(ctx))
+	// Output: user.id=42
+}
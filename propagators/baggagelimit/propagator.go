@@ -0,0 +1,167 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package baggagelimit provides a W3C Baggage propagator enforcing limits
+// on the baggage it propagates.
+//
+// The baggage propagator of go.opentelemetry.io/otel/propagation accepts
+// any valid baggage sent on the wire. The Propagator of this package bounds
+// the number and size of the members propagated and filters them by key,
+// both when extracting baggage from and injecting it into carriers.
+package baggagelimit // import "go.opentelemetry.io/contrib/propagators/baggagelimit"
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+const (
+	baggageHeader = "baggage"
+	listDelimiter = ","
+)
+
+// Propagator is a propagator that supports the W3C Baggage format and
+// enforces the limits and key filters it is configured with.
+//
+// Members are extracted in the order they are listed in the carrier and
+// injected in the order of their keys. The members exceeding a limit are
+// dropped, the others are still propagated.
+type Propagator struct {
+	cfg config
+}
+
+var _ propagation.TextMapPropagator = Propagator{}
+
+// New returns a Propagator configured with opts.
+func New(opts ...Option) Propagator {
+	return Propagator{cfg: newConfig(opts...)}
+}
+
+// Inject sets the baggage members from ctx passing the limits and key
+// filters into the carrier.
+func (p Propagator) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	members := baggage.FromContext(ctx).Members()
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].Key() < members[j].Key()
+	})
+
+	bStr := p.limit(members).String()
+	if bStr != "" {
+		carrier.Set(baggageHeader, bStr)
+	}
+}
+
+// Extract returns a copy of parent with the baggage members from the
+// carrier passing the limits and key filters added.
+//
+// Like the W3C Baggage propagator, parent is returned unchanged if the
+// baggage of the carrier is invalid.
+func (p Propagator) Extract(parent context.Context, carrier propagation.TextMapCarrier) context.Context {
+	bStr := carrier.Get(baggageHeader)
+	if bStr == "" {
+		return parent
+	}
+
+	// Validate the whole baggage first to behave like the W3C Baggage
+	// propagator.
+	if _, err := baggage.Parse(bStr); err != nil {
+		return parent
+	}
+
+	members, err := parseMembers(bStr)
+	if err != nil {
+		return parent
+	}
+	return baggage.ContextWithBaggage(parent, p.limit(members))
+}
+
+// Fields returns the keys who's values are set with Inject.
+func (p Propagator) Fields() []string {
+	return []string{baggageHeader}
+}
+
+// parseMembers returns the members of the baggage-string bStr in the order
+// they are listed. Duplicate members are resolved by last-one-wins, as done
+// by baggage.Parse.
+func parseMembers(bStr string) ([]baggage.Member, error) {
+	var members []baggage.Member
+	index := make(map[string]int)
+	for _, memberStr := range strings.Split(bStr, listDelimiter) {
+		bag, err := baggage.Parse(memberStr)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range bag.Members() {
+			if i, ok := index[m.Key()]; ok {
+				members[i] = m
+				continue
+			}
+			index[m.Key()] = len(members)
+			members = append(members, m)
+		}
+	}
+	return members, nil
+}
+
+// limit returns the baggage of the members passing the key filters and
+// limits of p.
+func (p Propagator) limit(members []baggage.Member) baggage.Baggage {
+	kept := make([]baggage.Member, 0, len(members))
+	size := 0
+	for _, m := range members {
+		if p.cfg.MaxMembers > 0 && len(kept) >= p.cfg.MaxMembers {
+			break
+		}
+		if !p.allowed(m.Key()) {
+			continue
+		}
+
+		n := len(m.String())
+		if p.cfg.MaxMemberBytes > 0 && n > p.cfg.MaxMemberBytes {
+			continue
+		}
+		if len(kept) > 0 {
+			n += len(listDelimiter)
+		}
+		if p.cfg.MaxBytes > 0 && size+n > p.cfg.MaxBytes {
+			continue
+		}
+
+		kept = append(kept, m)
+		size += n
+	}
+
+	bag, err := baggage.New(kept...)
+	if err != nil {
+		// Only valid members are passed, this is not expected to happen.
+		return baggage.Baggage{}
+	}
+	return bag
+}
+
+// allowed returns whether the member with key passes the key filters.
+func (p Propagator) allowed(key string) bool {
+	if _, ok := p.cfg.DeniedKeys[key]; ok {
+		return false
+	}
+	if p.cfg.AllowedKeys == nil {
+		return true
+	}
+	_, ok := p.cfg.AllowedKeys[key]
+	return ok
+}
@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package baggagelimit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   []Option
+		header string
+		want   string
+	}{
+		{
+			name:   "no limits",
+			header: "key1=val1,key2=val2;prop=1",
+			want:   "key1=val1,key2=val2;prop=1",
+		},
+		{
+			name:   "invalid baggage",
+			opts:   []Option{WithMaxMembers(1)},
+			header: "key1=val1,=val2",
+			want:   "",
+		},
+		{
+			name:   "max members",
+			opts:   []Option{WithMaxMembers(2)},
+			header: "key3=val3,key1=val1,key2=val2",
+			want:   "key1=val1,key3=val3",
+		},
+		{
+			name:   "duplicate members",
+			opts:   []Option{WithMaxMembers(2)},
+			header: "key1=val1,key2=val2,key1=val3,key3=val3",
+			want:   "key1=val3,key2=val2",
+		},
+		{
+			name:   "max member bytes",
+			opts:   []Option{WithMaxMemberBytes(9)},
+			header: "key1=val1,key2=value2,key3=val3",
+			want:   "key1=val1,key3=val3",
+		},
+		{
+			name:   "max bytes",
+			opts:   []Option{WithMaxBytes(20)},
+			header: "key1=val1,key2=value2,key3=val3",
+			want:   "key1=val1,key3=val3",
+		},
+		{
+			name:   "allowed keys",
+			opts:   []Option{WithAllowedKeys("key1"), WithAllowedKeys("key3")},
+			header: "key1=val1,key2=val2,key3=val3",
+			want:   "key1=val1,key3=val3",
+		},
+		{
+			name:   "denied keys",
+			opts:   []Option{WithAllowedKeys("key1", "key2"), WithDeniedKeys("key2")},
+			header: "key1=val1,key2=val2,key3=val3",
+			want:   "key1=val1",
+		},
+		{
+			name:   "filtered members not counted",
+			opts:   []Option{WithDeniedKeys("key1"), WithMaxMembers(1)},
+			header: "key1=val1,key2=val2,key3=val3",
+			want:   "key2=val2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			carrier := propagation.MapCarrier{"baggage": tt.header}
+			ctx := New(tt.opts...).Extract(context.Background(), carrier)
+
+			want, err := baggage.Parse(tt.want)
+			require.NoError(t, err)
+			assert.Equal(t, want, baggage.FromContext(ctx))
+		})
+	}
+}
+
+func TestExtractNoHeader(t *testing.T) {
+	bag, err := baggage.Parse("key=value")
+	require.NoError(t, err)
+	ctx := baggage.ContextWithBaggage(context.Background(), bag)
+
+	ctx = New().Extract(ctx, propagation.MapCarrier{})
+	assert.Equal(t, bag, baggage.FromContext(ctx))
+}
+
+func TestInject(t *testing.T) {
+	bag, err := baggage.Parse("key3=val3,key1=val1,key2=val2,secret=val4")
+	require.NoError(t, err)
+	ctx := baggage.ContextWithBaggage(context.Background(), bag)
+
+	carrier := propagation.MapCarrier{}
+	New(WithDeniedKeys("secret"), WithMaxMembers(2)).Inject(ctx, carrier)
+
+	got, err := baggage.Parse(carrier.Get("baggage"))
+	require.NoError(t, err)
+	want, err := baggage.Parse("key1=val1,key2=val2")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestInjectEmpty(t *testing.T) {
+	bag, err := baggage.Parse("secret=value")
+	require.NoError(t, err)
+	ctx := baggage.ContextWithBaggage(context.Background(), bag)
+
+	carrier := propagation.MapCarrier{}
+	New(WithDeniedKeys("secret")).Inject(ctx, carrier)
+	assert.Empty(t, carrier)
+}
+
+func TestFields(t *testing.T) {
+	assert.Equal(t, []string{"baggage"}, New().Fields())
+}
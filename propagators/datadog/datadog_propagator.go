@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog // import "go.opentelemetry.io/contrib/propagators/datadog"
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	traceIDHeader          = "x-datadog-trace-id"
+	parentIDHeader         = "x-datadog-parent-id"
+	samplingPriorityHeader = "x-datadog-sampling-priority"
+	originHeader           = "x-datadog-origin"
+	tagsHeader             = "x-datadog-tags"
+
+	// traceIDHighTag is the tag propagating the upper 64 bits of 128-bit
+	// trace IDs as 16 lower case hex characters.
+	traceIDHighTag = "_dd.p.tid"
+
+	// traceStateKey is the TraceState member key used by Datadog.
+	traceStateKey     = "dd"
+	priorityPrefix    = "s:"
+	originPrefix      = "o:"
+	traceStateDivider = ";"
+
+	priorityAutoReject = 0
+	priorityAutoKeep   = 1
+)
+
+// Datadog propagator serializes SpanContext to/from Datadog headers.
+//
+// Datadog format:
+//
+//	x-datadog-trace-id: {decimal lower 64 bits of the trace ID}
+//	x-datadog-parent-id: {decimal span ID}
+//	x-datadog-sampling-priority: {sampling priority}
+//	x-datadog-origin: {origin}
+//	x-datadog-tags: _dd.p.tid={hex upper 64 bits of the trace ID}
+type Datadog struct{}
+
+var _ propagation.TextMapPropagator = Datadog{}
+
+// Inject injects the span context of ctx into carrier as Datadog headers.
+func (Datadog) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return
+	}
+
+	tid := sc.TraceID()
+	sid := sc.SpanID()
+	carrier.Set(traceIDHeader, strconv.FormatUint(binary.BigEndian.Uint64(tid[8:]), 10))
+	carrier.Set(parentIDHeader, strconv.FormatUint(binary.BigEndian.Uint64(sid[:]), 10))
+	if high := tid[:8]; binary.BigEndian.Uint64(high) != 0 {
+		carrier.Set(tagsHeader, traceIDHighTag+"="+hex.EncodeToString(high))
+	}
+
+	priority, origin := fromTraceState(sc.TraceState())
+	// Only use the recorded priority if it matches the sampling decision,
+	// which might have been made after it was recorded.
+	if priority == nil || (*priority > priorityAutoReject) != sc.IsSampled() {
+		p := priorityAutoReject
+		if sc.IsSampled() {
+			p = priorityAutoKeep
+		}
+		priority = &p
+	}
+	carrier.Set(samplingPriorityHeader, strconv.Itoa(*priority))
+	if origin != "" {
+		carrier.Set(originHeader, origin)
+	}
+}
+
+// Extract returns a copy of ctx with the span context of the Datadog headers
+// of carrier set as its remote span context. ctx is returned unchanged if
+// the headers are missing or invalid.
+func (Datadog) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	sc, err := extract(carrier)
+	if err != nil || !sc.IsValid() {
+		return ctx
+	}
+	return trace.ContextWithRemoteSpanContext(ctx, sc)
+}
+
+// Fields returns the Datadog header keys whose values are set with Inject.
+func (Datadog) Fields() []string {
+	return []string{traceIDHeader, parentIDHeader, samplingPriorityHeader, originHeader, tagsHeader}
+}
+
+func extract(carrier propagation.TextMapCarrier) (trace.SpanContext, error) {
+	var scc trace.SpanContextConfig
+
+	low, err := strconv.ParseUint(carrier.Get(traceIDHeader), 10, 64)
+	if err != nil {
+		return trace.SpanContext{}, fmt.Errorf("invalid trace ID: %w", err)
+	}
+	binary.BigEndian.PutUint64(scc.TraceID[8:], low)
+	// An invalid tag is ignored, the trace ID is then 64-bit.
+	copy(scc.TraceID[:8], traceIDHigh(carrier.Get(tagsHeader)))
+
+	parent, err := strconv.ParseUint(carrier.Get(parentIDHeader), 10, 64)
+	if err != nil {
+		return trace.SpanContext{}, fmt.Errorf("invalid parent ID: %w", err)
+	}
+	binary.BigEndian.PutUint64(scc.SpanID[:], parent)
+
+	var members []string
+	if p := carrier.Get(samplingPriorityHeader); p != "" {
+		priority, err := strconv.Atoi(p)
+		if err != nil {
+			return trace.SpanContext{}, fmt.Errorf("invalid sampling priority: %w", err)
+		}
+		if priority > priorityAutoReject {
+			scc.TraceFlags = trace.FlagsSampled
+		}
+		members = append(members, priorityPrefix+strconv.Itoa(priority))
+	}
+	if o := carrier.Get(originHeader); o != "" {
+		members = append(members, originPrefix+sanitizeOrigin(o))
+	}
+	if len(members) > 0 {
+		ts, err := trace.TraceState{}.Insert(traceStateKey, strings.Join(members, traceStateDivider))
+		if err == nil {
+			scc.TraceState = ts
+		}
+	}
+
+	return trace.NewSpanContext(scc), nil
+}
+
+// traceIDHigh returns the upper 64 bits of the trace ID propagated with the
+// _dd.p.tid tag of tags, or nil if it is missing or invalid.
+func traceIDHigh(tags string) []byte {
+	for _, tag := range strings.Split(tags, ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(tag), "=")
+		if !ok || k != traceIDHighTag || len(v) != 16 || strings.ToLower(v) != v {
+			continue
+		}
+		if high, err := hex.DecodeString(v); err == nil {
+			return high
+		}
+	}
+	return nil
+}
+
+// fromTraceState returns the sampling priority and origin recorded in the
+// Datadog member of ts. The priority is nil if none is recorded.
+func fromTraceState(ts trace.TraceState) (priority *int, origin string) {
+	for _, field := range strings.Split(ts.Get(traceStateKey), traceStateDivider) {
+		switch {
+		case strings.HasPrefix(field, priorityPrefix):
+			if p, err := strconv.Atoi(strings.TrimPrefix(field, priorityPrefix)); err == nil {
+				priority = &p
+			}
+		case strings.HasPrefix(field, originPrefix):
+			origin = strings.TrimPrefix(field, originPrefix)
+		}
+	}
+	return priority, origin
+}
+
+// sanitizeOrigin replaces the characters of origin not allowed in a
+// TraceState value, or used as separators in the Datadog member, with "_".
+func sanitizeOrigin(origin string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 || r > 0x7e || r == ',' || r == ';' || r == '=' {
+			return '_'
+		}
+		return r
+	}, origin)
+}
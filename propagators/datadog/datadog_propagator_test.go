@@ -0,0 +1,273 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/propagators/datadog"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	traceID64  = trace.TraceID{14: 0x04, 15: 0xd2}
+	traceID128 = trace.TraceID{0x64, 0x0c, 0xfd, 0x8d, 0, 0, 0, 0, 14: 0x04, 15: 0xd2}
+	spanID     = trace.SpanID{6: 0x16, 7: 0x2e}
+)
+
+func traceState(t *testing.T, s string) trace.TraceState {
+	ts, err := trace.ParseTraceState(s)
+	require.NoError(t, err)
+	return ts
+}
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    trace.SpanContextConfig
+	}{
+		{
+			name: "sampled",
+			headers: map[string]string{
+				"x-datadog-trace-id":          "1234",
+				"x-datadog-parent-id":         "5678",
+				"x-datadog-sampling-priority": "1",
+			},
+			want: trace.SpanContextConfig{
+				TraceID:    traceID64,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsSampled,
+				TraceState: traceState(t, "dd=s:1"),
+				Remote:     true,
+			},
+		},
+		{
+			name: "user reject with origin",
+			headers: map[string]string{
+				"x-datadog-trace-id":          "1234",
+				"x-datadog-parent-id":         "5678",
+				"x-datadog-sampling-priority": "-1",
+				"x-datadog-origin":            "synthetics",
+			},
+			want: trace.SpanContextConfig{
+				TraceID:    traceID64,
+				SpanID:     spanID,
+				TraceState: traceState(t, "dd=s:-1;o:synthetics"),
+				Remote:     true,
+			},
+		},
+		{
+			name: "128-bit trace ID",
+			headers: map[string]string{
+				"x-datadog-trace-id":          "1234",
+				"x-datadog-parent-id":         "5678",
+				"x-datadog-sampling-priority": "2",
+				"x-datadog-tags":              "_dd.p.dm=-4,_dd.p.tid=640cfd8d00000000",
+			},
+			want: trace.SpanContextConfig{
+				TraceID:    traceID128,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsSampled,
+				TraceState: traceState(t, "dd=s:2"),
+				Remote:     true,
+			},
+		},
+		{
+			name: "invalid trace ID tag",
+			headers: map[string]string{
+				"x-datadog-trace-id":  "1234",
+				"x-datadog-parent-id": "5678",
+				"x-datadog-tags":      "_dd.p.tid=640cfd8d0000000g",
+			},
+			want: trace.SpanContextConfig{
+				TraceID: traceID64,
+				SpanID:  spanID,
+				Remote:  true,
+			},
+		},
+		{
+			name: "missing parent ID",
+			headers: map[string]string{
+				"x-datadog-trace-id": "1234",
+			},
+		},
+		{
+			name: "zero trace ID",
+			headers: map[string]string{
+				"x-datadog-trace-id":  "0",
+				"x-datadog-parent-id": "5678",
+			},
+		},
+		{
+			name: "invalid trace ID",
+			headers: map[string]string{
+				"x-datadog-trace-id":  "-1234",
+				"x-datadog-parent-id": "5678",
+			},
+		},
+		{
+			name: "invalid sampling priority",
+			headers: map[string]string{
+				"x-datadog-trace-id":          "1234",
+				"x-datadog-parent-id":         "5678",
+				"x-datadog-sampling-priority": "keep",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			for k, v := range tt.headers {
+				header.Set(k, v)
+			}
+			ctx := datadog.Datadog{}.Extract(context.Background(), propagation.HeaderCarrier(header))
+			assert.Equal(t, trace.NewSpanContext(tt.want), trace.SpanContextFromContext(ctx))
+		})
+	}
+}
+
+func TestInject(t *testing.T) {
+	tests := []struct {
+		name string
+		scc  trace.SpanContextConfig
+		want map[string]string
+	}{
+		{
+			name: "sampled",
+			scc: trace.SpanContextConfig{
+				TraceID:    traceID64,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsSampled,
+			},
+			want: map[string]string{
+				"x-datadog-trace-id":          "1234",
+				"x-datadog-parent-id":         "5678",
+				"x-datadog-sampling-priority": "1",
+			},
+		},
+		{
+			name: "not sampled",
+			scc: trace.SpanContextConfig{
+				TraceID: traceID64,
+				SpanID:  spanID,
+			},
+			want: map[string]string{
+				"x-datadog-trace-id":          "1234",
+				"x-datadog-parent-id":         "5678",
+				"x-datadog-sampling-priority": "0",
+			},
+		},
+		{
+			name: "128-bit trace ID",
+			scc: trace.SpanContextConfig{
+				TraceID:    traceID128,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsSampled,
+			},
+			want: map[string]string{
+				"x-datadog-trace-id":          "1234",
+				"x-datadog-parent-id":         "5678",
+				"x-datadog-sampling-priority": "1",
+				"x-datadog-tags":              "_dd.p.tid=640cfd8d00000000",
+			},
+		},
+		{
+			name: "recorded priority and origin",
+			scc: trace.SpanContextConfig{
+				TraceID:    traceID64,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsSampled,
+				TraceState: traceState(t, "dd=s:2;o:rum,other=value"),
+			},
+			want: map[string]string{
+				"x-datadog-trace-id":          "1234",
+				"x-datadog-parent-id":         "5678",
+				"x-datadog-sampling-priority": "2",
+				"x-datadog-origin":            "rum",
+			},
+		},
+		{
+			name: "recorded priority overridden by sampling decision",
+			scc: trace.SpanContextConfig{
+				TraceID:    traceID64,
+				SpanID:     spanID,
+				TraceState: traceState(t, "dd=s:2"),
+			},
+			want: map[string]string{
+				"x-datadog-trace-id":          "1234",
+				"x-datadog-parent-id":         "5678",
+				"x-datadog-sampling-priority": "0",
+			},
+		},
+		{
+			name: "invalid",
+			scc: trace.SpanContextConfig{
+				TraceID: traceID64,
+			},
+			want: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(tt.scc))
+			header := http.Header{}
+			datadog.Datadog{}.Inject(ctx, propagation.HeaderCarrier(header))
+
+			got := map[string]string{}
+			for k := range header {
+				got[http.CanonicalHeaderKey(k)] = header.Get(k)
+			}
+			want := map[string]string{}
+			for k, v := range tt.want {
+				want[http.CanonicalHeaderKey(k)] = v
+			}
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	header := http.Header{}
+	header.Set("x-datadog-trace-id", "1234")
+	header.Set("x-datadog-parent-id", "5678")
+	header.Set("x-datadog-sampling-priority", "2")
+	header.Set("x-datadog-origin", "synthetics")
+	header.Set("x-datadog-tags", "_dd.p.tid=640cfd8d00000000")
+
+	p := datadog.Datadog{}
+	ctx := p.Extract(context.Background(), propagation.HeaderCarrier(header))
+	got := http.Header{}
+	p.Inject(ctx, propagation.HeaderCarrier(got))
+	assert.Equal(t, header, got)
+}
+
+func TestFields(t *testing.T) {
+	assert.Equal(t, []string{
+		"x-datadog-trace-id",
+		"x-datadog-parent-id",
+		"x-datadog-sampling-priority",
+		"x-datadog-origin",
+		"x-datadog-tags",
+	}, datadog.Datadog{}.Fields())
+}
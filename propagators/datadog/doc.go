@@ -0,0 +1,24 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package datadog implements a propagator of the headers used by Datadog
+// tracing libraries, so services receiving requests from, or sending requests
+// to, Datadog instrumented services are part of the same traces.
+//
+// Datadog trace IDs are 64-bit, the upper 64 bits of 128-bit trace IDs are
+// propagated with the _dd.p.tid tag of the x-datadog-tags header. The
+// sampling priority and origin of a trace are recorded in the "dd" member of
+// the TraceState, as done by Datadog tracing libraries when propagating W3C
+// Trace Context, so they are propagated unchanged to downstream services.
+package datadog // import "go.opentelemetry.io/contrib/propagators/datadog"
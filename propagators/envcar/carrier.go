@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package envcar provides a TextMapCarrier propagating context with
+// environment variables, as defined by the OpenTelemetry specification:
+// https://opentelemetry.io/docs/specs/otel/context/env-carriers/
+//
+// Propagation fields are mapped to upper-cased environment variable names,
+// e.g. the traceparent, tracestate and baggage fields of the W3C propagators
+// are the TRACEPARENT, TRACESTATE and BAGGAGE environment variables. This
+// allows propagating context to spawned processes, e.g. batch jobs or
+// command line tools, that do not receive requests.
+package envcar // import "go.opentelemetry.io/contrib/propagators/envcar"
+
+import (
+	"context"
+	"os"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// Carrier is a TextMapCarrier reading the environment variables of the
+// current process.
+//
+// Use it to extract the context a process was started with:
+//
+//	ctx = otel.GetTextMapPropagator().Extract(ctx, envcar.Carrier{})
+type Carrier struct {
+	// SetEnvFunc is called by Set with the environment variable name and
+	// value of a propagation field, e.g. to add it to the environment of a
+	// process to spawn. If nil, Set does nothing: the environment of the
+	// current process is never modified.
+	SetEnvFunc func(name, value string)
+}
+
+var _ propagation.TextMapCarrier = Carrier{}
+
+// Get returns the value of the environment variable of the key propagation
+// field.
+func (c Carrier) Get(key string) string {
+	return os.Getenv(Name(key))
+}
+
+// Set calls SetEnvFunc with the environment variable name of the key
+// propagation field and value.
+func (c Carrier) Set(key, value string) {
+	if c.SetEnvFunc == nil {
+		return
+	}
+	c.SetEnvFunc(Name(key), value)
+}
+
+// Keys returns the names of the environment variables of the current
+// process.
+func (c Carrier) Keys() []string {
+	env := os.Environ()
+	keys := make([]string, 0, len(env))
+	for _, kv := range env {
+		if name, _, ok := strings.Cut(kv, "="); ok && name != "" {
+			keys = append(keys, name)
+		}
+	}
+	return keys
+}
+
+// Name returns the environment variable name of a propagation field: the
+// field name upper-cased, with the characters not allowed in portable
+// environment variable names replaced by an underscore.
+func Name(field string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case 'a' <= r && r <= 'z':
+			return r - 'a' + 'A'
+		case 'A' <= r && r <= 'Z', '0' <= r && r <= '9', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, field)
+}
+
+// Environ returns a copy of env, in the "key=value" form of os.Environ, with
+// the propagation fields of ctx injected by p added. Variables of env with
+// the same name are replaced. If env is nil, the environment of the current
+// process is used.
+//
+// The result is meant to be used as the environment of a process to spawn,
+// e.g. the Env field of an exec.Cmd.
+func Environ(ctx context.Context, p propagation.TextMapPropagator, env []string) []string {
+	vars := map[string]string{}
+	p.Inject(ctx, Carrier{SetEnvFunc: func(name, value string) {
+		vars[name] = value
+	}})
+
+	if env == nil {
+		env = os.Environ()
+	}
+	out := make([]string, 0, len(env)+len(vars))
+	for _, kv := range env {
+		name, _, _ := strings.Cut(kv, "=")
+		if _, ok := vars[name]; ok {
+			continue
+		}
+		out = append(out, kv)
+	}
+	for _, name := range sortedKeys(vars) {
+		out = append(out, name+"="+vars[name])
+	}
+	return out
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
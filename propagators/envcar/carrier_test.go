@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package envcar_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/propagators/envcar"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	prop = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+
+	sc = trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+)
+
+const (
+	traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	bag         = "key=value"
+)
+
+func contextWithBaggage(t *testing.T) context.Context {
+	b, err := baggage.Parse(bag)
+	require.NoError(t, err)
+	ctx := baggage.ContextWithBaggage(context.Background(), b)
+	return trace.ContextWithSpanContext(ctx, sc)
+}
+
+func TestCarrierExtract(t *testing.T) {
+	t.Setenv("TRACEPARENT", traceparent)
+	t.Setenv("BAGGAGE", bag)
+
+	ctx := prop.Extract(context.Background(), envcar.Carrier{})
+	assert.Equal(t, sc, trace.SpanContextFromContext(ctx))
+	assert.Equal(t, "value", baggage.FromContext(ctx).Member("key").Value())
+}
+
+func TestCarrierInject(t *testing.T) {
+	got := map[string]string{}
+	c := envcar.Carrier{SetEnvFunc: func(name, value string) { got[name] = value }}
+	prop.Inject(contextWithBaggage(t), c)
+	assert.Equal(t, map[string]string{"TRACEPARENT": traceparent, "BAGGAGE": bag}, got)
+
+	// Without SetEnvFunc the environment is not modified.
+	t.Setenv("TRACEPARENT", "")
+	envcar.Carrier{}.Set("traceparent", traceparent)
+	assert.Empty(t, envcar.Carrier{}.Get("traceparent"))
+}
+
+func TestCarrierKeys(t *testing.T) {
+	t.Setenv("OTEL_ENVCAR_TEST", "value=1")
+	assert.Contains(t, envcar.Carrier{}.Keys(), "OTEL_ENVCAR_TEST")
+}
+
+func TestName(t *testing.T) {
+	assert.Equal(t, "TRACEPARENT", envcar.Name("traceparent"))
+	assert.Equal(t, "X_B3_TRACEID", envcar.Name("x-b3-traceid"))
+	assert.Equal(t, "UBER_TRACE_ID", envcar.Name("Uber-Trace-Id"))
+	assert.Equal(t, "OT_BAGGAGE__", envcar.Name("ot-baggage-é"))
+}
+
+func TestEnviron(t *testing.T) {
+	env := []string{"PATH=/bin", "TRACEPARENT=stale", "EMPTY="}
+	got := envcar.Environ(contextWithBaggage(t), prop, env)
+	assert.Equal(t, []string{"PATH=/bin", "EMPTY=", "BAGGAGE=" + bag, "TRACEPARENT=" + traceparent}, got)
+	assert.Equal(t, []string{"PATH=/bin", "TRACEPARENT=stale", "EMPTY="}, env, "env modified")
+
+	t.Setenv("OTEL_ENVCAR_TEST", "value")
+	got = envcar.Environ(context.Background(), prop, nil)
+	assert.Contains(t, got, "OTEL_ENVCAR_TEST=value")
+}
@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package envcar_test
+
+import (
+	"context"
+	"os/exec"
+
+	"go.opentelemetry.io/contrib/propagators/envcar"
+	"go.opentelemetry.io/otel"
+)
+
+func ExampleEnviron() {
+	ctx, span := otel.Tracer("example").Start(context.Background(), "run job")
+	defer span.End()
+
+	// The job continues the trace by extracting the context from its
+	// TRACEPARENT, TRACESTATE and BAGGAGE environment variables.
+	cmd := exec.CommandContext(ctx, "job")
+	cmd.Env = envcar.Environ(ctx, otel.GetTextMapPropagator(), nil)
+	_ = cmd.Run()
+}
+
+func ExampleCarrier() {
+	// Continue the trace of the parent process.
+	ctx := otel.GetTextMapPropagator().Extract(context.Background(), envcar.Carrier{})
+	_, span := otel.Tracer("example").Start(ctx, "job")
+	defer span.End()
+}
@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaeger // import "go.opentelemetry.io/contrib/propagators/jaeger"
+
+// DefaultBaggagePrefix is the prefix of the headers used by Jaeger clients
+// to propagate baggage items, i.e. uberctx-{key}: {value}.
+const DefaultBaggagePrefix = "uberctx-"
+
+type config struct {
+	// BaggagePrefix is the prefix of the headers baggage members are
+	// propagated with. Baggage is not propagated if empty.
+	BaggagePrefix string
+
+	// TraceID64Bit determines if only the lower 64 bits of trace IDs are
+	// injected.
+	TraceID64Bit bool
+}
+
+// Option interface used for setting optional config properties.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (o optionFunc) apply(c *config) {
+	o(c)
+}
+
+// newConfig creates a new config struct and applies opts to it.
+func newConfig(opts ...Option) *config {
+	c := &config{}
+	for _, opt := range opts {
+		opt.apply(c)
+	}
+	return c
+}
+
+// WithBaggagePrefix configures the propagator to propagate the Baggage of
+// the context with one header per member, named prefix followed by the
+// member key. Use DefaultBaggagePrefix to interoperate with Jaeger clients
+// using their default configuration.
+//
+// When extracting, the comma-separated jaeger-baggage header, e.g.
+//
+//	jaeger-baggage: k1=v1, k2=v2
+//
+// is also accepted. Headers matching the prefix take precedence over it.
+func WithBaggagePrefix(prefix string) Option {
+	return optionFunc(func(c *config) {
+		c.BaggagePrefix = prefix
+	})
+}
+
+// WithTraceID64Bit configures the propagator to inject 64-bit trace IDs, the
+// right-most 64 bits of the 128-bit trace ID, for legacy Jaeger agents and
+// clients that do not support 128-bit trace IDs. Both widths are accepted
+// when extracting regardless of this option.
+//
+// Peers only using the propagated 64-bit trace ID will not be part of the
+// same trace as the spans of this service.
+func WithTraceID64Bit() Option {
+	return optionFunc(func(c *config) {
+		c.TraceID64Bit = true
+	})
+}
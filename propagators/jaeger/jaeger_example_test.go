@@ -24,3 +24,10 @@ func ExampleJaeger() {
 	// register jaeger propagator
 	otel.SetTextMapPropagator(p)
 }
+
+func ExampleNew_baggage() {
+	// Create a Jaeger propagator that also propagates baggage with the
+	// headers used by Jaeger clients, i.e. uberctx-{key}: {value}.
+	p := jaeger.New(jaeger.WithBaggagePrefix(jaeger.DefaultBaggagePrefix))
+	otel.SetTextMapPropagator(p)
+}
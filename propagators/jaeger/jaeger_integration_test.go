@@ -17,6 +17,7 @@ package jaeger_test
 import (
 	"context"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -24,6 +25,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 
 	"go.opentelemetry.io/contrib/propagators/jaeger"
+	"go.opentelemetry.io/otel/baggage"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -107,3 +109,106 @@ func TestInjectJaeger(t *testing.T) {
 		}
 	}
 }
+
+func TestJaegerBaggage(t *testing.T) {
+	tests := []struct {
+		name    string
+		prefix  string
+		headers map[string]string
+		want    map[string]string
+	}{
+		{
+			name:   "default prefix",
+			prefix: jaeger.DefaultBaggagePrefix,
+			headers: map[string]string{
+				"uberctx-key1": "value1",
+				"uberctx-key2": "value%202",
+				"other-key3":   "value3",
+			},
+			want: map[string]string{
+				"key1": "value1",
+				"key2": "value 2",
+			},
+		},
+		{
+			name:   "custom prefix",
+			prefix: "Baggage-",
+			headers: map[string]string{
+				"baggage-key1": "value1",
+				"uberctx-key2": "value2",
+			},
+			want: map[string]string{
+				"key1": "value1",
+			},
+		},
+		{
+			name:   "jaeger-baggage header",
+			prefix: jaeger.DefaultBaggagePrefix,
+			headers: map[string]string{
+				"jaeger-baggage": "key1=value1, key2=value2,invalid",
+				"uberctx-key2":   "prefixed",
+			},
+			want: map[string]string{
+				"key1": "value1",
+				"key2": "prefixed",
+			},
+		},
+		{
+			name: "disabled",
+			headers: map[string]string{
+				"uberctx-key1":   "value1",
+				"jaeger-baggage": "key2=value2",
+			},
+			want: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := make(http.Header, len(tt.headers))
+			for k, v := range tt.headers {
+				header.Set(k, v)
+			}
+
+			propagator := jaeger.New(jaeger.WithBaggagePrefix(tt.prefix))
+			ctx := propagator.Extract(context.Background(), propagation.HeaderCarrier(header))
+			got := make(map[string]string)
+			for _, m := range baggage.FromContext(ctx).Members() {
+				got[m.Key()] = m.Value()
+			}
+			assert.Equal(t, tt.want, got)
+
+			injected := http.Header{}
+			propagator.Inject(ctx, propagation.HeaderCarrier(injected))
+			assert.Len(t, injected, len(tt.want))
+			for k, v := range tt.want {
+				assert.Equal(t, strings.ReplaceAll(v, " ", "+"), injected.Get(tt.prefix+k))
+			}
+		})
+	}
+}
+
+func TestJaegerTraceID64Bit(t *testing.T) {
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+
+	header := http.Header{}
+	jaeger.Jaeger{}.Inject(ctx, propagation.HeaderCarrier(header))
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736:00f067aa0ba902b7:0:1", header.Get("uber-trace-id"))
+
+	propagator := jaeger.New(jaeger.WithTraceID64Bit())
+	header = http.Header{}
+	propagator.Inject(ctx, propagation.HeaderCarrier(header))
+	assert.Equal(t, "a3ce929d0e0e4736:00f067aa0ba902b7:0:1", header.Get("uber-trace-id"))
+
+	// Both widths are extracted.
+	got := trace.SpanContextFromContext(propagator.Extract(context.Background(), propagation.HeaderCarrier(header)))
+	assert.Equal(t, trace.TraceID{8: 0xa3, 9: 0xce, 10: 0x92, 11: 0x9d, 12: 0x0e, 13: 0x0e, 14: 0x47, 15: 0x36}, got.TraceID())
+	header.Set("uber-trace-id", "4bf92f3577b34da6a3ce929d0e0e4736:00f067aa0ba902b7:0:1")
+	got = trace.SpanContextFromContext(propagator.Extract(context.Background(), propagation.HeaderCarrier(header)))
+	assert.Equal(t, sc.TraceID(), got.TraceID())
+}
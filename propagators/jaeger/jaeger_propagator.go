@@ -18,17 +18,21 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
 	"strconv"
 	"strings"
 
+	"go.opentelemetry.io/otel/baggage"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 )
 
 const (
 	jaegerHeader        = "uber-trace-id"
+	jaegerBaggageHeader = "jaeger-baggage"
 	separator           = ":"
 	traceID128bitsWidth = 128 / 4
+	traceID64bitsWidth  = 64 / 4
 	spanIDWidth         = 64 / 4
 
 	idPaddingChar = "0"
@@ -56,19 +60,39 @@ var (
 // Jaeger format:
 //
 // uber-trace-id: {trace-id}:{span-id}:{parent-span-id}:{flags}.
-type Jaeger struct{}
+//
+// The zero value Jaeger only propagates the uber-trace-id header, use New to
+// configure it.
+type Jaeger struct {
+	cfg config
+}
 
 var _ propagation.TextMapPropagator = &Jaeger{}
 
+// New returns a Jaeger propagator configured with opts.
+func New(opts ...Option) Jaeger {
+	return Jaeger{cfg: *newConfig(opts...)}
+}
+
 // Inject injects a context to the carrier following jaeger format.
 // The parent span ID is set to an dummy parent span id as the most implementations do.
 func (jaeger Jaeger) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	if prefix := jaeger.cfg.BaggagePrefix; prefix != "" {
+		for _, m := range baggage.FromContext(ctx).Members() {
+			carrier.Set(prefix+m.Key(), url.QueryEscape(m.Value()))
+		}
+	}
+
 	sc := trace.SpanFromContext(ctx).SpanContext()
 	headers := []string{}
 	if !sc.TraceID().IsValid() || !sc.SpanID().IsValid() {
 		return
 	}
-	headers = append(headers, sc.TraceID().String(), sc.SpanID().String(), deprecatedParentSpanID)
+	traceID := sc.TraceID().String()
+	if jaeger.cfg.TraceID64Bit {
+		traceID = traceID[traceID128bitsWidth-traceID64bitsWidth:]
+	}
+	headers = append(headers, traceID, sc.SpanID().String(), deprecatedParentSpanID)
 	if debugFromContext(ctx) {
 		headers = append(headers, fmt.Sprintf("%x", flagsDebug|flagsSampled))
 	} else if sc.IsSampled() {
@@ -82,6 +106,10 @@ func (jaeger Jaeger) Inject(ctx context.Context, carrier propagation.TextMapCarr
 
 // Extract extracts a context from the carrier if it contains Jaeger headers.
 func (jaeger Jaeger) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	if prefix := jaeger.cfg.BaggagePrefix; prefix != "" {
+		ctx = extractBaggage(ctx, carrier, prefix)
+	}
+
 	// extract tracing information
 	if h := carrier.Get(jaegerHeader); h != "" {
 		ctx, sc, err := extract(ctx, h)
@@ -93,6 +121,51 @@ func (jaeger Jaeger) Extract(ctx context.Context, carrier propagation.TextMapCar
 	return ctx
 }
 
+// extractBaggage returns a copy of ctx with the baggage items propagated in
+// carrier added to its Baggage. Invalid items are ignored.
+func extractBaggage(ctx context.Context, carrier propagation.TextMapCarrier, prefix string) context.Context {
+	var members []baggage.Member
+
+	// Jaeger clients allow setting baggage items with the jaeger-baggage
+	// header, e.g. for requests sent with curl.
+	if h := carrier.Get(jaegerBaggageHeader); h != "" {
+		if h, err := url.QueryUnescape(h); err == nil {
+			for _, kv := range strings.Split(h, ",") {
+				k, v, ok := strings.Cut(strings.TrimSpace(kv), "=")
+				if !ok {
+					continue
+				}
+				if m, err := baggage.NewMember(k, url.QueryEscape(v)); err == nil {
+					members = append(members, m)
+				}
+			}
+		}
+	}
+
+	prefix = strings.ToLower(prefix)
+	for _, k := range carrier.Keys() {
+		// Carriers, e.g. propagation.HeaderCarrier, may canonicalize keys.
+		key := strings.ToLower(k)
+		if !strings.HasPrefix(key, prefix) || len(key) == len(prefix) {
+			continue
+		}
+		if m, err := baggage.NewMember(key[len(prefix):], carrier.Get(k)); err == nil {
+			members = append(members, m)
+		}
+	}
+
+	if len(members) == 0 {
+		return ctx
+	}
+	b := baggage.FromContext(ctx)
+	for _, m := range members {
+		if next, err := b.SetMember(m); err == nil {
+			b = next
+		}
+	}
+	return baggage.ContextWithBaggage(ctx, b)
+}
+
 func extract(ctx context.Context, headerVal string) (context.Context, trace.SpanContext, error) {
 	var (
 		scc = trace.SpanContextConfig{}
@@ -162,6 +235,9 @@ func extract(ctx context.Context, headerVal string) (context.Context, trace.Span
 }
 
 // Fields returns the Jaeger header key whose value is set with Inject.
+//
+// Baggage headers are not included as their names depend on the propagated
+// Baggage.
 func (jaeger Jaeger) Fields() []string {
 	return []string{jaegerHeader}
 }
@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencensus // import "go.opentelemetry.io/contrib/propagators/opencensus"
+
+import (
+	"context"
+
+	"google.golang.org/grpc/metadata"
+
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// GRPCMetadataKey is the gRPC metadata key OpenCensus gRPC instrumentation
+// propagates the binary encoded span context with.
+const GRPCMetadataKey = binaryHeader
+
+// metadataCarrier adapts metadata.MD to the TextMapCarrier interface. gRPC
+// encodes the binary values of keys with the "-bin" suffix itself.
+type metadataCarrier metadata.MD
+
+var _ propagation.TextMapCarrier = metadataCarrier{}
+
+func (c metadataCarrier) Get(key string) string {
+	if v := metadata.MD(c).Get(key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// InjectMetadata injects the binary encoded span context of ctx into md
+// with the GRPCMetadataKey key.
+func (b Binary) InjectMetadata(ctx context.Context, md metadata.MD) {
+	b.Inject(ctx, metadataCarrier(md))
+}
+
+// ExtractMetadata extracts the binary encoded span context stored with the
+// GRPCMetadataKey key of md, and returns a copy of ctx with it set as the
+// remote span context.
+func (b Binary) ExtractMetadata(ctx context.Context, md metadata.MD) context.Context {
+	return b.Extract(ctx, metadataCarrier(md))
+}
+
+// OutgoingContext returns a copy of ctx with the binary encoded span context
+// of ctx added to its outgoing gRPC metadata. It is meant to be used by gRPC
+// clients calling services instrumented with OpenCensus.
+func (b Binary) OutgoingContext(ctx context.Context) context.Context {
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+	b.InjectMetadata(ctx, md)
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// IncomingContext returns a copy of ctx with the span context extracted from
+// its incoming gRPC metadata set as the remote span context. It is meant to
+// be used by gRPC servers called by clients instrumented with OpenCensus.
+func (b Binary) IncomingContext(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	return b.ExtractMetadata(ctx, md)
+}
@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencensus
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestMetadata(t *testing.T) {
+	prop := Binary{}
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+
+	md := metadata.MD{}
+	prop.InjectMetadata(ctx, md)
+	want := fmt.Sprintf(headerFmt, "\x01", "\x01")
+	if got := md.Get(GRPCMetadataKey); len(got) != 1 || got[0] != want {
+		t.Fatalf("Got metadata = %q, want %q", got, want)
+	}
+
+	got := trace.SpanContextFromContext(prop.ExtractMetadata(context.Background(), md))
+	if !got.Equal(sc.WithRemote(true)) {
+		t.Errorf("Got span context = %v, want %v", got, sc)
+	}
+}
+
+func TestGRPCContext(t *testing.T) {
+	prop := Binary{}
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  spanID,
+	})
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "key", "value")
+	ctx = trace.ContextWithSpanContext(ctx, sc)
+
+	ctx = prop.OutgoingContext(ctx)
+	md, _ := metadata.FromOutgoingContext(ctx)
+	if got := md.Get("key"); len(got) != 1 || got[0] != "value" {
+		t.Errorf("Got metadata key = %q, want %q", got, "value")
+	}
+
+	// The outgoing metadata of the client is the incoming metadata of the
+	// server.
+	ctx = metadata.NewIncomingContext(context.Background(), md)
+	got := trace.SpanContextFromContext(prop.IncomingContext(ctx))
+	if !got.Equal(sc.WithRemote(true)) {
+		t.Errorf("Got span context = %v, want %v", got, sc)
+	}
+
+	got = trace.SpanContextFromContext(prop.IncomingContext(context.Background()))
+	if got.IsValid() {
+		t.Errorf("Got span context = %v, want an invalid one", got)
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ot // import "go.opentelemetry.io/contrib/propagators/ot"
+
+import "strings"
+
+type config struct {
+	// MaxBaggageMembers is the maximum number of baggage members extracted.
+	// There is no limit if it is not positive.
+	MaxBaggageMembers int
+
+	// MaxBaggageBytes is the maximum combined length of the keys and values
+	// of the baggage members extracted. There is no limit if it is not
+	// positive.
+	MaxBaggageBytes int
+
+	// BaggageKeys are the baggage keys extracted. All keys are extracted if
+	// it is nil.
+	BaggageKeys map[string]struct{}
+}
+
+// Option interface used for setting optional config properties.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (o optionFunc) apply(c *config) {
+	o(c)
+}
+
+// newConfig creates a new config struct and applies opts to it.
+func newConfig(opts ...Option) *config {
+	c := &config{}
+	for _, opt := range opts {
+		opt.apply(c)
+	}
+	return c
+}
+
+// WithMaxBaggageMembers limits the number of baggage members extracted from
+// ot-baggage-* headers to n. Members are extracted in the order of their
+// keys, the ones exceeding the limit are dropped.
+func WithMaxBaggageMembers(n int) Option {
+	return optionFunc(func(c *config) {
+		c.MaxBaggageMembers = n
+	})
+}
+
+// WithMaxBaggageBytes limits the combined length of the keys and values of
+// the baggage members extracted from ot-baggage-* headers to n bytes.
+// Members are extracted in the order of their keys, the ones exceeding the
+// limit are dropped.
+func WithMaxBaggageBytes(n int) Option {
+	return optionFunc(func(c *config) {
+		c.MaxBaggageBytes = n
+	})
+}
+
+// WithBaggageKeys restricts the baggage members extracted from ot-baggage-*
+// headers to the ones with the passed keys. Keys are case-insensitive.
+func WithBaggageKeys(keys ...string) Option {
+	return optionFunc(func(c *config) {
+		if c.BaggageKeys == nil {
+			c.BaggageKeys = make(map[string]struct{}, len(keys))
+		}
+		for _, k := range keys {
+			c.BaggageKeys[strings.ToLower(k)] = struct{}{}
+		}
+	})
+}
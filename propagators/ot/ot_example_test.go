@@ -24,3 +24,14 @@ func ExampleOT() {
 	// register ot propagator
 	otel.SetTextMapPropagator(otPropagator)
 }
+
+func ExampleNew() {
+	// Create an OT propagator only extracting up to 8 members of known
+	// baggage keys from the ot-baggage-* headers.
+	otPropagator := ot.New(
+		ot.WithBaggageKeys("tenant", "user"),
+		ot.WithMaxBaggageMembers(8),
+		ot.WithMaxBaggageBytes(1024),
+	)
+	otel.SetTextMapPropagator(otPropagator)
+}
@@ -126,3 +126,67 @@ func TestInjectOT(t *testing.T) {
 		}
 	}
 }
+
+func TestExtractOTBaggageLimits(t *testing.T) {
+	headers := map[string]string{
+		traceIDHeader:     traceID16Str,
+		spanIDHeader:      spanIDStr,
+		sampledHeader:     "true",
+		"ot-baggage-key1": "value1",
+		"ot-baggage-key2": "value2",
+		"ot-baggage-key3": "value3",
+	}
+
+	tests := []struct {
+		name string
+		opts []ot.Option
+		want map[string]string
+	}{
+		{
+			name: "no limits",
+			want: map[string]string{"key1": "value1", "key2": "value2", "key3": "value3"},
+		},
+		{
+			name: "max members",
+			opts: []ot.Option{ot.WithMaxBaggageMembers(2)},
+			want: map[string]string{"key1": "value1", "key2": "value2"},
+		},
+		{
+			name: "max bytes",
+			opts: []ot.Option{ot.WithMaxBaggageBytes(25)},
+			want: map[string]string{"key1": "value1", "key2": "value2"},
+		},
+		{
+			name: "keys",
+			opts: []ot.Option{ot.WithBaggageKeys("KEY1", "key3", "key4")},
+			want: map[string]string{"key1": "value1", "key3": "value3"},
+		},
+		{
+			name: "keys and max members",
+			opts: []ot.Option{ot.WithBaggageKeys("key2", "key3"), ot.WithMaxBaggageMembers(1)},
+			want: map[string]string{"key2": "value2"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			h := make(http.Header, len(headers))
+			for k, v := range headers {
+				h.Set(k, v)
+			}
+
+			ctx := ot.New(tc.opts...).Extract(context.Background(), propagation.HeaderCarrier(h))
+			if !trace.SpanContextFromContext(ctx).IsValid() {
+				t.Fatal("invalid span context extracted")
+			}
+
+			got := map[string]string{}
+			for _, m := range baggage.FromContext(ctx).Members() {
+				got[m.Key()] = m.Value()
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("-want +got %s", diff)
+			}
+		})
+	}
+}
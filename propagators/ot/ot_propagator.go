@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"go.uber.org/multierr"
@@ -49,10 +50,20 @@ var (
 )
 
 // OT propagator serializes SpanContext to/from ot-trace-* headers.
-type OT struct{}
+//
+// The zero value OT extracts all the ot-baggage-* headers, use New to limit
+// the baggage extracted.
+type OT struct {
+	cfg *config
+}
 
 var _ propagation.TextMapPropagator = OT{}
 
+// New returns an OT propagator configured with opts.
+func New(opts ...Option) OT {
+	return OT{cfg: newConfig(opts...)}
+}
+
 // Inject injects a context into the carrier as OT headers.
 // NOTE: In order to interop with systems that use the OT header format, trace ids MUST be 64-bits.
 func (o OT) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
@@ -94,7 +105,11 @@ func (o OT) Extract(ctx context.Context, carrier propagation.TextMapCarrier) con
 		return ctx
 	}
 
-	bags, err := extractBags(carrier)
+	cfg := o.cfg
+	if cfg == nil {
+		cfg = &config{}
+	}
+	bags, err := extractBags(carrier, cfg)
 	if err != nil {
 		return trace.ContextWithRemoteSpanContext(ctx, sc)
 	}
@@ -107,22 +122,47 @@ func (o OT) Fields() []string {
 	return []string{traceIDHeader, spanIDHeader, sampledHeader}
 }
 
-// extractBags extracts OpenTracing baggage information from carrier.
-func extractBags(carrier propagation.TextMapCarrier) (baggage.Baggage, error) {
-	var err error
-	var members []baggage.Member
+// extractBags extracts OpenTracing baggage information from carrier within
+// the limits of cfg.
+func extractBags(carrier propagation.TextMapCarrier, cfg *config) (baggage.Baggage, error) {
+	var keys []string
 	for _, key := range carrier.Keys() {
 		lowerKey := strings.ToLower(key)
 		if !strings.HasPrefix(lowerKey, baggageHeaderPrefix) {
 			continue
 		}
-		strippedKey := strings.TrimPrefix(lowerKey, baggageHeaderPrefix)
-		member, e := baggage.NewMember(strippedKey, carrier.Get(key))
+		if cfg.BaggageKeys != nil {
+			if _, ok := cfg.BaggageKeys[strings.TrimPrefix(lowerKey, baggageHeaderPrefix)]; !ok {
+				continue
+			}
+		}
+		keys = append(keys, key)
+	}
+	// Extract members in a deterministic order as the ones exceeding limits
+	// are dropped.
+	sort.Strings(keys)
+
+	var (
+		err     error
+		members []baggage.Member
+		size    int
+	)
+	for _, key := range keys {
+		if cfg.MaxBaggageMembers > 0 && len(members) >= cfg.MaxBaggageMembers {
+			break
+		}
+		strippedKey := strings.TrimPrefix(strings.ToLower(key), baggageHeaderPrefix)
+		value := carrier.Get(key)
+		if cfg.MaxBaggageBytes > 0 && size+len(strippedKey)+len(value) > cfg.MaxBaggageBytes {
+			continue
+		}
+		member, e := baggage.NewMember(strippedKey, value)
 		if e != nil {
 			err = multierr.Append(err, e)
 			continue
 		}
 		members = append(members, member)
+		size += len(strippedKey) + len(value)
 	}
 	bags, e := baggage.New(members...)
 	if err != nil {
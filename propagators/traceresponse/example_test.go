@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traceresponse_test
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"go.opentelemetry.io/contrib/propagators/traceresponse"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+func ExampleTraceResponse_server() {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Inject the span context of the server span, e.g. started by
+		// otelhttp, before writing the response headers.
+		traceresponse.TraceResponse{}.Inject(r.Context(), propagation.HeaderCarrier(w.Header()))
+		w.WriteHeader(http.StatusOK)
+	})
+	_ = http.ListenAndServe(":8080", handler)
+}
+
+func ExampleTraceResponse_client() {
+	ctx, span := otel.Tracer("client").Start(context.Background(), "request")
+	defer span.End()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://localhost:8080", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	// Correlate the request with the trace of the server.
+	ctx = traceresponse.TraceResponse{}.Extract(ctx, propagation.HeaderCarrier(resp.Header))
+	if sc := traceresponse.SpanContextFromContext(ctx); sc.IsValid() {
+		log.Printf("request processed by span %s of trace %s", sc.SpanID(), sc.TraceID())
+	}
+}
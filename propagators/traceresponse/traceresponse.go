@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package traceresponse implements the traceresponse header of the W3C Trace
+// Context Level 2 specification:
+// https://w3c.github.io/trace-context/#traceresponse-header
+//
+// Servers inject their span context into response carriers, e.g. the
+// headers of an HTTP response, and clients, browsers or gateways extract it
+// to correlate a request with the trace of the server processing it.
+package traceresponse // import "go.opentelemetry.io/contrib/propagators/traceresponse"
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	traceResponseHeader = "traceresponse"
+
+	supportedVersion  = 0
+	maxVersion        = 254
+	versionWidth      = 2
+	traceIDWidth      = 32
+	spanIDWidth       = 16
+	flagsWidth        = 2
+	separator         = "-"
+	traceResponseSize = versionWidth + traceIDWidth + spanIDWidth + flagsWidth + 3*len(separator)
+)
+
+type keyType int
+
+const spanContextKey keyType = 0
+
+// TraceResponse is a propagator of the W3C traceresponse header.
+//
+// Unlike the request propagators, the extracted span context is not the
+// remote parent of the spans started by the client, it identifies the span
+// of the server having processed the request. It is retrieved with
+// SpanContextFromContext, e.g. to record it or link it to other spans.
+//
+// Example traceresponse header:
+//
+//	traceresponse: 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01
+type TraceResponse struct{}
+
+var _ propagation.TextMapPropagator = TraceResponse{}
+
+// Inject sets the span context of ctx as the traceresponse header of carrier.
+// Nothing is injected if ctx does not hold a valid span context.
+func (TraceResponse) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return
+	}
+	h := fmt.Sprintf("%.2x-%s-%s-%s",
+		supportedVersion,
+		sc.TraceID(),
+		sc.SpanID(),
+		sc.TraceFlags()&trace.FlagsSampled,
+	)
+	carrier.Set(traceResponseHeader, h)
+}
+
+// Extract returns a copy of ctx holding the span context of the
+// traceresponse header of carrier, if valid. It does not change the span
+// context of ctx.
+func (TraceResponse) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	sc := extract(carrier.Get(traceResponseHeader))
+	if !sc.IsValid() {
+		return ctx
+	}
+	return context.WithValue(ctx, spanContextKey, sc)
+}
+
+// Fields returns the keys whose values are set with Inject.
+func (TraceResponse) Fields() []string {
+	return []string{traceResponseHeader}
+}
+
+// SpanContextFromContext returns the span context extracted from a
+// traceresponse header stored in ctx. An invalid span context is returned
+// if there is none.
+func SpanContextFromContext(ctx context.Context) trace.SpanContext {
+	if sc, ok := ctx.Value(spanContextKey).(trace.SpanContext); ok {
+		return sc
+	}
+	return trace.SpanContext{}
+}
+
+// extract parses h, returning an invalid span context if it is not a valid
+// traceresponse header value.
+func extract(h string) trace.SpanContext {
+	if len(h) < traceResponseSize {
+		return trace.SpanContext{}
+	}
+	parts := strings.Split(h, separator)
+	if len(parts) < 4 || len(parts[0]) != versionWidth || strings.ToLower(h) != h {
+		return trace.SpanContext{}
+	}
+
+	ver, err := hex.DecodeString(parts[0])
+	if err != nil || int(ver[0]) > maxVersion {
+		return trace.SpanContext{}
+	}
+	// Future versions may append fields, only the ones of version 00 are
+	// used.
+	if ver[0] == supportedVersion && len(parts) != 4 {
+		return trace.SpanContext{}
+	}
+	if len(parts[1]) != traceIDWidth || len(parts[2]) != spanIDWidth || len(parts[3]) != flagsWidth {
+		return trace.SpanContext{}
+	}
+
+	var scc trace.SpanContextConfig
+	if scc.TraceID, err = trace.TraceIDFromHex(parts[1]); err != nil {
+		return trace.SpanContext{}
+	}
+	if scc.SpanID, err = trace.SpanIDFromHex(parts[2]); err != nil {
+		return trace.SpanContext{}
+	}
+	flags, err := hex.DecodeString(parts[3])
+	if err != nil {
+		return trace.SpanContext{}
+	}
+	scc.TraceFlags = trace.TraceFlags(flags[0]) & trace.FlagsSampled
+	scc.Remote = true
+	return trace.NewSpanContext(scc)
+}
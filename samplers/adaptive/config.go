@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adaptive // import "go.opentelemetry.io/contrib/samplers/adaptive"
+
+import "time"
+
+const (
+	defaultAdjustmentInterval = 10 * time.Second
+	defaultMinProbability     = 1e-5
+	defaultInitialProbability = 1
+	defaultMaxOperations      = 256
+)
+
+type config struct {
+	interval           time.Duration
+	minProbability     float64
+	initialProbability float64
+	maxOperations      int
+	now                func() time.Time
+}
+
+// Option applies configuration settings to a sampler.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (fn optionFunc) apply(c *config) {
+	fn(c)
+}
+
+// newConfig returns the config of a sampler with opts applied.
+func newConfig(opts ...Option) config {
+	c := config{
+		interval:           defaultAdjustmentInterval,
+		minProbability:     defaultMinProbability,
+		initialProbability: defaultInitialProbability,
+		maxOperations:      defaultMaxOperations,
+		now:                time.Now,
+	}
+	for _, opt := range opts {
+		opt.apply(&c)
+	}
+	if c.interval <= 0 {
+		c.interval = defaultAdjustmentInterval
+	}
+	c.minProbability = clamp(c.minProbability, 0, 1)
+	c.initialProbability = clamp(c.initialProbability, c.minProbability, 1)
+	if c.maxOperations < 1 {
+		c.maxOperations = defaultMaxOperations
+	}
+	return c
+}
+
+// WithAdjustmentInterval sets the interval the throughput of the operations
+// is measured over before adjusting their sampling probability to d. It
+// defaults to 10 seconds.
+func WithAdjustmentInterval(d time.Duration) Option {
+	return optionFunc(func(c *config) {
+		c.interval = d
+	})
+}
+
+// WithMinProbability sets the lowest sampling probability of an operation to
+// p, ensuring all the operations are sampled, however high their throughput.
+// It defaults to 1e-5.
+func WithMinProbability(p float64) Option {
+	return optionFunc(func(c *config) {
+		c.minProbability = p
+	})
+}
+
+// WithInitialProbability sets the sampling probability of an operation before
+// its throughput is first measured to p. It defaults to 1: all the spans of a
+// new operation are sampled during the first adjustment interval.
+func WithInitialProbability(p float64) Option {
+	return optionFunc(func(c *config) {
+		c.initialProbability = p
+	})
+}
+
+// WithMaxOperations sets the maximum number of operations the sampler adjusts
+// the probability of to n, bounding its memory usage. The spans of the other
+// operations share a single sampling probability. It defaults to 256.
+func WithMaxOperations(n int) Option {
+	return optionFunc(func(c *config) {
+		c.maxOperations = n
+	})
+}
+
+// withClock sets the clock of the sampler, for testing.
+func withClock(now func() time.Time) Option {
+	return optionFunc(func(c *config) {
+		c.now = now
+	})
+}
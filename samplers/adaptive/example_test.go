@@ -0,0 +1,32 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adaptive_test
+
+import (
+	"time"
+
+	"go.opentelemetry.io/contrib/samplers/adaptive"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+func ExampleNewSampler() {
+	// Sample about 2 traces per second of each operation, adjusting the
+	// probabilities every 30 seconds. The spans of a sampled trace are all
+	// sampled.
+	sampler := trace.ParentBased(adaptive.NewSampler(2, adaptive.WithAdjustmentInterval(30*time.Second)))
+
+	tp := trace.NewTracerProvider(trace.WithSampler(sampler))
+	_ = tp
+}
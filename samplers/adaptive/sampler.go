@@ -0,0 +1,170 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package adaptive provides a sampler adjusting the sampling probability of
+// each operation, identified by the span name, to sample a target number of
+// spans per second, similar to the adaptive sampling of Jaeger but local to
+// the process.
+//
+// The sampler measures the throughput of the operations over an adjustment
+// interval and sets their probability to sample the target number of spans
+// per second: the rarely executed operations are sampled with a high
+// probability while the throughput of the busy ones is bounded. Use it as the
+// root sampler of trace.ParentBased, the descendant spans following the
+// decision of their root span:
+//
+//	sampler := trace.ParentBased(adaptive.NewSampler(1))
+package adaptive // import "go.opentelemetry.io/contrib/samplers/adaptive"
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// operation is the sampling state of an operation.
+type operation struct {
+	// probability is the sampling probability of the operation.
+	probability float64
+	// count is the number of spans of the operation during the current
+	// adjustment interval.
+	count int64
+}
+
+// sampler samples the spans of each operation with a probability adjusted to
+// its throughput.
+type sampler struct {
+	target        float64
+	interval      time.Duration
+	minProb       float64
+	initialProb   float64
+	maxOperations int
+	now           func() time.Time
+
+	mu         sync.Mutex
+	operations map[string]*operation
+	// overflow is the operation of the spans whose operation is not
+	// tracked, once maxOperations are.
+	overflow *operation
+	// start is the start of the current adjustment interval.
+	start time.Time
+}
+
+// compile time assertion that sampler implements the trace.Sampler interface.
+var _ trace.Sampler = (*sampler)(nil)
+
+// NewSampler returns a sampler adjusting the sampling probability of each
+// operation to sample spansPerSecond spans of the operation per second on
+// average.
+//
+// The probabilities are adjusted at the interval set with
+// WithAdjustmentInterval, based on the throughput of the operations during the
+// elapsed interval. The sampling decisions are consistent with the
+// trace.TraceIDRatioBased sampler of the same probability.
+func NewSampler(spansPerSecond float64, opts ...Option) trace.Sampler {
+	c := newConfig(opts...)
+	return &sampler{
+		target:        math.Max(0, spansPerSecond),
+		interval:      c.interval,
+		minProb:       c.minProbability,
+		initialProb:   c.initialProbability,
+		maxOperations: c.maxOperations,
+		now:           c.now,
+		operations:    make(map[string]*operation),
+		overflow:      &operation{probability: c.initialProbability},
+		start:         c.now(),
+	}
+}
+
+// ShouldSample samples the span with the probability of its operation.
+func (s *sampler) ShouldSample(p trace.SamplingParameters) trace.SamplingResult {
+	decision := trace.Drop
+	if traceIDRatio(p.TraceID) < s.probability(p.Name) {
+		decision = trace.RecordAndSample
+	}
+	return trace.SamplingResult{
+		Decision:   decision,
+		Tracestate: oteltrace.SpanContextFromContext(p.ParentContext).TraceState(),
+	}
+}
+
+// traceIDRatio returns the position of id in the trace ID space, in the
+// interval [0, 1), using the bits trace.TraceIDRatioBased samples on.
+func traceIDRatio(id oteltrace.TraceID) float64 {
+	return float64(binary.BigEndian.Uint64(id[8:16])>>1) / (1 << 63)
+}
+
+// probability counts a span of the operation name and returns the sampling
+// probability of the operation.
+func (s *sampler) probability(name string) float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now := s.now(); now.Sub(s.start) >= s.interval {
+		s.adjust(now.Sub(s.start))
+		s.start = now
+	}
+
+	op, ok := s.operations[name]
+	if !ok {
+		if len(s.operations) < s.maxOperations {
+			op = &operation{probability: s.initialProb}
+			s.operations[name] = op
+		} else {
+			op = s.overflow
+		}
+	}
+	op.count++
+	return op.probability
+}
+
+// adjust sets the probability of the operations from their throughput during
+// the elapsed interval, and resets their count. The operations without span
+// during the interval are forgotten.
+func (s *sampler) adjust(elapsed time.Duration) {
+	for name, op := range s.operations {
+		if op.count == 0 {
+			delete(s.operations, name)
+			continue
+		}
+		s.adjustOperation(op, elapsed)
+	}
+	if s.overflow.count > 0 {
+		s.adjustOperation(s.overflow, elapsed)
+	}
+}
+
+// adjustOperation sets the probability of op to sample the target number of
+// spans per second of the throughput of op during elapsed.
+func (s *sampler) adjustOperation(op *operation, elapsed time.Duration) {
+	rate := float64(op.count) / elapsed.Seconds()
+	op.probability = clamp(s.target/rate, s.minProb, 1)
+	op.count = 0
+}
+
+// Description returns the description of the sampler, with its target
+// number of spans per second and its minimum probability.
+func (s *sampler) Description() string {
+	return fmt.Sprintf("AdaptiveSampler{%g,%g}", s.target, s.minProb)
+}
+
+// clamp returns v bounded to the interval [lo, hi].
+func clamp(v, lo, hi float64) float64 {
+	return math.Min(math.Max(v, lo), hi)
+}
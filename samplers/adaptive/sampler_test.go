@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adaptive
+
+import (
+	"context"
+	"encoding/binary"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// clock is a manually advanced clock.
+type clock struct{ t time.Time }
+
+func (c *clock) now() time.Time { return c.t }
+
+func (c *clock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+// sampled returns the number of spans of the operation name sampled by s out
+// of n, with random trace IDs.
+func sampled(s trace.Sampler, rnd *rand.Rand, name string, n int) int {
+	var count int
+	for i := 0; i < n; i++ {
+		var id oteltrace.TraceID
+		binary.BigEndian.PutUint64(id[8:], rnd.Uint64())
+		p := trace.SamplingParameters{TraceID: id, Name: name}
+		if s.ShouldSample(p).Decision == trace.RecordAndSample {
+			count++
+		}
+	}
+	return count
+}
+
+func TestSamplerAdjustsProbability(t *testing.T) {
+	c := &clock{t: time.Unix(0, 0)}
+	rnd := rand.New(rand.NewSource(1))
+	s := NewSampler(1, withClock(c.now)).(*sampler)
+
+	// All the spans are sampled during the first interval.
+	assert.Equal(t, 1000, sampled(s, rnd, "busy", 1000))
+	assert.Equal(t, 5, sampled(s, rnd, "rare", 5))
+
+	c.advance(10 * time.Second)
+	// 100 spans per second.
+	busy := sampled(s, rnd, "busy", 1000)
+	assert.InDelta(t, 10, busy, 8)
+	assert.Equal(t, 0.01, s.operations["busy"].probability)
+	// 0.5 span per second.
+	assert.Equal(t, 5, sampled(s, rnd, "rare", 5))
+	assert.Equal(t, 1.0, s.operations["rare"].probability)
+}
+
+func TestSamplerMinProbability(t *testing.T) {
+	c := &clock{t: time.Unix(0, 0)}
+	rnd := rand.New(rand.NewSource(1))
+	s := NewSampler(0, WithMinProbability(0.1), withClock(c.now)).(*sampler)
+
+	sampled(s, rnd, "op", 100)
+	c.advance(10 * time.Second)
+	sampled(s, rnd, "op", 1)
+	assert.Equal(t, 0.1, s.operations["op"].probability)
+}
+
+func TestSamplerInitialProbability(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	s := NewSampler(1, WithInitialProbability(0))
+	assert.Equal(t, 0, sampled(s, rnd, "op", 100))
+}
+
+func TestSamplerAdjustmentInterval(t *testing.T) {
+	c := &clock{t: time.Unix(0, 0)}
+	rnd := rand.New(rand.NewSource(1))
+	s := NewSampler(1, WithAdjustmentInterval(time.Minute), withClock(c.now)).(*sampler)
+
+	sampled(s, rnd, "op", 600)
+	c.advance(30 * time.Second)
+	sampled(s, rnd, "op", 1)
+	assert.Equal(t, 1.0, s.operations["op"].probability, "interval not elapsed")
+
+	c.advance(30 * time.Second)
+	sampled(s, rnd, "op", 1)
+	// 601 spans in a minute.
+	assert.InDelta(t, 60.0/601, s.operations["op"].probability, 1e-9)
+}
+
+func TestSamplerMaxOperations(t *testing.T) {
+	c := &clock{t: time.Unix(0, 0)}
+	rnd := rand.New(rand.NewSource(1))
+	s := NewSampler(1, WithMaxOperations(1), withClock(c.now)).(*sampler)
+
+	sampled(s, rnd, "first", 10)
+	sampled(s, rnd, "second", 100)
+	sampled(s, rnd, "third", 100)
+	assert.Len(t, s.operations, 1)
+	assert.Contains(t, s.operations, "first")
+
+	c.advance(10 * time.Second)
+	sampled(s, rnd, "second", 1)
+	assert.Equal(t, 0.05, s.overflow.probability, "shared by the untracked operations")
+}
+
+func TestSamplerForgetsIdleOperations(t *testing.T) {
+	c := &clock{t: time.Unix(0, 0)}
+	rnd := rand.New(rand.NewSource(1))
+	s := NewSampler(1, withClock(c.now)).(*sampler)
+
+	sampled(s, rnd, "idle", 100)
+	sampled(s, rnd, "active", 100)
+	c.advance(10 * time.Second)
+	sampled(s, rnd, "active", 1)
+	assert.Contains(t, s.operations, "idle")
+
+	c.advance(10 * time.Second)
+	sampled(s, rnd, "active", 1)
+	assert.NotContains(t, s.operations, "idle")
+}
+
+func TestSamplerConsistentWithTraceIDRatioBased(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	s := NewSampler(1, WithInitialProbability(0.25))
+	ratio := trace.TraceIDRatioBased(0.25)
+	for i := 0; i < 1000; i++ {
+		var id oteltrace.TraceID
+		binary.BigEndian.PutUint64(id[8:], rnd.Uint64())
+		p := trace.SamplingParameters{TraceID: id, Name: "op"}
+		assert.Equal(t, ratio.ShouldSample(p).Decision, s.ShouldSample(p).Decision)
+	}
+}
+
+func TestSamplerTracestate(t *testing.T) {
+	ts, err := oteltrace.ParseTraceState("key=value")
+	assert.NoError(t, err)
+	sc := oteltrace.NewSpanContext(oteltrace.SpanContextConfig{TraceState: ts})
+	p := trace.SamplingParameters{ParentContext: oteltrace.ContextWithSpanContext(context.Background(), sc)}
+	assert.Equal(t, ts, NewSampler(1).ShouldSample(p).Tracestate)
+}
+
+func TestSamplerDescription(t *testing.T) {
+	assert.Equal(t, "AdaptiveSampler{2,1e-05}", NewSampler(2).Description())
+	assert.Equal(t, "AdaptiveSampler{0,0.01}", NewSampler(-1, WithMinProbability(0.01)).Description())
+}
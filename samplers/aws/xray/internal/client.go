@@ -19,12 +19,19 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 )
 
+// getSamplingRulesInput is used to request a page of sampling rules.
+type getSamplingRulesInput struct {
+	NextToken *string `json:"NextToken"`
+}
+
 // getSamplingRulesOutput is used to store parsed json sampling rules.
 type getSamplingRulesOutput struct {
+	NextToken           *string                `json:"NextToken,omitempty"`
 	SamplingRuleRecords []*samplingRuleRecords `json:"SamplingRuleRecords"`
 }
 
@@ -134,28 +141,31 @@ func newClient(endpoint url.URL) (client *xrayClient, err error) {
 }
 
 // getSamplingRules calls the collector(aws proxy enabled) for sampling rules.
+// The pages of rules returned by X-Ray are all retrieved and merged.
 func (c *xrayClient) getSamplingRules(ctx context.Context) (*getSamplingRulesOutput, error) {
-	emptySamplingRulesInputJSON := []byte(`{"NextToken": null}`)
-
-	body := bytes.NewReader(emptySamplingRulesInputJSON)
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.samplingRulesURL, body)
-	if err != nil {
-		return nil, fmt.Errorf("xray client: failed to create http request: %w", err)
+	samplingRulesOutput := new(getSamplingRulesOutput)
+	seen := make(map[string]bool)
+
+	var input getSamplingRulesInput
+	for {
+		var page *getSamplingRulesOutput
+		if err := c.post(ctx, c.samplingRulesURL, input, &page); err != nil {
+			return nil, err
+		}
+		if page == nil {
+			return samplingRulesOutput, nil
+		}
+		samplingRulesOutput.SamplingRuleRecords = append(samplingRulesOutput.SamplingRuleRecords, page.SamplingRuleRecords...)
+
+		if page.NextToken == nil || *page.NextToken == "" {
+			return samplingRulesOutput, nil
+		}
+		if seen[*page.NextToken] {
+			return nil, fmt.Errorf("xray client: sampling rules pagination loops on token %q", *page.NextToken)
+		}
+		seen[*page.NextToken] = true
+		input.NextToken = page.NextToken
 	}
-
-	output, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("xray client: unable to retrieve sampling settings: %w", err)
-	}
-	defer output.Body.Close()
-
-	var samplingRulesOutput *getSamplingRulesOutput
-	if err := json.NewDecoder(output.Body).Decode(&samplingRulesOutput); err != nil {
-		return nil, fmt.Errorf("xray client: unable to unmarshal the response body: %w", err)
-	}
-
-	return samplingRulesOutput, nil
 }
 
 // getSamplingTargets calls the collector(aws proxy enabled) for sampling targets.
@@ -164,27 +174,41 @@ func (c *xrayClient) getSamplingTargets(ctx context.Context, s []*samplingStatis
 		SamplingStatisticsDocuments: s,
 	}
 
-	statisticsByte, err := json.Marshal(statistics)
-	if err != nil {
+	var samplingTargetsOutput *getSamplingTargetsOutput
+	if err := c.post(ctx, c.samplingTargetsURL, statistics, &samplingTargetsOutput); err != nil {
 		return nil, err
 	}
-	body := bytes.NewReader(statisticsByte)
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.samplingTargetsURL, body)
+	return samplingTargetsOutput, nil
+}
+
+// post sends the JSON encoding of input to endpoint and decodes the JSON
+// response into output. A response with a status other than 200 is an error.
+func (c *xrayClient) post(ctx context.Context, endpoint string, input, output interface{}) error {
+	inputByte, err := json.Marshal(input)
 	if err != nil {
-		return nil, fmt.Errorf("xray client: failed to create http request: %w", err)
+		return err
 	}
+	body := bytes.NewReader(inputByte)
 
-	output, err := c.httpClient.Do(req)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, body)
 	if err != nil {
-		return nil, fmt.Errorf("xray client: unable to retrieve sampling settings: %w", err)
+		return fmt.Errorf("xray client: failed to create http request: %w", err)
 	}
-	defer output.Body.Close()
 
-	var samplingTargetsOutput *getSamplingTargetsOutput
-	if err := json.NewDecoder(output.Body).Decode(&samplingTargetsOutput); err != nil {
-		return nil, fmt.Errorf("xray client: unable to unmarshal the response body: %w", err)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("xray client: unable to retrieve sampling settings: %w", err)
 	}
+	defer resp.Body.Close()
 
-	return samplingTargetsOutput, nil
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("xray client: unable to retrieve sampling settings: status code %d: %s", resp.StatusCode, msg)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(output); err != nil {
+		return fmt.Errorf("xray client: unable to unmarshal the response body: %w", err)
+	}
+	return nil
 }
@@ -16,6 +16,7 @@ package internal
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -272,3 +273,84 @@ func TestEndpointIsNotReachable(t *testing.T) {
 	_, err = client.getSamplingRules(context.Background())
 	assert.Error(t, err)
 }
+
+func TestGetSamplingRulesPagination(t *testing.T) {
+	pages := map[string]string{
+		"":      `{"NextToken": "page2", "SamplingRuleRecords": [{"SamplingRule": {"RuleName": "r1"}}]}`,
+		"page2": `{"NextToken": "page3", "SamplingRuleRecords": [{"SamplingRule": {"RuleName": "r2"}}]}`,
+		"page3": `{"SamplingRuleRecords": [{"SamplingRule": {"RuleName": "r3"}}]}`,
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		var input getSamplingRulesInput
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&input))
+		var token string
+		if input.NextToken != nil {
+			token = *input.NextToken
+		}
+		_, err := res.Write([]byte(pages[token]))
+		require.NoError(t, err)
+	}))
+	t.Cleanup(testServer.Close)
+	u, err := url.Parse(testServer.URL)
+	require.NoError(t, err)
+	client, err := newClient(*u)
+	require.NoError(t, err)
+
+	samplingRules, err := client.getSamplingRules(context.Background())
+	require.NoError(t, err)
+	var names []string
+	for _, r := range samplingRules.SamplingRuleRecords {
+		names = append(names, r.SamplingRule.RuleName)
+	}
+	assert.Equal(t, []string{"r1", "r2", "r3"}, names)
+}
+
+func TestGetSamplingRulesPaginationLoop(t *testing.T) {
+	client := createTestClient(t, []byte(`{"NextToken": "again", "SamplingRuleRecords": []}`))
+
+	_, err := client.getSamplingRules(context.Background())
+	assert.ErrorContains(t, err, "pagination loops")
+}
+
+func TestErrorStatusCode(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, _ *http.Request) {
+		res.WriteHeader(http.StatusForbidden)
+		_, err := res.Write([]byte(`{"message": "denied"}`))
+		require.NoError(t, err)
+	}))
+	t.Cleanup(testServer.Close)
+	u, err := url.Parse(testServer.URL)
+	require.NoError(t, err)
+	client, err := newClient(*u)
+	require.NoError(t, err)
+
+	_, err = client.getSamplingRules(context.Background())
+	assert.ErrorContains(t, err, "status code 403")
+	assert.ErrorContains(t, err, "denied")
+
+	_, err = client.getSamplingTargets(context.Background(), nil)
+	assert.ErrorContains(t, err, "status code 403")
+}
+
+func TestErrorStatusCodeOnLaterPage(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		var input getSamplingRulesInput
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&input))
+		if input.NextToken == nil {
+			_, err := res.Write([]byte(`{"NextToken": "page2", "SamplingRuleRecords": [{"SamplingRule": {"RuleName": "r1"}}]}`))
+			require.NoError(t, err)
+			return
+		}
+		res.WriteHeader(http.StatusTooManyRequests)
+	}))
+	t.Cleanup(testServer.Close)
+	u, err := url.Parse(testServer.URL)
+	require.NoError(t, err)
+	client, err := newClient(*u)
+	require.NoError(t, err)
+
+	// The rules of the pages retrieved before the error are not returned.
+	samplingRules, err := client.getSamplingRules(context.Background())
+	assert.ErrorContains(t, err, "status code 429")
+	assert.Nil(t, samplingRules)
+}
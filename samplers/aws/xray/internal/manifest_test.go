@@ -16,6 +16,8 @@ package internal
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"sync"
 	"testing"
@@ -89,6 +91,31 @@ func TestRefreshManifestError(t *testing.T) {
 	assert.Error(t, err)
 }
 
+// assert that the rules are kept when X-Ray answers with an error status.
+func TestRefreshManifestErrorStatusCode(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, _ *http.Request) {
+		res.WriteHeader(http.StatusInternalServerError)
+		_, err := res.Write([]byte(`{"NextToken": null, "SamplingRuleRecords": []}`))
+		require.NoError(t, err)
+	}))
+	t.Cleanup(testServer.Close)
+	endpoint, err := url.Parse(testServer.URL)
+	require.NoError(t, err)
+
+	client, err := newClient(*endpoint)
+	require.NoError(t, err)
+
+	rules := []Rule{{ruleProperties: ruleProperties{RuleName: "r1"}}}
+	m := &Manifest{
+		Rules:      rules,
+		xrayClient: client,
+	}
+
+	err = m.RefreshManifestRules(context.Background())
+	assert.ErrorContains(t, err, "status code 500")
+	assert.Equal(t, rules, m.Rules)
+}
+
 // assert that manifest rule r2 is a match for sampling.
 func TestMatchAgainstManifestRules(t *testing.T) {
 	r1 := Rule{
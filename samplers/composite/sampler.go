@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package composite provides samplers combining the sampling decisions of
+// other samplers with boolean operators, e.g. to sample a ratio of the
+// spans which are not health checks without implementing a sampler:
+//
+//	sampler := composite.AndSampler(
+//		trace.TraceIDRatioBased(0.1),
+//		composite.NotSampler(healthCheckSampler),
+//	)
+//
+// The sampling decisions are ordered: Drop < RecordOnly < RecordAndSample.
+// AndSampler returns the lowest decision of its samplers and OrSampler the
+// highest, evaluating the samplers in order and stopping as soon as the
+// decision is known.
+package composite // import "go.opentelemetry.io/contrib/samplers/composite"
+
+import (
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// rank returns the order of the decision d.
+func rank(d trace.SamplingDecision) int {
+	switch d {
+	case trace.RecordAndSample:
+		return 2
+	case trace.RecordOnly:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// chain evaluates samplers in order until stop returns true for a decision,
+// and returns the decision selected by better among the evaluated ones.
+//
+// Each sampler is passed the tracestate returned by the previous one, so
+// their modifications are combined in the result. The attributes of the
+// samplers recording the span are merged, the latter samplers taking
+// precedence.
+func chain(samplers []trace.Sampler, p trace.SamplingParameters, initial trace.SamplingDecision, better func(a, b trace.SamplingDecision) bool, stop func(trace.SamplingDecision) bool) trace.SamplingResult {
+	psc := oteltrace.SpanContextFromContext(p.ParentContext)
+	result := trace.SamplingResult{Decision: initial, Tracestate: psc.TraceState()}
+	for _, s := range samplers {
+		r := s.ShouldSample(p)
+		if better(r.Decision, result.Decision) {
+			result.Decision = r.Decision
+		}
+		if r.Decision != trace.Drop {
+			result.Attributes = append(result.Attributes, r.Attributes...)
+		}
+		result.Tracestate = r.Tracestate
+
+		if stop(r.Decision) {
+			break
+		}
+		// Pass the tracestate to the next sampler.
+		psc = psc.WithTraceState(r.Tracestate)
+		p.ParentContext = oteltrace.ContextWithSpanContext(p.ParentContext, psc)
+	}
+	if result.Decision == trace.Drop {
+		result.Attributes = nil
+	}
+	return result
+}
+
+// description returns the description of the operator name on samplers.
+func description(name string, samplers []trace.Sampler) string {
+	descs := make([]string, 0, len(samplers))
+	for _, s := range samplers {
+		descs = append(descs, s.Description())
+	}
+	return fmt.Sprintf("%s{%s}", name, strings.Join(descs, ","))
+}
+
+// andSampler returns the lowest decision of its samplers.
+type andSampler struct {
+	samplers []trace.Sampler
+}
+
+// compile time assertion that andSampler implements the trace.Sampler interface.
+var _ trace.Sampler = (*andSampler)(nil)
+
+// AndSampler returns a sampler recording or sampling the spans only if all
+// the samplers record or sample them. The samplers are evaluated in order
+// until one drops the span. The span is sampled if no sampler is passed.
+func AndSampler(samplers ...trace.Sampler) trace.Sampler {
+	return &andSampler{samplers: samplers}
+}
+
+// ShouldSample returns the lowest decision of the samplers.
+func (s *andSampler) ShouldSample(p trace.SamplingParameters) trace.SamplingResult {
+	return chain(s.samplers, p, trace.RecordAndSample,
+		func(a, b trace.SamplingDecision) bool { return rank(a) < rank(b) },
+		func(d trace.SamplingDecision) bool { return d == trace.Drop },
+	)
+}
+
+// Description returns "AndSampler{...}" with the descriptions of the
+// samplers.
+func (s *andSampler) Description() string {
+	return description("AndSampler", s.samplers)
+}
+
+// orSampler returns the highest decision of its samplers.
+type orSampler struct {
+	samplers []trace.Sampler
+}
+
+// compile time assertion that orSampler implements the trace.Sampler interface.
+var _ trace.Sampler = (*orSampler)(nil)
+
+// OrSampler returns a sampler recording or sampling the spans if any of the
+// samplers records or samples them. The samplers are evaluated in order
+// until one samples the span. The span is dropped if no sampler is passed.
+func OrSampler(samplers ...trace.Sampler) trace.Sampler {
+	return &orSampler{samplers: samplers}
+}
+
+// ShouldSample returns the highest decision of the samplers.
+func (s *orSampler) ShouldSample(p trace.SamplingParameters) trace.SamplingResult {
+	return chain(s.samplers, p, trace.Drop,
+		func(a, b trace.SamplingDecision) bool { return rank(a) > rank(b) },
+		func(d trace.SamplingDecision) bool { return d == trace.RecordAndSample },
+	)
+}
+
+// Description returns "OrSampler{...}" with the descriptions of the
+// samplers.
+func (s *orSampler) Description() string {
+	return description("OrSampler", s.samplers)
+}
+
+// notSampler inverts the decision of its sampler.
+type notSampler struct {
+	sampler trace.Sampler
+}
+
+// compile time assertion that notSampler implements the trace.Sampler interface.
+var _ trace.Sampler = (*notSampler)(nil)
+
+// NotSampler returns a sampler sampling the spans dropped by sampler and
+// dropping the spans it samples. The spans only recorded by sampler are
+// recorded.
+//
+// The tracestate of the parent is kept: the modifications of the tracestate
+// by sampler describe the opposite decision. The attributes of sampler are
+// only kept for the recorded spans.
+func NotSampler(sampler trace.Sampler) trace.Sampler {
+	return &notSampler{sampler: sampler}
+}
+
+// ShouldSample returns the inverse of the decision of the sampler.
+func (s *notSampler) ShouldSample(p trace.SamplingParameters) trace.SamplingResult {
+	r := s.sampler.ShouldSample(p)
+	result := trace.SamplingResult{
+		Tracestate: oteltrace.SpanContextFromContext(p.ParentContext).TraceState(),
+	}
+	switch r.Decision {
+	case trace.RecordAndSample:
+		result.Decision = trace.Drop
+	case trace.RecordOnly:
+		result.Decision = trace.RecordOnly
+		result.Attributes = r.Attributes
+	default:
+		result.Decision = trace.RecordAndSample
+	}
+	return result
+}
+
+// Description returns "NotSampler{...}" with the description of the
+// sampler.
+func (s *notSampler) Description() string {
+	return description("NotSampler", []trace.Sampler{s.sampler})
+}
@@ -0,0 +1,192 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package composite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// decisionSampler returns its decision, with an attribute and a tracestate
+// entry named after the sampler.
+type decisionSampler struct {
+	decision trace.SamplingDecision
+	name     string
+	calls    *int
+}
+
+func (s decisionSampler) ShouldSample(p trace.SamplingParameters) trace.SamplingResult {
+	if s.calls != nil {
+		*s.calls++
+	}
+	ts, _ := oteltrace.SpanContextFromContext(p.ParentContext).TraceState().Insert(s.name, "1")
+	return trace.SamplingResult{
+		Decision:   s.decision,
+		Attributes: []attribute.KeyValue{attribute.String("sampler", s.name)},
+		Tracestate: ts,
+	}
+}
+
+func (s decisionSampler) Description() string { return s.name }
+
+var (
+	drop   = decisionSampler{decision: trace.Drop, name: "drop"}
+	record = decisionSampler{decision: trace.RecordOnly, name: "record"}
+	sample = decisionSampler{decision: trace.RecordAndSample, name: "sample"}
+)
+
+func params(t *testing.T) trace.SamplingParameters {
+	ts, err := oteltrace.ParseTraceState("parent=1")
+	require.NoError(t, err)
+	sc := oteltrace.NewSpanContext(oteltrace.SpanContextConfig{TraceState: ts})
+	return trace.SamplingParameters{
+		ParentContext: oteltrace.ContextWithSpanContext(context.Background(), sc),
+		Name:          "span",
+	}
+}
+
+func TestAndSampler(t *testing.T) {
+	tests := []struct {
+		name       string
+		samplers   []trace.Sampler
+		decision   trace.SamplingDecision
+		attrs      []attribute.KeyValue
+		tracestate string
+	}{
+		{
+			name:       "empty",
+			decision:   trace.RecordAndSample,
+			tracestate: "parent=1",
+		},
+		{
+			name:       "all sample",
+			samplers:   []trace.Sampler{sample, decisionSampler{decision: trace.RecordAndSample, name: "other"}},
+			decision:   trace.RecordAndSample,
+			attrs:      []attribute.KeyValue{attribute.String("sampler", "sample"), attribute.String("sampler", "other")},
+			tracestate: "other=1,sample=1,parent=1",
+		},
+		{
+			name:       "record",
+			samplers:   []trace.Sampler{sample, record},
+			decision:   trace.RecordOnly,
+			attrs:      []attribute.KeyValue{attribute.String("sampler", "sample"), attribute.String("sampler", "record")},
+			tracestate: "record=1,sample=1,parent=1",
+		},
+		{
+			name:       "drop",
+			samplers:   []trace.Sampler{sample, drop, record},
+			decision:   trace.Drop,
+			tracestate: "drop=1,sample=1,parent=1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AndSampler(tt.samplers...).ShouldSample(params(t))
+			assert.Equal(t, tt.decision, got.Decision)
+			assert.Equal(t, tt.attrs, got.Attributes)
+			assert.Equal(t, tt.tracestate, got.Tracestate.String())
+		})
+	}
+}
+
+func TestAndSamplerShortCircuit(t *testing.T) {
+	var calls int
+	s := AndSampler(drop, decisionSampler{decision: trace.RecordAndSample, name: "next", calls: &calls})
+	assert.Equal(t, trace.Drop, s.ShouldSample(params(t)).Decision)
+	assert.Equal(t, 0, calls)
+}
+
+func TestOrSampler(t *testing.T) {
+	tests := []struct {
+		name       string
+		samplers   []trace.Sampler
+		decision   trace.SamplingDecision
+		attrs      []attribute.KeyValue
+		tracestate string
+	}{
+		{
+			name:       "empty",
+			decision:   trace.Drop,
+			tracestate: "parent=1",
+		},
+		{
+			name:       "all drop",
+			samplers:   []trace.Sampler{drop, decisionSampler{decision: trace.Drop, name: "other"}},
+			decision:   trace.Drop,
+			tracestate: "other=1,drop=1,parent=1",
+		},
+		{
+			name:       "record",
+			samplers:   []trace.Sampler{drop, record},
+			decision:   trace.RecordOnly,
+			attrs:      []attribute.KeyValue{attribute.String("sampler", "record")},
+			tracestate: "record=1,drop=1,parent=1",
+		},
+		{
+			name:       "sample",
+			samplers:   []trace.Sampler{record, drop, sample},
+			decision:   trace.RecordAndSample,
+			attrs:      []attribute.KeyValue{attribute.String("sampler", "record"), attribute.String("sampler", "sample")},
+			tracestate: "sample=1,drop=1,record=1,parent=1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := OrSampler(tt.samplers...).ShouldSample(params(t))
+			assert.Equal(t, tt.decision, got.Decision)
+			assert.Equal(t, tt.attrs, got.Attributes)
+			assert.Equal(t, tt.tracestate, got.Tracestate.String())
+		})
+	}
+}
+
+func TestOrSamplerShortCircuit(t *testing.T) {
+	var calls int
+	s := OrSampler(sample, decisionSampler{decision: trace.Drop, name: "next", calls: &calls})
+	assert.Equal(t, trace.RecordAndSample, s.ShouldSample(params(t)).Decision)
+	assert.Equal(t, 0, calls)
+}
+
+func TestNotSampler(t *testing.T) {
+	tests := []struct {
+		sampler  trace.Sampler
+		decision trace.SamplingDecision
+		attrs    []attribute.KeyValue
+	}{
+		{sample, trace.Drop, nil},
+		{drop, trace.RecordAndSample, nil},
+		{record, trace.RecordOnly, []attribute.KeyValue{attribute.String("sampler", "record")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sampler.Description(), func(t *testing.T) {
+			got := NotSampler(tt.sampler).ShouldSample(params(t))
+			assert.Equal(t, tt.decision, got.Decision)
+			assert.Equal(t, tt.attrs, got.Attributes)
+			assert.Equal(t, "parent=1", got.Tracestate.String())
+		})
+	}
+}
+
+func TestDescription(t *testing.T) {
+	s := AndSampler(trace.TraceIDRatioBased(0.5), NotSampler(OrSampler(drop, record)))
+	assert.Equal(t, "AndSampler{TraceIDRatioBased{0.5},NotSampler{OrSampler{drop,record}}}", s.Description())
+}
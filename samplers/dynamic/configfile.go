@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamic // import "go.opentelemetry.io/contrib/samplers/dynamic"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/contrib/config"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+// SetConfig replaces the sampler the sampling decisions are delegated to with
+// the sampler described by cfg, as returned by ConfigSampler. The delegate
+// is kept if cfg is invalid.
+//
+// It is meant to be called whenever the OpenTelemetry configuration file is
+// reloaded, e.g. by a file watcher or on an OpAMP remote configuration, to
+// apply the new sampling policy without replacing the tracer provider.
+func (s *DynamicSampler) SetConfig(cfg *config.Sampler) error {
+	sampler, err := ConfigSampler(cfg)
+	if err != nil {
+		return err
+	}
+	s.SetDelegate(sampler)
+	return nil
+}
+
+// ConfigSampler returns the sampler described by the sampler of the tracer
+// provider of an OpenTelemetry configuration file. For example:
+//
+//	tracer_provider:
+//	  sampler:
+//	    parent_based:
+//	      root:
+//	        trace_id_ratio_based:
+//	          ratio: 0.1
+//
+// The always_on, always_off, trace_id_ratio_based and parent_based samplers
+// are supported. A nil cfg returns the default sampler of the SDK,
+// trace.ParentBased(trace.AlwaysSample()).
+func ConfigSampler(cfg *config.Sampler) (trace.Sampler, error) {
+	if cfg == nil {
+		return trace.ParentBased(trace.AlwaysSample()), nil
+	}
+
+	var samplers []trace.Sampler
+	if cfg.AlwaysOn != nil {
+		samplers = append(samplers, trace.AlwaysSample())
+	}
+	if cfg.AlwaysOff != nil {
+		samplers = append(samplers, trace.NeverSample())
+	}
+	if cfg.TraceIDRatioBased != nil {
+		samplers = append(samplers, configTraceIDRatioBased(cfg.TraceIDRatioBased))
+	}
+	if cfg.ParentBased != nil {
+		sampler, err := configParentBased(cfg.ParentBased)
+		if err != nil {
+			return nil, err
+		}
+		samplers = append(samplers, sampler)
+	}
+	if cfg.JaegerRemote != nil {
+		return nil, fmt.Errorf("dynamic: sampler: unsupported sampler %q", "jaeger_remote")
+	}
+	if len(samplers) != 1 {
+		return nil, fmt.Errorf("dynamic: sampler: exactly one sampler expected, got %d", len(samplers))
+	}
+	return samplers[0], nil
+}
+
+func configTraceIDRatioBased(cfg *config.SamplerTraceIDRatioBased) trace.Sampler {
+	ratio := 1.0
+	if cfg.Ratio != nil {
+		ratio = *cfg.Ratio
+	}
+	return trace.TraceIDRatioBased(ratio)
+}
+
+func configParentBased(cfg *config.SamplerParentBased) (trace.Sampler, error) {
+	root := trace.AlwaysSample()
+	if cfg.Root != nil {
+		sampler, err := ConfigSampler(cfg.Root)
+		if err != nil {
+			return nil, err
+		}
+		root = sampler
+	}
+
+	var opts []trace.ParentBasedSamplerOption
+	for _, parent := range []struct {
+		cfg *config.Sampler
+		opt func(trace.Sampler) trace.ParentBasedSamplerOption
+	}{
+		{cfg.RemoteParentSampled, trace.WithRemoteParentSampled},
+		{cfg.RemoteParentNotSampled, trace.WithRemoteParentNotSampled},
+		{cfg.LocalParentSampled, trace.WithLocalParentSampled},
+		{cfg.LocalParentNotSampled, trace.WithLocalParentNotSampled},
+	} {
+		if parent.cfg == nil {
+			continue
+		}
+		sampler, err := ConfigSampler(parent.cfg)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, parent.opt(sampler))
+	}
+	return trace.ParentBased(root, opts...), nil
+}
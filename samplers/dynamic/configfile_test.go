@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/config"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+func ratio(r float64) *float64 {
+	return &r
+}
+
+func TestConfigSampler(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *config.Sampler
+		want trace.Sampler
+	}{
+		{
+			name: "nil",
+			want: trace.ParentBased(trace.AlwaysSample()),
+		},
+		{
+			name: "always on",
+			cfg:  &config.Sampler{AlwaysOn: config.SamplerAlwaysOn{}},
+			want: trace.AlwaysSample(),
+		},
+		{
+			name: "always off",
+			cfg:  &config.Sampler{AlwaysOff: config.SamplerAlwaysOff{}},
+			want: trace.NeverSample(),
+		},
+		{
+			name: "ratio",
+			cfg:  &config.Sampler{TraceIDRatioBased: &config.SamplerTraceIDRatioBased{Ratio: ratio(0.25)}},
+			want: trace.TraceIDRatioBased(0.25),
+		},
+		{
+			name: "default ratio",
+			cfg:  &config.Sampler{TraceIDRatioBased: &config.SamplerTraceIDRatioBased{}},
+			want: trace.TraceIDRatioBased(1),
+		},
+		{
+			name: "parent based",
+			cfg: &config.Sampler{ParentBased: &config.SamplerParentBased{
+				Root: &config.Sampler{
+					TraceIDRatioBased: &config.SamplerTraceIDRatioBased{Ratio: ratio(0.1)},
+				},
+				RemoteParentNotSampled: &config.Sampler{AlwaysOn: config.SamplerAlwaysOn{}},
+			}},
+			want: trace.ParentBased(trace.TraceIDRatioBased(0.1), trace.WithRemoteParentNotSampled(trace.AlwaysSample())),
+		},
+		{
+			name: "parent based default root",
+			cfg:  &config.Sampler{ParentBased: &config.SamplerParentBased{}},
+			want: trace.ParentBased(trace.AlwaysSample()),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ConfigSampler(tt.cfg)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.Description(), got.Description())
+		})
+	}
+}
+
+func TestConfigSamplerErrors(t *testing.T) {
+	for _, cfg := range []*config.Sampler{
+		{},
+		{AlwaysOn: config.SamplerAlwaysOn{}, AlwaysOff: config.SamplerAlwaysOff{}},
+		{JaegerRemote: &config.SamplerJaegerRemote{}},
+		{ParentBased: &config.SamplerParentBased{Root: &config.Sampler{}}},
+		{ParentBased: &config.SamplerParentBased{
+			LocalParentSampled: &config.Sampler{JaegerRemote: &config.SamplerJaegerRemote{}},
+		}},
+	} {
+		_, err := ConfigSampler(cfg)
+		assert.Error(t, err, "%+v", cfg)
+	}
+}
+
+func TestSetConfig(t *testing.T) {
+	s := NewSampler(trace.AlwaysSample())
+
+	require.NoError(t, s.SetConfig(&config.Sampler{
+		TraceIDRatioBased: &config.SamplerTraceIDRatioBased{Ratio: ratio(0.5)},
+	}))
+	assert.Equal(t, "DynamicSampler{TraceIDRatioBased{0.5}}", s.Description())
+
+	assert.Error(t, s.SetConfig(&config.Sampler{}))
+	assert.Equal(t, "DynamicSampler{TraceIDRatioBased{0.5}}", s.Description(), "delegate kept")
+}
@@ -0,0 +1,30 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamic_test
+
+import (
+	"go.opentelemetry.io/contrib/samplers/dynamic"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+func ExampleNewSampler() {
+	sampler := dynamic.NewSampler(trace.TraceIDRatioBased(0.1))
+	tp := trace.NewTracerProvider(trace.WithSampler(trace.ParentBased(sampler)))
+	_ = tp
+
+	// Sample half of the traces started from now on, e.g. when the
+	// configuration of the service changes.
+	sampler.SetRatio(0.5)
+}
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dynamic provides a sampler whose sampling configuration can be
+// updated at runtime, e.g. by a configuration reload, an operations endpoint
+// or a feature flag, without replacing the tracer provider:
+//
+//	sampler := dynamic.NewSampler(trace.TraceIDRatioBased(0.1))
+//	tp := trace.NewTracerProvider(trace.WithSampler(trace.ParentBased(sampler)))
+//
+//	// Later, e.g. on a configuration change.
+//	sampler.SetRatio(0.5)
+//
+// The SetConfig method applies the sampler of an OpenTelemetry configuration
+// file, as decoded in the config.Sampler of the go.opentelemetry.io/contrib/config
+// module, to update the sampling policy whenever the configuration is
+// reloaded.
+package dynamic // import "go.opentelemetry.io/contrib/samplers/dynamic"
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+// delegate holds the sampler the decisions are delegated to.
+type delegate struct {
+	trace.Sampler
+}
+
+// DynamicSampler is a sampler delegating its sampling decisions to a sampler
+// which can be replaced at runtime. It is safe for concurrent use.
+type DynamicSampler struct { // nolint: revive  // Distinguishes it from the trace.Sampler interface.
+	delegate atomic.Pointer[delegate]
+}
+
+// compile time assertion that DynamicSampler implements the trace.Sampler
+// interface.
+var _ trace.Sampler = (*DynamicSampler)(nil)
+
+// NewSampler returns a DynamicSampler delegating its sampling decisions to
+// sampler. The default sampler of the SDK, trace.ParentBased(trace.AlwaysSample()),
+// is used if sampler is nil.
+func NewSampler(sampler trace.Sampler) *DynamicSampler {
+	if sampler == nil {
+		sampler = trace.ParentBased(trace.AlwaysSample())
+	}
+	s := &DynamicSampler{}
+	s.delegate.Store(&delegate{sampler})
+	return s
+}
+
+// SetDelegate atomically replaces the sampler the sampling decisions are
+// delegated to with sampler. The spans started afterwards are sampled by
+// sampler. It does nothing if sampler is nil.
+func (s *DynamicSampler) SetDelegate(sampler trace.Sampler) {
+	if sampler == nil {
+		return
+	}
+	s.delegate.Store(&delegate{sampler})
+}
+
+// SetRatio atomically replaces the sampler the sampling decisions are
+// delegated to with trace.TraceIDRatioBased(fraction).
+func (s *DynamicSampler) SetRatio(fraction float64) {
+	s.SetDelegate(trace.TraceIDRatioBased(fraction))
+}
+
+// Delegate returns the sampler the sampling decisions are delegated to.
+func (s *DynamicSampler) Delegate() trace.Sampler {
+	return s.delegate.Load().Sampler
+}
+
+// ShouldSample returns the sampling result of the current delegate.
+func (s *DynamicSampler) ShouldSample(p trace.SamplingParameters) trace.SamplingResult {
+	return s.Delegate().ShouldSample(p)
+}
+
+// Description returns the description of the sampler, with the description
+// of its current delegate.
+func (s *DynamicSampler) Description() string {
+	return fmt.Sprintf("DynamicSampler{%s}", s.Delegate().Description())
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamic
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+func TestNewSamplerNil(t *testing.T) {
+	s := NewSampler(nil)
+	assert.Equal(t, trace.ParentBased(trace.AlwaysSample()).Description(), s.Delegate().Description())
+}
+
+func TestSetDelegate(t *testing.T) {
+	s := NewSampler(trace.NeverSample())
+	p := trace.SamplingParameters{TraceID: oteltrace.TraceID{1}}
+	assert.Equal(t, trace.Drop, s.ShouldSample(p).Decision)
+
+	s.SetDelegate(trace.AlwaysSample())
+	assert.Equal(t, trace.RecordAndSample, s.ShouldSample(p).Decision)
+	assert.Equal(t, "DynamicSampler{AlwaysOnSampler}", s.Description())
+
+	s.SetDelegate(nil)
+	assert.Equal(t, "DynamicSampler{AlwaysOnSampler}", s.Description(), "nil ignored")
+}
+
+func TestSetRatio(t *testing.T) {
+	s := NewSampler(trace.AlwaysSample())
+	s.SetRatio(0.25)
+	assert.Equal(t, "DynamicSampler{TraceIDRatioBased{0.25}}", s.Description())
+
+	s.SetRatio(0)
+	p := trace.SamplingParameters{TraceID: oteltrace.TraceID{1}}
+	assert.Equal(t, trace.Drop, s.ShouldSample(p).Decision)
+}
+
+func TestConcurrentUpdates(t *testing.T) {
+	s := NewSampler(trace.AlwaysSample())
+	p := trace.SamplingParameters{TraceID: oteltrace.TraceID{1}}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				s.SetRatio(float64(i) / 4)
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_ = s.ShouldSample(p)
+				_ = s.Description()
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package force // import "go.opentelemetry.io/contrib/samplers/force"
+
+import "go.opentelemetry.io/otel/trace"
+
+// member is a key-value pair, matching any value if value is empty.
+type member struct {
+	key   string
+	value string
+}
+
+// match returns if the member matches a member with the value v, present if
+// ok.
+func (m member) match(v string, ok bool) bool {
+	return ok && (m.value == "" || m.value == v)
+}
+
+type config struct {
+	baggage    []member
+	traceState []member
+	traceFlags []trace.TraceFlags
+}
+
+// Option applies configuration settings to a sampler.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (fn optionFunc) apply(c *config) {
+	fn(c)
+}
+
+// newConfig returns the config of a sampler with opts applied.
+func newConfig(opts ...Option) config {
+	var c config
+	for _, opt := range opts {
+		opt.apply(&c)
+	}
+	return c
+}
+
+// WithBaggage forces the sampling of the spans whose parent context carries
+// the baggage member key with the value, or with any value if value is
+// empty, e.g. WithBaggage("force-trace", "1").
+func WithBaggage(key, value string) Option {
+	return optionFunc(func(c *config) {
+		c.baggage = append(c.baggage, member{key: key, value: value})
+	})
+}
+
+// WithTraceState forces the sampling of the spans whose parent span context
+// has the tracestate member key with the value, or with any value if value is
+// empty. For example, WithTraceState("b3", "d") forces the sampling of the
+// requests with the B3 debug flag, recorded in the tracestate by the B3
+// propagator configured with b3.WithDebugTraceState.
+func WithTraceState(key, value string) Option {
+	return optionFunc(func(c *config) {
+		c.traceState = append(c.traceState, member{key: key, value: value})
+	})
+}
+
+// WithTraceFlags forces the sampling of the spans whose parent span context
+// has all the non-zero trace flags set, e.g. a debug flag set by a
+// propagator.
+func WithTraceFlags(flags trace.TraceFlags) Option {
+	return optionFunc(func(c *config) {
+		c.traceFlags = append(c.traceFlags, flags)
+	})
+}
@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package force_test
+
+import (
+	"go.opentelemetry.io/contrib/samplers/force"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+func ExampleNewSampler() {
+	// Sample 1% of the traces, and all the requests with the
+	// "force-trace=1" baggage member or the B3 debug flag recorded in the
+	// tracestate.
+	sampler := force.NewSampler(
+		trace.ParentBased(trace.TraceIDRatioBased(0.01)),
+		force.WithBaggage("force-trace", "1"),
+		force.WithTraceState("b3", "d"),
+	)
+
+	tp := trace.NewTracerProvider(trace.WithSampler(sampler))
+	_ = tp
+}
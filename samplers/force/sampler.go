@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package force provides a sampler forcing the sampling of the requests
+// flagged for tracing, e.g. with a baggage member or a debug flag, and
+// delegating the sampling decision of the other spans. It allows tracing
+// specific requests on demand in production:
+//
+//	sampler := force.NewSampler(
+//		trace.ParentBased(trace.TraceIDRatioBased(0.01)),
+//		force.WithBaggage("force-trace", "1"),
+//	)
+//
+// The conditions are evaluated on the parent context of the spans: the
+// baggage of the context, and the tracestate and trace flags of the parent
+// span context, e.g. extracted from the request by the propagators.
+package force // import "go.opentelemetry.io/contrib/samplers/force"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// sampler samples the spans matching its conditions, and delegates the
+// sampling decision of the others.
+type sampler struct {
+	delegate trace.Sampler
+	config
+}
+
+// compile time assertion that sampler implements the trace.Sampler interface.
+var _ trace.Sampler = (*sampler)(nil)
+
+// NewSampler returns a sampler sampling the spans matching any of the
+// conditions set with the options, and delegating the sampling decision of
+// the other spans to delegate.
+//
+// The sampling of the spans is forced regardless of the sampling decision
+// of their parent: use the sampler as the root of the sampling configuration,
+// wrapping trace.ParentBased, to force the sampling of the child spans of an
+// unsampled parent.
+func NewSampler(delegate trace.Sampler, opts ...Option) trace.Sampler {
+	return &sampler{delegate: delegate, config: newConfig(opts...)}
+}
+
+// ShouldSample samples the span if it matches a condition of the sampler, or
+// returns the sampling result of the delegate.
+func (s *sampler) ShouldSample(p trace.SamplingParameters) trace.SamplingResult {
+	psc := oteltrace.SpanContextFromContext(p.ParentContext)
+	if s.forced(p, psc) {
+		return trace.SamplingResult{
+			Decision:   trace.RecordAndSample,
+			Tracestate: psc.TraceState(),
+		}
+	}
+	return s.delegate.ShouldSample(p)
+}
+
+// forced returns if the span of p, child of psc, matches a condition.
+func (s *sampler) forced(p trace.SamplingParameters, psc oteltrace.SpanContext) bool {
+	if len(s.baggage) > 0 {
+		bag := baggage.FromContext(p.ParentContext)
+		for _, m := range s.baggage {
+			mb := bag.Member(m.key)
+			if m.match(mb.Value(), mb.Key() != "") {
+				return true
+			}
+		}
+	}
+	for _, m := range s.traceState {
+		v := psc.TraceState().Get(m.key)
+		if m.match(v, v != "") {
+			return true
+		}
+	}
+	for _, f := range s.traceFlags {
+		if f != 0 && psc.TraceFlags()&f == f {
+			return true
+		}
+	}
+	return false
+}
+
+// Description returns the description of the sampler, with the description
+// of its delegate.
+func (s *sampler) Description() string {
+	return fmt.Sprintf("ForceSampler{%s}", s.delegate.Description())
+}
@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package force
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+func withBaggage(t *testing.T, s string) context.Context {
+	bag, err := baggage.Parse(s)
+	require.NoError(t, err)
+	return baggage.ContextWithBaggage(context.Background(), bag)
+}
+
+func withParent(t *testing.T, ts string, flags oteltrace.TraceFlags) context.Context {
+	state, err := oteltrace.ParseTraceState(ts)
+	require.NoError(t, err)
+	sc := oteltrace.NewSpanContext(oteltrace.SpanContextConfig{
+		TraceID:    oteltrace.TraceID{1},
+		SpanID:     oteltrace.SpanID{1},
+		TraceFlags: flags,
+		TraceState: state,
+		Remote:     true,
+	})
+	return oteltrace.ContextWithRemoteSpanContext(context.Background(), sc)
+}
+
+func TestSampler(t *testing.T) {
+	s := NewSampler(trace.NeverSample(),
+		WithBaggage("force-trace", "1"),
+		WithBaggage("debug", ""),
+		WithTraceState("b3", "d"),
+		WithTraceFlags(0x80),
+	)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want trace.SamplingDecision
+	}{
+		{"none", ctx, trace.Drop},
+		{"baggage value", withBaggage(t, "force-trace=1"), trace.RecordAndSample},
+		{"baggage other value", withBaggage(t, "force-trace=0"), trace.Drop},
+		{"baggage any value", withBaggage(t, "debug=yes"), trace.RecordAndSample},
+		{"baggage other key", withBaggage(t, "other=1"), trace.Drop},
+		{"tracestate", withParent(t, "b3=d", 0), trace.RecordAndSample},
+		{"tracestate other value", withParent(t, "b3=x", 0), trace.Drop},
+		{"trace flags", withParent(t, "", 0x81), trace.RecordAndSample},
+		{"other trace flags", withParent(t, "", 0x01), trace.Drop},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := s.ShouldSample(trace.SamplingParameters{ParentContext: tt.ctx})
+			assert.Equal(t, tt.want, got.Decision)
+		})
+	}
+}
+
+func TestSamplerKeepsTracestate(t *testing.T) {
+	s := NewSampler(trace.NeverSample(), WithTraceState("b3", "d"))
+	ctx := withParent(t, "b3=d,other=1", 0)
+	got := s.ShouldSample(trace.SamplingParameters{ParentContext: ctx})
+	assert.Equal(t, trace.RecordAndSample, got.Decision)
+	assert.Equal(t, "b3=d,other=1", got.Tracestate.String())
+}
+
+func TestSamplerZeroTraceFlags(t *testing.T) {
+	s := NewSampler(trace.NeverSample(), WithTraceFlags(0))
+	got := s.ShouldSample(trace.SamplingParameters{ParentContext: context.Background()})
+	assert.Equal(t, trace.Drop, got.Decision)
+}
+
+func TestSamplerDelegates(t *testing.T) {
+	s := NewSampler(trace.AlwaysSample(), WithBaggage("force-trace", "1"))
+	got := s.ShouldSample(trace.SamplingParameters{ParentContext: context.Background()})
+	assert.Equal(t, trace.RecordAndSample, got.Decision)
+}
+
+func TestSamplerDescription(t *testing.T) {
+	s := NewSampler(trace.TraceIDRatioBased(0.5))
+	assert.Equal(t, "ForceSampler{TraceIDRatioBased{0.5}}", s.Description())
+}
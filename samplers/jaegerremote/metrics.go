@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaegerremote // import "go.opentelemetry.io/contrib/samplers/jaegerremote"
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// scopeName is the instrumentation scope name of the sampler metrics.
+const scopeName = "go.opentelemetry.io/contrib/samplers/jaegerremote"
+
+// Metric names.
+const (
+	strategyPolls      = "jaegerremote.strategy.polls"       // Int64Counter
+	strategyLastUpdate = "jaegerremote.strategy.last_update" // Int64ObservableGauge
+	strategyOperations = "jaegerremote.strategy.operations"  // Int64ObservableGauge
+)
+
+// Attributes of the strategyPolls metric.
+var (
+	resultKey = attribute.Key("result")
+	stageKey  = attribute.Key("stage")
+
+	pollSucceeded   = metric.WithAttributes(resultKey.String("success"))
+	pollFetchFailed = metric.WithAttributes(resultKey.String("failure"), stageKey.String("fetch"))
+	pollParseFailed = metric.WithAttributes(resultKey.String("failure"), stageKey.String("parse"))
+	pollApplyFailed = metric.WithAttributes(resultKey.String("failure"), stageKey.String("update"))
+)
+
+// samplerMetrics are the self-observability metrics of a Sampler.
+type samplerMetrics struct {
+	polls      metric.Int64Counter
+	lastUpdate metric.Int64ObservableGauge
+	operations metric.Int64ObservableGauge
+
+	reg metric.Registration
+}
+
+// newSamplerMetrics returns the metrics of s, created with the meter
+// provider of its configuration. Errors creating the instruments are sent to
+// the global error handler.
+func newSamplerMetrics(s *Sampler) *samplerMetrics {
+	meter := s.meterProvider.Meter(
+		scopeName,
+		metric.WithInstrumentationVersion(Version()),
+	)
+	m := new(samplerMetrics)
+
+	var err error
+	m.polls, err = meter.Int64Counter(
+		strategyPolls,
+		metric.WithUnit("{poll}"),
+		metric.WithDescription("The number of polls of the sampling strategy, by result and, for failures, by stage of the failure."),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+	m.lastUpdate, err = meter.Int64ObservableGauge(
+		strategyLastUpdate,
+		metric.WithUnit("s"),
+		metric.WithDescription("The Unix time of the last successful update of the sampling strategy."),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+	m.operations, err = meter.Int64ObservableGauge(
+		strategyOperations,
+		metric.WithUnit("{operation}"),
+		metric.WithDescription("The number of operations with a dedicated sampler of the per-operation sampling strategy."),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	m.reg, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		// The last update is not observed until the strategy is updated.
+		if t := atomic.LoadInt64(&s.lastUpdate); t != 0 {
+			o.ObserveInt64(m.lastUpdate, time.Unix(0, t).Unix())
+		}
+		o.ObserveInt64(m.operations, int64(s.operations()))
+		return nil
+	}, m.lastUpdate, m.operations)
+	if err != nil {
+		otel.Handle(err)
+	}
+	return m
+}
+
+// unregister stops the observation of the gauges.
+func (m *samplerMetrics) unregister() {
+	if m.reg == nil {
+		return
+	}
+	if err := m.reg.Unregister(); err != nil {
+		otel.Handle(err)
+	}
+}
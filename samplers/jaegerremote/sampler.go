@@ -230,6 +230,12 @@ type perOperationSampler struct {
 	lowerBound     float64
 	maxOperations  int
 
+	// see description in perOperationSamplerParams
+	unknownOperationPolicy UnknownOperationPolicy
+	// unknownSampler samples the unknown operations, unless the
+	// policy is UnknownOperationDefaultProbability.
+	unknownSampler trace.Sampler
+
 	// see description in perOperationSamplerParams
 	operationNameLateBinding bool
 }
@@ -239,6 +245,9 @@ type perOperationSamplerParams struct {
 	// Max number of operations that will be tracked. Other operations will be given default strategy.
 	MaxOperations int
 
+	// Policy sampling the operations without a strategy.
+	UnknownOperationPolicy UnknownOperationPolicy
+
 	// Opt-in feature for applications that require late binding of span name via explicit call to SetOperationName.
 	// When this feature is enabled, the sampler will return retryable=true from OnCreateSpan(), thus leaving
 	// the sampling decision as non-final (and the span as writeable). This may lead to degraded performance
@@ -256,21 +265,14 @@ func newPerOperationSampler(params perOperationSamplerParams) *perOperationSampl
 	if params.MaxOperations <= 0 {
 		params.MaxOperations = defaultMaxOperations
 	}
-	samplers := make(map[string]*guaranteedThroughputProbabilisticSampler)
-	for _, strategy := range params.Strategies.PerOperationStrategies {
-		sampler := newGuaranteedThroughputProbabilisticSampler(
-			params.Strategies.DefaultLowerBoundTracesPerSecond,
-			strategy.ProbabilisticSampling.SamplingRate,
-		)
-		samplers[strategy.Operation] = sampler
-	}
-	return &perOperationSampler{
-		samplers:                 samplers,
-		defaultSampler:           newProbabilisticSampler(params.Strategies.DefaultSamplingProbability),
-		lowerBound:               params.Strategies.DefaultLowerBoundTracesPerSecond,
+	s := &perOperationSampler{
+		samplers:                 make(map[string]*guaranteedThroughputProbabilisticSampler),
 		maxOperations:            params.MaxOperations,
+		unknownOperationPolicy:   params.UnknownOperationPolicy,
 		operationNameLateBinding: params.OperationNameLateBinding,
 	}
+	s.update(params.Strategies)
+	return s
 }
 
 func (s *perOperationSampler) ShouldSample(p trace.SamplingParameters) trace.SamplingResult {
@@ -285,6 +287,10 @@ func (s *perOperationSampler) getSamplerForOperation(operation string) trace.Sam
 		defer s.RUnlock()
 		return sampler
 	}
+	if s.unknownSampler != nil {
+		defer s.RUnlock()
+		return s.unknownSampler
+	}
 	s.RUnlock()
 	s.Lock()
 	defer s.Unlock()
@@ -311,9 +317,16 @@ func (s *perOperationSampler) update(strategies *jaeger_api_v2.PerOperationSampl
 	s.Lock()
 	defer s.Unlock()
 	newSamplers := map[string]*guaranteedThroughputProbabilisticSampler{}
+	lowest := strategies.DefaultSamplingProbability
 	for _, strategy := range strategies.PerOperationStrategies {
 		operation := strategy.Operation
 		samplingRate := strategy.ProbabilisticSampling.SamplingRate
+		lowest = math.Min(lowest, samplingRate)
+		if s.unknownOperationPolicy != UnknownOperationDefaultProbability && len(newSamplers) >= s.maxOperations {
+			// Bound the memory usage: the other operations are
+			// sampled as unknown operations.
+			continue
+		}
 		lowerBound := strategies.DefaultLowerBoundTracesPerSecond
 		if sampler, ok := s.samplers[operation]; ok {
 			sampler.update(lowerBound, samplingRate)
@@ -327,8 +340,16 @@ func (s *perOperationSampler) update(strategies *jaeger_api_v2.PerOperationSampl
 		}
 	}
 	s.lowerBound = strategies.DefaultLowerBoundTracesPerSecond
-	if s.defaultSampler.SamplingRate() != strategies.DefaultSamplingProbability {
+	if s.defaultSampler == nil || s.defaultSampler.SamplingRate() != strategies.DefaultSamplingProbability {
 		s.defaultSampler = newProbabilisticSampler(strategies.DefaultSamplingProbability)
 	}
+	switch s.unknownOperationPolicy {
+	case UnknownOperationParentBased:
+		s.unknownSampler = trace.ParentBased(s.defaultSampler)
+	case UnknownOperationLowestProbability:
+		s.unknownSampler = newProbabilisticSampler(lowest)
+	default:
+		s.unknownSampler = nil
+	}
 	s.samplers = newSamplers
 }
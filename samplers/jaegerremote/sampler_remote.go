@@ -18,6 +18,7 @@ package jaegerremote // import "go.opentelemetry.io/contrib/samplers/jaegerremot
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -69,13 +70,15 @@ type samplerUpdater interface {
 type Sampler struct {
 	// These fields must be first in the struct because `sync/atomic` expects 64-bit alignment.
 	// Cf. https://github.com/uber/jaeger-client-go/issues/155, https://goo.gl/zW7dgq
-	closed int64 // 0 - not closed, 1 - closed
+	closed     int64 // 0 - not closed, 1 - closed
+	lastUpdate int64 // Unix time in nanoseconds of the last successful update, 0 if none
 
 	sync.RWMutex // used to serialize access to samplerConfig.sampler
 	config
 
 	serviceName string
 	doneChan    chan *sync.WaitGroup
+	metrics     *samplerMetrics
 }
 
 // New creates a sampler that periodically pulls
@@ -90,6 +93,7 @@ func New(
 		serviceName: serviceName,
 		doneChan:    make(chan *sync.WaitGroup),
 	}
+	sampler.metrics = newSamplerMetrics(sampler)
 	go sampler.pollController()
 	return sampler
 }
@@ -114,6 +118,7 @@ func (s *Sampler) Close() {
 	wg.Add(1)
 	s.doneChan <- &wg
 	wg.Wait()
+	s.metrics.unregister()
 }
 
 // Description returns a human-readable name for the Sampler.
@@ -150,13 +155,16 @@ func (s *Sampler) setSampler(sampler trace.Sampler) {
 // UpdateSampler forces the sampler to fetch sampling strategy from backend server.
 // This function is called automatically on a timer, but can also be safely called manually, e.g. from tests.
 func (s *Sampler) UpdateSampler() {
+	ctx := context.Background()
 	res, err := s.samplingFetcher.Fetch(s.serviceName)
 	if err != nil {
+		s.metrics.polls.Add(ctx, 1, pollFetchFailed)
 		s.logger.Error(err, "failed to fetch sampling strategy")
 		return
 	}
 	strategy, err := s.samplingParser.Parse(res)
 	if err != nil {
+		s.metrics.polls.Add(ctx, 1, pollParseFailed)
 		s.logger.Error(err, "failed to parse sampling strategy response")
 		return
 	}
@@ -165,9 +173,26 @@ func (s *Sampler) UpdateSampler() {
 	defer s.Unlock()
 
 	if err := s.updateSamplerViaUpdaters(strategy); err != nil {
+		s.metrics.polls.Add(ctx, 1, pollApplyFailed)
 		s.logger.Error(err, "failed to handle sampling strategy response", "response", res)
 		return
 	}
+	atomic.StoreInt64(&s.lastUpdate, time.Now().UnixNano())
+	s.metrics.polls.Add(ctx, 1, pollSucceeded)
+}
+
+// operations returns the number of operations with a dedicated sampler if
+// the per-operation sampling strategy is used, 0 otherwise.
+func (s *Sampler) operations() int {
+	s.RLock()
+	defer s.RUnlock()
+	pos, ok := s.sampler.(*perOperationSampler)
+	if !ok {
+		return 0
+	}
+	pos.RLock()
+	defer pos.RUnlock()
+	return len(pos.samplers)
 }
 
 // NB: this function should only be called while holding a Write lock.
@@ -240,6 +265,7 @@ func (u *rateLimitingSamplerUpdater) Update(sampler trace.Sampler, strategy inte
 // Fields have the same meaning as in perOperationSamplerParams.
 type perOperationSamplerUpdater struct {
 	MaxOperations            int
+	UnknownOperationPolicy   UnknownOperationPolicy
 	OperationNameLateBinding bool
 }
 
@@ -257,6 +283,7 @@ func (u *perOperationSamplerUpdater) Update(sampler trace.Sampler, strategy inte
 			}
 			return newPerOperationSampler(perOperationSamplerParams{
 				MaxOperations:            u.MaxOperations,
+				UnknownOperationPolicy:   u.UnknownOperationPolicy,
 				OperationNameLateBinding: u.OperationNameLateBinding,
 				Strategies:               operations,
 			}), nil
@@ -269,15 +296,23 @@ func (u *perOperationSamplerUpdater) Update(sampler trace.Sampler, strategy inte
 
 type httpSamplingStrategyFetcher struct {
 	serverURL  string
-	httpClient http.Client
+	httpClient *http.Client
+	headers    http.Header
 }
 
-func newHTTPSamplingStrategyFetcher(serverURL string) *httpSamplingStrategyFetcher {
-	return &httpSamplingStrategyFetcher{
-		serverURL: serverURL,
-		httpClient: http.Client{
+// newHTTPSamplingStrategyFetcher returns a fetcher of the sampling strategies
+// served at serverURL. The requests are sent with httpClient, or a client
+// with the default timeout if it is nil, and carry headers.
+func newHTTPSamplingStrategyFetcher(serverURL string, httpClient *http.Client, headers http.Header) *httpSamplingStrategyFetcher {
+	if httpClient == nil {
+		httpClient = &http.Client{
 			Timeout: defaultRemoteSamplingTimeout,
-		},
+		}
+	}
+	return &httpSamplingStrategyFetcher{
+		serverURL:  serverURL,
+		httpClient: httpClient,
+		headers:    headers,
 	}
 }
 
@@ -286,7 +321,15 @@ func (f *httpSamplingStrategyFetcher) Fetch(serviceName string) ([]byte, error)
 	v.Set("service", serviceName)
 	uri := f.serverURL + "?" + v.Encode()
 
-	resp, err := f.httpClient.Get(uri)
+	req, err := http.NewRequest(http.MethodGet, uri, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	for k, vs := range f.headers {
+		req.Header[k] = vs
+	}
+
+	resp, err := f.httpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
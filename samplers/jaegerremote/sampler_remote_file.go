@@ -0,0 +1,173 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaegerremote // import "go.opentelemetry.io/contrib/samplers/jaegerremote"
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	jaeger_api_v2 "go.opentelemetry.io/contrib/samplers/jaegerremote/internal/proto-gen/jaeger-idl/proto/api_v2"
+)
+
+// defaultFileSamplingProbability is the sampling probability of the services
+// without a strategy when the strategies do not define a default strategy,
+// as with the Jaeger Collector.
+const defaultFileSamplingProbability = 0.001
+
+// Strategy types of the strategies file.
+const (
+	probabilisticStrategyType = "probabilistic"
+	rateLimitingStrategyType  = "ratelimiting"
+)
+
+// strategies are the sampling strategies of services, in the format of the
+// strategies file of the Jaeger Collector.
+type strategies struct {
+	ServiceStrategies []serviceStrategy `json:"service_strategies"`
+	DefaultStrategy   *serviceStrategy  `json:"default_strategy"`
+}
+
+// strategy is a sampling strategy, its type defining the meaning of its
+// parameter: the sampling probability or the maximum number of traces per
+// second.
+type strategy struct {
+	Type  string  `json:"type"`
+	Param float64 `json:"param"`
+}
+
+// operationStrategy is the sampling strategy of an operation.
+type operationStrategy struct {
+	Operation string `json:"operation"`
+	strategy
+}
+
+// serviceStrategy is the sampling strategy of a service, and of its
+// operations if it is probabilistic.
+type serviceStrategy struct {
+	Service string `json:"service"`
+	strategy
+	OperationStrategies []operationStrategy `json:"operation_strategies"`
+}
+
+// fileSamplingStrategyFetcher fetches sampling strategies from a strategies
+// file, read on each fetch so its updates are applied, or from strategies
+// defined inline.
+type fileSamplingStrategyFetcher struct {
+	path string
+	data []byte
+}
+
+func newFileSamplingStrategyFetcher(path string) *fileSamplingStrategyFetcher {
+	return &fileSamplingStrategyFetcher{path: path}
+}
+
+func newInlineSamplingStrategyFetcher(data []byte) *fileSamplingStrategyFetcher {
+	return &fileSamplingStrategyFetcher{data: append([]byte(nil), data...)}
+}
+
+// Fetch returns the sampling strategy of serviceName encoded in JSON, as
+// returned by the HTTP sampling endpoint, so it is parsed the same way.
+func (f *fileSamplingStrategyFetcher) Fetch(serviceName string) ([]byte, error) {
+	data := f.data
+	if f.path != "" {
+		var err error
+		if data, err = os.ReadFile(f.path); err != nil {
+			return nil, err
+		}
+	}
+
+	var s strategies
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse sampling strategies: %w", err)
+	}
+	resp, err := s.response(serviceName)
+	if err != nil {
+		return nil, err
+	}
+	return marshalSamplingStrategy(resp)
+}
+
+// response returns the sampling strategy of serviceName, as returned by the
+// Jaeger Collector: the strategy of the service, or the default one, merged
+// with the operation strategies of the default strategy.
+func (s strategies) response(serviceName string) (*jaeger_api_v2.SamplingStrategyResponse, error) {
+	defaultStrategy := serviceStrategy{
+		strategy: strategy{Type: probabilisticStrategyType, Param: defaultFileSamplingProbability},
+	}
+	if s.DefaultStrategy != nil {
+		defaultStrategy = *s.DefaultStrategy
+	}
+
+	ss := defaultStrategy
+	for _, candidate := range s.ServiceStrategies {
+		if candidate.Service == serviceName {
+			ss = candidate
+			break
+		}
+	}
+
+	resp := new(jaeger_api_v2.SamplingStrategyResponse)
+	switch ss.Type {
+	case probabilisticStrategyType:
+		resp.StrategyType = jaeger_api_v2.SamplingStrategyType_PROBABILISTIC
+		resp.ProbabilisticSampling = &jaeger_api_v2.ProbabilisticSamplingStrategy{
+			SamplingRate: ss.Param,
+		}
+	case rateLimitingStrategyType:
+		resp.StrategyType = jaeger_api_v2.SamplingStrategyType_RATE_LIMITING
+		resp.RateLimitingSampling = &jaeger_api_v2.RateLimitingSamplingStrategy{
+			MaxTracesPerSecond: int32(ss.Param),
+		}
+		// Operation strategies only apply to probabilistic strategies.
+		return resp, nil
+	default:
+		return nil, fmt.Errorf("unsupported sampling strategy type %q of service %q", ss.Type, ss.Service)
+	}
+
+	operations := operationStrategies(ss.OperationStrategies, defaultStrategy.OperationStrategies)
+	if len(operations) > 0 {
+		resp.OperationSampling = &jaeger_api_v2.PerOperationSamplingStrategies{
+			DefaultSamplingProbability: ss.Param,
+			PerOperationStrategies:     operations,
+		}
+	}
+	return resp, nil
+}
+
+// operationStrategies returns the probabilistic strategies of the operations
+// of a service, the ones of service taking precedence over the defaults.
+// Other strategies are not supported for operations and are ignored.
+func operationStrategies(service, defaults []operationStrategy) []*jaeger_api_v2.OperationSamplingStrategy {
+	var (
+		seen   = make(map[string]bool)
+		result []*jaeger_api_v2.OperationSamplingStrategy
+	)
+	for _, ops := range [][]operationStrategy{service, defaults} {
+		for _, op := range ops {
+			if op.Type != probabilisticStrategyType || seen[op.Operation] {
+				continue
+			}
+			seen[op.Operation] = true
+			result = append(result, &jaeger_api_v2.OperationSamplingStrategy{
+				Operation: op.Operation,
+				ProbabilisticSampling: &jaeger_api_v2.ProbabilisticSamplingStrategy{
+					SamplingRate: op.Param,
+				},
+			})
+		}
+	}
+	return result
+}
@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaegerremote
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	jaeger_api_v2 "go.opentelemetry.io/contrib/samplers/jaegerremote/internal/proto-gen/jaeger-idl/proto/api_v2"
+)
+
+const testStrategies = `{
+  "service_strategies": [
+    {
+      "service": "foo",
+      "type": "probabilistic",
+      "param": 0.8,
+      "operation_strategies": [
+        {"operation": "op1", "type": "probabilistic", "param": 0.2},
+        {"operation": "op2", "type": "ratelimiting", "param": 5}
+      ]
+    },
+    {"service": "bar", "type": "ratelimiting", "param": 5}
+  ],
+  "default_strategy": {
+    "type": "probabilistic",
+    "param": 0.5,
+    "operation_strategies": [
+      {"operation": "op1", "type": "probabilistic", "param": 0.1},
+      {"operation": "/health", "type": "probabilistic", "param": 0}
+    ]
+  }
+}`
+
+func fetchStrategy(t *testing.T, f SamplingStrategyFetcher, service string) *jaeger_api_v2.SamplingStrategyResponse {
+	t.Helper()
+	body, err := f.Fetch(service)
+	require.NoError(t, err)
+	strategy, err := new(samplingStrategyParserImpl).Parse(body)
+	require.NoError(t, err)
+	return strategy.(*jaeger_api_v2.SamplingStrategyResponse)
+}
+
+func TestInlineSamplingStrategyFetcher(t *testing.T) {
+	f := newInlineSamplingStrategyFetcher([]byte(testStrategies))
+
+	assert.Equal(t, &jaeger_api_v2.SamplingStrategyResponse{
+		StrategyType:          jaeger_api_v2.SamplingStrategyType_PROBABILISTIC,
+		ProbabilisticSampling: &jaeger_api_v2.ProbabilisticSamplingStrategy{SamplingRate: 0.8},
+		OperationSampling: &jaeger_api_v2.PerOperationSamplingStrategies{
+			DefaultSamplingProbability: 0.8,
+			PerOperationStrategies: []*jaeger_api_v2.OperationSamplingStrategy{
+				{Operation: "op1", ProbabilisticSampling: &jaeger_api_v2.ProbabilisticSamplingStrategy{SamplingRate: 0.2}},
+				{Operation: "/health", ProbabilisticSampling: &jaeger_api_v2.ProbabilisticSamplingStrategy{SamplingRate: 0}},
+			},
+		},
+	}, fetchStrategy(t, f, "foo"))
+
+	assert.Equal(t, &jaeger_api_v2.SamplingStrategyResponse{
+		StrategyType:         jaeger_api_v2.SamplingStrategyType_RATE_LIMITING,
+		RateLimitingSampling: &jaeger_api_v2.RateLimitingSamplingStrategy{MaxTracesPerSecond: 5},
+	}, fetchStrategy(t, f, "bar"))
+
+	assert.Equal(t, &jaeger_api_v2.SamplingStrategyResponse{
+		StrategyType:          jaeger_api_v2.SamplingStrategyType_PROBABILISTIC,
+		ProbabilisticSampling: &jaeger_api_v2.ProbabilisticSamplingStrategy{SamplingRate: 0.5},
+		OperationSampling: &jaeger_api_v2.PerOperationSamplingStrategies{
+			DefaultSamplingProbability: 0.5,
+			PerOperationStrategies: []*jaeger_api_v2.OperationSamplingStrategy{
+				{Operation: "op1", ProbabilisticSampling: &jaeger_api_v2.ProbabilisticSamplingStrategy{SamplingRate: 0.1}},
+				{Operation: "/health", ProbabilisticSampling: &jaeger_api_v2.ProbabilisticSamplingStrategy{SamplingRate: 0}},
+			},
+		},
+	}, fetchStrategy(t, f, "unknown"))
+}
+
+func TestInlineSamplingStrategyFetcherDefault(t *testing.T) {
+	f := newInlineSamplingStrategyFetcher([]byte(`{}`))
+	assert.Equal(t, &jaeger_api_v2.SamplingStrategyResponse{
+		StrategyType:          jaeger_api_v2.SamplingStrategyType_PROBABILISTIC,
+		ProbabilisticSampling: &jaeger_api_v2.ProbabilisticSamplingStrategy{SamplingRate: defaultFileSamplingProbability},
+	}, fetchStrategy(t, f, "foo"))
+}
+
+func TestInlineSamplingStrategyFetcherErrors(t *testing.T) {
+	_, err := newInlineSamplingStrategyFetcher([]byte(`{`)).Fetch("foo")
+	assert.ErrorContains(t, err, "failed to parse sampling strategies")
+
+	_, err = newInlineSamplingStrategyFetcher([]byte(`{"default_strategy": {"type": "adaptive"}}`)).Fetch("foo")
+	assert.ErrorContains(t, err, `unsupported sampling strategy type "adaptive"`)
+}
+
+func TestFileSamplingStrategyFetcherReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "strategies.json")
+	f := newFileSamplingStrategyFetcher(path)
+
+	_, err := f.Fetch("foo")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	require.NoError(t, os.WriteFile(path, []byte(testStrategies), 0o600))
+	assert.Equal(t, 0.8, fetchStrategy(t, f, "foo").GetProbabilisticSampling().GetSamplingRate())
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"default_strategy": {"type": "probabilistic", "param": 0.3}}`), 0o600))
+	assert.Equal(t, 0.3, fetchStrategy(t, f, "foo").GetProbabilisticSampling().GetSamplingRate())
+}
+
+func TestRemotelyControlledSamplerStrategiesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "strategies.json")
+	require.NoError(t, os.WriteFile(path, []byte(testStrategies), 0o600))
+
+	sampler := New("foo",
+		WithSamplingStrategiesFile(path),
+		WithSamplingRefreshInterval(time.Hour),
+	)
+	sampler.Close() // Returns once the sampler is updated on startup.
+
+	pos, ok := sampler.sampler.(*perOperationSampler)
+	require.True(t, ok, "sampler not updated")
+	assert.Equal(t, 0.8, pos.defaultSampler.SamplingRate())
+	assert.Len(t, pos.samplers, 2)
+}
+
+func TestSamplingStrategiesOptions(t *testing.T) {
+	data := []byte(testStrategies)
+	c := newConfig(WithSamplingStrategies(data))
+	data[0] = 'x'
+	assert.Equal(t, 0.8, fetchStrategy(t, c.samplingFetcher, "foo").GetProbabilisticSampling().GetSamplingRate())
+
+	c = newConfig(WithSamplingStrategiesFile("strategies.json"))
+	assert.Equal(t, newFileSamplingStrategyFetcher("strategies.json"), c.samplingFetcher)
+}
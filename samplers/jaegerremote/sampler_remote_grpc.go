@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaegerremote // import "go.opentelemetry.io/contrib/samplers/jaegerremote"
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
+	"google.golang.org/grpc"
+
+	jaeger_api_v2 "go.opentelemetry.io/contrib/samplers/jaegerremote/internal/proto-gen/jaeger-idl/proto/api_v2"
+)
+
+// getSamplingStrategyMethod is the full name of the method of the Jaeger
+// SamplingManager gRPC service returning the sampling strategy of a service.
+const getSamplingStrategyMethod = "/jaeger.api_v2.SamplingManager/GetSamplingStrategy"
+
+// grpcSamplingStrategyFetcher fetches sampling strategies from the
+// SamplingManager gRPC service, e.g. of the Jaeger Collector.
+type grpcSamplingStrategyFetcher struct {
+	conn grpc.ClientConnInterface
+}
+
+func newGRPCSamplingStrategyFetcher(conn grpc.ClientConnInterface) *grpcSamplingStrategyFetcher {
+	return &grpcSamplingStrategyFetcher{conn: conn}
+}
+
+// Fetch returns the sampling strategy of serviceName encoded in JSON, as
+// returned by the HTTP sampling endpoint, so it is parsed the same way.
+func (f *grpcSamplingStrategyFetcher) Fetch(serviceName string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultRemoteSamplingTimeout)
+	defer cancel()
+
+	req := &jaeger_api_v2.SamplingStrategyParameters{ServiceName: serviceName}
+	resp := new(jaeger_api_v2.SamplingStrategyResponse)
+	if err := f.conn.Invoke(ctx, getSamplingStrategyMethod, req, resp, grpc.ForceCodec(gogoCodec{})); err != nil {
+		return nil, err
+	}
+	return marshalSamplingStrategy(resp)
+}
+
+// marshalSamplingStrategy returns the JSON encoding of resp, as returned by
+// the HTTP sampling endpoint.
+func marshalSamplingStrategy(resp *jaeger_api_v2.SamplingStrategyResponse) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := new(jsonpb.Marshaler).Marshal(&buf, resp); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// gogoCodec is the gRPC codec of the gogo protobuf messages generated for the
+// Jaeger API, which are not supported by the default codec of gRPC.
+type gogoCodec struct{}
+
+// Marshal returns the protobuf encoding of v.
+func (gogoCodec) Marshal(v interface{}) ([]byte, error) {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("failed to marshal, message is %T, want proto.Message", v)
+	}
+	return proto.Marshal(msg)
+}
+
+// Unmarshal decodes the protobuf encoded data into v.
+func (gogoCodec) Unmarshal(data []byte, v interface{}) error {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Errorf("failed to unmarshal, message is %T, want proto.Message", v)
+	}
+	return proto.Unmarshal(data, msg)
+}
+
+// Name returns the name of the protobuf codec, used as the content-subtype
+// of the requests.
+func (gogoCodec) Name() string {
+	return "proto"
+}
@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaegerremote
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	jaeger_api_v2 "go.opentelemetry.io/contrib/samplers/jaegerremote/internal/proto-gen/jaeger-idl/proto/api_v2"
+)
+
+// samplingManagerServer serves the strategies of services, by service name,
+// with the SamplingManager gRPC service.
+type samplingManagerServer map[string]*jaeger_api_v2.SamplingStrategyResponse
+
+func (s samplingManagerServer) getSamplingStrategy(_ context.Context, req *jaeger_api_v2.SamplingStrategyParameters) (*jaeger_api_v2.SamplingStrategyResponse, error) {
+	if resp, ok := s[req.ServiceName]; ok {
+		return resp, nil
+	}
+	return nil, status.Errorf(codes.NotFound, "no strategy for %q", req.ServiceName)
+}
+
+// startSamplingManager starts a gRPC server of strategies and returns a
+// connection to it.
+func startSamplingManager(t *testing.T, strategies samplingManagerServer) *grpc.ClientConn {
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(grpc.ForceServerCodec(gogoCodec{}))
+	srv.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "jaeger.api_v2.SamplingManager",
+		HandlerType: (*interface{})(nil),
+		Methods: []grpc.MethodDesc{{
+			MethodName: "GetSamplingStrategy",
+			Handler: func(_ interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+				req := new(jaeger_api_v2.SamplingStrategyParameters)
+				if err := dec(req); err != nil {
+					return nil, err
+				}
+				return strategies.getSamplingStrategy(ctx, req)
+			},
+		}},
+	}, nil)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func TestGRPCSamplingStrategyFetcher(t *testing.T) {
+	conn := startSamplingManager(t, samplingManagerServer{
+		"foo": getSamplingStrategyResponse(jaeger_api_v2.SamplingStrategyType_RATE_LIMITING, 5),
+	})
+	fetcher := newGRPCSamplingStrategyFetcher(conn)
+
+	body, err := fetcher.Fetch("foo")
+	require.NoError(t, err)
+	strategy, err := new(samplingStrategyParserImpl).Parse(body)
+	require.NoError(t, err)
+	assert.Equal(t, int32(5), strategy.(*jaeger_api_v2.SamplingStrategyResponse).GetRateLimitingSampling().GetMaxTracesPerSecond())
+
+	_, err = fetcher.Fetch("bar")
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestRemotelyControlledSamplerGRPC(t *testing.T) {
+	conn := startSamplingManager(t, samplingManagerServer{
+		"foo": getSamplingStrategyResponse(jaeger_api_v2.SamplingStrategyType_PROBABILISTIC, 0.5),
+	})
+	sampler := New("foo",
+		WithSamplingServerGRPCConn(conn),
+		WithSamplingRefreshInterval(time.Hour),
+	)
+	sampler.Close() // Returns once the sampler is updated on startup.
+
+	ps, ok := sampler.sampler.(*probabilisticSampler)
+	require.True(t, ok, "sampler not updated")
+	assert.Equal(t, 0.5, ps.SamplingRate())
+}
@@ -17,10 +17,14 @@
 package jaegerremote // import "go.opentelemetry.io/contrib/samplers/jaegerremote"
 
 import (
+	"net/http"
 	"time"
 
 	"github.com/go-logr/logr"
+	"google.golang.org/grpc"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/sdk/trace"
 )
 
@@ -29,10 +33,13 @@ type config struct {
 	samplingServerURL       string
 	samplingRefreshInterval time.Duration
 	samplingFetcher         SamplingStrategyFetcher
+	httpClient              *http.Client
+	httpHeaders             http.Header
 	samplingParser          samplingStrategyParser
 	updaters                []samplerUpdater
 	posParams               perOperationSamplerParams
 	logger                  logr.Logger
+	meterProvider           metric.MeterProvider
 }
 
 // newConfig returns an appropriately configured config.
@@ -41,7 +48,6 @@ func newConfig(options ...Option) config {
 		sampler:                 newProbabilisticSampler(0.001),
 		samplingServerURL:       defaultSamplingServerURL,
 		samplingRefreshInterval: defaultSamplingRefreshInterval,
-		samplingFetcher:         newHTTPSamplingStrategyFetcher(defaultSamplingServerURL),
 		samplingParser:          new(samplingStrategyParserImpl),
 		updaters: []samplerUpdater{
 			new(probabilisticSamplerUpdater),
@@ -56,8 +62,15 @@ func newConfig(options ...Option) config {
 	for _, option := range options {
 		option.apply(&c)
 	}
+	if c.samplingFetcher == nil {
+		c.samplingFetcher = newHTTPSamplingStrategyFetcher(c.samplingServerURL, c.httpClient, c.httpHeaders)
+	}
+	if c.meterProvider == nil {
+		c.meterProvider = otel.GetMeterProvider()
+	}
 	c.updaters = append([]samplerUpdater{&perOperationSamplerUpdater{
 		MaxOperations:            c.posParams.MaxOperations,
+		UnknownOperationPolicy:   c.posParams.UnknownOperationPolicy,
 		OperationNameLateBinding: c.posParams.OperationNameLateBinding,
 	}}, c.updaters...)
 	return c
@@ -87,19 +100,108 @@ func WithInitialSampler(sampler trace.Sampler) Option {
 func WithSamplingServerURL(samplingServerURL string) Option {
 	return optionFunc(func(c *config) {
 		c.samplingServerURL = samplingServerURL
-		// The default port of jaeger agent is 5778, but there are other ports specified by the user, so the sampling address and fetch address are strongly bound
-		c.samplingFetcher = newHTTPSamplingStrategyFetcher(samplingServerURL)
+		// The default port of jaeger agent is 5778, but there are other ports specified by the user, so the sampling address and fetch address are strongly bound.
+		// The HTTP fetcher of the url is created once all the options are applied.
+		c.samplingFetcher = nil
+	})
+}
+
+// WithHTTPClient creates a Option that sets the HTTP client used to fetch the
+// sampling strategies from the sampling server, e.g. with a transport
+// presenting a client certificate for mTLS, or adding authentication
+// headers refreshed over time. The timeout of the client should be set, a
+// client with a timeout of 10 seconds is used by default.
+func WithHTTPClient(client *http.Client) Option {
+	return optionFunc(func(c *config) {
+		c.httpClient = client
+	})
+}
+
+// WithHTTPHeaders creates a Option that sets headers sent with the requests
+// fetching the sampling strategies from the sampling server, e.g. an
+// Authorization header when the server is behind an authenticating gateway.
+func WithHTTPHeaders(headers map[string]string) Option {
+	return optionFunc(func(c *config) {
+		c.httpHeaders = make(http.Header, len(headers))
+		for k, v := range headers {
+			c.httpHeaders.Set(k, v)
+		}
+	})
+}
+
+// WithSamplingServerGRPCConn creates a Option that fetches the sampling
+// strategies from the SamplingManager gRPC service of the server conn is
+// connected to, e.g. the Jaeger Collector on port 14250, instead of the HTTP
+// sampling server. The connection, its security and its lifetime are
+// managed by the caller.
+func WithSamplingServerGRPCConn(conn grpc.ClientConnInterface) Option {
+	return optionFunc(func(c *config) {
+		c.samplingFetcher = newGRPCSamplingStrategyFetcher(conn)
+	})
+}
+
+// WithSamplingStrategiesFile creates a Option that reads the sampling
+// strategies from the file at path, in the format of the strategies file of
+// the Jaeger Collector, instead of fetching them from a sampling server. The
+// file is read on each refresh of the sampling strategy, so its updates are
+// applied without restarting the application.
+func WithSamplingStrategiesFile(path string) Option {
+	return optionFunc(func(c *config) {
+		c.samplingFetcher = newFileSamplingStrategyFetcher(path)
+	})
+}
+
+// WithSamplingStrategies creates a Option that uses the sampling strategies
+// of the JSON data, in the format of the strategies file of the Jaeger
+// Collector, instead of fetching them from a sampling server.
+func WithSamplingStrategies(data []byte) Option {
+	return optionFunc(func(c *config) {
+		c.samplingFetcher = newInlineSamplingStrategyFetcher(data)
 	})
 }
 
 // WithMaxOperations creates a Option that sets the maximum number of
-// operations the sampler will keep track of.
+// operations the sampler will keep track of. Unless the UnknownOperationPolicy
+// is UnknownOperationDefaultProbability, the operations of the per-operation
+// sampling strategy beyond this number are sampled according to the policy,
+// bounding the memory usage of the sampler.
 func WithMaxOperations(maxOperations int) Option {
 	return optionFunc(func(c *config) {
 		c.posParams.MaxOperations = maxOperations
 	})
 }
 
+// UnknownOperationPolicy defines how the per-operation sampling strategy
+// samples the operations it has no strategy for.
+type UnknownOperationPolicy int
+
+const (
+	// UnknownOperationDefaultProbability samples each unknown operation with
+	// the default sampling probability of the strategy, guaranteeing it the
+	// lower bound of traces per second of the strategy. Each operation is
+	// tracked until the maximum number of operations is reached, the other
+	// operations are then only sampled with the default probability. This is
+	// the default policy.
+	UnknownOperationDefaultProbability UnknownOperationPolicy = iota
+	// UnknownOperationParentBased follows the sampling decision of the parent
+	// span for the unknown operations, and samples the root spans with the
+	// default sampling probability of the strategy. The unknown operations
+	// are not tracked.
+	UnknownOperationParentBased
+	// UnknownOperationLowestProbability samples the unknown operations with
+	// the lowest sampling probability of the strategy, among its default and
+	// per-operation probabilities. The unknown operations are not tracked.
+	UnknownOperationLowestProbability
+)
+
+// WithUnknownOperationPolicy creates a Option that sets how the operations
+// without a strategy of the per-operation sampling strategy are sampled.
+func WithUnknownOperationPolicy(policy UnknownOperationPolicy) Option {
+	return optionFunc(func(c *config) {
+		c.posParams.UnknownOperationPolicy = policy
+	})
+}
+
 // WithOperationNameLateBinding creates a Option that sets the respective
 // field in the perOperationSamplerParams.
 func WithOperationNameLateBinding(enable bool) Option {
@@ -123,6 +225,17 @@ func WithLogger(logger logr.Logger) Option {
 	})
 }
 
+// WithMeterProvider configures the sampler to report its metrics with
+// provider: the polls of the sampling strategy by result, the time of the
+// last successful update of the strategy and the number of operations of the
+// per-operation strategy. The global MeterProvider is used if provider is
+// nil or not set.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return optionFunc(func(c *config) {
+		c.meterProvider = provider
+	})
+}
+
 // WithSamplingStrategyFetcher creates an Option that initializes the sampling strategy fetcher.
 // Custom fetcher can be used for setting custom headers, timeouts, etc., or getting
 // sampling strategies from a different source, like files.
@@ -17,9 +17,12 @@
 package jaegerremote
 
 import (
+	"context"
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"sync"
 	"testing"
 	"time"
@@ -30,6 +33,9 @@ import (
 
 	jaeger_api_v2 "go.opentelemetry.io/contrib/samplers/jaegerremote/internal/proto-gen/jaeger-idl/proto/api_v2"
 	"go.opentelemetry.io/contrib/samplers/jaegerremote/internal/testutils"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	"go.opentelemetry.io/otel/sdk/trace"
 	oteltrace "go.opentelemetry.io/otel/trace"
 )
@@ -112,6 +118,7 @@ func TestRemoteSamplerOptions(t *testing.T) {
 	sampler := New(
 		"test",
 		WithMaxOperations(42),
+		WithUnknownOperationPolicy(UnknownOperationLowestProbability),
 		WithOperationNameLateBinding(true),
 		WithInitialSampler(initSampler),
 		WithSamplingServerURL("my url"),
@@ -123,13 +130,14 @@ func TestRemoteSamplerOptions(t *testing.T) {
 	)
 	defer sampler.Close()
 	assert.Equal(t, 42, sampler.posParams.MaxOperations)
+	assert.Equal(t, UnknownOperationLowestProbability, sampler.posParams.UnknownOperationPolicy)
 	assert.True(t, sampler.posParams.OperationNameLateBinding)
 	assert.Same(t, initSampler, sampler.sampler)
 	assert.Equal(t, "my url", sampler.samplingServerURL)
 	assert.Equal(t, 42*time.Second, sampler.samplingRefreshInterval)
 	assert.Same(t, fetcher, sampler.samplingFetcher)
 	assert.Same(t, parser, sampler.samplingParser)
-	assert.EqualValues(t, sampler.updaters[0], &perOperationSamplerUpdater{MaxOperations: 42, OperationNameLateBinding: true})
+	assert.EqualValues(t, sampler.updaters[0], &perOperationSamplerUpdater{MaxOperations: 42, UnknownOperationPolicy: UnknownOperationLowestProbability, OperationNameLateBinding: true})
 	assert.Equal(t, logger, sampler.logger)
 }
 
@@ -590,6 +598,137 @@ func TestSamplingStrategyParserImpl_Error(t *testing.T) {
 }
 
 func TestDefaultSamplingStrategyFetcher_Timeout(t *testing.T) {
-	fetcher := newHTTPSamplingStrategyFetcher("")
+	fetcher := newHTTPSamplingStrategyFetcher("", nil, nil)
 	assert.Equal(t, defaultRemoteSamplingTimeout, fetcher.httpClient.Timeout)
 }
+
+func TestHTTPSamplingStrategyFetcherClientAndHeaders(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"strategyType":0,"probabilisticSampling":{"samplingRate":0.5}}`))
+	}))
+	defer srv.Close()
+
+	c := newConfig(
+		WithSamplingServerURL(srv.URL),
+		WithHTTPClient(srv.Client()),
+		WithHTTPHeaders(map[string]string{"authorization": "Bearer token"}),
+	)
+	fetcher, ok := c.samplingFetcher.(*httpSamplingStrategyFetcher)
+	require.True(t, ok)
+	assert.Same(t, srv.Client(), fetcher.httpClient)
+
+	body, err := fetcher.Fetch("test")
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "samplingRate")
+
+	// The client does not trust the certificate of the server.
+	c = newConfig(
+		WithSamplingServerURL(srv.URL),
+		WithHTTPHeaders(map[string]string{"Authorization": "Bearer token"}),
+	)
+	_, err = c.samplingFetcher.Fetch("test")
+	assert.Error(t, err)
+
+	c = newConfig(WithSamplingServerURL(srv.URL), WithHTTPClient(srv.Client()))
+	_, err = c.samplingFetcher.Fetch("test")
+	assert.ErrorContains(t, err, "401")
+}
+
+func TestSamplingStrategyFetcherOptionsOrder(t *testing.T) {
+	fetcher := new(fakeSamplingFetcher)
+	c := newConfig(WithSamplingServerURL("my url"), WithSamplingStrategyFetcher(fetcher))
+	assert.Same(t, fetcher, c.samplingFetcher)
+
+	c = newConfig(WithSamplingStrategyFetcher(fetcher), WithSamplingServerURL("my url"))
+	httpFetcher, ok := c.samplingFetcher.(*httpSamplingStrategyFetcher)
+	require.True(t, ok)
+	assert.Equal(t, "my url", httpFetcher.serverURL)
+}
+
+// switchingFetcher fetches its response, or fails if it is nil.
+type switchingFetcher struct {
+	response []byte
+}
+
+func (f *switchingFetcher) Fetch(string) ([]byte, error) {
+	if f.response == nil {
+		return nil, errors.New("query error")
+	}
+	return f.response, nil
+}
+
+func TestRemotelyControlledSamplerMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	fetcher := new(switchingFetcher)
+	sampler := &Sampler{
+		config: newConfig(
+			WithSamplingStrategyFetcher(fetcher),
+			withSamplingStrategyParser(new(testSamplingStrategyParser)),
+			WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+		),
+		serviceName: "test",
+	}
+	sampler.metrics = newSamplerMetrics(sampler)
+
+	collect := func() map[string]metricdata.Aggregation {
+		var rm metricdata.ResourceMetrics
+		require.NoError(t, reader.Collect(context.Background(), &rm))
+		got := make(map[string]metricdata.Aggregation)
+		for _, sm := range rm.ScopeMetrics {
+			assert.Equal(t, scopeName, sm.Scope.Name)
+			for _, m := range sm.Metrics {
+				got[m.Name] = m.Data
+			}
+		}
+		return got
+	}
+
+	got := collect()
+	assert.NotContains(t, got, strategyLastUpdate, "last update reported before any update")
+	require.Contains(t, got, strategyOperations)
+	assert.Equal(t, int64(0), got[strategyOperations].(metricdata.Gauge[int64]).DataPoints[0].Value)
+
+	sampler.UpdateSampler() // Fetch failure.
+	fetcher.response = []byte("unknown")
+	sampler.UpdateSampler() // Parse failure.
+	fetcher.response = []byte("probabilistic")
+	before := time.Now().Unix()
+	sampler.UpdateSampler()
+	sampler.UpdateSampler()
+
+	got = collect()
+	polls, ok := got[strategyPolls].(metricdata.Sum[int64])
+	require.True(t, ok)
+	counts := make(map[attribute.Set]int64)
+	for _, dp := range polls.DataPoints {
+		counts[dp.Attributes] = dp.Value
+	}
+	assert.Equal(t, map[attribute.Set]int64{
+		attribute.NewSet(resultKey.String("success")):                           2,
+		attribute.NewSet(resultKey.String("failure"), stageKey.String("fetch")): 1,
+		attribute.NewSet(resultKey.String("failure"), stageKey.String("parse")): 1,
+	}, counts)
+
+	lastUpdate, ok := got[strategyLastUpdate].(metricdata.Gauge[int64])
+	require.True(t, ok)
+	assert.GreaterOrEqual(t, lastUpdate.DataPoints[0].Value, before)
+
+	sampler.setSampler(newPerOperationSampler(perOperationSamplerParams{
+		Strategies: &jaeger_api_v2.PerOperationSamplingStrategies{
+			PerOperationStrategies: []*jaeger_api_v2.OperationSamplingStrategy{
+				{Operation: "op1", ProbabilisticSampling: &jaeger_api_v2.ProbabilisticSamplingStrategy{}},
+				{Operation: "op2", ProbabilisticSampling: &jaeger_api_v2.ProbabilisticSamplingStrategy{}},
+			},
+		},
+	}))
+	got = collect()
+	assert.Equal(t, int64(2), got[strategyOperations].(metricdata.Gauge[int64]).DataPoints[0].Value)
+
+	sampler.metrics.unregister()
+	got = collect()
+	assert.NotContains(t, got, strategyOperations, "gauges observed after unregistration")
+}
@@ -17,6 +17,7 @@
 package jaegerremote
 
 import (
+	"context"
 	"encoding/binary"
 	"testing"
 
@@ -233,3 +234,92 @@ func TestMaxOperations(t *testing.T) {
 	result := sampler.ShouldSample(makeSamplingParameters(testMaxID-10, testFirstTimeOperationName))
 	assert.Equal(t, trace.RecordAndSample, result.Decision)
 }
+
+func TestMaxOperationsStrategies(t *testing.T) {
+	strategies := &jaeger_api_v2.PerOperationSamplingStrategies{
+		DefaultSamplingProbability: testDefaultSamplingProbability,
+		PerOperationStrategies: []*jaeger_api_v2.OperationSamplingStrategy{
+			{Operation: "op1", ProbabilisticSampling: &jaeger_api_v2.ProbabilisticSamplingStrategy{SamplingRate: 0.1}},
+			{Operation: "op2", ProbabilisticSampling: &jaeger_api_v2.ProbabilisticSamplingStrategy{SamplingRate: 0.2}},
+			{Operation: "op3", ProbabilisticSampling: &jaeger_api_v2.ProbabilisticSamplingStrategy{SamplingRate: 0.3}},
+		},
+	}
+
+	// The operations of the strategy are all tracked with the default
+	// policy.
+	sampler := newPerOperationSampler(perOperationSamplerParams{
+		MaxOperations: 2,
+		Strategies:    strategies,
+	})
+	assert.Len(t, sampler.samplers, 3)
+	sampler.update(strategies)
+	assert.Len(t, sampler.samplers, 3)
+
+	sampler = newPerOperationSampler(perOperationSamplerParams{
+		MaxOperations:          2,
+		UnknownOperationPolicy: UnknownOperationParentBased,
+		Strategies:             strategies,
+	})
+	assert.Len(t, sampler.samplers, 2)
+	assert.NotContains(t, sampler.samplers, "op3")
+
+	sampler.update(strategies)
+	assert.Len(t, sampler.samplers, 2)
+
+	// Sampled as an unknown root operation, with the default probability.
+	result := sampler.ShouldSample(makeSamplingParameters(testMaxID-10, "op3"))
+	assert.Equal(t, trace.RecordAndSample, result.Decision)
+}
+
+func TestUnknownOperationPolicy(t *testing.T) {
+	strategies := &jaeger_api_v2.PerOperationSamplingStrategies{
+		DefaultSamplingProbability:       testDefaultSamplingProbability,
+		DefaultLowerBoundTracesPerSecond: 2.0,
+		PerOperationStrategies: []*jaeger_api_v2.OperationSamplingStrategy{
+			{Operation: testOperationName, ProbabilisticSampling: &jaeger_api_v2.ProbabilisticSamplingStrategy{SamplingRate: 0.1}},
+		},
+	}
+	sampledParent := oteltrace.ContextWithSpanContext(context.Background(), oteltrace.NewSpanContext(oteltrace.SpanContextConfig{
+		TraceID:    oteltrace.TraceID{1},
+		SpanID:     oteltrace.SpanID{1},
+		TraceFlags: oteltrace.FlagsSampled,
+	}))
+	unsampledParent := oteltrace.ContextWithSpanContext(context.Background(), oteltrace.NewSpanContext(oteltrace.SpanContextConfig{
+		TraceID: oteltrace.TraceID{1},
+		SpanID:  oteltrace.SpanID{1},
+	}))
+	withParent := func(ctx context.Context, p trace.SamplingParameters) trace.SamplingParameters {
+		p.ParentContext = ctx
+		return p
+	}
+
+	tests := []struct {
+		name   string
+		policy UnknownOperationPolicy
+		params trace.SamplingParameters
+		want   trace.SamplingDecision
+	}{
+		{"parent based/sampled parent", UnknownOperationParentBased, withParent(sampledParent, makeSamplingParameters(testMaxID+10, testFirstTimeOperationName)), trace.RecordAndSample},
+		{"parent based/unsampled parent", UnknownOperationParentBased, withParent(unsampledParent, makeSamplingParameters(testMaxID-10, testFirstTimeOperationName)), trace.Drop},
+		{"parent based/root sampled", UnknownOperationParentBased, makeSamplingParameters(testMaxID-10, testFirstTimeOperationName), trace.RecordAndSample},
+		{"parent based/root dropped", UnknownOperationParentBased, makeSamplingParameters(testMaxID+10, testFirstTimeOperationName), trace.Drop},
+		{"lowest probability/sampled", UnknownOperationLowestProbability, makeSamplingParameters(testMaxID/8, testFirstTimeOperationName), trace.RecordAndSample},
+		{"lowest probability/dropped", UnknownOperationLowestProbability, makeSamplingParameters(testMaxID/4, testFirstTimeOperationName), trace.Drop},
+		{"known operation", UnknownOperationLowestProbability, makeSamplingParameters(testMaxID/8, testOperationName), trace.RecordAndSample},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sampler := newPerOperationSampler(perOperationSamplerParams{
+				MaxOperations:          testDefaultMaxOperations,
+				UnknownOperationPolicy: tt.policy,
+				Strategies:             strategies,
+			})
+			// Consume the lower bound of the known operation.
+			sampler.ShouldSample(makeSamplingParameters(testMaxID+10, testOperationName))
+
+			result := sampler.ShouldSample(tt.params)
+			assert.Equal(t, tt.want, result.Decision)
+			assert.NotContains(t, sampler.samplers, testFirstTimeOperationName, "unknown operations are not tracked")
+		})
+	}
+}
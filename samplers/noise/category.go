@@ -0,0 +1,183 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package noise // import "go.opentelemetry.io/contrib/samplers/noise"
+
+import (
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Category is a category of spans recording well-known noise.
+type Category string
+
+const (
+	// HealthChecks are the requests of liveness and health checks, e.g.
+	// "GET /healthz", and the calls of the gRPC health checking service.
+	HealthChecks Category = "health_checks"
+	// ReadinessProbes are the requests of readiness probes, e.g.
+	// "GET /readyz", and the requests of the Kubernetes probes.
+	ReadinessProbes Category = "readiness_probes"
+	// MetricsScrapes are the requests of metrics scrapes, e.g.
+	// "GET /metrics", and the requests of Prometheus.
+	MetricsScrapes Category = "metrics_scrapes"
+)
+
+// allCategories are the categories dropped by default.
+var allCategories = []Category{HealthChecks, ReadinessProbes, MetricsScrapes}
+
+// The attributes identifying the noise. Both the current and the previous
+// semantic conventions of the HTTP attributes are supported.
+const (
+	urlPathKey           = attribute.Key("url.path")
+	httpTargetKey        = attribute.Key("http.target")
+	httpRouteKey         = attribute.Key("http.route")
+	userAgentOriginalKey = attribute.Key("user_agent.original")
+	httpUserAgentKey     = attribute.Key("http.user_agent")
+	rpcServiceKey        = attribute.Key("rpc.service")
+)
+
+// grpcHealthService is the name of the gRPC health checking service.
+const grpcHealthService = "grpc.health.v1.Health"
+
+// pattern identifies the spans of a category.
+type pattern struct {
+	// paths are the lowercase request paths, without trailing slash.
+	paths []string
+	// userAgents are the prefixes of the user agents of the requests.
+	userAgents []string
+	// rpcServices are the names of the RPC services.
+	rpcServices []string
+}
+
+// patterns are the default patterns of the categories.
+var patterns = map[Category]pattern{
+	HealthChecks: {
+		paths:       []string{"/health", "/healthz", "/healthcheck", "/_health", "/livez", "/liveness", "/ping"},
+		rpcServices: []string{grpcHealthService},
+	},
+	ReadinessProbes: {
+		paths:      []string{"/ready", "/readyz", "/readiness"},
+		userAgents: []string{"kube-probe/"},
+	},
+	MetricsScrapes: {
+		paths:      []string{"/metrics"},
+		userAgents: []string{"Prometheus/"},
+	},
+}
+
+// matcher identifies the noise by the path, user agent and RPC service of
+// the spans.
+type matcher struct {
+	paths       map[string]struct{}
+	userAgents  []string
+	rpcServices map[string]struct{}
+}
+
+// newMatcher returns a matcher of the patterns of categories, and of the
+// additional paths.
+func newMatcher(categories []Category, paths []string) matcher {
+	m := matcher{
+		paths:       make(map[string]struct{}),
+		rpcServices: make(map[string]struct{}),
+	}
+	for _, c := range categories {
+		p := patterns[c]
+		for _, path := range p.paths {
+			m.paths[path] = struct{}{}
+		}
+		m.userAgents = append(m.userAgents, p.userAgents...)
+		for _, s := range p.rpcServices {
+			m.rpcServices[s] = struct{}{}
+		}
+	}
+	for _, path := range paths {
+		m.paths[normalizePath(path)] = struct{}{}
+	}
+	return m
+}
+
+// match returns if the span of p is noise.
+func (m matcher) match(p trace.SamplingParameters) bool {
+	if m.matchPath(spanNamePath(p.Name)) {
+		return true
+	}
+	if name, _, ok := strings.Cut(p.Name, "/"); ok && m.matchRPCService(name) {
+		return true
+	}
+	for _, kv := range p.Attributes {
+		switch kv.Key {
+		case urlPathKey, httpTargetKey, httpRouteKey:
+			if m.matchPath(kv.Value.AsString()) {
+				return true
+			}
+		case userAgentOriginalKey, httpUserAgentKey:
+			if m.matchUserAgent(kv.Value.AsString()) {
+				return true
+			}
+		case rpcServiceKey:
+			if m.matchRPCService(kv.Value.AsString()) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (m matcher) matchPath(path string) bool {
+	if path == "" {
+		return false
+	}
+	_, ok := m.paths[normalizePath(path)]
+	return ok
+}
+
+func (m matcher) matchUserAgent(ua string) bool {
+	for _, prefix := range m.userAgents {
+		if strings.HasPrefix(ua, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m matcher) matchRPCService(service string) bool {
+	_, ok := m.rpcServices[service]
+	return ok
+}
+
+// spanNamePath returns the path of the span name, e.g. "/healthz" for
+// "GET /healthz", or "" if the name does not end with a path.
+func spanNamePath(name string) string {
+	if i := strings.LastIndexByte(name, ' '); i >= 0 {
+		name = name[i+1:]
+	}
+	if !strings.HasPrefix(name, "/") {
+		return ""
+	}
+	return name
+}
+
+// normalizePath returns path lowercased, without query nor trailing slash.
+func normalizePath(path string) string {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	if len(path) > 1 {
+		path = strings.TrimSuffix(path, "/")
+	}
+	return strings.ToLower(path)
+}
@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package noise // import "go.opentelemetry.io/contrib/samplers/noise"
+
+type config struct {
+	categories []Category
+	paths      []string
+}
+
+// Option applies configuration settings to a sampler.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (fn optionFunc) apply(c *config) {
+	fn(c)
+}
+
+// newConfig returns a config with opts applied.
+func newConfig(opts ...Option) config {
+	var c config
+	for _, opt := range opts {
+		opt.apply(&c)
+	}
+	if c.categories == nil {
+		c.categories = allCategories
+	}
+	return c
+}
+
+// WithCategories sets the categories of spans dropped by the sampler. All
+// the categories are dropped by default.
+func WithCategories(categories ...Category) Option {
+	categories = append([]Category{}, categories...)
+	return optionFunc(func(c *config) {
+		c.categories = categories
+	})
+}
+
+// WithPaths adds the request paths of spans dropped by the sampler, in
+// addition to the ones of its categories, e.g. WithPaths("/status").
+func WithPaths(paths ...string) Option {
+	paths = append([]string(nil), paths...)
+	return optionFunc(func(c *config) {
+		c.paths = append(c.paths, paths...)
+	})
+}
@@ -0,0 +1,33 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package noise_test
+
+import (
+	"go.opentelemetry.io/contrib/samplers/noise"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+func ExampleNewSampler() {
+	// Sample 10% of the traces, never recording the health checks,
+	// readiness probes and metrics scrapes, nor the requests of the
+	// "/status" endpoint.
+	sampler := noise.NewSampler(
+		trace.ParentBased(trace.TraceIDRatioBased(0.1)),
+		noise.WithPaths("/status"),
+	)
+
+	tp := trace.NewTracerProvider(trace.WithSampler(sampler))
+	_ = tp
+}
@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package noise provides a sampler dropping the spans of well-known noise,
+// i.e. health checks, readiness probes and metrics scrapes, before they are
+// recorded, and delegating the sampling decision of the other spans:
+//
+//	sampler := noise.NewSampler(trace.ParentBased(trace.TraceIDRatioBased(0.1)))
+//
+// The noise is identified by the name of the spans, e.g. "GET /healthz", and
+// by the attributes passed when starting them: the request path (url.path,
+// http.target or http.route), the user agent (user_agent.original or
+// http.user_agent), e.g. the Kubernetes probes or Prometheus, and the RPC
+// service (rpc.service), e.g. the gRPC health checking service.
+package noise // import "go.opentelemetry.io/contrib/samplers/noise"
+
+import (
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// sampler drops the noise and delegates the other sampling decisions.
+type sampler struct {
+	delegate   trace.Sampler
+	categories []Category
+	matcher    matcher
+}
+
+// compile time assertion that sampler implements the trace.Sampler interface.
+var _ trace.Sampler = (*sampler)(nil)
+
+// NewSampler returns a sampler dropping the spans of the categories set with
+// WithCategories, all by default, and of the paths set with WithPaths, and
+// delegating the sampling decision of the other spans to delegate.
+func NewSampler(delegate trace.Sampler, opts ...Option) trace.Sampler {
+	c := newConfig(opts...)
+	return &sampler{
+		delegate:   delegate,
+		categories: c.categories,
+		matcher:    newMatcher(c.categories, c.paths),
+	}
+}
+
+// ShouldSample drops the span if it is noise, or returns the sampling result
+// of the delegate.
+func (s *sampler) ShouldSample(p trace.SamplingParameters) trace.SamplingResult {
+	if s.matcher.match(p) {
+		return trace.SamplingResult{
+			Decision:   trace.Drop,
+			Tracestate: oteltrace.SpanContextFromContext(p.ParentContext).TraceState(),
+		}
+	}
+	return s.delegate.ShouldSample(p)
+}
+
+// Description returns the description of the sampler, with its categories
+// and the description of its delegate.
+func (s *sampler) Description() string {
+	names := make([]string, 0, len(s.categories))
+	for _, c := range s.categories {
+		names = append(names, string(c))
+	}
+	return fmt.Sprintf("NoiseSampler{categories:[%s],delegate:%s}", strings.Join(names, ","), s.delegate.Description())
+}
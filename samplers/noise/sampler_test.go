@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package noise
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestSampler(t *testing.T) {
+	s := NewSampler(trace.AlwaysSample(), WithPaths("/status/"))
+
+	tests := []struct {
+		name  string
+		p     trace.SamplingParameters
+		noise bool
+	}{
+		{"health check name", trace.SamplingParameters{Name: "GET /healthz"}, true},
+		{"health check path", trace.SamplingParameters{Name: "GET", Attributes: []attribute.KeyValue{attribute.String("url.path", "/health/")}}, true},
+		{"health check target", trace.SamplingParameters{Name: "GET", Attributes: []attribute.KeyValue{attribute.String("http.target", "/Health?full=1")}}, true},
+		{"grpc health check name", trace.SamplingParameters{Name: "grpc.health.v1.Health/Check"}, true},
+		{"grpc health check service", trace.SamplingParameters{Name: "Check", Attributes: []attribute.KeyValue{attribute.String("rpc.service", "grpc.health.v1.Health")}}, true},
+		{"readiness probe", trace.SamplingParameters{Name: "/readyz"}, true},
+		{"kubernetes probe", trace.SamplingParameters{Name: "GET /", Attributes: []attribute.KeyValue{attribute.String("user_agent.original", "kube-probe/1.27")}}, true},
+		{"metrics scrape route", trace.SamplingParameters{Name: "GET", Attributes: []attribute.KeyValue{attribute.String("http.route", "/metrics")}}, true},
+		{"prometheus", trace.SamplingParameters{Name: "GET /stats", Attributes: []attribute.KeyValue{attribute.String("http.user_agent", "Prometheus/2.47.0")}}, true},
+		{"additional path", trace.SamplingParameters{Name: "GET /status"}, true},
+		{"request", trace.SamplingParameters{Name: "GET /checkout", Attributes: []attribute.KeyValue{attribute.String("url.path", "/checkout")}}, false},
+		{"health prefix", trace.SamplingParameters{Name: "GET /healthz/details"}, false},
+		{"rpc", trace.SamplingParameters{Name: "shop.Cart/Add", Attributes: []attribute.KeyValue{attribute.String("rpc.service", "shop.Cart")}}, false},
+		{"user agent", trace.SamplingParameters{Name: "GET /", Attributes: []attribute.KeyValue{attribute.String("user_agent.original", "curl/8.0")}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := trace.RecordAndSample
+			if tt.noise {
+				want = trace.Drop
+			}
+			assert.Equal(t, want, s.ShouldSample(tt.p).Decision)
+		})
+	}
+}
+
+func TestSamplerCategories(t *testing.T) {
+	s := NewSampler(trace.AlwaysSample(), WithCategories(MetricsScrapes))
+	assert.Equal(t, trace.Drop, s.ShouldSample(trace.SamplingParameters{Name: "GET /metrics"}).Decision)
+	assert.Equal(t, trace.RecordAndSample, s.ShouldSample(trace.SamplingParameters{Name: "GET /healthz"}).Decision)
+
+	s = NewSampler(trace.AlwaysSample(), WithCategories(), WithPaths("/ping"))
+	assert.Equal(t, trace.RecordAndSample, s.ShouldSample(trace.SamplingParameters{Name: "GET /metrics"}).Decision)
+	assert.Equal(t, trace.Drop, s.ShouldSample(trace.SamplingParameters{Name: "GET /ping"}).Decision)
+}
+
+func TestSamplerDelegates(t *testing.T) {
+	s := NewSampler(trace.NeverSample())
+	assert.Equal(t, trace.Drop, s.ShouldSample(trace.SamplingParameters{Name: "GET /checkout"}).Decision)
+}
+
+func TestSamplerDescription(t *testing.T) {
+	assert.Equal(t,
+		"NoiseSampler{categories:[health_checks,readiness_probes,metrics_scrapes],delegate:AlwaysOnSampler}",
+		NewSampler(trace.AlwaysSample()).Description(),
+	)
+	assert.Equal(t,
+		"NoiseSampler{categories:[health_checks],delegate:AlwaysOffSampler}",
+		NewSampler(trace.NeverSample(), WithCategories(HealthChecks)).Description(),
+	)
+}
@@ -30,7 +30,9 @@ type (
 
 // ParentProbabilityBased is an implementation of the OpenTelemetry
 // Trace Sampler interface that provides additional checks for tracestate
-// Probability Sampling fields.
+// Probability Sampling fields: the threshold of the parent is erased when
+// it is invalid, the parent is not sampled, or the threshold rejects the
+// randomness of the trace.
 func ParentProbabilityBased(root sdktrace.Sampler, samplers ...sdktrace.ParentBasedSamplerOption) sdktrace.Sampler {
 	return &parentProbabilitySampler{
 		delegate: sdktrace.ParentBased(root, samplers...),
@@ -45,7 +47,15 @@ func (p *parentProbabilitySampler) ShouldSample(params sdktrace.SamplingParamete
 	// with or without a parent TraceId and SpanId.
 	state := psc.TraceState()
 
-	otts, err := parseOTelTraceState(state.Get(traceStateKey), psc.IsSampled())
+	otts, err := parseOTelTraceState(state.Get(traceStateKey))
+	if err == nil && otts.hasThreshold() {
+		// Invariant checking: the threshold is only meaningful
+		// for sampled spans whose randomness it does not reject.
+		if !psc.IsSampled() || randomness(otts, psc.TraceID()) < otts.threshold {
+			otts.threshold = maxAdjustedCount
+			err = parseError(thresholdSubkey, errTraceStateInconsistent)
+		}
+	}
 	if err != nil {
 		otel.Handle(err)
 		value := otts.serialize()
@@ -51,22 +51,23 @@ func TestParentSamplerValidContext(t *testing.T) {
 		sampled bool
 	}
 	for _, valid := range []testCase{
-		// sampled tests
-		{"r:10", true},
-		{"r:10;a:b", true},
-		{"r:10;p:1", true},
-		{"r:10;p:10", true},
-		{"r:10;p:10;a:b", true},
-		{"r:10;p:63", true},
-		{"r:10;p:63;a:b", true},
-		{"p:0", true},
-		{"p:10;a:b", true},
-		{"p:63", true},
-		{"p:63;a:b", true},
+		// sampled tests, the randomness of the trace ID is
+		// 0xce929d0e0e4736
+		{"rv:0123456789abcd", true},
+		{"rv:0123456789abcd;a:b", true},
+		{"th:0", true},
+		{"th:8", true},
+		{"th:8;a:b", true},
+		{"th:c", true},
+		{"th:ce929d0e0e4736", true},
+		{"th:0;rv:0123456789abcd", true},
+		{"th:f;rv:ffffffffffffff", true},
+		{"th:f;rv:ffffffffffffff;a:b", true},
 
 		// unsampled tests
-		{"r:10", false},
-		{"r:10;a:b", false},
+		{"rv:0123456789abcd", false},
+		{"rv:0123456789abcd;a:b", false},
+		{"a:b", false},
 	} {
 		t.Run(testName(valid.in), func(t *testing.T) {
 			traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
@@ -118,16 +119,22 @@ func TestParentSamplerInvalidContext(t *testing.T) {
 	}
 	for _, invalid := range []testCase{
 		// sampled
-		{"r:100", true, ""},
-		{"r:100;p:1", true, ""},
-		{"r:100;p:1;a:b", true, "a:b"},
-		{"r:10;p:100", true, "r:10"},
-		{"r:10;p:100;a:b", true, "r:10;a:b"},
+		{"rv:100", true, ""},
+		{"th:8;rv:100", true, ""},
+		{"th:8;rv:100;a:b", true, "a:b"},
+		{"th:x;rv:0123456789abcd", true, "rv:0123456789abcd"},
+		{"th:x;rv:0123456789abcd;a:b", true, "rv:0123456789abcd;a:b"},
+
+		// sampled, the threshold rejects the randomness
+		{"th:d", true, ""},
+		{"th:d;a:b", true, "a:b"},
+		{"th:8;rv:0123456789abcd", true, "rv:0123456789abcd"},
+		{"th:8;rv:0123456789abcd;a:b", true, "rv:0123456789abcd;a:b"},
 
 		// unsampled
-		{"r:63;p:1", false, ""},
-		{"r:10;p:1", false, "r:10"},
-		{"r:10;p:1;a:b", false, "r:10;a:b"},
+		{"th:0", false, ""},
+		{"th:0;rv:0123456789abcd", false, "rv:0123456789abcd"},
+		{"th:0;rv:0123456789abcd;a:b", false, "rv:0123456789abcd;a:b"},
 	} {
 		testInvalid := func(t *testing.T, isChildContext bool) {
 			traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
@@ -13,11 +13,18 @@
 // limitations under the License.
 
 // Package consistent provides a consistent probability based sampler.
+//
+// The sampler implements the OpenTelemetry tracestate probability sampling
+// specification: the sampling decisions compare 56 bits of randomness of the
+// trace with a rejection threshold, and the threshold of the sampled spans is
+// recorded in the th sub-key of the OpenTelemetry tracestate entry, e.g.
+// "ot=th:c", allowing to compute their adjusted count.
 package consistent // import "go.opentelemetry.io/contrib/samplers/probability/consistent"
 
 import (
+	"encoding/binary"
 	"fmt"
-	"math/bits"
+	"math"
 	"math/rand"
 	"sync"
 
@@ -26,6 +33,10 @@ import (
 	"go.opentelemetry.io/otel/trace"
 )
 
+// randomFlag is the W3C Trace Context Level 2 flag indicating the 56 least
+// significant bits of the trace ID are random.
+const randomFlag = trace.TraceFlags(0x02)
+
 type (
 	// ProbabilityBasedOption is an option to the
 	// ConssitentProbabilityBased sampler.
@@ -42,29 +53,27 @@ type (
 	}
 
 	consistentProbabilityBased struct {
-		// "LAC" is an abbreviation for the logarithm of
-		// adjusted count.  Greater values have greater
-		// representivity, therefore lesser sampling
-		// probability.
-
-		// lowLAC is the lower-probability log-adjusted count
-		lowLAC uint8
-		// highLAC is the higher-probability log-adjusted
-		// count.  except for the zero probability special
-		// case, highLAC == lowLAC - 1.
-		highLAC uint8
-		// lowProb is the probability that lowLAC should be used,
-		// in the interval (0, 1].  For exact powers of two and the
-		// special case of 0 probability, lowProb == 1.
-		lowProb float64
+		// fraction is the configured sampling probability.
+		fraction float64
+		// threshold is the rejection threshold: spans are
+		// sampled when their randomness is greater or equal
+		// to it. The zero probability is represented by
+		// maxAdjustedCount, which rejects every randomness.
+		threshold uint64
 
 		// lock protects rnd
 		lock sync.Mutex
-		rnd  *rand.Rand
+		// rnd generates the explicit randomness values, nil
+		// when the randomness of the trace ID is used.
+		rnd *rand.Rand
 	}
 )
 
-// WithRandomSource sets the source of the randomness used by the Sampler.
+// WithRandomSource sets the source of the explicit randomness values (the rv
+// sub-key of the tracestate) generated by the Sampler for the traces whose
+// trace ID is not known to be random, i.e. the root spans and the children of
+// the remote parents without the W3C random flag nor an explicit randomness
+// value. By default the 56 least significant bits of the trace ID are used.
 func WithRandomSource(source rand.Source) ProbabilityBasedOption {
 	return consistentProbabilityBasedRandomSource{source}
 }
@@ -73,51 +82,69 @@ func (s consistentProbabilityBasedRandomSource) apply(cfg *consistentProbability
 	cfg.source = s.Source
 }
 
-// ProbabilityBased samples a given fraction of traces.  Based on the
-// OpenTelemetry specification, this Sampler supports only power-of-two
-// fractions.  When the input fraction is not a power of two, it will
-// be rounded down.
+// ProbabilityBased samples a given fraction of traces. The fraction is
+// converted to a 56-bit rejection threshold, supporting any probability
+// with a precision of 2^-56.
 // - Fractions >= 1 will always sample.
-// - Fractions < 2^-62 are treated as zero.
+// - Fractions < 2^-57 are treated as zero and never sample.
 //
-// This Sampler sets the OpenTelemetry tracestate p-value and/or r-value.
+// This Sampler sets the threshold of the OpenTelemetry tracestate (th) of the
+// sampled spans, and erases it from the spans it does not sample. The
+// explicit randomness (rv) of the tracestate, when present, is used instead
+// of the randomness of the trace ID.
 //
 // To respect the parent trace's `SampledFlag`, this sampler should be
 // used as the root delegate of a `Parent` sampler.
 func ProbabilityBased(fraction float64, opts ...ProbabilityBasedOption) sdktrace.Sampler {
-	cfg := consistentProbabilityBasedConfig{
-		source: rand.NewSource(rand.Int63()),
-	}
+	var cfg consistentProbabilityBasedConfig
 	for _, opt := range opts {
 		opt.apply(&cfg)
 	}
 
-	if fraction < 0 {
+	if fraction < 0 || math.IsNaN(fraction) {
 		fraction = 0
 	} else if fraction > 1 {
 		fraction = 1
 	}
 
-	lowLAC, highLAC, lowProb := splitProb(fraction)
-
-	return &consistentProbabilityBased{
-		lowLAC:  lowLAC,
-		highLAC: highLAC,
-		lowProb: lowProb,
-		rnd:     rand.New(cfg.source),
+	cs := &consistentProbabilityBased{
+		fraction:  fraction,
+		threshold: probabilityToThreshold(fraction),
+	}
+	if cs.threshold == maxAdjustedCount {
+		cs.fraction = 0
 	}
+	if cfg.source != nil {
+		cs.rnd = rand.New(cfg.source)
+	}
+	return cs
 }
 
-func (cs *consistentProbabilityBased) newR() uint8 {
-	cs.lock.Lock()
-	defer cs.lock.Unlock()
-	return uint8(bits.LeadingZeros64(uint64(cs.rnd.Int63())) - 1)
+// probabilityToThreshold returns the rejection threshold sampling with
+// probability fraction, in the interval [0, 1].
+func probabilityToThreshold(fraction float64) uint64 {
+	// Note: the product is exact, the scale is a power of two.
+	return maxAdjustedCount - uint64(math.Round(fraction*float64(maxAdjustedCount)))
+}
+
+// traceIDRandomness returns the 56 least significant bits of the trace ID.
+func traceIDRandomness(id trace.TraceID) uint64 {
+	return binary.BigEndian.Uint64(id[8:]) & randomnessMask
 }
 
-func (cs *consistentProbabilityBased) lowChoice() bool {
+// randomness returns the randomness of the trace: its explicit randomness
+// value when present, the randomness of the trace ID otherwise.
+func randomness(otts otelTraceState, id trace.TraceID) uint64 {
+	if otts.hasRandomness() {
+		return otts.randomness
+	}
+	return traceIDRandomness(id)
+}
+
+func (cs *consistentProbabilityBased) newRandomness() uint64 {
 	cs.lock.Lock()
 	defer cs.lock.Unlock()
-	return cs.rnd.Float64() < cs.lowProb
+	return uint64(cs.rnd.Int63()) & randomnessMask
 }
 
 // ShouldSample implements "go.opentelemetry.io/otel/sdk/trace".Sampler.
@@ -129,38 +156,34 @@ func (cs *consistentProbabilityBased) ShouldSample(p sdktrace.SamplingParameters
 	// for root decisions.
 	state := psc.TraceState()
 
-	otts, err := parseOTelTraceState(state.Get(traceStateKey), psc.IsSampled())
+	otts, err := parseOTelTraceState(state.Get(traceStateKey))
 	if err != nil {
-		// Note: a state.Insert(traceStateKey)
-		// follows, nothing else needs to be done here.
+		// Note: the tracestate entry is rewritten below,
+		// nothing else needs to be done here.
 		otel.Handle(err)
 	}
 
-	if !otts.hasRValue() {
-		otts.rvalue = cs.newR()
+	if !otts.hasRandomness() && cs.rnd != nil && psc.TraceFlags()&randomFlag == 0 {
+		otts.randomness = cs.newRandomness()
 	}
 
 	var decision sdktrace.SamplingDecision
-	var lac uint8
-
-	if cs.lowProb == 1 || cs.lowChoice() {
-		lac = cs.lowLAC
-	} else {
-		lac = cs.highLAC
-	}
-
-	if lac <= otts.rvalue {
+	if randomness(otts, p.TraceID) >= cs.threshold {
 		decision = sdktrace.RecordAndSample
-		otts.pvalue = lac
+		otts.threshold = cs.threshold
 	} else {
 		decision = sdktrace.Drop
-		otts.pvalue = invalidValue
+		otts.threshold = maxAdjustedCount
 	}
 
-	// Note: see the note in
-	// "go.opentelemetry.io/otel/trace".TraceState.Insert(). The
-	// error below is not a condition we're supposed to handle.
-	state, _ = state.Insert(traceStateKey, otts.serialize())
+	if value := otts.serialize(); len(value) > 0 {
+		// Note: see the note in
+		// "go.opentelemetry.io/otel/trace".TraceState.Insert(). The
+		// error below is not a condition we're supposed to handle.
+		state, _ = state.Insert(traceStateKey, value)
+	} else {
+		state = state.Delete(traceStateKey)
+	}
 
 	return sdktrace.SamplingResult{
 		Decision:   decision,
@@ -170,10 +193,5 @@ func (cs *consistentProbabilityBased) ShouldSample(p sdktrace.SamplingParameters
 
 // Description returns "ProbabilityBased{%g}" with the configured probability.
 func (cs *consistentProbabilityBased) Description() string {
-	var prob float64
-	if cs.lowLAC != pZeroValue {
-		prob = cs.lowProb * expToFloat64(-int(cs.lowLAC))
-		prob += (1 - cs.lowProb) * expToFloat64(-int(cs.highLAC))
-	}
-	return fmt.Sprintf("ProbabilityBased{%g}", prob)
+	return fmt.Sprintf("ProbabilityBased{%g}", cs.fraction)
 }
@@ -17,8 +17,8 @@ package consistent
 import (
 	"context"
 	"fmt"
+	"math"
 	"math/rand"
-	"strings"
 	"sync"
 	"testing"
 
@@ -30,27 +30,9 @@ import (
 	"go.opentelemetry.io/otel/trace"
 )
 
-type (
-	testDegrees int
-	pValue      int
-
-	testErrorHandler struct {
-		lock   sync.Mutex
-		errors []error
-	}
-)
-
-func parsePR(s string) (p, r string) {
-	for _, kvf := range strings.Split(s, ";") {
-		kv := strings.SplitN(kvf, ":", 2)
-		switch kv[0] {
-		case "p":
-			p = kv[1]
-		case "r":
-			r = kv[1]
-		}
-	}
-	return
+type testErrorHandler struct {
+	lock   sync.Mutex
+	errors []error
 }
 
 func (eh *testErrorHandler) Handle(err error) {
@@ -66,7 +48,7 @@ func (eh *testErrorHandler) Errors() []error {
 }
 
 func TestSamplerDescription(t *testing.T) {
-	const minProb = 0x1p-62 // 2.168404344971009e-19
+	const minProb = 0x1p-57 // 6.938893903907228e-18
 
 	for _, tc := range []struct {
 		prob   float64
@@ -79,17 +61,18 @@ func TestSamplerDescription(t *testing.T) {
 		{0.003, "ProbabilityBased{0.003}"},
 		{0.99999999, "ProbabilityBased{0.99999999}"},
 		{0.00000001, "ProbabilityBased{1e-08}"},
-		{minProb, "ProbabilityBased{2.168404344971009e-19}"},
-		{minProb * 1.5, "ProbabilityBased{3.2526065174565133e-19}"},
-		{3e-19, "ProbabilityBased{3e-19}"},
+		{minProb, "ProbabilityBased{6.938893903907228e-18}"},
+		{minProb * 1.5, "ProbabilityBased{1.0408340855860843e-17}"},
+		{1e-17, "ProbabilityBased{1e-17}"},
 
 		// out-of-range > 1
 		{1.01, "ProbabilityBased{1}"},
 		{101.1, "ProbabilityBased{1}"},
 
-		// out-of-range < 2^-62
+		// out-of-range < 2^-57
 		{-1, "ProbabilityBased{0}"},
 		{-0.001, "ProbabilityBased{0}"},
+		{math.NaN(), "ProbabilityBased{0}"},
 		{minProb * 0.999, "ProbabilityBased{0}"},
 	} {
 		s := ProbabilityBased(tc.prob)
@@ -97,17 +80,33 @@ func TestSamplerDescription(t *testing.T) {
 	}
 }
 
+func TestProbabilityToThreshold(t *testing.T) {
+	for _, tc := range []struct {
+		prob      float64
+		threshold uint64
+	}{
+		{1, 0},
+		{0.5, 0x80000000000000},
+		{0.25, 0xc0000000000000},
+		{0x1p-56, 0xffffffffffffff},
+		{0x1p-57, 0xffffffffffffff},
+		{0x1p-58, maxAdjustedCount},
+		{0, maxAdjustedCount},
+	} {
+		require.Equal(t, tc.threshold, probabilityToThreshold(tc.prob), "%g", tc.prob)
+	}
+}
+
 func getUnknowns(otts otelTraceState) string {
-	otts.pvalue = invalidValue
-	otts.rvalue = invalidValue
+	otts.threshold = maxAdjustedCount
+	otts.randomness = maxAdjustedCount
 	return otts.serialize()
 }
 
 func TestSamplerBehavior(t *testing.T) {
 	type testGroup struct {
 		probability float64
-		minP        uint8
-		maxP        uint8
+		threshold   uint64
 	}
 	type testCase struct {
 		isRoot        bool
@@ -117,10 +116,10 @@ func TestSamplerBehavior(t *testing.T) {
 	}
 
 	for _, group := range []testGroup{
-		{1.0, 0, 0},
-		{0.75, 0, 1},
-		{0.5, 1, 1},
-		{0, 63, 63},
+		{1.0, 0},
+		{0.75, 0x40000000000000},
+		{0.5, 0x80000000000000},
+		{0, maxAdjustedCount},
 	} {
 		t.Run(fmt.Sprint(group.probability), func(t *testing.T) {
 			for _, test := range []testCase{
@@ -130,25 +129,26 @@ func TestSamplerBehavior(t *testing.T) {
 				{true, false, "", false},
 				{true, false, "a:b", false},
 
-				// non-roots insert r
+				// non-roots insert rv
 				{false, true, "", false},
 				{false, true, "a:b", false},
 				{false, false, "", false},
 				{false, false, "a:b", false},
 
-				// error cases: r-p inconsistency
-				{false, true, "r:10;p:20", true},
-				{false, true, "r:10;p:20;a:b", true},
-				{false, false, "r:10;p:5", true},
-				{false, false, "r:10;p:5;a:b", true},
-
-				// error cases: out-of-range
-				{false, false, "r:100", true},
-				{false, false, "r:100;a:b", true},
-				{false, true, "r:100;p:100", true},
-				{false, true, "r:100;p:100;a:b", true},
-				{false, true, "r:10;p:100", true},
-				{false, true, "r:10;p:100;a:b", true},
+				// non-roots use the explicit randomness
+				{false, true, "rv:00000000000000", false},
+				{false, true, "rv:ffffffffffffff;a:b", false},
+				{false, false, "th:8;rv:9abcdef0123456", false},
+
+				// error cases: invalid randomness
+				{false, false, "rv:100", true},
+				{false, false, "rv:100;a:b", true},
+				{false, true, "th:8;rv:ABCDEF01234567", true},
+
+				// error cases: invalid threshold
+				{false, true, "th:100000000000000", true},
+				{false, true, "th:x;a:b", true},
+				{false, true, "th:g;rv:80000000000000", true},
 			} {
 				t.Run(testName(test.ctxTracestate), func(t *testing.T) {
 					handler := &testErrorHandler{}
@@ -186,8 +186,8 @@ func TestSamplerBehavior(t *testing.T) {
 					)
 
 					// Note: the error below is sometimes expected
-					testState, _ := parseOTelTraceState(test.ctxTracestate, test.parentSampled)
-					hasRValue := testState.hasRValue()
+					testState, _ := parseOTelTraceState(test.ctxTracestate)
+					hasRandomness := testState.hasRandomness()
 
 					const repeats = 10
 					for i := 0; i < repeats; i++ {
@@ -199,27 +199,27 @@ func TestSamplerBehavior(t *testing.T) {
 								Kind:          trace.SpanKindServer,
 							},
 						)
-						sampled := result.Decision == sdktrace.RecordAndSample
 
 						// The result is deterministically random. Parse the tracestate
 						// to see that it is consistent.
-						otts, err := parseOTelTraceState(result.Tracestate.Get(traceStateKey), sampled)
+						otts, err := parseOTelTraceState(result.Tracestate.Get(traceStateKey))
 						require.NoError(t, err)
-						require.True(t, otts.hasRValue())
+						require.True(t, otts.hasRandomness())
 						require.Equal(t, []attribute.KeyValue(nil), result.Attributes)
 
-						if otts.hasPValue() {
-							require.LessOrEqual(t, group.minP, otts.pvalue)
-							require.LessOrEqual(t, otts.pvalue, group.maxP)
+						if otts.hasThreshold() {
+							require.Equal(t, group.threshold, otts.threshold)
+							require.GreaterOrEqual(t, otts.randomness, otts.threshold)
 							require.Equal(t, sdktrace.RecordAndSample, result.Decision)
 						} else {
+							require.Less(t, otts.randomness, group.threshold)
 							require.Equal(t, sdktrace.Drop, result.Decision)
 						}
 
 						require.Equal(t, getUnknowns(testState), getUnknowns(otts))
 
-						if hasRValue {
-							require.Equal(t, testState.rvalue, otts.rvalue)
+						if hasRandomness {
+							require.Equal(t, testState.randomness, otts.randomness)
 						}
 
 						if test.hasErrors {
@@ -233,3 +233,48 @@ func TestSamplerBehavior(t *testing.T) {
 		})
 	}
 }
+
+func TestSamplerTraceIDRandomness(t *testing.T) {
+	// The 56 least significant bits of the trace ID are 0xce929d0e0e4736.
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+
+	for _, tc := range []struct {
+		name    string
+		prob    float64
+		flags   trace.TraceFlags
+		source  bool
+		sampled bool
+		expect  string
+	}{
+		{"root/0.25", 0.25, 0, false, true, "th:c"},
+		{"root/0.1", 0.1, 0, false, false, ""},
+		{"root/1", 1, 0, false, true, "th:0"},
+		{"random flag/0.25", 0.25, randomFlag, true, true, "th:c"},
+		{"random flag/0.1", 0.1, randomFlag, true, false, ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var opts []ProbabilityBasedOption
+			if tc.source {
+				opts = append(opts, WithRandomSource(rand.NewSource(1)))
+			}
+			sampler := ProbabilityBased(tc.prob, opts...)
+
+			sccfg := trace.SpanContextConfig{TraceFlags: tc.flags}
+			if tc.flags != 0 {
+				sccfg.TraceID = traceID
+				sccfg.SpanID = spanID
+			}
+			parentCtx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(sccfg))
+
+			result := sampler.ShouldSample(sdktrace.SamplingParameters{
+				ParentContext: parentCtx,
+				TraceID:       traceID,
+				Name:          "test",
+			})
+			require.Equal(t, tc.sampled, result.Decision == sdktrace.RecordAndSample)
+			// No explicit randomness is generated.
+			require.Equal(t, tc.expect, result.Tracestate.Get(traceStateKey))
+		})
+	}
+}
@@ -22,28 +22,40 @@ import (
 
 const (
 	traceStateKey       = "ot"
-	pValueSubkey        = "p"
-	rValueSubkey        = "r"
-	pZeroValue          = 63
-	invalidValue        = pZeroValue + 1 // invalid for p or r
+	thresholdSubkey     = "th"
+	randomnessSubkey    = "rv"
 	traceStateSizeLimit = 256
+
+	// randomnessBits is the number of bits of the randomness values and
+	// of the rejection thresholds.
+	randomnessBits = 56
+	// hexDigits is the number of hexadecimal digits of a randomness value
+	// and the maximum number of digits of a threshold.
+	hexDigits = randomnessBits / 4
+	// maxAdjustedCount is 2^56, the number of distinct randomness values.
+	// It is out of range for thresholds and randomness values, and is
+	// used to indicate their absence. As a threshold, it rejects every
+	// randomness value.
+	maxAdjustedCount = uint64(1) << randomnessBits
+	// randomnessMask masks the 56 randomness bits of a value.
+	randomnessMask = maxAdjustedCount - 1
 )
 
 var (
 	errTraceStateSyntax       = fmt.Errorf("otel tracestate: %w", strconv.ErrSyntax)
-	errTraceStateInconsistent = fmt.Errorf("r-value and p-value are inconsistent")
+	errTraceStateInconsistent = fmt.Errorf("threshold and sampled flag or randomness are inconsistent")
 )
 
 type otelTraceState struct {
-	rvalue  uint8 // valid in the interval [0, 62]
-	pvalue  uint8 // valid in the interval [0, 63]
-	unknown []string
+	threshold  uint64 // valid in the interval [0, 2^56)
+	randomness uint64 // valid in the interval [0, 2^56)
+	unknown    []string
 }
 
 func newTraceState() otelTraceState {
 	return otelTraceState{
-		rvalue: invalidValue, // out-of-range => !hasRValue()
-		pvalue: invalidValue, // out-of-range => !hasPValue()
+		threshold:  maxAdjustedCount, // out-of-range => !hasThreshold()
+		randomness: maxAdjustedCount, // out-of-range => !hasRandomness()
 	}
 }
 
@@ -55,12 +67,12 @@ func (otts otelTraceState) serialize() string {
 		}
 	}
 
-	if otts.hasPValue() {
-		_, _ = sb.WriteString(fmt.Sprintf("p:%d", otts.pvalue))
+	if otts.hasThreshold() {
+		_, _ = sb.WriteString(thresholdSubkey + ":" + formatThreshold(otts.threshold))
 	}
-	if otts.hasRValue() {
+	if otts.hasRandomness() {
 		semi()
-		_, _ = sb.WriteString(fmt.Sprintf("r:%d", otts.rvalue))
+		_, _ = sb.WriteString(fmt.Sprintf("%s:%0*x", randomnessSubkey, hexDigits, otts.randomness))
 	}
 	for _, unk := range otts.unknown {
 		ex := 0
@@ -77,6 +89,16 @@ func (otts otelTraceState) serialize() string {
 	return sb.String()
 }
 
+// formatThreshold returns the encoding of the threshold t: its 14
+// hexadecimal digits with the trailing zeros removed, or "0" for the
+// zero threshold which samples every span.
+func formatThreshold(t uint64) string {
+	if t == 0 {
+		return "0"
+	}
+	return strings.TrimRight(fmt.Sprintf("%0*x", hexDigits, t), "0")
+}
+
 func isValueByte(r byte) bool {
 	if isLCAlphaNum(r) {
 		return true
@@ -102,8 +124,8 @@ func isUCAlpha(r byte) bool {
 	return r >= 'A' && r <= 'Z'
 }
 
-func parseOTelTraceState(ts string, isSampled bool) (otelTraceState, error) { // nolint: revive
-	var pval, rval string
+func parseOTelTraceState(ts string) (otelTraceState, error) { // nolint: revive
+	var thval, rvval string
 	var unknown []string
 
 	if len(ts) == 0 {
@@ -142,11 +164,11 @@ func parseOTelTraceState(ts string, isSampled bool) (otelTraceState, error) { //
 			break
 		}
 
-		if key == pValueSubkey {
+		if key == thresholdSubkey {
 			// Note: does the spec say how to handle duplicates?
-			pval = tail[0:sepPos]
-		} else if key == rValueSubkey {
-			rval = tail[0:sepPos]
+			thval = tail[0:sepPos]
+		} else if key == randomnessSubkey {
+			rvval = tail[0:sepPos]
 		} else {
 			unknown = append(unknown, ts[0:sepPos+eqPos+1])
 		}
@@ -170,56 +192,56 @@ func parseOTelTraceState(ts string, isSampled bool) (otelTraceState, error) { //
 	otts := newTraceState()
 	otts.unknown = unknown
 
-	// Note: set R before P, so that P won't propagate if R has an error.
-	value, err := parseNumber(rValueSubkey, rval, pZeroValue-1)
-	if err != nil {
-		return otts, err
-	}
-	otts.rvalue = value
-
-	value, err = parseNumber(pValueSubkey, pval, pZeroValue)
-	if err != nil {
-		return otts, err
+	// Note: set the randomness before the threshold, so that the
+	// threshold won't propagate if the randomness has an error.
+	if rvval != "" {
+		if len(rvval) != hexDigits {
+			return otts, parseError(randomnessSubkey, strconv.ErrSyntax)
+		}
+		value, err := parseHex(randomnessSubkey, rvval)
+		if err != nil {
+			return otts, err
+		}
+		otts.randomness = value
 	}
-	otts.pvalue = value
-
-	// Invariant checking: unset P when the values are inconsistent.
-	if otts.hasPValue() && otts.hasRValue() {
-		implied := otts.pvalue <= otts.rvalue || otts.pvalue == pZeroValue
 
-		if !isSampled || !implied {
-			// Note: the error ensures the parent-based
-			// sampler repairs the broken tracestate entry.
-			otts.pvalue = invalidValue
-			return otts, parseError(pValueSubkey, errTraceStateInconsistent)
+	if thval != "" {
+		if len(thval) > hexDigits {
+			return otts, parseError(thresholdSubkey, strconv.ErrRange)
 		}
+		value, err := parseHex(thresholdSubkey, thval)
+		if err != nil {
+			return otts, err
+		}
+		// The trailing zeros are omitted from the encoding.
+		otts.threshold = value << (4 * (hexDigits - len(thval)))
 	}
 
 	return otts, nil
 }
 
-func parseNumber(key string, input string, maximum uint8) (uint8, error) {
-	if input == "" {
-		return maximum + 1, nil
+// parseHex parses the lowercase hexadecimal input of the key.
+func parseHex(key string, input string) (uint64, error) {
+	for i := 0; i < len(input); i++ {
+		if c := input[i]; !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			return 0, parseError(key, strconv.ErrSyntax)
+		}
 	}
-	value, err := strconv.ParseUint(input, 10, 64)
+	value, err := strconv.ParseUint(input, 16, 64)
 	if err != nil {
-		return maximum + 1, parseError(key, err)
-	}
-	if value > uint64(maximum) {
-		return maximum + 1, parseError(key, strconv.ErrRange)
+		return 0, parseError(key, err)
 	}
-	return uint8(value), nil
+	return value, nil
 }
 
 func parseError(key string, err error) error {
 	return fmt.Errorf("otel tracestate: %s-value %w", key, err)
 }
 
-func (otts otelTraceState) hasRValue() bool {
-	return otts.rvalue < pZeroValue
+func (otts otelTraceState) hasThreshold() bool {
+	return otts.threshold < maxAdjustedCount
 }
 
-func (otts otelTraceState) hasPValue() bool {
-	return otts.pvalue <= pZeroValue
+func (otts otelTraceState) hasRandomness() bool {
+	return otts.randomness < maxAdjustedCount
 }
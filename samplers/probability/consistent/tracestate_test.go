@@ -33,19 +33,38 @@ func testName(in string) string {
 
 func TestNewTraceState(t *testing.T) {
 	otts := newTraceState()
-	require.False(t, otts.hasPValue())
-	require.False(t, otts.hasRValue())
+	require.False(t, otts.hasThreshold())
+	require.False(t, otts.hasRandomness())
 	require.Equal(t, "", otts.serialize())
 }
 
-func TestTraceStatePRValueSerialize(t *testing.T) {
+func TestTraceStateSerialize(t *testing.T) {
 	otts := newTraceState()
-	otts.pvalue = 3
-	otts.rvalue = 4
+	otts.threshold = 0xc0000000000000
+	otts.randomness = 0x0123456789abcd
 	otts.unknown = []string{"a:b", "c:d"}
-	require.True(t, otts.hasPValue())
-	require.True(t, otts.hasRValue())
-	require.Equal(t, "p:3;r:4;a:b;c:d", otts.serialize())
+	require.True(t, otts.hasThreshold())
+	require.True(t, otts.hasRandomness())
+	require.Equal(t, "th:c;rv:0123456789abcd;a:b;c:d", otts.serialize())
+}
+
+func TestFormatThreshold(t *testing.T) {
+	for _, tc := range []struct {
+		threshold uint64
+		expect    string
+	}{
+		{0, "0"},
+		{0x80000000000000, "8"},
+		{0xc0000000000000, "c"},
+		{0x00000000000001, "00000000000001"},
+		{0xffffffffffffff, "ffffffffffffff"},
+		{0x10200000000000, "102"},
+		{probabilityToThreshold(0.1), "e6666666666666"},
+		{probabilityToThreshold(0x1p-4), "f"},
+		{probabilityToThreshold(0x1p-56), "ffffffffffffff"},
+	} {
+		require.Equal(t, tc.expect, formatThreshold(tc.threshold))
+	}
 }
 
 func TestTraceStateSerializeOverflow(t *testing.T) {
@@ -54,152 +73,83 @@ func TestTraceStateSerializeOverflow(t *testing.T) {
 	otts.unknown = []string{long}
 	// this drops the extra key, sorry!
 	require.Equal(t, long, otts.serialize())
-	otts.pvalue = 1
-	require.Equal(t, "p:1", otts.serialize())
+	otts.threshold = 0x80000000000000
+	require.Equal(t, "th:8", otts.serialize())
 }
 
-func TestParseTraceStateUnsampled(t *testing.T) {
+func TestParseTraceState(t *testing.T) {
 	type testCase struct {
 		in        string
-		rval      uint8
+		th, rv    uint64
 		expectErr error
 	}
-	const notset = 255
-	for _, test := range []testCase{
-		// All are unsampled tests, i.e., `sampled` is not set in traceparent.
-		{"r:2", 2, nil},
-		{"r:1;", notset, strconv.ErrSyntax},
-		{"r:1", 1, nil},
-		{"r:1=p:2", notset, strconv.ErrSyntax},
-		{"r:1;p:2=s:3", notset, strconv.ErrSyntax},
-		{":1;p:2=s:3", notset, strconv.ErrSyntax},
-		{":;p:2=s:3", notset, strconv.ErrSyntax},
-		{":;:", notset, strconv.ErrSyntax},
-		{":", notset, strconv.ErrSyntax},
-		{"", notset, nil},
-		{"r:;p=1", notset, strconv.ErrSyntax},
-		{"r:1", 1, nil},
-		{"r:10", 10, nil},
-		{"r:33", 33, nil},
-		{"r:61", 61, nil},
-		{"r:62", 62, nil},                      // max r-value
-		{"r:63", notset, strconv.ErrRange},     // out-of-range
-		{"r:100", notset, strconv.ErrRange},    // out-of-range
-		{"r:100001", notset, strconv.ErrRange}, // out-of-range
-		{"p:64", notset, strconv.ErrRange},
-		{"p:100", notset, strconv.ErrRange},
-		{"r:1a", notset, strconv.ErrSyntax}, // not-hexadecimal
-		{"p:-1", notset, strconv.ErrSyntax}, // non-negative
-
-		// Inconsistent trace state: any p-value when unsampled
-		{"p:4;r:2", 2, errTraceStateInconsistent},
-		{"p:1;r:2", 2, errTraceStateInconsistent},
-	} {
-		t.Run(testName(test.in), func(t *testing.T) {
-			// Note: passing isSampled=false as stated above.
-			otts, err := parseOTelTraceState(test.in, false)
-
-			require.False(t, otts.hasPValue(), "should have no p-value")
-
-			if test.expectErr != nil {
-				require.True(t, errors.Is(err, test.expectErr), "not expecting %v", err)
-			}
-			if test.rval != notset {
-				require.True(t, otts.hasRValue())
-				require.Equal(t, test.rval, otts.rvalue)
-			} else {
-				require.False(t, otts.hasRValue(), "should have no r-value")
-			}
-			require.EqualValues(t, []string(nil), otts.unknown)
-
-			if test.expectErr == nil {
-				// Require serialize to round-trip
-				otts2, err := parseOTelTraceState(otts.serialize(), false)
-				require.NoError(t, err)
-				require.Equal(t, otts, otts2)
-			}
-		})
-	}
-}
-
-func TestParseTraceStateSampled(t *testing.T) {
-	type testCase struct {
-		in         string
-		rval, pval uint8
-		expectErr  error
-	}
-	const notset = 255
+	const notset = maxAdjustedCount
 	for _, test := range []testCase{
-		// All are sampled tests, i.e., `sampled` is set in traceparent.
-		{"r:2;p:2", 2, 2, nil},
-		{"r:2;p:1", 2, 1, nil},
-		{"r:2;p:0", 2, 0, nil},
-
-		{"r:1;p:1", 1, 1, nil},
-		{"r:1;p:0", 1, 0, nil},
-
-		{"r:0;p:0", 0, 0, nil},
-
-		{"r:62;p:0", 62, 0, nil},
-		{"r:62;p:62", 62, 62, nil},
-
-		// The important special case:
-		{"r:0;p:63", 0, 63, nil},
-		{"r:2;p:63", 2, 63, nil},
-		{"r:62;p:63", 62, 63, nil},
-
-		// Inconsistent p causes unset p-value.
-		{"r:2;p:3", 2, notset, errTraceStateInconsistent},
-		{"r:2;p:4", 2, notset, errTraceStateInconsistent},
-		{"r:2;p:62", 2, notset, errTraceStateInconsistent},
-		{"r:0;p:1", 0, notset, errTraceStateInconsistent},
-		{"r:1;p:2", 1, notset, errTraceStateInconsistent},
-		{"r:61;p:62", 61, notset, errTraceStateInconsistent},
-
-		// Inconsistent r causes unset p-value and r-value.
-		{"r:63;p:2", notset, notset, strconv.ErrRange},
-		{"r:120;p:2", notset, notset, strconv.ErrRange},
-		{"r:ab;p:2", notset, notset, strconv.ErrSyntax},
-
-		// Syntax is tested before range errors
-		{"r:ab;p:77", notset, notset, strconv.ErrSyntax},
-
-		// p without r (when sampled)
-		{"p:1", notset, 1, nil},
-		{"p:62", notset, 62, nil},
-		{"p:63", notset, 63, nil},
-
-		// r without p (when sampled)
-		{"r:2", 2, notset, nil},
-		{"r:62", 62, notset, nil},
-		{"r:0", 0, notset, nil},
+		{"", notset, notset, nil},
+		{"th:8", 0x80000000000000, notset, nil},
+		{"th:0", 0, notset, nil},
+		{"th:c", 0xc0000000000000, notset, nil},
+		{"th:fd", 0xfd000000000000, notset, nil},
+		{"th:ffffffffffffff", 0xffffffffffffff, notset, nil},
+		{"th:00000000000001", 1, notset, nil},
+		{"rv:0123456789abcd", notset, 0x0123456789abcd, nil},
+		{"rv:ffffffffffffff", notset, 0xffffffffffffff, nil},
+		{"th:8;rv:80000000000000", 0x80000000000000, 0x80000000000000, nil},
+		{"rv:80000000000000;th:8", 0x80000000000000, 0x80000000000000, nil},
+
+		// Note: the consistency of the threshold with the randomness
+		// is checked by the parent sampler.
+		{"th:8;rv:00000000000000", 0x80000000000000, 0, nil},
+
+		// Syntax errors
+		{"th:8;", notset, notset, strconv.ErrSyntax},
+		{"th:8=rv:2", notset, notset, strconv.ErrSyntax},
+		{":8;rv:2", notset, notset, strconv.ErrSyntax},
+		{":;:", notset, notset, strconv.ErrSyntax},
+		{":", notset, notset, strconv.ErrSyntax},
+		{"th:;rv=1", notset, notset, strconv.ErrSyntax},
+
+		// Invalid thresholds
+		{"th:C", notset, notset, strconv.ErrSyntax},              // uppercase
+		{"th:g", notset, notset, strconv.ErrSyntax},              // not-hexadecimal
+		{"th:-1", notset, notset, strconv.ErrSyntax},             // non-negative
+		{"th:0x8", notset, notset, strconv.ErrSyntax},            // no prefix
+		{"th:100000000000000", notset, notset, strconv.ErrRange}, // 15 digits
+
+		// Invalid randomness causes unset threshold and randomness.
+		{"rv:8", notset, notset, strconv.ErrSyntax},
+		{"th:8;rv:0123456789abc", notset, notset, strconv.ErrSyntax},
+		{"th:8;rv:0123456789abcde", notset, notset, strconv.ErrSyntax},
+		{"th:8;rv:0123456789ABCD", notset, notset, strconv.ErrSyntax},
+
+		// Invalid threshold preserves the randomness.
+		{"th:x;rv:0123456789abcd", notset, 0x0123456789abcd, strconv.ErrSyntax},
 	} {
 		t.Run(testName(test.in), func(t *testing.T) {
-			// Note: passing isSampled=true as stated above.
-			otts, err := parseOTelTraceState(test.in, true)
+			otts, err := parseOTelTraceState(test.in)
 
 			if test.expectErr != nil {
 				require.True(t, errors.Is(err, test.expectErr), "not expecting %v", err)
 			} else {
 				require.NoError(t, err)
 			}
-			if test.pval != notset {
-				require.True(t, otts.hasPValue())
-				require.Equal(t, test.pval, otts.pvalue)
+			if test.th != notset {
+				require.True(t, otts.hasThreshold())
+				require.Equal(t, test.th, otts.threshold)
 			} else {
-				require.False(t, otts.hasPValue(), "should have no p-value")
+				require.False(t, otts.hasThreshold(), "should have no threshold")
 			}
-			if test.rval != notset {
-				require.True(t, otts.hasRValue())
-				require.Equal(t, test.rval, otts.rvalue)
+			if test.rv != notset {
+				require.True(t, otts.hasRandomness())
+				require.Equal(t, test.rv, otts.randomness)
 			} else {
-				require.False(t, otts.hasRValue(), "should have no r-value")
+				require.False(t, otts.hasRandomness(), "should have no randomness")
 			}
 			require.EqualValues(t, []string(nil), otts.unknown)
 
 			if test.expectErr == nil {
 				// Require serialize to round-trip
-				otts2, err := parseOTelTraceState(otts.serialize(), true)
+				otts2, err := parseOTelTraceState(otts.serialize())
 				require.NoError(t, err)
 				require.Equal(t, otts, otts2)
 			}
@@ -209,80 +159,78 @@ func TestParseTraceStateSampled(t *testing.T) {
 
 func TestParseTraceStateExtra(t *testing.T) {
 	type testCase struct {
-		in         string
-		rval, pval uint8
-		sampled    bool
-		extra      []string
-		expectErr  error
+		in        string
+		th, rv    uint64
+		extra     []string
+		expectErr error
 	}
-	const notset = 255
+	const notset = maxAdjustedCount
 	for _, test := range []testCase{
 		// one field
-		{"e100:1", notset, notset, false, []string{"e100:1"}, nil},
+		{"e100:1", notset, notset, []string{"e100:1"}, nil},
 
 		// two fields
-		{"e1:1;e2:2", notset, notset, false, []string{"e1:1", "e2:2"}, nil},
-		{"e1:1;e2:2", notset, notset, false, []string{"e1:1", "e2:2"}, nil},
+		{"e1:1;e2:2", notset, notset, []string{"e1:1", "e2:2"}, nil},
 
 		// one extra key, three ways
-		{"r:2;p:2;extra:stuff", 2, 2, true, []string{"extra:stuff"}, nil},
-		{"extra:stuff;r:2;p:2", 2, 2, true, []string{"extra:stuff"}, nil},
-		{"p:2;extra:stuff;r:2", 2, 2, true, []string{"extra:stuff"}, nil},
+		{"th:8;rv:80000000000000;extra:stuff", 0x80000000000000, 0x80000000000000, []string{"extra:stuff"}, nil},
+		{"extra:stuff;th:8;rv:80000000000000", 0x80000000000000, 0x80000000000000, []string{"extra:stuff"}, nil},
+		{"rv:80000000000000;extra:stuff;th:8", 0x80000000000000, 0x80000000000000, []string{"extra:stuff"}, nil},
+
+		// the older p-values and r-values are unknown keys
+		{"p:2;r:3", notset, notset, []string{"p:2", "r:3"}, nil},
 
-		// extra with inconsistent p with and without sampling
-		{"r:3;extra:stuff;p:4", 3, notset, true, []string{"extra:stuff"}, errTraceStateInconsistent},
-		{"extra:stuff;r:3;p:2", 3, notset, false, []string{"extra:stuff"}, errTraceStateInconsistent},
+		// extra with invalid threshold
+		{"th:x;extra:stuff", notset, notset, []string{"extra:stuff"}, strconv.ErrSyntax},
 
 		// two extra fields
-		{"e100:100;r:2;p:1;e101:101", 2, 1, true, []string{"e100:100", "e101:101"}, nil},
-		{"r:2;p:1;e100:100;e101:101", 2, 1, true, []string{"e100:100", "e101:101"}, nil},
-		{"e100:100;e101:101;r:2;p:1", 2, 1, true, []string{"e100:100", "e101:101"}, nil},
+		{"e100:100;th:4;e101:101", 0x40000000000000, notset, []string{"e100:100", "e101:101"}, nil},
+		{"th:4;e100:100;e101:101", 0x40000000000000, notset, []string{"e100:100", "e101:101"}, nil},
+		{"e100:100;e101:101;th:4", 0x40000000000000, notset, []string{"e100:100", "e101:101"}, nil},
 
 		// parse error prevents capturing unrecognized keys
-		{"1:1;u:V", notset, notset, true, nil, strconv.ErrSyntax},
-		{"X:1;u:V", notset, notset, true, nil, strconv.ErrSyntax},
-		{"x:1;u:V", notset, notset, true, []string{"x:1", "u:V"}, nil},
+		{"1:1;u:V", notset, notset, nil, strconv.ErrSyntax},
+		{"X:1;u:V", notset, notset, nil, strconv.ErrSyntax},
+		{"x:1;u:V", notset, notset, []string{"x:1", "u:V"}, nil},
 
 		// no trailing ;
-		{"x:1;", notset, notset, true, nil, strconv.ErrSyntax},
+		{"x:1;", notset, notset, nil, strconv.ErrSyntax},
 
 		// empty key
-		{"x:", notset, notset, true, []string{"x:"}, nil},
+		{"x:", notset, notset, []string{"x:"}, nil},
 
 		// charset test
-		{"x:0X1FFF;y:.-_-.;z:", notset, notset, true, []string{"x:0X1FFF", "y:.-_-.", "z:"}, nil},
-		{"x1y2z3:1-2-3;y1:y_1;xy:-;r:50", 50, notset, true, []string{"x1y2z3:1-2-3", "y1:y_1", "xy:-"}, nil},
+		{"x:0X1FFF;y:.-_-.;z:", notset, notset, []string{"x:0X1FFF", "y:.-_-.", "z:"}, nil},
+		{"x1y2z3:1-2-3;y1:y_1;xy:-;th:5", 0x50000000000000, notset, []string{"x1y2z3:1-2-3", "y1:y_1", "xy:-"}, nil},
 
 		// size exceeded
-		{"x:" + strings.Repeat("_", 255), notset, notset, false, nil, strconv.ErrSyntax},
-		{"x:" + strings.Repeat("_", 254), notset, notset, false, []string{"x:" + strings.Repeat("_", 254)}, nil},
+		{"x:" + strings.Repeat("_", 255), notset, notset, nil, strconv.ErrSyntax},
+		{"x:" + strings.Repeat("_", 254), notset, notset, []string{"x:" + strings.Repeat("_", 254)}, nil},
 	} {
 		t.Run(testName(test.in), func(t *testing.T) {
-			// Note: These tests are independent of sampling state,
-			// so both are tested.
-			otts, err := parseOTelTraceState(test.in, test.sampled)
+			otts, err := parseOTelTraceState(test.in)
 
 			if test.expectErr != nil {
 				require.True(t, errors.Is(err, test.expectErr), "not expecting %v", err)
 			} else {
 				require.NoError(t, err)
 			}
-			if test.pval != notset {
-				require.True(t, otts.hasPValue())
-				require.Equal(t, test.pval, otts.pvalue)
+			if test.th != notset {
+				require.True(t, otts.hasThreshold())
+				require.Equal(t, test.th, otts.threshold)
 			} else {
-				require.False(t, otts.hasPValue(), "should have no p-value")
+				require.False(t, otts.hasThreshold(), "should have no threshold")
 			}
-			if test.rval != notset {
-				require.True(t, otts.hasRValue())
-				require.Equal(t, test.rval, otts.rvalue)
+			if test.rv != notset {
+				require.True(t, otts.hasRandomness())
+				require.Equal(t, test.rv, otts.randomness)
 			} else {
-				require.False(t, otts.hasRValue(), "should have no r-value")
+				require.False(t, otts.hasRandomness(), "should have no randomness")
 			}
 			require.EqualValues(t, test.extra, otts.unknown)
 
-			// on success w/o r-value or p-value, serialize() should not modify
-			if !otts.hasRValue() && !otts.hasPValue() && test.expectErr == nil {
+			// on success w/o threshold or randomness, serialize() should not modify
+			if !otts.hasThreshold() && !otts.hasRandomness() && test.expectErr == nil {
 				require.Equal(t, test.in, otts.serialize())
 			}
 		})
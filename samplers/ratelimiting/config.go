@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimiting // import "go.opentelemetry.io/contrib/samplers/ratelimiting"
+
+import (
+	"math"
+	"time"
+)
+
+type config struct {
+	burst float64
+	now   func() time.Time
+}
+
+// Option applies configuration settings to a sampler.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (fn optionFunc) apply(c *config) {
+	fn(c)
+}
+
+// newConfig returns the config of a sampler of spansPerSecond with opts
+// applied.
+func newConfig(spansPerSecond float64, opts ...Option) config {
+	c := config{now: time.Now}
+	for _, opt := range opts {
+		opt.apply(&c)
+	}
+	if c.burst < 1 {
+		// Sample at least one span at once, or the sampler never samples.
+		c.burst = math.Max(1, spansPerSecond)
+	}
+	return c
+}
+
+// WithBurst sets the maximum number of spans sampled at once, after a period
+// of low traffic, to n. It defaults to the number of spans per second of the
+// sampler, and at least 1.
+func WithBurst(n float64) Option {
+	return optionFunc(func(c *config) {
+		c.burst = n
+	})
+}
+
+// withClock sets the clock of the sampler, for testing.
+func withClock(now func() time.Time) Option {
+	return optionFunc(func(c *config) {
+		c.now = now
+	})
+}
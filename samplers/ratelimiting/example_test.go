@@ -0,0 +1,29 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimiting_test
+
+import (
+	"go.opentelemetry.io/contrib/samplers/ratelimiting"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+func ExampleNewSampler() {
+	// Sample at most 10 traces per second, and up to 50 at once after a
+	// period of low traffic. The spans of a sampled trace are all sampled.
+	sampler := trace.ParentBased(ratelimiting.NewSampler(10, ratelimiting.WithBurst(50)))
+
+	tp := trace.NewTracerProvider(trace.WithSampler(sampler))
+	_ = tp
+}
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ratelimiting provides a sampler sampling at most a number of spans
+// per second, regardless of the traffic of the service.
+//
+// The limit applies to the spans the sampler decides on. Use it as the root
+// sampler of trace.ParentBased to cap the number of sampled traces, the
+// descendant spans following the decision of their root span:
+//
+//	sampler := trace.ParentBased(ratelimiting.NewSampler(10))
+package ratelimiting // import "go.opentelemetry.io/contrib/samplers/ratelimiting"
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// sampler samples spans with a token bucket: each sampled span takes a
+// token, tokens are added at the rate of the sampler up to its burst.
+type sampler struct {
+	rate  float64
+	burst float64
+
+	mu      sync.Mutex
+	balance float64
+	last    time.Time
+	now     func() time.Time
+}
+
+// compile time assertion that sampler implements the trace.Sampler interface.
+var _ trace.Sampler = (*sampler)(nil)
+
+// NewSampler returns a sampler sampling at most spansPerSecond spans per
+// second on average. Up to the burst of the sampler, set with WithBurst, spans
+// are sampled at once after a period of low traffic. No span is sampled if
+// spansPerSecond is not positive.
+func NewSampler(spansPerSecond float64, opts ...Option) trace.Sampler {
+	c := newConfig(spansPerSecond, opts...)
+	return &sampler{
+		rate:    spansPerSecond,
+		burst:   c.burst,
+		balance: c.burst,
+		last:    c.now(),
+		now:     c.now,
+	}
+}
+
+// ShouldSample samples the span if the rate limit is not reached.
+func (s *sampler) ShouldSample(p trace.SamplingParameters) trace.SamplingResult {
+	decision := trace.Drop
+	if s.take() {
+		decision = trace.RecordAndSample
+	}
+	return trace.SamplingResult{
+		Decision:   decision,
+		Tracestate: oteltrace.SpanContextFromContext(p.ParentContext).TraceState(),
+	}
+}
+
+// take returns if a token is available, taking it.
+func (s *sampler) take() bool {
+	if s.rate <= 0 {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if elapsed := now.Sub(s.last); elapsed > 0 {
+		s.balance += elapsed.Seconds() * s.rate
+		if s.balance > s.burst {
+			s.balance = s.burst
+		}
+	}
+	s.last = now
+
+	if s.balance < 1 {
+		return false
+	}
+	s.balance--
+	return true
+}
+
+// Description returns the description of the sampler, with its rate and
+// burst.
+func (s *sampler) Description() string {
+	return fmt.Sprintf("RateLimitingSampler{%g,%g}", s.rate, s.burst)
+}
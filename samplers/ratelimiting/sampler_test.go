@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimiting
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// clock is a manually advanced clock.
+type clock struct{ t time.Time }
+
+func (c *clock) now() time.Time { return c.t }
+
+func (c *clock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+// sampled returns the number of spans sampled by s out of n.
+func sampled(s trace.Sampler, n int) int {
+	var count int
+	for i := 0; i < n; i++ {
+		if s.ShouldSample(trace.SamplingParameters{}).Decision == trace.RecordAndSample {
+			count++
+		}
+	}
+	return count
+}
+
+func TestSamplerRate(t *testing.T) {
+	c := &clock{t: time.Unix(0, 0)}
+	s := NewSampler(10, withClock(c.now))
+
+	assert.Equal(t, 10, sampled(s, 100), "initial burst")
+	assert.Equal(t, 0, sampled(s, 100), "bucket not empty")
+
+	c.advance(100 * time.Millisecond)
+	assert.Equal(t, 1, sampled(s, 100))
+
+	c.advance(time.Hour)
+	assert.Equal(t, 10, sampled(s, 100), "burst exceeded")
+}
+
+func TestSamplerFractionalRate(t *testing.T) {
+	c := &clock{t: time.Unix(0, 0)}
+	s := NewSampler(0.5, withClock(c.now))
+
+	assert.Equal(t, 1, sampled(s, 10), "minimum burst")
+	c.advance(time.Second)
+	assert.Equal(t, 0, sampled(s, 10))
+	c.advance(time.Second)
+	assert.Equal(t, 1, sampled(s, 10))
+}
+
+func TestSamplerBurst(t *testing.T) {
+	c := &clock{t: time.Unix(0, 0)}
+	s := NewSampler(1, WithBurst(5), withClock(c.now))
+
+	assert.Equal(t, 5, sampled(s, 100))
+	c.advance(2 * time.Second)
+	assert.Equal(t, 2, sampled(s, 100))
+}
+
+func TestSamplerNotPositive(t *testing.T) {
+	assert.Equal(t, 0, sampled(NewSampler(0), 10))
+	assert.Equal(t, 0, sampled(NewSampler(-1), 10))
+}
+
+func TestSamplerClockBackward(t *testing.T) {
+	c := &clock{t: time.Unix(10, 0)}
+	s := NewSampler(1, withClock(c.now))
+
+	assert.Equal(t, 1, sampled(s, 10))
+	c.advance(-time.Second)
+	assert.Equal(t, 0, sampled(s, 10))
+	c.advance(time.Second)
+	assert.Equal(t, 1, sampled(s, 10))
+}
+
+func TestSamplerTraceState(t *testing.T) {
+	ts, err := oteltrace.ParseTraceState("k=v")
+	require.NoError(t, err)
+	ctx := oteltrace.ContextWithSpanContext(context.Background(), oteltrace.NewSpanContext(oteltrace.SpanContextConfig{
+		TraceID:    oteltrace.TraceID{1},
+		SpanID:     oteltrace.SpanID{1},
+		TraceState: ts,
+	}))
+
+	s := NewSampler(1)
+	for i := 0; i < 2; i++ {
+		res := s.ShouldSample(trace.SamplingParameters{ParentContext: ctx})
+		assert.Equal(t, ts, res.Tracestate)
+	}
+}
+
+func TestSamplerConcurrentSafe(t *testing.T) {
+	s := NewSampler(100)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sampled(s, 100)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestSamplerDescription(t *testing.T) {
+	assert.Equal(t, "RateLimitingSampler{10,20}", NewSampler(10, WithBurst(20)).Description())
+}
@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rulebased // import "go.opentelemetry.io/contrib/samplers/rulebased"
+
+import "go.opentelemetry.io/otel/sdk/trace"
+
+type config struct {
+	rules []rule
+}
+
+// Option applies configuration settings to a sampler.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (fn optionFunc) apply(c *config) {
+	fn(c)
+}
+
+// newConfig returns a config with opts applied.
+func newConfig(opts ...Option) config {
+	var c config
+	for _, opt := range opts {
+		opt.apply(&c)
+	}
+	return c
+}
+
+// WithRule appends a rule delegating the sampling decision of the spans
+// matched by all the matchers to sampler. A rule without matchers matches
+// all the spans. The rules are evaluated in the order they are passed.
+func WithRule(sampler trace.Sampler, matchers ...Matcher) Option {
+	r := rule{
+		matchers: append([]Matcher(nil), matchers...),
+		sampler:  sampler,
+	}
+	return optionFunc(func(c *config) {
+		c.rules = append(c.rules, r)
+	})
+}
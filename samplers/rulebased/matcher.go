@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rulebased // import "go.opentelemetry.io/contrib/samplers/rulebased"
+
+import (
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// Matcher reports if a span, described by the parameters of its sampling
+// decision, matches a rule.
+type Matcher func(trace.SamplingParameters) bool
+
+// SpanName returns a Matcher of the spans whose name matches the glob
+// pattern, where '*' matches any sequence of characters, including an empty
+// one and '/', and '?' matches any single character.
+func SpanName(pattern string) Matcher {
+	re := globRegexp(pattern)
+	return func(p trace.SamplingParameters) bool {
+		return re.MatchString(p.Name)
+	}
+}
+
+// globRegexp returns the regular expression matching the strings matched by
+// the glob pattern.
+func globRegexp(pattern string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString(`^(?s:`)
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			b.WriteString(`.*`)
+		case '?':
+			b.WriteString(`.`)
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString(`)$`)
+	return regexp.MustCompile(b.String())
+}
+
+// SpanKind returns a Matcher of the spans of one of kinds.
+func SpanKind(kinds ...oteltrace.SpanKind) Matcher {
+	kinds = append([]oteltrace.SpanKind(nil), kinds...)
+	return func(p trace.SamplingParameters) bool {
+		for _, k := range kinds {
+			if p.Kind == k {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// AttributeEquals returns a Matcher of the spans started with the attribute
+// key equal to value.
+func AttributeEquals(key attribute.Key, value attribute.Value) Matcher {
+	return func(p trace.SamplingParameters) bool {
+		v, ok := attributeValue(p, key)
+		return ok && v == value
+	}
+}
+
+// AttributeMatches returns a Matcher of the spans started with the attribute
+// key whose value, formatted as with attribute.Value.Emit, matches re.
+func AttributeMatches(key attribute.Key, re *regexp.Regexp) Matcher {
+	return func(p trace.SamplingParameters) bool {
+		v, ok := attributeValue(p, key)
+		return ok && re.MatchString(v.Emit())
+	}
+}
+
+// attributeValue returns the last value of the attribute key of the span,
+// the one recorded by the SDK.
+func attributeValue(p trace.SamplingParameters, key attribute.Key) (attribute.Value, bool) {
+	for i := len(p.Attributes) - 1; i >= 0; i-- {
+		if p.Attributes[i].Key == key {
+			return p.Attributes[i].Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
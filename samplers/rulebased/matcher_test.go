@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rulebased
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+func TestSpanName(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{"GET /health", "GET /health", true},
+		{"GET /health", "GET /healthz", false},
+		{"GET /health*", "GET /healthz", true},
+		{"GET /*", "GET /api/v1/users", true},
+		{"*", "", true},
+		{"?", "é", true},
+		{"?", "", false},
+		{"GET /users/?", "GET /users/12", false},
+		{"a.b", "axb", false},
+		{"[a]", "[a]", true},
+		{"*ready*", "/readyz", true},
+	}
+	for _, tt := range tests {
+		got := SpanName(tt.pattern)(trace.SamplingParameters{Name: tt.name})
+		assert.Equal(t, tt.want, got, "pattern %q, name %q", tt.pattern, tt.name)
+	}
+}
+
+func TestSpanKind(t *testing.T) {
+	m := SpanKind(oteltrace.SpanKindServer, oteltrace.SpanKindConsumer)
+	assert.True(t, m(trace.SamplingParameters{Kind: oteltrace.SpanKindServer}))
+	assert.True(t, m(trace.SamplingParameters{Kind: oteltrace.SpanKindConsumer}))
+	assert.False(t, m(trace.SamplingParameters{Kind: oteltrace.SpanKindClient}))
+	assert.False(t, SpanKind()(trace.SamplingParameters{Kind: oteltrace.SpanKindServer}))
+}
+
+func TestAttributeEquals(t *testing.T) {
+	p := trace.SamplingParameters{Attributes: []attribute.KeyValue{
+		attribute.String("http.route", "/old"),
+		attribute.Int("http.status_code", 200),
+		attribute.String("http.route", "/checkout"),
+		attribute.StringSlice("tags", []string{"a", "b"}),
+	}}
+	assert.True(t, AttributeEquals("http.route", attribute.StringValue("/checkout"))(p))
+	assert.False(t, AttributeEquals("http.route", attribute.StringValue("/old"))(p), "overwritten attribute matched")
+	assert.True(t, AttributeEquals("http.status_code", attribute.IntValue(200))(p))
+	assert.False(t, AttributeEquals("http.status_code", attribute.StringValue("200"))(p), "type not compared")
+	assert.True(t, AttributeEquals("tags", attribute.StringSliceValue([]string{"a", "b"}))(p))
+	assert.False(t, AttributeEquals("missing", attribute.StringValue(""))(p))
+}
+
+func TestAttributeMatches(t *testing.T) {
+	p := trace.SamplingParameters{Attributes: []attribute.KeyValue{
+		attribute.String("url.path", "/api/v2/orders"),
+		attribute.Int("http.status_code", 503),
+	}}
+	assert.True(t, AttributeMatches("url.path", regexp.MustCompile(`^/api/v\d+/`))(p))
+	assert.False(t, AttributeMatches("url.path", regexp.MustCompile(`^/internal/`))(p))
+	assert.True(t, AttributeMatches("http.status_code", regexp.MustCompile(`^5\d\d$`))(p))
+	assert.False(t, AttributeMatches("missing", regexp.MustCompile(`.*`))(p))
+}
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rulebased provides a sampler delegating the sampling decision of a
+// span to the sampler of the first rule matching the span, e.g. to never
+// sample health checks or to sample all the spans of an endpoint, without
+// implementing a sampler.
+//
+// The rules match the spans by name, kind and attributes:
+//
+//	sampler := rulebased.NewSampler(
+//		trace.TraceIDRatioBased(0.1),
+//		rulebased.WithRule(trace.NeverSample(), rulebased.SpanName("GET /health*")),
+//		rulebased.WithRule(trace.AlwaysSample(),
+//			rulebased.SpanKind(oteltrace.SpanKindServer),
+//			rulebased.AttributeEquals("http.route", attribute.StringValue("/checkout")),
+//		),
+//	)
+//
+// The rules are evaluated when spans start: only the attributes passed when
+// starting a span are matched.
+package rulebased // import "go.opentelemetry.io/contrib/samplers/rulebased"
+
+import (
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+// rule maps the spans matched by all its matchers to its sampler.
+type rule struct {
+	matchers []Matcher
+	sampler  trace.Sampler
+}
+
+// match returns if all the matchers of r match p.
+func (r rule) match(p trace.SamplingParameters) bool {
+	for _, m := range r.matchers {
+		if !m(p) {
+			return false
+		}
+	}
+	return true
+}
+
+// sampler delegates the sampling decisions to the sampler of the first
+// matching rule, or to its default sampler.
+type sampler struct {
+	rules          []rule
+	defaultSampler trace.Sampler
+}
+
+// compile time assertion that sampler implements the trace.Sampler interface.
+var _ trace.Sampler = (*sampler)(nil)
+
+// NewSampler returns a sampler evaluating the rules set with WithRule, in
+// order, and delegating the sampling decision of a span to the sampler of the
+// first rule matching the span. The decision is delegated to defaultSampler
+// if no rule matches the span.
+func NewSampler(defaultSampler trace.Sampler, opts ...Option) trace.Sampler {
+	c := newConfig(opts...)
+	return &sampler{
+		rules:          c.rules,
+		defaultSampler: defaultSampler,
+	}
+}
+
+// ShouldSample returns the sampling result of the sampler of the first rule
+// matching the span, or of the default sampler.
+func (s *sampler) ShouldSample(p trace.SamplingParameters) trace.SamplingResult {
+	for _, r := range s.rules {
+		if r.match(p) {
+			return r.sampler.ShouldSample(p)
+		}
+	}
+	return s.defaultSampler.ShouldSample(p)
+}
+
+// Description returns the description of the sampler, listing the samplers
+// of its rules and its default sampler.
+func (s *sampler) Description() string {
+	descs := make([]string, 0, len(s.rules))
+	for _, r := range s.rules {
+		descs = append(descs, r.sampler.Description())
+	}
+	return fmt.Sprintf("RuleBasedSampler{rules:[%s],default:%s}", strings.Join(descs, ","), s.defaultSampler.Description())
+}
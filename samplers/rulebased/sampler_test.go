@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rulebased
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// decisionSampler always returns its decision.
+type decisionSampler struct {
+	decision trace.SamplingDecision
+	name     string
+}
+
+func (s decisionSampler) ShouldSample(trace.SamplingParameters) trace.SamplingResult {
+	return trace.SamplingResult{
+		Decision:   s.decision,
+		Attributes: []attribute.KeyValue{attribute.String("sampler", s.name)},
+	}
+}
+
+func (s decisionSampler) Description() string { return s.name }
+
+func TestSampler(t *testing.T) {
+	s := NewSampler(
+		decisionSampler{trace.RecordOnly, "default"},
+		WithRule(decisionSampler{trace.Drop, "health"}, SpanName("GET /health*")),
+		WithRule(decisionSampler{trace.RecordAndSample, "checkout"},
+			SpanKind(oteltrace.SpanKindServer),
+			AttributeEquals("http.route", attribute.StringValue("/checkout")),
+		),
+		WithRule(decisionSampler{trace.Drop, "server"}, SpanKind(oteltrace.SpanKindServer)),
+	)
+
+	tests := []struct {
+		name string
+		p    trace.SamplingParameters
+		want string
+	}{
+		{
+			name: "first rule",
+			p:    trace.SamplingParameters{Name: "GET /healthz", Kind: oteltrace.SpanKindServer},
+			want: "health",
+		},
+		{
+			name: "all matchers",
+			p: trace.SamplingParameters{
+				Name:       "POST /checkout",
+				Kind:       oteltrace.SpanKindServer,
+				Attributes: []attribute.KeyValue{attribute.String("http.route", "/checkout")},
+			},
+			want: "checkout",
+		},
+		{
+			name: "partial match",
+			p: trace.SamplingParameters{
+				Name:       "POST /checkout",
+				Kind:       oteltrace.SpanKindClient,
+				Attributes: []attribute.KeyValue{attribute.String("http.route", "/checkout")},
+			},
+			want: "default",
+		},
+		{
+			name: "later rule",
+			p:    trace.SamplingParameters{Name: "GET /users", Kind: oteltrace.SpanKindServer},
+			want: "server",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := s.ShouldSample(tt.p)
+			assert.Equal(t, []attribute.KeyValue{attribute.String("sampler", tt.want)}, res.Attributes)
+		})
+	}
+}
+
+func TestSamplerRuleWithoutMatchers(t *testing.T) {
+	s := NewSampler(trace.NeverSample(), WithRule(trace.AlwaysSample()))
+	assert.Equal(t, trace.RecordAndSample, s.ShouldSample(trace.SamplingParameters{Name: "any"}).Decision)
+}
+
+func TestSamplerDescription(t *testing.T) {
+	s := NewSampler(trace.NeverSample(), WithRule(trace.AlwaysSample(), SpanName("*")))
+	assert.Equal(t, "RuleBasedSampler{rules:[AlwaysOnSampler],default:AlwaysOffSampler}", s.Description())
+}
@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zpages // import "go.opentelemetry.io/contrib/zpages"
+
+import (
+	"net/http"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// TracezPath is the path the tracez handler is mounted on by HandleTracez.
+// The links of the tracez page are relative, so a handler mounted on a custom
+// path needs to end with "/tracez" as well.
+const TracezPath = "/debug/tracez"
+
+// RegisterTracez creates a SpanProcessor, registers it with the tracer
+// provider and returns an http.Handler serving the tracez page for the spans
+// it records.
+//
+// The returned handler can be mounted on any router, for example the ones
+// instrumented by otelhttp, otelmux or otelecho:
+//
+//	router.Handle(zpages.TracezPath, zpages.RegisterTracez(tp))    // gorilla/mux
+//	e.GET(zpages.TracezPath, echo.WrapHandler(zpages.RegisterTracez(tp))) // echo
+func RegisterTracez(tp *sdktrace.TracerProvider) http.Handler {
+	sp := NewSpanProcessor()
+	tp.RegisterSpanProcessor(sp)
+	return NewTracezHandler(sp)
+}
+
+// HandleTracez registers a SpanProcessor with the tracer provider and mounts
+// the tracez handler serving its spans on mux at TracezPath.
+func HandleTracez(mux *http.ServeMux, tp *sdktrace.TracerProvider) {
+	mux.Handle(TracezPath, RegisterTracez(tp))
+}